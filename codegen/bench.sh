@@ -0,0 +1,26 @@
+#!/bin/bash -e
+GEN_DIR='./generated'
+ADX_OUT='bench_adx.txt'
+NO_ADX_OUT='bench_noadx.txt'
+
+field_sizes=(\
+128 192 256 320 384 448 512 \
+576 640 704 768 832 896 960 \
+1024
+)
+
+rm -f $ADX_OUT $NO_ADX_OUT
+for BIT_SIZE in "${field_sizes[@]}"
+do
+  echo 'B' $BIT_SIZE ADX
+  go run . -output $GEN_DIR -opt B -bit $BIT_SIZE -arch ADX
+  goreturns -w -p $GEN_DIR
+  go test ./generated -run NONE -bench Field >> $ADX_OUT
+  echo 'B' $BIT_SIZE
+  go run . -output $GEN_DIR -opt B -bit $BIT_SIZE
+  goreturns -w -p $GEN_DIR
+  go test ./generated -run NONE -bench Field >> $NO_ADX_OUT
+done
+
+go run ./benchmark -adx $ADX_OUT -noadx $NO_ADX_OUT -format markdown -out bench.md
+go run ./benchmark -adx $ADX_OUT -noadx $NO_ADX_OUT -format json -out bench.json
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// benchmark aggregates go test -bench outputs of ADX and non ADX builds into
+// a JSON or markdown table. Repeated runs of a benchmark, as with -count, are
+// averaged.
+
+type result struct {
+	Name    string   `json:"name"`
+	ADX     *float64 `json:"adx_ns_per_op"`
+	NoADX   *float64 `json:"no_adx_ns_per_op"`
+	Speedup *float64 `json:"speedup"`
+}
+
+// BenchmarkField/384_mul-8   	27243596	        43.4 ns/op
+var benchLine = regexp.MustCompile(`^Benchmark(\S+?)(-\d+)?\s+\d+\s+([0-9.e+]+) ns/op`)
+
+type samples struct {
+	order []string
+	sum   map[string]float64
+	n     map[string]int
+}
+
+func parse(r io.Reader) (*samples, error) {
+	s := &samples{sum: make(map[string]float64), n: make(map[string]int)}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m := benchLine.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m == nil {
+			continue
+		}
+		v, err := strconv.ParseFloat(m[3], 64)
+		if err != nil {
+			return nil, err
+		}
+		name := m[1]
+		if s.n[name] == 0 {
+			s.order = append(s.order, name)
+		}
+		s.sum[name] += v
+		s.n[name]++
+	}
+	return s, scanner.Err()
+}
+
+func (s *samples) mean(name string) *float64 {
+	if s == nil || s.n[name] == 0 {
+		return nil
+	}
+	v := s.sum[name] / float64(s.n[name])
+	return &v
+}
+
+func parseFile(filename string) (*samples, error) {
+	if filename == "" {
+		return nil, nil
+	}
+	if filename == "-" {
+		return parse(os.Stdin)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parse(f)
+}
+
+// aggregate pairs results by benchmark name in order of first appearance
+func aggregate(adx, noadx *samples) []result {
+	var names []string
+	seen := make(map[string]bool)
+	for _, s := range []*samples{adx, noadx} {
+		if s == nil {
+			continue
+		}
+		for _, name := range s.order {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	results := make([]result, len(names))
+	for i, name := range names {
+		r := result{Name: name, ADX: adx.mean(name), NoADX: noadx.mean(name)}
+		if r.ADX != nil && r.NoADX != nil && *r.ADX != 0 {
+			v := *r.NoADX / *r.ADX
+			r.Speedup = &v
+		}
+		results[i] = r
+	}
+	return results
+}
+
+func markdown(results []result) string {
+	cell := func(v *float64, format string) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprintf(format, *v)
+	}
+	var b strings.Builder
+	b.WriteString("| benchmark | ADX ns/op | non ADX ns/op | speedup |\n")
+	b.WriteString("|:--|--:|--:|--:|\n")
+	for _, r := range results {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", r.Name, cell(r.ADX, "%.1f"), cell(r.NoADX, "%.1f"), cell(r.Speedup, "%.2fx"))
+	}
+	return b.String()
+}
+
+func main() {
+	var adxFile string
+	var noadxFile string
+	var format string
+	var out string
+
+	flag.StringVar(&adxFile, "adx", "", "go test -bench output of ADX build, - for stdin")
+	flag.StringVar(&noadxFile, "noadx", "", "go test -bench output of non ADX build, - for stdin")
+	flag.StringVar(&format, "format", "markdown", "output format, json or markdown")
+	flag.StringVar(&out, "out", "", "output file, defaults to stdout")
+	flag.Parse()
+
+	if adxFile == "" && noadxFile == "" {
+		panic("at least one of -adx and -noadx is required")
+	}
+	adx, err := parseFile(adxFile)
+	if err != nil {
+		panic(err)
+	}
+	noadx, err := parseFile(noadxFile)
+	if err != nil {
+		panic(err)
+	}
+	results := aggregate(adx, noadx)
+	var b []byte
+	switch format {
+	case "json":
+		if b, err = json.MarshalIndent(results, "", "  "); err != nil {
+			panic(err)
+		}
+		b = append(b, '\n')
+	case "markdown":
+		b = []byte(markdown(results))
+	default:
+		panic(fmt.Sprintf("no such format %s", format))
+	}
+	if out == "" {
+		os.Stdout.Write(b)
+		return
+	}
+	if err := ioutil.WriteFile(out, b, 0600); err != nil {
+		panic(err)
+	}
+}
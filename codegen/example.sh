@@ -1,5 +1,5 @@
 #!/bin/bash -e
-N_FUZZ=1000
+N_ITER=1000
 GEN_DIR='./generated'
 ARCH='ADX'
 
@@ -39,4 +39,4 @@ go run . -output $GEN_DIR -opt D
 # format the code
 goreturns -w -p $GEN_DIR
 # run the test
-go test ./generated -v -fuzz $N_FUZZ
+go test ./generated -v -iter $N_ITER
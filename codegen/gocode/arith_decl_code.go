@@ -10,16 +10,30 @@ func arithmeticDeclerations(limbSize int, fixedModulus bool) string {
 			"\n//go:noescape\nfunc sub(c, a, b *fieldElement)\n" +
 			"\n//go:noescape\nfunc subn(a, b *fieldElement) uint64\n" +
 			"\n//go:noescape\nfunc _neg(c, a *fieldElement)\n" +
+			"\n//go:noescape\nfunc _cmov(c, a, b *fieldElement, cond uint64)\n" +
+			"\n//go:noescape\nfunc _cswap(a, b *fieldElement, cond uint64)\n" +
+			"\n//go:noescape\nfunc _condNeg(c, a *fieldElement, cond uint64)\n" +
+			"\n//go:noescape\nfunc _lookup(dst *fieldElement, table []fieldElement, idx uint64)\n" +
 			"\n//go:noescape\nfunc double(c, a *fieldElement)\n" +
-			"\n//go:noescape\nfunc mul(c, a, b *fieldElement)\n"
+			"\n//go:noescape\nfunc mul(c, a, b *fieldElement)\n" +
+			"\n//go:noescape\nfunc cmp(a, b *fieldElement) int8\n" +
+			"\n//go:noescape\nfunc mul_two(a *fieldElement) uint64\n" +
+			"\n//go:noescape\nfunc div_two(a *fieldElement)\n"
 	} else {
 		code += "\n//go:noescape\nfunc add(c, a, b, p *fieldElement)\n" +
 			"\n//go:noescape\nfunc addn(a, b *fieldElement) uint64\n" +
 			"\n//go:noescape\nfunc sub(c, a, b, p *fieldElement)\n" +
 			"\n//go:noescape\nfunc subn(a, b *fieldElement) uint64\n" +
 			"\n//go:noescape\nfunc _neg(c, a, p *fieldElement)\n" +
+			"\n//go:noescape\nfunc _cmov(c, a, b *fieldElement, cond uint64)\n" +
+			"\n//go:noescape\nfunc _cswap(a, b *fieldElement, cond uint64)\n" +
+			"\n//go:noescape\nfunc _condNeg(c, a, p *fieldElement, cond uint64)\n" +
+			"\n//go:noescape\nfunc _lookup(dst *fieldElement, table []fieldElement, idx uint64)\n" +
 			"\n//go:noescape\nfunc double(c, a, p *fieldElement)\n" +
-			"\n//go:noescape\nfunc mul(c, a, b, p *fieldElement, inp uint64)\n"
+			"\n//go:noescape\nfunc mul(c, a, b, p *fieldElement, inp uint64)\n" +
+			"\n//go:noescape\nfunc cmp(a, b *fieldElement) int8\n" +
+			"\n//go:noescape\nfunc mul_two(a *fieldElement) uint64\n" +
+			"\n//go:noescape\nfunc div_two(a *fieldElement)\n"
 	}
 	return code
 }
@@ -64,6 +78,18 @@ func subn%[1]d(a, b fieldElement) uint64
 //go:noescape
 func _neg%[1]d(c, a, p fieldElement)
 
+//go:noescape
+func cmov%[1]d(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap%[1]d(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg%[1]d(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup%[1]d(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double%[1]d(c, a, p fieldElement)
 
@@ -77,3 +103,159 @@ func mul_no_adx_bmi2_%[1]d(c, a, b, p fieldElement, inp uint64)
 	}
 	return code
 }
+
+// arithmeticTestHookMultiple exposes both multiplication backends of each limb
+// size to tests of the multiple limb size package
+func arithmeticTestHookMultiple(limbSizes []int) string {
+	code := `
+// mulBackends holds ADX and non ADX multiplication kernels of each limb size
+// so that both are run in the same test binary regardless of the no_adx_bmi2
+// build tag
+var mulBackends = map[int]struct {
+	adx, noadx func(c, a, b, p fieldElement, inp uint64)
+}{
+`
+	for _, limbSize := range limbSizes {
+		code += fmt.Sprintf("\t%[1]d: {mul%[1]d, mul_no_adx_bmi2_%[1]d},\n", limbSize)
+	}
+	return code + "}\n"
+}
+
+const mulBackendsCrossTest = `
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"golang.org/x/sys/cpu"
+)
+
+// mulCrossField returns a field with a random prime modulus of bit size
+func mulCrossField(limbSize, bitSize int) *field {
+	if bitSize < 3 {
+		bitSize = 3
+	}
+	pbig, err := rand.Prime(rand.Reader, bitSize)
+	if err != nil {
+		panic(err)
+	}
+	field, err := newField(padBytes(pbig.Bytes(), limbSize*8))
+	if err != nil {
+		panic(err)
+	}
+	return field
+}
+
+// mulCrossSamples returns adversarial and random operands below the modulus
+func mulCrossSamples(field *field) []*big.Int {
+	one := big.NewInt(1)
+	p := field.pbig
+	samples := []*big.Int{
+		new(big.Int),
+		big.NewInt(1),
+		new(big.Int).Sub(p, one),
+		new(big.Int).Sub(p, big.NewInt(2)),
+		new(big.Int).Set(field.rbig),
+		new(big.Int).Rsh(p, 1),
+		// all ones limbs and a single high bit
+		new(big.Int).Sub(new(big.Int).Lsh(one, uint(p.BitLen()-1)), one),
+		new(big.Int).Lsh(one, uint(p.BitLen()-2)),
+	}
+	for i := 0; i < fuz; i++ {
+		samples = append(samples, randBig(p))
+	}
+	return samples
+}
+
+// TestMulBackendsCross runs ADX and non ADX multiplication kernels on the same
+// inputs and reports mismatches per limb size
+func TestMulBackendsCross(t *testing.T) {
+	if !(cpu.X86.HasADX && cpu.X86.HasBMI2) {
+		t.Skip("ADX and BMI2 are not available")
+	}
+	for limbSize := from; limbSize <= to; limbSize++ {
+		backends, ok := mulBackends[limbSize]
+		if !ok {
+			continue
+		}
+		t.Run(fmt.Sprintf("%d", limbSize), func(t *testing.T) {
+			// full width, one bit short and random width moduli
+			bitSizes := []int{limbSize * 64, limbSize*64 - 1, (limbSize-1)*64 + 1 + int(randBig(big.NewInt(64)).Int64())}
+			mismatches := 0
+			for _, bitSize := range bitSizes {
+				field := mulCrossField(limbSize, bitSize)
+				rinv := new(big.Int).ModInverse(field.rbig, field.pbig)
+				samples := mulCrossSamples(field)
+				c0, _ := newFieldElement(limbSize)
+				c1, _ := newFieldElement(limbSize)
+				for _, big_a := range samples {
+					for _, big_b := range samples {
+						a := newFieldElementFromBigUnchecked(limbSize, big_a)
+						b := newFieldElementFromBigUnchecked(limbSize, big_b)
+						backends.adx(c0, a, b, field.p, field.inp)
+						backends.noadx(c1, a, b, field.p, field.inp)
+						expected := new(big.Int).Mul(big_a, big_b)
+						expected.Mul(expected, rinv).Mod(expected, field.pbig)
+						have0, have1 := field.toBigNoTransform(c0), field.toBigNoTransform(c1)
+						if have0.Cmp(expected) == 0 && have1.Cmp(expected) == 0 {
+							continue
+						}
+						if mismatches == 0 {
+							t.Errorf("p: %x\na: %x\nb: %x\nadx: %x\nnon adx: %x\nwant: %x", field.pbig, big_a, big_b, have0, have1, expected)
+						}
+						mismatches++
+					}
+				}
+			}
+			if mismatches != 0 {
+				t.Errorf("%d mismatches at limb size %d", mismatches, limbSize)
+			}
+		})
+	}
+}
+`
+
+const fieldBenchmarksMultiple = `
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+)
+
+// BenchmarkField covers every field operation at limb sizes in range of from
+// and to flags, sub benchmarks are named as <bit size>_<operation>
+func BenchmarkField(t *testing.B) {
+	for limbSize := from; limbSize <= to; limbSize++ {
+		field := randField(limbSize)
+		if field.limbSize != limbSize {
+			t.Fatalf("bad field construction")
+		}
+		a := field.randFieldElement(rand.Reader)
+		b := field.randFieldElement(rand.Reader)
+		c := field.newFieldElement()
+		in_a := field.toBytes(a)
+		e := randBig(field.pbig)
+		bench := func(op string, f func()) {
+			t.Run(fmt.Sprintf("%d_%s", limbSize*64, op), func(t *testing.B) {
+				for i := 0; i < t.N; i++ {
+					f()
+				}
+			})
+		}
+		bench("add", func() { field.add(c, a, b) })
+		bench("sub", func() { field.sub(c, a, b) })
+		bench("double", func() { field.double(c, a) })
+		bench("neg", func() { field.neg(c, a) })
+		bench("mul", func() { field.mul(c, a, b) })
+		bench("square", func() { field.square(c, a) })
+		bench("exp", func() { field.exp(c, a, e) })
+		bench("inverse", func() { field.inverse(c, a) })
+		bench("to_mont", func() { field.toMont(c, a) })
+		bench("from_mont", func() { field.fromMont(c, a) })
+		bench("to_bytes", func() { field.toBytes(a) })
+		bench("from_bytes", func() { field.newFieldElementFromBytes(in_a) })
+		bench("cmp", func() { field.cmp(a, b) })
+	}
+}
+`
@@ -0,0 +1,201 @@
+package gocode
+
+func ctTest(fixedModulus bool) string {
+	if fixedModulus {
+		return ctTestCommon + ctTestFixedModulus
+	}
+	return ctTestCommon + ctTestNonFixedModulus
+}
+
+const ctTestCommon = `
+import (
+	"crypto/rand"
+	"flag"
+	"math"
+	"sort"
+	"testing"
+	"time"
+)
+
+var ctMeasurements = flag.Int("ct", 0, "# of measurements per operation of constant time tests, zero skips them")
+var ctThreshold = flag.Float64("ct-threshold", 10, "welch t statistic above which an operation is reported as leaking")
+
+// welch accumulates measurements of two classes with welford's method
+type welch struct {
+	n    [2]float64
+	mean [2]float64
+	m2   [2]float64
+}
+
+func (w *welch) push(class int, x float64) {
+	w.n[class]++
+	d := x - w.mean[class]
+	w.mean[class] += d / w.n[class]
+	w.m2[class] += d * (x - w.mean[class])
+}
+
+// t returns absolute value of welch's t statistic
+func (w *welch) t() float64 {
+	if w.n[0] < 2 || w.n[1] < 2 {
+		return 0
+	}
+	v0 := w.m2[0] / (w.n[0] - 1)
+	v1 := w.m2[1] / (w.n[1] - 1)
+	den := math.Sqrt(v0/w.n[0] + v1/w.n[1])
+	if den == 0 {
+		return 0
+	}
+	return math.Abs(w.mean[0]-w.mean[1]) / den
+}
+
+// dudect times batch runs of op for n inputs of fixed and random classes
+// picked in random order and returns the largest t statistic over all
+// measurements and measurements cropped at lower percentiles. prepare is
+// called before any measurement so input generation is not timed.
+func dudect(n int, batch int, prepare func(class int, i int), op func(i int)) float64 {
+	bits := make([]byte, n)
+	if _, err := rand.Read(bits); err != nil {
+		panic(err)
+	}
+	classes := make([]int, n)
+	for i := range classes {
+		classes[i] = int(bits[i] & 1)
+		prepare(classes[i], i)
+	}
+	times := make([]float64, n)
+	for i := 0; i < n; i++ {
+		start := time.Now()
+		for k := 0; k < batch; k++ {
+			op(i)
+		}
+		times[i] = float64(time.Since(start))
+	}
+	sorted := append([]float64{}, times...)
+	sort.Float64s(sorted)
+	max := 0.0
+	for _, percentile := range []float64{1, 0.9, 0.5} {
+		limit := sorted[int(percentile*float64(n-1))]
+		w := new(welch)
+		for i, x := range times {
+			if x <= limit {
+				w.push(classes[i], x)
+			}
+		}
+		if t := w.t(); t > max {
+			max = t
+		}
+	}
+	return max
+}
+`
+
+const ctTestFixedModulus = `
+// TestConstantTime compares timings of operations with zero against random
+// inputs. Exponent of exp is public. Conditions of conditional operations and
+// lookup indexes are taken from the input so that they are fixed for zero
+// class.
+func TestConstantTime(t *testing.T) {
+	if *ctMeasurements == 0 {
+		t.Skip("constant time tests are enabled with -ct flag")
+	}
+	n := *ctMeasurements
+	b, _ := randFieldElement(rand.Reader)
+	e := randBig(pbig)
+	c, d := newFieldElement(), newFieldElement()
+	table := make([]fieldElement, 16)
+	for i := range table {
+		a, _ := randFieldElement(rand.Reader)
+		table[i].set(a)
+	}
+	for _, op := range []struct {
+		name  string
+		batch int
+		f     func(a *fieldElement)
+	}{
+		{"add", 32, func(a *fieldElement) { add(c, a, b) }},
+		{"sub", 32, func(a *fieldElement) { sub(c, a, b) }},
+		{"mul", 32, func(a *fieldElement) { mul(c, a, b) }},
+		{"neg", 32, func(a *fieldElement) { neg(c, a) }},
+		{"cmov", 32, func(a *fieldElement) { cmov(c, a, b, a[0]&1 == 1) }},
+		{"cswap", 32, func(a *fieldElement) { cswap(c, d, a[0]&1 == 1) }},
+		{"cond_neg", 32, func(a *fieldElement) { condNeg(c, a, a[0]&1 == 1) }},
+		{"lookup", 4, func(a *fieldElement) { constTimeLookup(c, table, int(a[0]%16)) }},
+		{"exp", 1, func(a *fieldElement) { exp(c, a, e) }},
+		{"inverse", 1, func(a *fieldElement) { inverse(c, a) }},
+	} {
+		// inputs of both classes share one allocation so that they do not
+		// differ in memory layout
+		inputs := make([]fieldElement, n)
+		stat := dudect(n, op.batch, func(class int, i int) {
+			if class == 0 {
+				inputs[i].set(zero)
+				return
+			}
+			a, _ := randFieldElement(rand.Reader)
+			inputs[i].set(a)
+		}, func(i int) {
+			op.f(&inputs[i])
+		})
+		t.Logf("%s, t: %.2f", op.name, stat)
+		if stat > *ctThreshold {
+			t.Errorf("%s leaks timing, t: %.2f", op.name, stat)
+		}
+	}
+}
+`
+
+const ctTestNonFixedModulus = `
+// TestConstantTime compares timings of operations with zero against random
+// inputs. Exponent of exp is public. Conditions of conditional operations and
+// lookup indexes are taken from the input so that they are fixed for zero
+// class.
+func TestConstantTime(t *testing.T) {
+	if *ctMeasurements == 0 {
+		t.Skip("constant time tests are enabled with -ct flag")
+	}
+	n := *ctMeasurements
+	field := randField()
+	b, _ := field.randFieldElement(rand.Reader)
+	e := randBig(field.pbig)
+	c, d := field.newFieldElement(), field.newFieldElement()
+	table := make([]fieldElement, 16)
+	for i := range table {
+		a, _ := field.randFieldElement(rand.Reader)
+		table[i].set(a)
+	}
+	for _, op := range []struct {
+		name  string
+		batch int
+		f     func(a *fieldElement)
+	}{
+		{"add", 32, func(a *fieldElement) { field.add(c, a, b) }},
+		{"sub", 32, func(a *fieldElement) { field.sub(c, a, b) }},
+		{"mul", 32, func(a *fieldElement) { field.mul(c, a, b) }},
+		{"neg", 32, func(a *fieldElement) { field.neg(c, a) }},
+		{"cmov", 32, func(a *fieldElement) { field.cmov(c, a, b, a[0]&1 == 1) }},
+		{"cswap", 32, func(a *fieldElement) { field.cswap(c, d, a[0]&1 == 1) }},
+		{"cond_neg", 32, func(a *fieldElement) { field.condNeg(c, a, a[0]&1 == 1) }},
+		{"lookup", 4, func(a *fieldElement) { field.constTimeLookup(c, table, int(a[0]%16)) }},
+		{"exp", 1, func(a *fieldElement) { field.exp(c, a, e) }},
+		{"inverse", 1, func(a *fieldElement) { field.inverse(c, a) }},
+	} {
+		// inputs of both classes share one allocation so that they do not
+		// differ in memory layout
+		inputs := make([]fieldElement, n)
+		stat := dudect(n, op.batch, func(class int, i int) {
+			if class == 0 {
+				inputs[i].set(field.zero)
+				return
+			}
+			a, _ := field.randFieldElement(rand.Reader)
+			inputs[i].set(a)
+		}, func(i int) {
+			op.f(&inputs[i])
+		})
+		t.Logf("%s, t: %.2f", op.name, stat)
+		if stat > *ctThreshold {
+			t.Errorf("%s leaks timing, t: %.2f", op.name, stat)
+		}
+	}
+}
+`
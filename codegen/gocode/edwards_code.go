@@ -0,0 +1,828 @@
+package gocode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+)
+
+// GenEdwards generates twisted edwards curve a*x^2 + y^2 = 1 + d*x^2*y^2
+// group over a fixed modulus field. Curve parameters are comma separated and
+// given as "a,d,gx,gy,n,h" where (gx, gy) generates subgroup of order n and h
+// is the cofactor.
+func GenEdwards(out string, modulus string, params string) error {
+	if len(modulus) < 2 || modulus[:2] != "0x" {
+		return fmt.Errorf("Bad format for modulus\n")
+	}
+	bts, err := hex.DecodeString(modulus[2:])
+	if err != nil {
+		return err
+	}
+	limbSize := resolveBitSize(len(bts)) / 64
+	modulusBig := new(big.Int).SetBytes(bts)
+	c, err := parseEdwardsParams(params, modulusBig)
+	if err != nil {
+		return err
+	}
+	outDir := filepath.Clean(out)
+	writeToFile(pkg("fp")+edwardsImpl(limbSize, modulusBig, c), filepath.Join(outDir, "edwards.go"))
+	writeToFile(pkg("fp")+edwardsTest, filepath.Join(outDir, "edwards_test.go"))
+	return nil
+}
+
+type edwardsParams struct {
+	a, d, gx, gy, n, h *big.Int
+	// unified addition is complete if a is square and d is not
+	complete bool
+}
+
+func parseEdwardsParams(in string, modulus *big.Int) (*edwardsParams, error) {
+	parts := strings.Split(in, ",")
+	if len(parts) != 6 {
+		return nil, fmt.Errorf("expected six curve parameters, have %d\n", len(parts))
+	}
+	v := make([]*big.Int, 6)
+	for i, part := range parts {
+		c, ok := new(big.Int).SetString(strings.TrimSpace(part), 0)
+		if !ok {
+			return nil, fmt.Errorf("bad curve parameter %s\n", part)
+		}
+		v[i] = c
+	}
+	for i := 0; i < 4; i++ {
+		v[i].Mod(v[i], modulus)
+	}
+	c := &edwardsParams{a: v[0], d: v[1], gx: v[2], gy: v[3], n: v[4], h: v[5]}
+	if c.n.Sign() <= 0 || c.h.Sign() <= 0 {
+		return nil, fmt.Errorf("order and cofactor should be positive\n")
+	}
+	if c.a.Sign() == 0 || c.d.Sign() == 0 || c.a.Cmp(c.d) == 0 {
+		return nil, fmt.Errorf("curve constants a and d should be non zero and distinct\n")
+	}
+	c.complete = isSquareBig(c.a, modulus) && !isSquareBig(c.d, modulus)
+	// a*gx^2 + gy^2 == 1 + d*gx^2*gy^2
+	x2 := new(big.Int).Mul(c.gx, c.gx)
+	y2 := new(big.Int).Mul(c.gy, c.gy)
+	lhs := new(big.Int).Mul(c.a, x2)
+	lhs.Add(lhs, y2)
+	rhs := new(big.Int).Mul(c.d, x2)
+	rhs.Mul(rhs, y2).Add(rhs, big.NewInt(1))
+	if lhs.Sub(lhs, rhs).Mod(lhs, modulus).Sign() != 0 {
+		return nil, fmt.Errorf("generator is not on curve\n")
+	}
+	return c, nil
+}
+
+// edwardsImpl returns extended coordinates implementation. Multiplication
+// with a is specialised for a = -1 and a = 1. Unified addition is emitted for
+// complete curves and dedicated addition otherwise.
+func edwardsImpl(limbSize int, modulus *big.Int, c *edwardsParams) string {
+	code := edwardsImports
+	code += encodeBigMont("edwardsA", limbSize, c.a, modulus)
+	code += encodeBigMont("edwardsD", limbSize, c.d, modulus)
+	code += encodeBigMont("edwardsGx", limbSize, c.gx, modulus)
+	code += encodeBigMont("edwardsGy", limbSize, c.gy, modulus)
+	code += fmt.Sprintf("var edwardsOrder, _ = new(big.Int).SetString(\"%s\", 10)\n\n", c.n.String())
+	code += fmt.Sprintf("var edwardsCofactor, _ = new(big.Int).SetString(\"%s\", 10)\n\n", c.h.String())
+	// one more bit is required for sign of x
+	code += fmt.Sprintf("const edwardsEncodedSize = %d\n\n", modulus.BitLen()/8+1)
+	code += edwardsCommon
+	if c.complete {
+		code += edwardsAddUnified
+	} else {
+		code += edwardsAddDedicated
+	}
+	minusOne := new(big.Int).Sub(modulus, big.NewInt(1))
+	switch {
+	case c.a.Cmp(minusOne) == 0:
+		code += edwardsMulByAMinusOne
+	case c.a.Cmp(big.NewInt(1)) == 0:
+		code += edwardsMulByAOne
+	default:
+		code += edwardsMulByA
+	}
+	return code
+}
+
+const edwardsImports = `
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+`
+
+const edwardsCommon = `
+// edwardsPoint is a twisted edwards curve point in extended coordinates
+// (X : Y : Z : T) representing affine (X/Z, Y/Z) with T = XY/Z. Neutral
+// element is (0 : 1 : 1 : 0).
+type edwardsPoint [4]fieldElement
+
+// newEdwardsPoint returns neutral element
+func newEdwardsPoint() *edwardsPoint {
+	return new(edwardsPoint).zero()
+}
+
+// newEdwardsPointFromAffine returns point (x, y) if it is on curve
+func newEdwardsPointFromAffine(x, y *fieldElement) (*edwardsPoint, error) {
+	p := &edwardsPoint{}
+	p[0].set(x)
+	p[1].set(y)
+	p[2].set(one)
+	mul(&p[3], x, y)
+	if !edwardsIsOnCurve(p) {
+		return nil, errors.New("point is not on curve")
+	}
+	return p, nil
+}
+
+// edwardsGenerator returns a new copy of subgroup generator
+func edwardsGenerator() *edwardsPoint {
+	p := &edwardsPoint{}
+	p[0].set(edwardsGx)
+	p[1].set(edwardsGy)
+	p[2].set(one)
+	mul(&p[3], edwardsGx, edwardsGy)
+	return p
+}
+
+func (p *edwardsPoint) set(q *edwardsPoint) *edwardsPoint {
+	p[0].set(&q[0])
+	p[1].set(&q[1])
+	p[2].set(&q[2])
+	p[3].set(&q[3])
+	return p
+}
+
+func (p *edwardsPoint) zero() *edwardsPoint {
+	p[0].set(zero)
+	p[1].set(one)
+	p[2].set(one)
+	p[3].set(zero)
+	return p
+}
+
+func (p *edwardsPoint) isZero() bool {
+	return isZero(&p[0]) && p[1].equal(&p[2])
+}
+
+func (p *edwardsPoint) equal(q *edwardsPoint) bool {
+	t0, t1 := newFieldElement(), newFieldElement()
+	mul(t0, &p[0], &q[2])
+	mul(t1, &q[0], &p[2])
+	if !t0.equal(t1) {
+		return false
+	}
+	mul(t0, &p[1], &q[2])
+	mul(t1, &q[1], &p[2])
+	return t0.equal(t1)
+}
+
+// edwardsAffine sets r to p with Z = 1
+func edwardsAffine(r, p *edwardsPoint) {
+	zInv := newFieldElement()
+	inverse(zInv, &p[2])
+	mul(&r[0], &p[0], zInv)
+	mul(&r[1], &p[1], zInv)
+	mul(&r[3], &r[0], &r[1])
+	r[2].set(one)
+}
+
+// edwardsIsOnCurve checks (a * X^2 + Y^2) * Z^2 = Z^4 + d * X^2 * Y^2 and
+// X * Y = Z * T
+func edwardsIsOnCurve(p *edwardsPoint) bool {
+	X, Y, Z, T := &p[0], &p[1], &p[2], &p[3]
+	if isZero(Z) {
+		return false
+	}
+	x2, y2, z2, lhs, rhs := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	mul(lhs, X, Y)
+	mul(rhs, Z, T)
+	if !lhs.equal(rhs) {
+		return false
+	}
+	mul(x2, X, X)
+	mul(y2, Y, Y)
+	mul(z2, Z, Z)
+	mulByA(lhs, x2)
+	add(lhs, lhs, y2)
+	mul(lhs, lhs, z2)
+	mul(rhs, x2, y2)
+	mul(rhs, rhs, edwardsD)
+	mul(z2, z2, z2)
+	add(rhs, rhs, z2)
+	return lhs.equal(rhs)
+}
+
+// edwardsIsInSubgroup checks if p is on curve and n * p is neutral element
+func edwardsIsInSubgroup(p *edwardsPoint) bool {
+	if !edwardsIsOnCurve(p) {
+		return false
+	}
+	t := newEdwardsPoint()
+	edwardsMulScalar(t, p, edwardsOrder)
+	return t.isZero()
+}
+
+// edwardsDouble sets r to 2 * p.
+// Hisil, Wong, Carter, Dawson 2008, dbl-2008-hwcd
+func edwardsDouble(r, p *edwardsPoint) {
+	A, B, C, D, E, F, G, H := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	X1, Y1, Z1 := &p[0], &p[1], &p[2]
+	mul(A, X1, X1)
+	mul(B, Y1, Y1)
+	mul(C, Z1, Z1)
+	double(C, C)
+	mulByA(D, A)
+	add(E, X1, Y1)
+	mul(E, E, E)
+	sub(E, E, A)
+	sub(E, E, B)
+	add(G, D, B)
+	sub(F, G, C)
+	sub(H, D, B)
+	mul(&r[0], E, F)
+	mul(&r[1], G, H)
+	mul(&r[3], E, H)
+	mul(&r[2], F, G)
+}
+
+func edwardsNeg(r, p *edwardsPoint) {
+	neg(&r[0], &p[0])
+	r[1].set(&p[1])
+	r[2].set(&p[2])
+	neg(&r[3], &p[3])
+}
+
+func edwardsSub(r, p, q *edwardsPoint) {
+	t := newEdwardsPoint()
+	edwardsNeg(t, q)
+	edwardsAdd(r, p, t)
+}
+
+// edwardsMulScalar sets r to e * p with double and add, it is not constant
+// time
+func edwardsMulScalar(r, p *edwardsPoint, e *big.Int) {
+	q, k := newEdwardsPoint(), new(big.Int).Abs(e)
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		edwardsDouble(q, q)
+		if k.Bit(i) == 1 {
+			edwardsAdd(q, q, p)
+		}
+	}
+	if e.Sign() < 0 {
+		edwardsNeg(q, q)
+	}
+	r.set(q)
+}
+
+// edwardsClearCofactor sets r to h * p
+func edwardsClearCofactor(r, p *edwardsPoint) {
+	edwardsMulScalar(r, p, edwardsCofactor)
+}
+
+// edwardsToBytes returns RFC 8032 style compressed encoding of p, that is
+// little endian y with sign of x at the most significant bit.
+func edwardsToBytes(p *edwardsPoint) []byte {
+	a := newEdwardsPoint()
+	edwardsAffine(a, p)
+	out := make([]byte, edwardsEncodedSize)
+	copy(out, encode(&a[1], littleEndianMinimal))
+	if sgn0(&a[0]) {
+		out[edwardsEncodedSize-1] |= 0x80
+	}
+	return out
+}
+
+// edwardsFromBytes decodes compressed point as in RFC 8032, section 5.1.3.
+// Decoded point is on curve but subgroup membership should be checked with
+// edwardsIsInSubgroup.
+func edwardsFromBytes(in []byte) (*edwardsPoint, error) {
+	if len(in) != edwardsEncodedSize {
+		return nil, fmt.Errorf("bad input size %d, expected %d", len(in), edwardsEncodedSize)
+	}
+	b := append([]byte{}, in...)
+	sign := b[edwardsEncodedSize-1]>>7 == 1
+	b[edwardsEncodedSize-1] &= 0x7f
+	for i := modulusByteSize; i < edwardsEncodedSize; i++ {
+		if b[i] != 0 {
+			return nil, fmt.Errorf("non zero padding byte at %d", i)
+		}
+	}
+	y, err := decode(b[:modulusByteSize], littleEndianMinimal)
+	if err != nil {
+		return nil, err
+	}
+	// x^2 = (y^2 - 1) / (d * y^2 - a)
+	x, u, v := newFieldElement(), newFieldElement(), newFieldElement()
+	mul(u, y, y)
+	mul(v, u, edwardsD)
+	sub(u, u, one)
+	sub(v, v, edwardsA)
+	if isZero(v) || !sqrtRatio(x, u, v) {
+		return nil, errors.New("point is not on curve")
+	}
+	if isZero(x) && sign {
+		return nil, errors.New("bad sign for zero x")
+	}
+	if sgn0(x) != sign {
+		neg(x, x)
+	}
+	return newEdwardsPointFromAffine(x, y)
+}
+`
+
+const edwardsAddUnified = `
+// edwardsAdd sets r to p + q with unified formulas which are complete since
+// a is square and d is non square.
+// Hisil, Wong, Carter, Dawson 2008, add-2008-hwcd
+func edwardsAdd(r, p, q *edwardsPoint) {
+	A, B, C, D, E, F, G, H := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	X1, Y1, Z1, T1 := &p[0], &p[1], &p[2], &p[3]
+	X2, Y2, Z2, T2 := &q[0], &q[1], &q[2], &q[3]
+	mul(A, X1, X2)
+	mul(B, Y1, Y2)
+	mul(C, T1, edwardsD)
+	mul(C, C, T2)
+	mul(D, Z1, Z2)
+	add(E, X1, Y1)
+	add(F, X2, Y2)
+	mul(E, E, F)
+	sub(E, E, A)
+	sub(E, E, B)
+	sub(F, D, C)
+	add(G, D, C)
+	mulByA(H, A)
+	sub(H, B, H)
+	mul(&r[0], E, F)
+	mul(&r[1], G, H)
+	mul(&r[3], E, H)
+	mul(&r[2], F, G)
+}
+`
+
+const edwardsAddDedicated = `
+// edwardsAdd sets r to p + q. Unified formulas are not complete since a is
+// non square or d is square, so dedicated formulas are used and equal points
+// are doubled. Result is correct if p - q is not a point of order two or
+// four, which holds for points of odd order subgroup. It is not constant
+// time.
+// Hisil, Wong, Carter, Dawson 2008, add-2008-hwcd-2
+func edwardsAdd(r, p, q *edwardsPoint) {
+	if p.equal(q) {
+		edwardsDouble(r, p)
+		return
+	}
+	A, B, C, D, E, F, G, H := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	X1, Y1, Z1, T1 := &p[0], &p[1], &p[2], &p[3]
+	X2, Y2, Z2, T2 := &q[0], &q[1], &q[2], &q[3]
+	mul(A, X1, X2)
+	mul(B, Y1, Y2)
+	mul(C, Z1, T2)
+	mul(D, T1, Z2)
+	add(E, D, C)
+	sub(F, X1, Y1)
+	add(G, X2, Y2)
+	mul(F, F, G)
+	add(F, F, B)
+	sub(F, F, A)
+	mulByA(G, A)
+	add(G, B, G)
+	sub(H, D, C)
+	mul(&r[0], E, F)
+	mul(&r[1], G, H)
+	mul(&r[3], E, H)
+	mul(&r[2], F, G)
+}
+`
+
+const edwardsMulByAMinusOne = `
+// mulByA sets c to a * x where a = -1
+func mulByA(c, x *fieldElement) {
+	neg(c, x)
+}
+`
+
+const edwardsMulByAOne = `
+// mulByA sets c to a * x where a = 1
+func mulByA(c, x *fieldElement) {
+	c.set(x)
+}
+`
+
+const edwardsMulByA = `
+// mulByA sets c to a * x
+func mulByA(c, x *fieldElement) {
+	mul(c, edwardsA, x)
+}
+`
+
+const edwardsTest = `
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// edwardsRefAdd adds affine points with big.Int reference arithmetic
+func edwardsRefAdd(p, q []*big.Int) []*big.Int {
+	a, d := toBig(edwardsA), toBig(edwardsD)
+	x1y2 := new(big.Int).Mul(p[0], q[1])
+	y1x2 := new(big.Int).Mul(p[1], q[0])
+	x1x2 := new(big.Int).Mul(p[0], q[0])
+	y1y2 := new(big.Int).Mul(p[1], q[1])
+	t := new(big.Int).Mul(x1x2, y1y2)
+	t.Mul(t, d).Mod(t, pbig)
+	one := big.NewInt(1)
+	// x3 = (x1y2 + y1x2) / (1 + t)
+	x := new(big.Int).Add(x1y2, y1x2)
+	x.Mul(x, new(big.Int).ModInverse(new(big.Int).Add(one, t), pbig)).Mod(x, pbig)
+	// y3 = (y1y2 - a x1x2) / (1 - t)
+	y := new(big.Int).Mul(a, x1x2)
+	y.Sub(y1y2, y)
+	y.Mul(y, new(big.Int).ModInverse(new(big.Int).Mod(new(big.Int).Sub(one, t), pbig), pbig)).Mod(y, pbig)
+	return []*big.Int{x, y}
+}
+
+func edwardsRefMul(p []*big.Int, e *big.Int) []*big.Int {
+	r := []*big.Int{big.NewInt(0), big.NewInt(1)}
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = edwardsRefAdd(r, r)
+		if e.Bit(i) == 1 {
+			r = edwardsRefAdd(r, p)
+		}
+	}
+	return r
+}
+
+func edwardsToRef(p *edwardsPoint) []*big.Int {
+	a := newEdwardsPoint()
+	edwardsAffine(a, p)
+	return []*big.Int{toBig(&a[0]), toBig(&a[1])}
+}
+
+func edwardsRefEqual(p, q []*big.Int) bool {
+	return p[0].Cmp(q[0]) == 0 && p[1].Cmp(q[1]) == 0
+}
+
+// randEdwardsPoint returns a random point on curve which is not necessarily
+// in the subgroup
+func randEdwardsPoint() *edwardsPoint {
+	for {
+		y, _ := randFieldElement(rand.Reader)
+		in := make([]byte, edwardsEncodedSize)
+		copy(in, encode(y, littleEndianMinimal))
+		if p, err := edwardsFromBytes(in); err == nil {
+			return p
+		}
+	}
+}
+
+func randEdwardsScalar(t *testing.T) *big.Int {
+	e, err := rand.Int(rand.Reader, edwardsOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+// randEdwardsProjective scales coordinates of p with a random factor
+func randEdwardsProjective(p *edwardsPoint) *edwardsPoint {
+	z, _ := randFieldElement(rand.Reader)
+	q := new(edwardsPoint)
+	for i := 0; i < 4; i++ {
+		mul(&q[i], &p[i], z)
+	}
+	// T = XY / Z
+	mul(&q[3], &q[3], z)
+	inverse(z, z)
+	mul(&q[3], &q[3], z)
+	return q
+}
+
+func TestEdwardsGenerator(t *testing.T) {
+	g := edwardsGenerator()
+	if !edwardsIsOnCurve(g) {
+		t.Fatalf("generator is not on curve")
+	}
+	if !edwardsIsInSubgroup(g) {
+		t.Fatalf("generator is not in subgroup")
+	}
+	if !newEdwardsPoint().isZero() || !edwardsIsInSubgroup(newEdwardsPoint()) {
+		t.Fatalf("bad neutral element")
+	}
+}
+
+func TestEdwardsAdd(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		p0, q0 := randEdwardsPoint(), randEdwardsPoint()
+		a, b := edwardsToRef(p0), edwardsToRef(q0)
+		p, q, r := randEdwardsProjective(p0), randEdwardsProjective(q0), newEdwardsPoint()
+		if !edwardsIsOnCurve(p) || !p.equal(p0) {
+			t.Fatalf("bad projective point")
+		}
+		edwardsAdd(r, p, q)
+		if !edwardsIsOnCurve(r) || !edwardsRefEqual(edwardsToRef(r), edwardsRefAdd(a, b)) {
+			t.Fatalf("bad point addition")
+		}
+		edwardsSub(r, r, q)
+		if !r.equal(p) {
+			t.Fatalf("bad point subtraction")
+		}
+		edwardsAdd(r, p, p)
+		if !edwardsRefEqual(edwardsToRef(r), edwardsRefAdd(a, a)) {
+			t.Fatalf("bad addition of equal points")
+		}
+		edwardsDouble(r, q)
+		if !edwardsIsOnCurve(r) || !edwardsRefEqual(edwardsToRef(r), edwardsRefAdd(b, b)) {
+			t.Fatalf("bad point doubling")
+		}
+		edwardsNeg(r, p)
+		edwardsAdd(r, r, p)
+		if !r.isZero() {
+			t.Fatalf("p - p should be neutral element")
+		}
+		edwardsAdd(r, p, newEdwardsPoint())
+		if !r.equal(p) {
+			t.Fatalf("p + 0 == p")
+		}
+	}
+}
+
+func TestEdwardsMulScalar(t *testing.T) {
+	g := edwardsGenerator()
+	for i := 0; i < fuz; i++ {
+		e := randEdwardsScalar(t)
+		r := newEdwardsPoint()
+		edwardsMulScalar(r, g, e)
+		if !edwardsRefEqual(edwardsToRef(r), edwardsRefMul(edwardsToRef(g), e)) {
+			t.Fatalf("bad scalar multiplication")
+		}
+		q := newEdwardsPoint()
+		edwardsMulScalar(q, g, new(big.Int).Sub(edwardsOrder, e))
+		edwardsAdd(q, q, r)
+		if !q.isZero() {
+			t.Fatalf("bad scalar multiplication")
+		}
+	}
+}
+
+func TestEdwardsSubgroup(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		p, r := randEdwardsPoint(), newEdwardsPoint()
+		edwardsClearCofactor(r, p)
+		if !edwardsIsInSubgroup(r) {
+			t.Fatalf("cofactor cleared point should be in subgroup")
+		}
+	}
+}
+
+func TestEdwardsEncoding(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		p := randEdwardsProjective(randEdwardsPoint())
+		b := edwardsToBytes(p)
+		if len(b) != edwardsEncodedSize {
+			t.Fatalf("bad encoding size")
+		}
+		q, err := edwardsFromBytes(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !q.equal(p) {
+			t.Fatalf("bad point encoding")
+		}
+		a := edwardsToRef(p)
+		e := reverseBytes(padBytes(a[1].Bytes(), edwardsEncodedSize))
+		e[edwardsEncodedSize-1] |= byte(a[0].Bit(0)) << 7
+		if !bytes.Equal(b, e) {
+			t.Fatalf("bad point encoding")
+		}
+	}
+	if b := edwardsToBytes(newEdwardsPoint()); !bytes.Equal(b, append([]byte{1}, make([]byte, edwardsEncodedSize-1)...)) {
+		t.Fatalf("bad encoding of neutral element")
+	}
+}
+
+func TestEdwardsEncodingErrors(t *testing.T) {
+	if _, err := edwardsFromBytes(make([]byte, edwardsEncodedSize-1)); err == nil {
+		t.Fatalf("short input should be rejected")
+	}
+	if _, err := edwardsFromBytes(make([]byte, edwardsEncodedSize+1)); err == nil {
+		t.Fatalf("long input should be rejected")
+	}
+	// y is not smaller than modulus
+	in := reverseBytes(padBytes(pbig.Bytes(), edwardsEncodedSize))
+	if _, err := edwardsFromBytes(in); err == nil {
+		t.Fatalf("non canonical y should be rejected")
+	}
+	// x is zero for y = 1 so sign bit should not be set
+	in = make([]byte, edwardsEncodedSize)
+	in[0], in[edwardsEncodedSize-1] = 1, 0x80
+	if _, err := edwardsFromBytes(in); err == nil {
+		t.Fatalf("negative zero x should be rejected")
+	}
+	// y with no corresponding x
+	for {
+		y, _ := randFieldElement(rand.Reader)
+		x, u, v := newFieldElement(), newFieldElement(), newFieldElement()
+		mul(u, y, y)
+		mul(v, u, edwardsD)
+		sub(u, u, one)
+		sub(v, v, edwardsA)
+		if sqrtRatio(x, u, v) {
+			continue
+		}
+		in := make([]byte, edwardsEncodedSize)
+		copy(in, encode(y, littleEndianMinimal))
+		if _, err := edwardsFromBytes(in); err == nil {
+			t.Fatalf("y with no corresponding x should be rejected")
+		}
+		break
+	}
+}
+
+// isEd25519 returns true if generated curve is edwards25519
+func isEd25519() bool {
+	p := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	d := new(big.Int).ModInverse(big.NewInt(121666), p)
+	d.Mul(d, big.NewInt(-121665)).Mod(d, p)
+	return pbig.Cmp(p) == 0 && toBig(edwardsD).Cmp(d) == 0 && edwardsCofactor.Cmp(big.NewInt(8)) == 0
+}
+
+// ed25519Vectors are the first lines of sign.input of Go standard library
+// in private key, public key, message, signature and message form
+var ed25519Vectors = []string{
+	"9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a:d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a::e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b:",
+	"4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c:3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c:72:92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c0072:",
+	"c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025:fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025:af82:6291d657deec24024827e69c3abe01a30ce548a284743a445e3680d7db5ac3ac18ff9b538d16f290ae67f760984dc6594a7c15e9716ed28dc027beceea1ec40aaf82:",
+	"0d4a05b07352a5436e180356da0ae6efa0345ff7fb1572575772e8005ed978e9e61a185bcef2613a6c7cb79763ce945d3b245d76114dd440bcf5f2dc1aa57057:e61a185bcef2613a6c7cb79763ce945d3b245d76114dd440bcf5f2dc1aa57057:cbc77b:d9868d52c2bebce5f3fa5a79891970f309cb6591e3e1702a70276fa97c24b3a8e58606c38c9758529da50ee31b8219cba45271c689afa60b0ea26c99db19b00ccbc77b:",
+}
+
+func leBig(in []byte) *big.Int {
+	return new(big.Int).SetBytes(reverseBytes(in))
+}
+
+// checkEd25519Vector derives public key and signature of RFC 8032 with
+// generated curve arithmetic
+func checkEd25519Vector(t *testing.T, line string) {
+	parts := strings.Split(line, ":")
+	if len(parts) != 5 {
+		t.Fatalf("bad vector %s", line)
+	}
+	priv, _ := hex.DecodeString(parts[0])
+	pub, _ := hex.DecodeString(parts[1])
+	msg, _ := hex.DecodeString(parts[2])
+	sig, _ := hex.DecodeString(parts[3])
+	sig = sig[:64]
+	g := edwardsGenerator()
+	h := sha512.Sum512(priv[:32])
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	s := leBig(h[:32])
+	A := newEdwardsPoint()
+	edwardsMulScalar(A, g, s)
+	if !bytes.Equal(edwardsToBytes(A), pub) {
+		t.Fatalf("bad public key")
+	}
+	if p, err := edwardsFromBytes(pub); err != nil || !p.equal(A) {
+		t.Fatalf("bad public key decoding")
+	}
+	hr := sha512.New()
+	hr.Write(h[32:])
+	hr.Write(msg)
+	r := leBig(hr.Sum(nil))
+	r.Mod(r, edwardsOrder)
+	R := newEdwardsPoint()
+	edwardsMulScalar(R, g, r)
+	encR := edwardsToBytes(R)
+	if !bytes.Equal(encR, sig[:32]) {
+		t.Fatalf("bad signature commitment")
+	}
+	hk := sha512.New()
+	hk.Write(encR)
+	hk.Write(pub)
+	hk.Write(msg)
+	k := leBig(hk.Sum(nil))
+	k.Mod(k, edwardsOrder)
+	S := new(big.Int).Mul(k, s)
+	S.Add(S, r).Mod(S, edwardsOrder)
+	if !bytes.Equal(reverseBytes(padBytes(S.Bytes(), 32)), sig[32:]) {
+		t.Fatalf("bad signature scalar")
+	}
+	// [S]B == R + [k]A
+	lhs, rhs := newEdwardsPoint(), newEdwardsPoint()
+	edwardsMulScalar(lhs, g, S)
+	edwardsMulScalar(rhs, A, k)
+	edwardsAdd(rhs, rhs, R)
+	if !lhs.equal(rhs) {
+		t.Fatalf("bad signature verification")
+	}
+}
+
+func TestEd25519Vectors(t *testing.T) {
+	if !isEd25519() {
+		t.Skip("curve is not edwards25519")
+	}
+	for _, line := range ed25519Vectors {
+		checkEd25519Vector(t, line)
+	}
+	// run complete set of standard library if it is available
+	f, err := os.Open(filepath.Join(runtime.GOROOT(), "src", "crypto", "ed25519", "testdata", "sign.input.gz"))
+	if err != nil {
+		t.Logf("skipping standard library vectors: %v", err)
+		return
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		checkEd25519Vector(t, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// isBandersnatch returns true if generated curve is bandersnatch over scalar
+// field of BLS12-381
+func isBandersnatch() bool {
+	p, _ := new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+	d, _ := new(big.Int).SetString("6389c12633c267cbc66e3bf86be3b6d8cb66677177e54f92b369f2f5188d58e7", 16)
+	a := new(big.Int).Sub(p, big.NewInt(5))
+	return pbig.Cmp(p) == 0 && toBig(edwardsA).Cmp(a) == 0 && toBig(edwardsD).Cmp(d) == 0
+}
+
+// bandersnatchVectors are multiples of bandersnatch generator in affine
+// coordinates computed with ecc/bls12-381/bandersnatch of gnark-crypto v0.22.0
+var bandersnatchVectors = []struct {
+	k, x, y string
+}{
+	{"1", "0x29c132cc2c0b34c5743711777bbe42f32b79c022ad998465e1e71866a252ae18", "0x2a6c669eda123e0f157d8b50badcd586358cad81eee464605e3167b6cc974166"},
+	{"2", "0x30433263b93777d7d9afef0ad0c2917e183ef5a9de026eeda53626c7c6631b2c", "0x2a2c8f6465887ceee9ee3185f32b42829e0dfa7f6c65f0071039026018903b8b"},
+	{"3", "0x2a7a99b0870a6244304b9231050859771fe941cad1bcaede655d2278621a3466", "0x2663e58bc157a7cf84d49524700a147bb53489232ea5962c3765bbfe95004080"},
+	{"123456789", "0x223b62023c556b8c087101272d0eee655af54324238608e61ebcea5f6a6d2666", "0x4d9ff9338a4531ec5273c7d8fa39d24823e488d0d87429e8f8a31bd257f82fdf"},
+	{"0x1cfb69d4ca675f520cce760202687600ff8f87007419047174fd06b52876e7e0", "0x4a2c7486fd924882bf02c6908de395122843e3e05264d7991e18e7985dad51e9", "0x2a6c669eda123e0f157d8b50badcd586358cad81eee464605e3167b6cc974166"},
+}
+
+func TestBandersnatchVectors(t *testing.T) {
+	if !isBandersnatch() {
+		t.Skip("curve is not bandersnatch")
+	}
+	n, _ := new(big.Int).SetString("1cfb69d4ca675f520cce760202687600ff8f87007419047174fd06b52876e7e1", 16)
+	if edwardsOrder.Cmp(n) != 0 || edwardsCofactor.Cmp(big.NewInt(4)) != 0 {
+		t.Fatalf("bad subgroup order or cofactor")
+	}
+	g := edwardsGenerator()
+	for _, v := range bandersnatchVectors {
+		k, _ := new(big.Int).SetString(v.k, 0)
+		x, _ := new(big.Int).SetString(v.x, 0)
+		y, _ := new(big.Int).SetString(v.y, 0)
+		r := newEdwardsPoint()
+		edwardsMulScalar(r, g, k)
+		if !edwardsRefEqual(edwardsToRef(r), []*big.Int{x, y}) {
+			t.Fatalf("bad scalar multiplication, k: %s", v.k)
+		}
+	}
+}
+`
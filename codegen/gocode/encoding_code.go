@@ -0,0 +1,701 @@
+package gocode
+
+import "fmt"
+
+// byteEncodings maps -encoding option values to byte encoding constants of
+// generated code.
+var byteEncodings = map[string]string{
+	"be":         "bigEndianPadded",
+	"be-minimal": "bigEndianMinimal",
+	"le":         "littleEndianPadded",
+	"le-minimal": "littleEndianMinimal",
+}
+
+// parseByteEncoding returns byte encoding constant name for given option value.
+// Empty value defaults to big endian limb padded encoding.
+func parseByteEncoding(enc string) (string, error) {
+	if enc == "" {
+		return byteEncodings["be"], nil
+	}
+	name, ok := byteEncodings[enc]
+	if !ok {
+		return "", fmt.Errorf("Bad byte encoding %s, expected one of be, be-minimal, le, le-minimal\n", enc)
+	}
+	return name, nil
+}
+
+// encodingImpl returns encoding code. In fixed modulus mode binary marshaling
+// encoding is baked in as a constant so that it cannot be changed at runtime.
+func encodingImpl(fixedModulus bool, encoding string) string {
+	if fixedModulus {
+		code := encodingImports + encodingFixedModulus
+		code += "\n// fieldEncoding is the byte encoding used by MarshalBinary and UnmarshalBinary.\n"
+		code += fmt.Sprintf("const fieldEncoding = %s\n", encoding)
+		return code
+	}
+	return encodingImports + encodingNonFixedModulus
+}
+
+func encodingTest(fixedModulus bool) string {
+	if fixedModulus {
+		return encodingTestImports + encodingTestFixedModulus
+	}
+	return encodingTestImports + encodingTestNonFixedModulus
+}
+
+const encodingImports = `
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+`
+
+const encodingFixedModulus = `
+// byteEncoding describes byte representation of canonical field elements.
+type byteEncoding uint8
+
+const (
+	bigEndianPadded byteEncoding = iota
+	bigEndianMinimal
+	littleEndianPadded
+	littleEndianMinimal
+)
+
+// littleEndian reports whether least significant byte comes first.
+func (enc byteEncoding) littleEndian() bool {
+	return enc&2 != 0
+}
+
+// minimal encodings are modulus byte length long rather than limb padded.
+func (enc byteEncoding) minimal() bool {
+	return enc&1 != 0
+}
+
+// encodedSize returns length of encoded field elements in bytes.
+func encodedSize(enc byteEncoding) int {
+	if enc.minimal() {
+		return modulusByteSize
+	}
+	return byteSize
+}
+
+// encode returns canonical value of fe with given byte encoding.
+func encode(fe *fieldElement, enc byteEncoding) []byte {
+	out := toBytes(fe)
+	if enc.minimal() {
+		out = out[byteSize-modulusByteSize:]
+	}
+	if enc.littleEndian() {
+		out = reverseBytes(out)
+	}
+	return out
+}
+
+// decode returns a field element from its canonical value with given byte
+// encoding. Input size must match the encoding, padding bytes must be zero
+// and value must be smaller than modulus.
+func decode(in []byte, enc byteEncoding) (*fieldElement, error) {
+	size := encodedSize(enc)
+	if len(in) != size {
+		return nil, fmt.Errorf("bad input size %d, expected %d", len(in), size)
+	}
+	if enc.littleEndian() {
+		in = reverseBytes(in)
+	}
+	if enc.minimal() {
+		in = padBytes(in, byteSize)
+	}
+	for i := 0; i < byteSize-modulusByteSize; i++ {
+		if in[i] != 0 {
+			if enc.littleEndian() {
+				i = byteSize - 1 - i
+			}
+			return nil, fmt.Errorf("non zero padding byte at %d", i)
+		}
+	}
+	fe, err := new(fieldElement).fromBytes(in)
+	if err != nil {
+		return nil, err
+	}
+	if !isValid(fe) {
+		return nil, fmt.Errorf("input is not smaller than modulus")
+	}
+	toMont(fe, fe)
+	return fe, nil
+}
+
+// MarshalBinary returns canonical value of the element in fieldEncoding.
+func (fe *fieldElement) MarshalBinary() ([]byte, error) {
+	return encode(fe, fieldEncoding), nil
+}
+
+// UnmarshalBinary sets the element to canonical value given in fieldEncoding.
+func (fe *fieldElement) UnmarshalBinary(in []byte) error {
+	t, err := decode(in, fieldEncoding)
+	if err != nil {
+		return err
+	}
+	fe.set(t)
+	return nil
+}
+
+// MarshalText returns canonical value of the element in 0x prefixed hex.
+func (fe *fieldElement) MarshalText() ([]byte, error) {
+	return []byte("0x" + toString(fe)), nil
+}
+
+// UnmarshalText sets the element to canonical value given in hex. 0x prefix
+// is optional and input can be shorter than byteSize.
+func (fe *fieldElement) UnmarshalText(in []byte) error {
+	data, err := decodeHex(string(in))
+	if err != nil {
+		return err
+	}
+	t, err := decode(padBytes(data, byteSize), bigEndianPadded)
+	if err != nil {
+		return err
+	}
+	fe.set(t)
+	return nil
+}
+
+// MarshalJSON encodes the element as a json string of MarshalText.
+func (fe *fieldElement) MarshalJSON() ([]byte, error) {
+	text, err := fe.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes the element from a json string of MarshalText.
+func (fe *fieldElement) UnmarshalJSON(in []byte) error {
+	var str string
+	if err := json.Unmarshal(in, &str); err != nil {
+		return err
+	}
+	return fe.UnmarshalText([]byte(str))
+}
+
+// Format implements fmt.Formatter. Canonical value of the element is printed
+// with the same verbs big.Int supports such as %x, %d and %v.
+func (fe *fieldElement) Format(s fmt.State, verb rune) {
+	toBig(fe).Format(s, verb)
+}
+
+func decodeHex(str string) ([]byte, error) {
+	if len(str) > 1 && (str[:2] == "0x" || str[:2] == "0X") {
+		str = str[2:]
+	}
+	if len(str)%2 == 1 {
+		str = "0" + str
+	}
+	data, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("bad hex input, %v", err)
+	}
+	if len(data) > byteSize {
+		return nil, fmt.Errorf("bad input size %d, expected at most %d", len(data), byteSize)
+	}
+	return data, nil
+}
+`
+
+const encodingNonFixedModulus = `
+// byteEncoding describes byte representation of canonical field elements.
+type byteEncoding uint8
+
+const (
+	bigEndianPadded byteEncoding = iota
+	bigEndianMinimal
+	littleEndianPadded
+	littleEndianMinimal
+)
+
+// littleEndian reports whether least significant byte comes first.
+func (enc byteEncoding) littleEndian() bool {
+	return enc&2 != 0
+}
+
+// minimal encodings are modulus byte length long rather than limb padded.
+func (enc byteEncoding) minimal() bool {
+	return enc&1 != 0
+}
+
+func (f *field) modulusByteSize() int {
+	return (f.pbig.BitLen() + 7) / 8
+}
+
+// encodedSize returns length of encoded field elements in bytes.
+func (f *field) encodedSize(enc byteEncoding) int {
+	if enc.minimal() {
+		return f.modulusByteSize()
+	}
+	return byteSize
+}
+
+// encode returns canonical value of fe with given byte encoding.
+func (f *field) encode(fe *fieldElement, enc byteEncoding) []byte {
+	out := f.toBytes(fe)
+	if enc.minimal() {
+		out = out[byteSize-f.modulusByteSize():]
+	}
+	if enc.littleEndian() {
+		out = reverseBytes(out)
+	}
+	return out
+}
+
+// decode returns a field element from its canonical value with given byte
+// encoding. Input size must match the encoding, padding bytes must be zero
+// and value must be smaller than modulus.
+func (f *field) decode(in []byte, enc byteEncoding) (*fieldElement, error) {
+	size := f.encodedSize(enc)
+	if len(in) != size {
+		return nil, fmt.Errorf("bad input size %d, expected %d", len(in), size)
+	}
+	if enc.littleEndian() {
+		in = reverseBytes(in)
+	}
+	if enc.minimal() {
+		in = padBytes(in, byteSize)
+	}
+	for i := 0; i < byteSize-f.modulusByteSize(); i++ {
+		if in[i] != 0 {
+			if enc.littleEndian() {
+				i = byteSize - 1 - i
+			}
+			return nil, fmt.Errorf("non zero padding byte at %d", i)
+		}
+	}
+	fe, err := new(fieldElement).fromBytes(in)
+	if err != nil {
+		return nil, err
+	}
+	if !f.isValid(fe) {
+		return nil, fmt.Errorf("input is not smaller than modulus")
+	}
+	f.toMont(fe, fe)
+	return fe, nil
+}
+
+// element binds a field element to its field so that standard encoding
+// interfaces can work with canonical values.
+type element struct {
+	f  *field
+	fe *fieldElement
+}
+
+// element returns fe bound to the field. A new element is allocated if fe is
+// nil which is useful for decoding.
+func (f *field) element(fe *fieldElement) *element {
+	if fe == nil {
+		fe = f.newFieldElement()
+	}
+	return &element{f, fe}
+}
+
+// MarshalBinary returns canonical value of the element in field encoding.
+func (e *element) MarshalBinary() ([]byte, error) {
+	return e.f.encode(e.fe, e.f.encoding), nil
+}
+
+// UnmarshalBinary sets the element to canonical value given in field encoding.
+func (e *element) UnmarshalBinary(in []byte) error {
+	t, err := e.f.decode(in, e.f.encoding)
+	if err != nil {
+		return err
+	}
+	e.fe.set(t)
+	return nil
+}
+
+// MarshalText returns canonical value of the element in 0x prefixed hex.
+func (e *element) MarshalText() ([]byte, error) {
+	return []byte("0x" + e.f.toString(e.fe)), nil
+}
+
+// UnmarshalText sets the element to canonical value given in hex. 0x prefix
+// is optional and input can be shorter than byteSize.
+func (e *element) UnmarshalText(in []byte) error {
+	data, err := decodeHex(string(in))
+	if err != nil {
+		return err
+	}
+	t, err := e.f.decode(padBytes(data, byteSize), bigEndianPadded)
+	if err != nil {
+		return err
+	}
+	e.fe.set(t)
+	return nil
+}
+
+// MarshalJSON encodes the element as a json string of MarshalText.
+func (e *element) MarshalJSON() ([]byte, error) {
+	text, err := e.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes the element from a json string of MarshalText.
+func (e *element) UnmarshalJSON(in []byte) error {
+	var str string
+	if err := json.Unmarshal(in, &str); err != nil {
+		return err
+	}
+	return e.UnmarshalText([]byte(str))
+}
+
+// Format implements fmt.Formatter. Canonical value of the element is printed
+// with the same verbs big.Int supports such as %x, %d and %v.
+func (e *element) Format(s fmt.State, verb rune) {
+	e.f.toBig(e.fe).Format(s, verb)
+}
+
+func decodeHex(str string) ([]byte, error) {
+	if len(str) > 1 && (str[:2] == "0x" || str[:2] == "0X") {
+		str = str[2:]
+	}
+	if len(str)%2 == 1 {
+		str = "0" + str
+	}
+	data, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("bad hex input, %v", err)
+	}
+	if len(data) > byteSize {
+		return nil, fmt.Errorf("bad input size %d, expected at most %d", len(data), byteSize)
+	}
+	return data, nil
+}
+`
+
+const encodingTestImports = `
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+)
+`
+
+const encodingTestFixedModulus = `
+var _ encoding.BinaryMarshaler = (*fieldElement)(nil)
+var _ encoding.BinaryUnmarshaler = (*fieldElement)(nil)
+var _ encoding.TextMarshaler = (*fieldElement)(nil)
+var _ encoding.TextUnmarshaler = (*fieldElement)(nil)
+var _ json.Marshaler = (*fieldElement)(nil)
+var _ json.Unmarshaler = (*fieldElement)(nil)
+var _ fmt.Formatter = (*fieldElement)(nil)
+
+func TestEncoding(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		a, _ := randFieldElement(rand.Reader)
+		expected := toBig(a)
+		// binary
+		bin, err := a.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(bin, encode(a, fieldEncoding)) {
+			t.Fatalf("binary encoding should follow field encoding")
+		}
+		b := newFieldElement()
+		if err := b.UnmarshalBinary(bin); err != nil {
+			t.Fatal(err)
+		}
+		if !a.equal(b) {
+			t.Fatalf("bad binary encoding")
+		}
+		// text
+		text, err := a.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != fmt.Sprintf("0x%0*x", byteSize*2, expected) {
+			t.Fatalf("text encoding should be canonical")
+		}
+		b = newFieldElement()
+		if err := b.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if !a.equal(b) {
+			t.Fatalf("bad text encoding")
+		}
+		// json
+		type config struct {
+			A *fieldElement
+			B []*fieldElement
+		}
+		c0 := config{a, []*fieldElement{a, one}}
+		data, err := json.Marshal(c0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c1 := config{}
+		if err := json.Unmarshal(data, &c1); err != nil {
+			t.Fatal(err)
+		}
+		if !c1.A.equal(a) || len(c1.B) != 2 || !c1.B[0].equal(a) || !c1.B[1].equal(one) {
+			t.Fatalf("bad json encoding")
+		}
+		// format
+		for _, verb := range []string{"%x", "%X", "%#x", "%d", "%v", "%s"} {
+			if fmt.Sprintf(verb, a) != fmt.Sprintf(verb, expected) {
+				t.Fatalf("bad formatting for %s", verb)
+			}
+		}
+	}
+	b := newFieldElement()
+	if err := b.UnmarshalText([]byte("0x1")); err != nil || !b.equal(one) {
+		t.Fatalf("short hex input should be accepted")
+	}
+}
+
+func TestEncodingErrors(t *testing.T) {
+	a := newFieldElement()
+	size := encodedSize(fieldEncoding)
+	in := padBytes(pbig.Bytes(), size)
+	if fieldEncoding.littleEndian() {
+		in = reverseBytes(in)
+	}
+	if err := a.UnmarshalBinary(in); err == nil {
+		t.Fatalf("modulus should be rejected")
+	}
+	if err := a.UnmarshalBinary(make([]byte, size-1)); err == nil {
+		t.Fatalf("short input should be rejected")
+	}
+	if err := a.UnmarshalBinary(make([]byte, size+1)); err == nil {
+		t.Fatalf("long input should be rejected")
+	}
+	if err := a.UnmarshalText([]byte("0x" + new(big.Int).Add(pbig, big.NewInt(1)).Text(16))); err == nil {
+		t.Fatalf("modulus + 1 should be rejected")
+	}
+	if err := a.UnmarshalText([]byte("0xzz")); err == nil {
+		t.Fatalf("bad hex should be rejected")
+	}
+	if err := a.UnmarshalJSON([]byte("1")); err == nil {
+		t.Fatalf("json number should be rejected")
+	}
+}
+
+func TestByteEncoding(t *testing.T) {
+	encodings := []byteEncoding{bigEndianPadded, bigEndianMinimal, littleEndianPadded, littleEndianMinimal}
+	if modulusByteSize != (pbig.BitLen()+7)/8 {
+		t.Fatalf("bad modulus byte size")
+	}
+	for i := 0; i < fuz; i++ {
+		a, _ := randFieldElement(rand.Reader)
+		expected := toBytes(a)
+		for _, enc := range encodings {
+			b := encode(a, enc)
+			if len(b) != encodedSize(enc) {
+				t.Fatalf("bad encoding size")
+			}
+			e := expected
+			if enc.minimal() {
+				e = e[byteSize-modulusByteSize:]
+			}
+			if enc.littleEndian() {
+				e = reverseBytes(e)
+			}
+			if !bytes.Equal(b, e) {
+				t.Fatalf("bad encoding %+v", enc)
+			}
+			c, err := decode(b, enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !a.equal(c) {
+				t.Fatalf("bad decoding %+v", enc)
+			}
+		}
+	}
+	for _, enc := range encodings {
+		size := encodedSize(enc)
+		in := padBytes(pbig.Bytes(), size)
+		if enc.littleEndian() {
+			in = reverseBytes(in)
+		}
+		if _, err := decode(in, enc); err == nil {
+			t.Fatalf("modulus should be rejected %+v", enc)
+		}
+		if _, err := decode(make([]byte, size-1), enc); err == nil {
+			t.Fatalf("short input should be rejected %+v", enc)
+		}
+		if !enc.minimal() && byteSize > modulusByteSize {
+			in := make([]byte, size)
+			in[0] = 1
+			if enc.littleEndian() {
+				in = reverseBytes(in)
+			}
+			if _, err := decode(in, enc); err == nil {
+				t.Fatalf("non zero padding should be rejected %+v", enc)
+			}
+		}
+	}
+}
+`
+
+const encodingTestNonFixedModulus = `
+var _ encoding.BinaryMarshaler = (*element)(nil)
+var _ encoding.BinaryUnmarshaler = (*element)(nil)
+var _ encoding.TextMarshaler = (*element)(nil)
+var _ encoding.TextUnmarshaler = (*element)(nil)
+var _ json.Marshaler = (*element)(nil)
+var _ json.Unmarshaler = (*element)(nil)
+var _ fmt.Formatter = (*element)(nil)
+
+func TestEncoding(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		field := randField()
+		a, _ := field.randFieldElement(rand.Reader)
+		expected := field.toBig(a)
+		// binary
+		bin, err := field.element(a).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(bin, padBytes(expected.Bytes(), byteSize)) {
+			t.Fatalf("binary encoding should be canonical")
+		}
+		b := field.element(nil)
+		if err := b.UnmarshalBinary(bin); err != nil {
+			t.Fatal(err)
+		}
+		if !a.equal(b.fe) {
+			t.Fatalf("bad binary encoding")
+		}
+		// text
+		text, err := field.element(a).MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != fmt.Sprintf("0x%0*x", byteSize*2, expected) {
+			t.Fatalf("text encoding should be canonical")
+		}
+		b = field.element(nil)
+		if err := b.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if !a.equal(b.fe) {
+			t.Fatalf("bad text encoding")
+		}
+		// json
+		data, err := json.Marshal([]*element{field.element(a), field.element(field.one)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := []*element{field.element(nil), field.element(nil)}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded[0].fe.equal(a) || !decoded[1].fe.equal(field.one) {
+			t.Fatalf("bad json encoding")
+		}
+		// format
+		for _, verb := range []string{"%x", "%X", "%#x", "%d", "%v", "%s"} {
+			if fmt.Sprintf(verb, field.element(a)) != fmt.Sprintf(verb, expected) {
+				t.Fatalf("bad formatting for %s", verb)
+			}
+		}
+	}
+}
+
+func TestEncodingErrors(t *testing.T) {
+	field := randField()
+	a := field.element(nil)
+	if err := a.UnmarshalBinary(padBytes(field.pbig.Bytes(), byteSize)); err == nil {
+		t.Fatalf("modulus should be rejected")
+	}
+	if err := a.UnmarshalBinary(make([]byte, byteSize-1)); err == nil {
+		t.Fatalf("short input should be rejected")
+	}
+	if err := a.UnmarshalText([]byte("0x" + new(big.Int).Add(field.pbig, big.NewInt(1)).Text(16))); err == nil {
+		t.Fatalf("modulus + 1 should be rejected")
+	}
+	if err := a.UnmarshalText([]byte("0xzz")); err == nil {
+		t.Fatalf("bad hex should be rejected")
+	}
+	if err := a.UnmarshalJSON([]byte("1")); err == nil {
+		t.Fatalf("json number should be rejected")
+	}
+}
+
+func TestByteEncoding(t *testing.T) {
+	encodings := []byteEncoding{bigEndianPadded, bigEndianMinimal, littleEndianPadded, littleEndianMinimal}
+	for i := 0; i < fuz; i++ {
+		// modulus shorter than limbs so that padding is exercised
+		pbig, err := rand.Prime(rand.Reader, byteSize*8-12)
+		if err != nil {
+			t.Fatal(err)
+		}
+		field, err := newField(padBytes(pbig.Bytes(), byteSize))
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, _ := field.randFieldElement(rand.Reader)
+		expected := field.toBytes(a)
+		for _, enc := range encodings {
+			b := field.encode(a, enc)
+			if len(b) != field.encodedSize(enc) {
+				t.Fatalf("bad encoding size")
+			}
+			e := expected
+			if enc.minimal() {
+				e = e[byteSize-field.modulusByteSize():]
+			}
+			if enc.littleEndian() {
+				e = reverseBytes(e)
+			}
+			if !bytes.Equal(b, e) {
+				t.Fatalf("bad encoding %+v", enc)
+			}
+			c, err := field.decode(b, enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !a.equal(c) {
+				t.Fatalf("bad decoding %+v", enc)
+			}
+			size := field.encodedSize(enc)
+			in := padBytes(pbig.Bytes(), size)
+			if enc.littleEndian() {
+				in = reverseBytes(in)
+			}
+			if _, err := field.decode(in, enc); err == nil {
+				t.Fatalf("modulus should be rejected %+v", enc)
+			}
+			if _, err := field.decode(make([]byte, size+1), enc); err == nil {
+				t.Fatalf("long input should be rejected %+v", enc)
+			}
+			if !enc.minimal() {
+				in := make([]byte, size)
+				in[0] = 1
+				if enc.littleEndian() {
+					in = reverseBytes(in)
+				}
+				if _, err := field.decode(in, enc); err == nil {
+					t.Fatalf("non zero padding should be rejected %+v", enc)
+				}
+			}
+		}
+		// binary marshaling follows field encoding
+		field.encoding = littleEndianMinimal
+		b, _ := field.element(a).MarshalBinary()
+		if !bytes.Equal(b, field.encode(a, littleEndianMinimal)) {
+			t.Fatalf("binary marshaling should follow field encoding")
+		}
+		c := field.element(nil)
+		if err := c.UnmarshalBinary(b); err != nil || !c.fe.equal(a) {
+			t.Fatalf("bad binary unmarshaling with field encoding")
+		}
+	}
+}
+`
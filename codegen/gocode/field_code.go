@@ -36,6 +36,8 @@ func fieldImpl(limbSize int, modulus *big.Int) string {
 		R.SetBit(R, byteSize*8, 1).Mod(R, modulus)
 		R2 := new(big.Int)
 		R2.Mul(R, R).Mod(R2, modulus)
+		R3 := new(big.Int)
+		R3.Mul(R2, R).Mod(R3, modulus)
 		inpT := new(big.Int).ModInverse(new(big.Int).Neg(modulus), new(big.Int).SetBit(new(big.Int), 64, 1))
 		if inpT == nil {
 			panic("cannot inverse modulus")
@@ -54,8 +56,12 @@ func fieldImpl(limbSize int, modulus *big.Int) string {
 		code += encodeBig("one", limbSize, R, true)
 		// r^2
 		code += encodeBig("r2", limbSize, R2, true)
+		// r^3
+		code += encodeBig("r3", limbSize, R3, true)
 		// actual one
 		code += encodeBig("_one", limbSize, big.NewInt(1), true)
+		// modulus byte length
+		code += fmt.Sprintf("const modulusByteSize = %d\n\n", (modulus.BitLen()+7)/8)
 		// pbig
 		code += fmt.Sprintf("var pbig, _ = new(big.Int).SetString(\"%s\", 10)\n\n", modulus.String())
 		// rbig
@@ -83,9 +89,12 @@ type field struct {
 	zero *fieldElement
 	r    *fieldElement
 	r2   *fieldElement
+	r3   *fieldElement
 	pbig *big.Int
 	rbig *big.Int
 	inp  uint64
+	// encoding is the byte encoding of binary marshaling
+	encoding byteEncoding
 }
 
 func newField(p []byte) (*field, error) {
@@ -100,6 +109,8 @@ func newField(p []byte) (*field, error) {
 	R.SetBit(R, byteSize*8, 1).Mod(R, f.pbig)
 	R2 := new(big.Int)
 	R2.Mul(R, R).Mod(R2, f.pbig)
+	R3 := new(big.Int)
+	R3.Mul(R2, R).Mod(R3, f.pbig)
 	inpT := new(big.Int).ModInverse(new(big.Int).Neg(f.pbig), new(big.Int).SetBit(new(big.Int), 64, 1))
 	if inpT == nil {
 		return nil, fmt.Errorf("modulus is not inversive")
@@ -115,6 +126,10 @@ func newField(p []byte) (*field, error) {
 	if err != nil {
 		return nil, err
 	}
+	f.r3, err = new(fieldElement).fromBytes(padBytes(R3.Bytes(), byteSize))
+	if err != nil {
+		return nil, err
+	}
 	f._one, err = new(fieldElement).fromBytes(padBytes([]byte{0, 0, 0, 1}, byteSize))
 	if err != nil {
 		return nil, err
@@ -168,6 +183,40 @@ func (f *field) newFieldElementFromString(in string) (*fieldElement, error) {
 	return fe, nil
 }
 
+// fromBytesWide reduces a big endian input of arbitrary length into the field.
+// Most significant chunk is multiplied with r^3 so that it lands in montgomery
+// domain already shifted by one chunk, remaining chunks are lifted with r^2 and
+// accumulated in horner fashion.
+func (f *field) fromBytesWide(in []byte) *fieldElement {
+	n := (len(in) + byteSize - 1) / byteSize
+	c := f.newFieldElement()
+	if n == 0 {
+		return c
+	}
+	padded := padBytes(in, n*byteSize)
+	c.fromBytes(padded[:byteSize])
+	if n == 1 {
+		f.mul(c, c, f.r2)
+		return c
+	}
+	f.mul(c, c, f.r3)
+	t := f.newFieldElement()
+	for i := 1; i < n; i++ {
+		t.fromBytes(padded[i*byteSize : (i+1)*byteSize])
+		f.mul(t, t, f.r2)
+		f.add(c, c, t)
+		if i != n-1 {
+			f.mul(c, c, f.r2)
+		}
+	}
+	return c
+}
+
+// fromBytesWideLE is little endian counterpart of fromBytesWide.
+func (f *field) fromBytesWideLE(in []byte) *fieldElement {
+	return f.fromBytesWide(reverseBytes(in))
+}
+
 func (f *field) toBytes(fe *fieldElement) []byte {
 	t := new(fieldElement)
 	f.fromMont(t, fe)
@@ -220,13 +269,44 @@ func (f *field) sub(c, a, b *fieldElement) {
 }
 
 func (f *field) neg(c, a *fieldElement) {
-	if a.equal(f.zero) {
-		a.set(f.zero)
-		return
-	}
 	_neg(c, a, f.p)
 }
 
+// cmov sets c to b if cond is true and to a otherwise in constant time.
+func (f *field) cmov(c, a, b *fieldElement, cond bool) {
+	_cmov(c, a, b, b2u(cond))
+}
+
+// cswap swaps a and b if cond is true in constant time.
+func (f *field) cswap(a, b *fieldElement, cond bool) {
+	_cswap(a, b, b2u(cond))
+}
+
+// condNeg sets c to -a if cond is true and to a otherwise in constant time.
+func (f *field) condNeg(c, a *fieldElement, cond bool) {
+	_condNeg(c, a, f.p, b2u(cond))
+}
+
+// constTimeLookup sets dst to table[idx] reading every entry of table so that
+// idx does not leak through memory access pattern. It panics if idx is out of
+// range.
+func (f *field) constTimeLookup(dst *fieldElement, table []fieldElement, idx int) {
+	if idx < 0 || idx >= len(table) {
+		panic("bad table index")
+	}
+	_lookup(dst, table, uint64(idx))
+}
+
+// b2u returns 1 if b is true and 0 otherwise. It compiles to a zero extension
+// of b rather than a branch.
+func b2u(b bool) uint64 {
+	var v uint64
+	if b {
+		v = 1
+	}
+	return v
+}
+
 func (f *field) mul(c, a, b *fieldElement) {
 	mul(c, a, b, f.p, f.inp)
 }
@@ -243,6 +323,11 @@ func (f *field) exp(c, a *fieldElement, e *big.Int) {
 	c.set(z)
 }
 
+// inverse sets c to a^-1 with fermat's little theorem. Inverse of zero is zero.
+func (f *field) inverse(c, a *fieldElement) {
+	f.exp(c, a, new(big.Int).Sub(f.pbig, big.NewInt(2)))
+}
+
 func padBytes(in []byte, size int) []byte {
 	out := make([]byte, size)
 	if len(in) > size {
@@ -251,6 +336,13 @@ func padBytes(in []byte, size int) []byte {
 	copy(out[size-len(in):], in)
 	return out
 }
+func reverseBytes(in []byte) []byte {
+	out := make([]byte, len(in))
+	for i := 0; i < len(in); i++ {
+		out[i] = in[len(in)-1-i]
+	}
+	return out
+}
 `
 
 const fieldImplFixedModulus0 = `
@@ -306,6 +398,40 @@ func newFieldElementFromString(in string) (*fieldElement, error) {
 	return fe, nil
 }
 
+// fromBytesWide reduces a big endian input of arbitrary length into the field.
+// Most significant chunk is multiplied with r^3 so that it lands in montgomery
+// domain already shifted by one chunk, remaining chunks are lifted with r^2 and
+// accumulated in horner fashion.
+func fromBytesWide(in []byte) *fieldElement {
+	n := (len(in) + byteSize - 1) / byteSize
+	c := newFieldElement()
+	if n == 0 {
+		return c
+	}
+	padded := padBytes(in, n*byteSize)
+	c.fromBytes(padded[:byteSize])
+	if n == 1 {
+		mul(c, c, r2)
+		return c
+	}
+	mul(c, c, r3)
+	t := newFieldElement()
+	for i := 1; i < n; i++ {
+		t.fromBytes(padded[i*byteSize : (i+1)*byteSize])
+		mul(t, t, r2)
+		add(c, c, t)
+		if i != n-1 {
+			mul(c, c, r2)
+		}
+	}
+	return c
+}
+
+// fromBytesWideLE is little endian counterpart of fromBytesWide.
+func fromBytesWideLE(in []byte) *fieldElement {
+	return fromBytesWide(reverseBytes(in))
+}
+
 func toBytes(fe *fieldElement) []byte {
 	t := new(fieldElement)
 	fromMont(t, fe)
@@ -346,13 +472,44 @@ func fromMont(c, a *fieldElement) {
 }
 
 func neg(c, a *fieldElement) {
-	if a.equal(zero) {
-		a.set(zero)
-		return
-	}
 	_neg(c, a)
 }
 
+// cmov sets c to b if cond is true and to a otherwise in constant time.
+func cmov(c, a, b *fieldElement, cond bool) {
+	_cmov(c, a, b, b2u(cond))
+}
+
+// cswap swaps a and b if cond is true in constant time.
+func cswap(a, b *fieldElement, cond bool) {
+	_cswap(a, b, b2u(cond))
+}
+
+// condNeg sets c to -a if cond is true and to a otherwise in constant time.
+func condNeg(c, a *fieldElement, cond bool) {
+	_condNeg(c, a, b2u(cond))
+}
+
+// b2u returns 1 if b is true and 0 otherwise. It compiles to a zero extension
+// of b rather than a branch.
+func b2u(b bool) uint64 {
+	var v uint64
+	if b {
+		v = 1
+	}
+	return v
+}
+
+// constTimeLookup sets dst to table[idx] reading every entry of table so that
+// idx does not leak through memory access pattern. It panics if idx is out of
+// range.
+func constTimeLookup(dst *fieldElement, table []fieldElement, idx int) {
+	if idx < 0 || idx >= len(table) {
+		panic("bad table index")
+	}
+	_lookup(dst, table, uint64(idx))
+}
+
 func exp(c, a *fieldElement, e *big.Int) {
 	z := newFieldElement()
 	z.set(r)
@@ -365,6 +522,11 @@ func exp(c, a *fieldElement, e *big.Int) {
 	c.set(z)
 }
 
+// inverse sets c to a^-1 with fermat's little theorem. Inverse of zero is zero.
+func inverse(c, a *fieldElement) {
+	exp(c, a, new(big.Int).Sub(pbig, big.NewInt(2)))
+}
+
 func padBytes(in []byte, size int) []byte {
 	out := make([]byte, size)
 	if len(in) > size {
@@ -373,4 +535,11 @@ func padBytes(in []byte, size int) []byte {
 	copy(out[size-len(in):], in)
 	return out
 }
+func reverseBytes(in []byte) []byte {
+	out := make([]byte, len(in))
+	for i := 0; i < len(in); i++ {
+		out[i] = in[len(in)-1-i]
+	}
+	return out
+}
 `
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"crypto/rand"
 	"flag"
+	"fmt"
 	"math/big"
 	"testing"
 )
@@ -12,7 +13,7 @@ import (
 var fuz int
 
 func TestMain(m *testing.M) {
-	_fuz := flag.Int("fuzz", 1, "# of iters")
+	_fuz := flag.Int("iter", 1, "# of iters")
 	flag.Parse()
 	fuz = *_fuz
 	m.Run()
@@ -38,37 +39,35 @@ func randBig(max *big.Int) *big.Int {
 	return bi
 }
 
+// BenchmarkField covers every field operation, sub benchmarks are named as
+// <bit size>_<operation> to be aggregated across field sizes
 func BenchmarkField(t *testing.B) {
 	in_a := randBytes(pbig)
 	in_b := randBytes(pbig)
 	a, _ := newFieldElementFromBytes(in_a)
 	b, _ := newFieldElementFromBytes(in_b)
 	c := newFieldElement()
-	t.Run("add", func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			add(c, a, b)
-		}
-	})
-	t.Run("double", func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			double(c, a)
-		}
-	})
-	t.Run("sub", func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			sub(c, a, b)
-		}
-	})
-	t.Run("mul", func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			mul(c, a, b)
-		}
-	})
-	t.Run("cmp", func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			a.equal(b)
-		}
-	})
+	e := randBig(pbig)
+	bench := func(op string, f func()) {
+		t.Run(fmt.Sprintf("%d_%s", byteSize*8, op), func(t *testing.B) {
+			for i := 0; i < t.N; i++ {
+				f()
+			}
+		})
+	}
+	bench("add", func() { add(c, a, b) })
+	bench("sub", func() { sub(c, a, b) })
+	bench("double", func() { double(c, a) })
+	bench("neg", func() { neg(c, a) })
+	bench("mul", func() { mul(c, a, b) })
+	bench("square", func() { mul(c, a, a) })
+	bench("exp", func() { exp(c, a, e) })
+	bench("inverse", func() { inverse(c, a) })
+	bench("to_mont", func() { toMont(c, a) })
+	bench("from_mont", func() { fromMont(c, a) })
+	bench("to_bytes", func() { toBytes(a) })
+	bench("from_bytes", func() { newFieldElementFromBytes(in_a) })
+	bench("cmp", func() { cmp(a, b) })
 }
 
 func TestCompare(t *testing.T) {
@@ -144,6 +143,32 @@ func TestSerialization(t *testing.T) {
 	}
 }
 
+func TestWideReduction(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		for _, size := range []int{0, 1, byteSize - 1, byteSize, byteSize + 1, byteSize * 2, byteSize*3 + 5} {
+			in := make([]byte, size)
+			if _, err := rand.Read(in); err != nil {
+				t.Fatal(err)
+			}
+			if i == 0 {
+				for k := 0; k < size; k++ {
+					in[k] = 0xff
+				}
+			}
+			expected := new(big.Int).SetBytes(in)
+			expected.Mod(expected, pbig)
+			a := fromBytesWide(in)
+			if toBig(a).Cmp(expected) != 0 {
+				t.Fatalf("bad wide reduction (big endian), input size %d", size)
+			}
+			a = fromBytesWideLE(reverseBytes(in))
+			if toBig(a).Cmp(expected) != 0 {
+				t.Fatalf("bad wide reduction (little endian), input size %d", size)
+			}
+		}
+	}
+}
+
 func TestAdditionCrossAgainstBigInt(t *testing.T) {
 	for i := 0; i < fuz; i++ {
 		a, _ := randFieldElement(rand.Reader)
@@ -240,6 +265,77 @@ func TestAdditionProperties(t *testing.T) {
 	}
 }
 
+func TestConditionalOperations(t *testing.T) {
+	c, d, negA := newFieldElement(), newFieldElement(), newFieldElement()
+	neg(c, zero)
+	if !c.equal(zero) {
+		t.Fatalf("-0 == 0")
+	}
+	for i := 0; i < fuz; i++ {
+		a, _ := randFieldElement(rand.Reader)
+		b, _ := randFieldElement(rand.Reader)
+		sub(negA, zero, a)
+		for _, cond := range []bool{false, true} {
+			x, y, z := a, b, a
+			if cond {
+				x, y, z = b, a, negA
+			}
+			cmov(c, a, b, cond)
+			if !c.equal(x) {
+				t.Fatalf("bad conditional move, cond: %t", cond)
+			}
+			c.set(a)
+			d.set(b)
+			cswap(c, d, cond)
+			if !c.equal(x) || !d.equal(y) {
+				t.Fatalf("bad conditional swap, cond: %t", cond)
+			}
+			c.set(a)
+			condNeg(c, c, cond)
+			if !c.equal(z) {
+				t.Fatalf("bad conditional negation, cond: %t", cond)
+			}
+			condNeg(c, zero, cond)
+			if !c.equal(zero) {
+				t.Fatalf("bad conditional negation of zero, cond: %t", cond)
+			}
+		}
+	}
+}
+
+func TestConstTimeLookup(t *testing.T) {
+	c := newFieldElement()
+	for _, n := range []int{1, 3, 16} {
+		table := make([]fieldElement, n)
+		for j := range table {
+			a, _ := randFieldElement(rand.Reader)
+			table[j].set(a)
+		}
+		for idx := 0; idx < n; idx++ {
+			constTimeLookup(c, table, idx)
+			if !c.equal(&table[idx]) {
+				t.Fatalf("bad lookup, n: %d, idx: %d", n, idx)
+			}
+		}
+		for _, idx := range []int{-1, n} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatalf("out of range lookup does not panic, n: %d, idx: %d", n, idx)
+					}
+				}()
+				constTimeLookup(c, table, idx)
+			}()
+		}
+		// output is an entry of the table
+		expected := new(fieldElement).set(&table[n-1])
+		constTimeLookup(&table[0], table, n-1)
+		if !table[0].equal(expected) {
+			t.Fatalf("bad lookup into table entry, n: %d", n)
+		}
+	}
+}
+
 func TestMultiplicationCrossAgainstBigInt(t *testing.T) {
 	for i := 0; i < fuz; i++ {
 		a, _ := randFieldElement(rand.Reader)
@@ -319,6 +415,26 @@ func TestExponentiation(t *testing.T) {
 	}
 }
 
+func TestInversion(t *testing.T) {
+	u := newFieldElement()
+	inverse(u, zero)
+	if !u.equal(zero) {
+		t.Fatalf("(0^-1) == 0)")
+	}
+	inverse(u, one)
+	if !u.equal(one) {
+		t.Fatalf("(1^-1) == 1)")
+	}
+	for i := 0; i < fuz; i++ {
+		a, _ := randFieldElement(rand.Reader)
+		inverse(u, a)
+		mul(u, u, a)
+		if !u.equal(r) {
+			t.Fatalf("(r*a) * r*(a^-1) == r)")
+		}
+	}
+}
+
 `
 
 const fieldTestNonFixedModulus = `
@@ -326,6 +442,7 @@ import (
 	"bytes"
 	"crypto/rand"
 	"flag"
+	"fmt"
 	"math/big"
 	"testing"
 )
@@ -333,7 +450,7 @@ import (
 var fuz int
 
 func TestMain(m *testing.M) {
-	_fuz := flag.Int("fuzz", 1, "# of iters")
+	_fuz := flag.Int("iter", 1, "# of iters")
 	flag.Parse()
 	fuz = *_fuz
 	m.Run()
@@ -374,6 +491,8 @@ func randField() *field {
 	return field
 }
 
+// BenchmarkField covers every field operation, sub benchmarks are named as
+// <bit size>_<operation> to be aggregated across field sizes
 func BenchmarkField(t *testing.B) {
 	field := randField()
 	in_a := randBytes(field.pbig)
@@ -381,31 +500,27 @@ func BenchmarkField(t *testing.B) {
 	a, _ := field.newFieldElementFromBytes(in_a)
 	b, _ := field.newFieldElementFromBytes(in_b)
 	c := field.newFieldElement()
-	t.Run("add", func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			field.add(c, a, b)
-		}
-	})
-	t.Run("double", func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			field.double(c, a)
-		}
-	})
-	t.Run("sub", func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			field.sub(c, a, b)
-		}
-	})
-	t.Run("mul", func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			field.mul(c, a, b)
-		}
-	})
-	t.Run("cmp", func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			a.equal(b)
-		}
-	})
+	e := randBig(field.pbig)
+	bench := func(op string, f func()) {
+		t.Run(fmt.Sprintf("%d_%s", byteSize*8, op), func(t *testing.B) {
+			for i := 0; i < t.N; i++ {
+				f()
+			}
+		})
+	}
+	bench("add", func() { field.add(c, a, b) })
+	bench("sub", func() { field.sub(c, a, b) })
+	bench("double", func() { field.double(c, a) })
+	bench("neg", func() { field.neg(c, a) })
+	bench("mul", func() { field.mul(c, a, b) })
+	bench("square", func() { field.mul(c, a, a) })
+	bench("exp", func() { field.exp(c, a, e) })
+	bench("inverse", func() { field.inverse(c, a) })
+	bench("to_mont", func() { field.toMont(c, a) })
+	bench("from_mont", func() { field.fromMont(c, a) })
+	bench("to_bytes", func() { field.toBytes(a) })
+	bench("from_bytes", func() { field.newFieldElementFromBytes(in_a) })
+	bench("cmp", func() { cmp(a, b) })
 }
 
 func TestCompare(t *testing.T) {
@@ -487,6 +602,33 @@ func TestSerialization(t *testing.T) {
 	}
 }
 
+func TestWideReduction(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		field := randField()
+		for _, size := range []int{0, 1, byteSize - 1, byteSize, byteSize + 1, byteSize * 2, byteSize*3 + 5} {
+			in := make([]byte, size)
+			if _, err := rand.Read(in); err != nil {
+				t.Fatal(err)
+			}
+			if i == 0 {
+				for k := 0; k < size; k++ {
+					in[k] = 0xff
+				}
+			}
+			expected := new(big.Int).SetBytes(in)
+			expected.Mod(expected, field.pbig)
+			a := field.fromBytesWide(in)
+			if field.toBig(a).Cmp(expected) != 0 {
+				t.Fatalf("bad wide reduction (big endian), input size %d", size)
+			}
+			a = field.fromBytesWideLE(reverseBytes(in))
+			if field.toBig(a).Cmp(expected) != 0 {
+				t.Fatalf("bad wide reduction (little endian), input size %d", size)
+			}
+		}
+	}
+}
+
 func TestAdditionCrossAgainstBigInt(t *testing.T) {
 	for i := 0; i < fuz; i++ {
 		field := randField()
@@ -586,6 +728,81 @@ func TestAdditionProperties(t *testing.T) {
 	}
 }
 
+func TestConditionalOperations(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		field := randField()
+		c, d, negA := field.newFieldElement(), field.newFieldElement(), field.newFieldElement()
+		field.neg(c, field.zero)
+		if !c.equal(field.zero) {
+			t.Fatalf("-0 == 0")
+		}
+		a, _ := field.randFieldElement(rand.Reader)
+		b, _ := field.randFieldElement(rand.Reader)
+		field.sub(negA, field.zero, a)
+		for _, cond := range []bool{false, true} {
+			x, y, z := a, b, a
+			if cond {
+				x, y, z = b, a, negA
+			}
+			field.cmov(c, a, b, cond)
+			if !c.equal(x) {
+				t.Fatalf("bad conditional move, cond: %t", cond)
+			}
+			c.set(a)
+			d.set(b)
+			field.cswap(c, d, cond)
+			if !c.equal(x) || !d.equal(y) {
+				t.Fatalf("bad conditional swap, cond: %t", cond)
+			}
+			c.set(a)
+			field.condNeg(c, c, cond)
+			if !c.equal(z) {
+				t.Fatalf("bad conditional negation, cond: %t", cond)
+			}
+			field.condNeg(c, field.zero, cond)
+			if !c.equal(field.zero) {
+				t.Fatalf("bad conditional negation of zero, cond: %t", cond)
+			}
+		}
+	}
+}
+
+func TestConstTimeLookup(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		field := randField()
+		c := field.newFieldElement()
+		for _, n := range []int{1, 3, 16} {
+			table := make([]fieldElement, n)
+			for j := range table {
+				a, _ := field.randFieldElement(rand.Reader)
+				table[j].set(a)
+			}
+			for idx := 0; idx < n; idx++ {
+				field.constTimeLookup(c, table, idx)
+				if !c.equal(&table[idx]) {
+					t.Fatalf("bad lookup, n: %d, idx: %d", n, idx)
+				}
+			}
+			for _, idx := range []int{-1, n} {
+				func() {
+					defer func() {
+						if recover() == nil {
+							t.Fatalf("out of range lookup does not panic, n: %d, idx: %d", n, idx)
+						}
+					}()
+					field.constTimeLookup(c, table, idx)
+				}()
+			}
+			// output is an entry of the table
+			expected := new(fieldElement).set(&table[n-1])
+			field.constTimeLookup(&table[0], table, n-1)
+			if !table[0].equal(expected) {
+				t.Fatalf("bad lookup into table entry, n: %d", n)
+			}
+		}
+	}
+}
+
 func TestMultiplicationCrossAgainstBigInt(t *testing.T) {
 	for i := 0; i < fuz; i++ {
 		field := randField()
@@ -669,4 +886,388 @@ func TestExponentiation(t *testing.T) {
 		}
 	}
 }
+
+func TestInversion(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		field := randField()
+		u := field.newFieldElement()
+		field.inverse(u, field.zero)
+		if !u.equal(field.zero) {
+			t.Fatalf("(0^-1) == 0)")
+		}
+		field.inverse(u, field.one)
+		if !u.equal(field.one) {
+			t.Fatalf("(1^-1) == 1)")
+		}
+		a, _ := field.randFieldElement(rand.Reader)
+		field.inverse(u, a)
+		field.mul(u, u, a)
+		if !u.equal(field.r) {
+			t.Fatalf("(r*a) * r*(a^-1) == r)")
+		}
+	}
+}
+`
+
+const fieldFuzzFixedModulus = `
+// fuzzSeeds returns encodings of zero, one, p - 1 and all ones limbs
+func fuzzSeeds() [][]byte {
+	ones := make([]byte, byteSize)
+	for i := range ones {
+		ones[i] = 0xff
+	}
+	pm1 := new(big.Int).Sub(pbig, big.NewInt(1))
+	return [][]byte{
+		padBytes([]byte{0}, byteSize),
+		padBytes([]byte{1}, byteSize),
+		padBytes(pm1.Bytes(), byteSize),
+		ones,
+	}
+}
+
+// fuzzElement reduces fuzz input into the field and returns the element
+// with its big.Int counterpart
+func fuzzElement(in []byte) (*fieldElement, *big.Int) {
+	v := new(big.Int).SetBytes(in)
+	v.Mod(v, pbig)
+	fe, err := newFieldElementFromBig(v)
+	if err != nil {
+		panic(err)
+	}
+	return fe, v
+}
+
+func fuzzUnary(f *testing.F, op func(c, a *fieldElement), expected func(a *big.Int) *big.Int) {
+	for _, a := range fuzzSeeds() {
+		f.Add(a)
+	}
+	f.Fuzz(func(t *testing.T, in []byte) {
+		a, big_a := fuzzElement(in)
+		want := expected(big_a)
+		c := newFieldElement()
+		op(c, a)
+		if have := toBig(c); have.Cmp(want) != 0 {
+			t.Fatalf("a: %x\nhave %x\nwant %x", big_a, have, want)
+		}
+		op(a, a)
+		if have := toBig(a); have.Cmp(want) != 0 {
+			t.Fatalf("a: %x, aliased\nhave %x\nwant %x", big_a, have, want)
+		}
+	})
+}
+
+func fuzzBinary(f *testing.F, op func(c, a, b *fieldElement), expected func(a, b *big.Int) *big.Int) {
+	for _, a := range fuzzSeeds() {
+		for _, b := range fuzzSeeds() {
+			f.Add(a, b)
+		}
+	}
+	f.Fuzz(func(t *testing.T, in_a, in_b []byte) {
+		a, big_a := fuzzElement(in_a)
+		b, big_b := fuzzElement(in_b)
+		want := expected(big_a, big_b)
+		c := newFieldElement()
+		op(c, a, b)
+		if have := toBig(c); have.Cmp(want) != 0 {
+			t.Fatalf("a: %x, b: %x\nhave %x\nwant %x", big_a, big_b, have, want)
+		}
+		op(a, a, b)
+		if have := toBig(a); have.Cmp(want) != 0 {
+			t.Fatalf("a: %x, b: %x, aliased\nhave %x\nwant %x", big_a, big_b, have, want)
+		}
+	})
+}
+
+func FuzzAdd(f *testing.F) {
+	fuzzBinary(f, add, func(a, b *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Add(a, b), pbig)
+	})
+}
+
+func FuzzSub(f *testing.F) {
+	fuzzBinary(f, sub, func(a, b *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Sub(a, b), pbig)
+	})
+}
+
+func FuzzMul(f *testing.F) {
+	fuzzBinary(f, mul, func(a, b *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Mul(a, b), pbig)
+	})
+}
+
+func FuzzDouble(f *testing.F) {
+	fuzzUnary(f, double, func(a *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Lsh(a, 1), pbig)
+	})
+}
+
+func FuzzNeg(f *testing.F) {
+	fuzzUnary(f, neg, func(a *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Neg(a), pbig)
+	})
+}
+
+func FuzzInverse(f *testing.F) {
+	fuzzUnary(f, inverse, func(a *big.Int) *big.Int {
+		// inverse of zero is zero
+		if a.Sign() == 0 {
+			return new(big.Int)
+		}
+		return new(big.Int).ModInverse(a, pbig)
+	})
+}
+
+func FuzzExp(f *testing.F) {
+	for _, a := range fuzzSeeds() {
+		for _, e := range fuzzSeeds() {
+			f.Add(a, e)
+		}
+	}
+	f.Fuzz(func(t *testing.T, in_a, in_e []byte) {
+		a, big_a := fuzzElement(in_a)
+		e := new(big.Int).SetBytes(in_e)
+		want := new(big.Int).Exp(big_a, e, pbig)
+		c := newFieldElement()
+		exp(c, a, e)
+		if have := toBig(c); have.Cmp(want) != 0 {
+			t.Fatalf("a: %x, e: %x\nhave %x\nwant %x", big_a, e, have, want)
+		}
+	})
+}
+
+func FuzzSerialization(f *testing.F) {
+	for _, in := range fuzzSeeds() {
+		f.Add(in)
+	}
+	f.Add(padBytes(pbig.Bytes(), byteSize))
+	f.Add([]byte{1})
+	f.Fuzz(func(t *testing.T, in []byte) {
+		v := new(big.Int).SetBytes(in)
+		valid := len(in) == byteSize && v.Cmp(pbig) < 0
+		a, err := newFieldElementFromBytes(in)
+		if (err == nil) != valid {
+			t.Fatalf("in: %x, valid: %t, err: %v", in, valid, err)
+		}
+		// wide reduction accepts any input
+		want := new(big.Int).Mod(v, pbig)
+		if have := toBig(fromBytesWide(in)); have.Cmp(want) != 0 {
+			t.Fatalf("in: %x, bad wide reduction\nhave %x\nwant %x", in, have, want)
+		}
+		if !valid {
+			return
+		}
+		if !bytes.Equal(toBytes(a), in) {
+			t.Fatalf("in: %x, bad serialization (bytes)", in)
+		}
+		a1, err := newFieldElementFromString(toString(a))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.equal(a1) {
+			t.Fatalf("in: %x, bad serialization (str)", in)
+		}
+		a1, err = newFieldElementFromBig(toBig(a))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.equal(a1) {
+			t.Fatalf("in: %x, bad serialization (big.Int)", in)
+		}
+	})
+}
+`
+
+const fieldFuzzNonFixedModulus = `
+var fuzzFieldInstance *field
+
+// fuzzField returns the field of the largest prime modulus that fits in
+// byteSize bytes so that fuzz failures can be replayed
+func fuzzField() *field {
+	if fuzzFieldInstance != nil {
+		return fuzzFieldInstance
+	}
+	pbig := new(big.Int).Lsh(big.NewInt(1), byteSize*8)
+	pbig.Sub(pbig, big.NewInt(1))
+	for !pbig.ProbablyPrime(20) {
+		pbig.Sub(pbig, big.NewInt(2))
+	}
+	field, err := newField(pbig.Bytes())
+	if err != nil {
+		panic(err)
+	}
+	fuzzFieldInstance = field
+	return field
+}
+
+// fuzzSeeds returns encodings of zero, one, p - 1 and all ones limbs
+func fuzzSeeds(field *field) [][]byte {
+	ones := make([]byte, byteSize)
+	for i := range ones {
+		ones[i] = 0xff
+	}
+	pm1 := new(big.Int).Sub(field.pbig, big.NewInt(1))
+	return [][]byte{
+		padBytes([]byte{0}, byteSize),
+		padBytes([]byte{1}, byteSize),
+		padBytes(pm1.Bytes(), byteSize),
+		ones,
+	}
+}
+
+// fuzzElement reduces fuzz input into the field and returns the element
+// with its big.Int counterpart
+func fuzzElement(field *field, in []byte) (*fieldElement, *big.Int) {
+	v := new(big.Int).SetBytes(in)
+	v.Mod(v, field.pbig)
+	fe, err := field.newFieldElementFromBig(v)
+	if err != nil {
+		panic(err)
+	}
+	return fe, v
+}
+
+func fuzzUnary(f *testing.F, op func(field *field, c, a *fieldElement), expected func(p, a *big.Int) *big.Int) {
+	field := fuzzField()
+	for _, a := range fuzzSeeds(field) {
+		f.Add(a)
+	}
+	f.Fuzz(func(t *testing.T, in []byte) {
+		a, big_a := fuzzElement(field, in)
+		want := expected(field.pbig, big_a)
+		c := field.newFieldElement()
+		op(field, c, a)
+		if have := field.toBig(c); have.Cmp(want) != 0 {
+			t.Fatalf("a: %x\nhave %x\nwant %x", big_a, have, want)
+		}
+		op(field, a, a)
+		if have := field.toBig(a); have.Cmp(want) != 0 {
+			t.Fatalf("a: %x, aliased\nhave %x\nwant %x", big_a, have, want)
+		}
+	})
+}
+
+func fuzzBinary(f *testing.F, op func(field *field, c, a, b *fieldElement), expected func(p, a, b *big.Int) *big.Int) {
+	field := fuzzField()
+	for _, a := range fuzzSeeds(field) {
+		for _, b := range fuzzSeeds(field) {
+			f.Add(a, b)
+		}
+	}
+	f.Fuzz(func(t *testing.T, in_a, in_b []byte) {
+		a, big_a := fuzzElement(field, in_a)
+		b, big_b := fuzzElement(field, in_b)
+		want := expected(field.pbig, big_a, big_b)
+		c := field.newFieldElement()
+		op(field, c, a, b)
+		if have := field.toBig(c); have.Cmp(want) != 0 {
+			t.Fatalf("a: %x, b: %x\nhave %x\nwant %x", big_a, big_b, have, want)
+		}
+		op(field, a, a, b)
+		if have := field.toBig(a); have.Cmp(want) != 0 {
+			t.Fatalf("a: %x, b: %x, aliased\nhave %x\nwant %x", big_a, big_b, have, want)
+		}
+	})
+}
+
+func FuzzAdd(f *testing.F) {
+	fuzzBinary(f, (*field).add, func(p, a, b *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Add(a, b), p)
+	})
+}
+
+func FuzzSub(f *testing.F) {
+	fuzzBinary(f, (*field).sub, func(p, a, b *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Sub(a, b), p)
+	})
+}
+
+func FuzzMul(f *testing.F) {
+	fuzzBinary(f, (*field).mul, func(p, a, b *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Mul(a, b), p)
+	})
+}
+
+func FuzzDouble(f *testing.F) {
+	fuzzUnary(f, (*field).double, func(p, a *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Lsh(a, 1), p)
+	})
+}
+
+func FuzzNeg(f *testing.F) {
+	fuzzUnary(f, (*field).neg, func(p, a *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Neg(a), p)
+	})
+}
+
+func FuzzInverse(f *testing.F) {
+	fuzzUnary(f, (*field).inverse, func(p, a *big.Int) *big.Int {
+		// inverse of zero is zero
+		if a.Sign() == 0 {
+			return new(big.Int)
+		}
+		return new(big.Int).ModInverse(a, p)
+	})
+}
+
+func FuzzExp(f *testing.F) {
+	field := fuzzField()
+	for _, a := range fuzzSeeds(field) {
+		for _, e := range fuzzSeeds(field) {
+			f.Add(a, e)
+		}
+	}
+	f.Fuzz(func(t *testing.T, in_a, in_e []byte) {
+		a, big_a := fuzzElement(field, in_a)
+		e := new(big.Int).SetBytes(in_e)
+		want := new(big.Int).Exp(big_a, e, field.pbig)
+		c := field.newFieldElement()
+		field.exp(c, a, e)
+		if have := field.toBig(c); have.Cmp(want) != 0 {
+			t.Fatalf("a: %x, e: %x\nhave %x\nwant %x", big_a, e, have, want)
+		}
+	})
+}
+
+func FuzzSerialization(f *testing.F) {
+	field := fuzzField()
+	for _, in := range fuzzSeeds(field) {
+		f.Add(in)
+	}
+	f.Add(padBytes(field.pbig.Bytes(), byteSize))
+	f.Add([]byte{1})
+	f.Fuzz(func(t *testing.T, in []byte) {
+		v := new(big.Int).SetBytes(in)
+		valid := len(in) == byteSize && v.Cmp(field.pbig) < 0
+		a, err := field.newFieldElementFromBytes(in)
+		if (err == nil) != valid {
+			t.Fatalf("in: %x, valid: %t, err: %v", in, valid, err)
+		}
+		// wide reduction accepts any input
+		want := new(big.Int).Mod(v, field.pbig)
+		if have := field.toBig(field.fromBytesWide(in)); have.Cmp(want) != 0 {
+			t.Fatalf("in: %x, bad wide reduction\nhave %x\nwant %x", in, have, want)
+		}
+		if !valid {
+			return
+		}
+		if !bytes.Equal(field.toBytes(a), in) {
+			t.Fatalf("in: %x, bad serialization (bytes)", in)
+		}
+		a1, err := field.newFieldElementFromString(field.toString(a))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.equal(a1) {
+			t.Fatalf("in: %x, bad serialization (str)", in)
+		}
+		a1, err = field.newFieldElementFromBig(field.toBig(a))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.equal(a1) {
+			t.Fatalf("in: %x, bad serialization (big.Int)", in)
+		}
+	})
+}
 `
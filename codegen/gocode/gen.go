@@ -27,9 +27,16 @@ func GenDeclerationsForMultiple(out string, limbSizes []int) {
 	outDir := filepath.Clean(out)
 	arithmeticDeclerationsCode := pkg("fp") + arithmeticDeclerationsMultiple(limbSizes)
 	writeToFile(arithmeticDeclerationsCode, filepath.Join(outDir, "arithmetic_decl.go"))
+	writeToFile(pkg("fp")+arithmeticTestHookMultiple(limbSizes), filepath.Join(outDir, "arithmetic_hook_test.go"))
+	writeToFile(pkg("fp")+mulBackendsCrossTest, filepath.Join(outDir, "mul_cross_test.go"))
+	writeToFile(pkg("fp")+fieldBenchmarksMultiple, filepath.Join(outDir, "field_bench_test.go"))
 }
 
-func GenField(out string, bitSize int, modulus string, opt string) error {
+// GenField generates field implementation. sswu and ell2 are optional comma
+// separated map to curve constants given as "A,B,Z" and "J,K,Z". encoding is
+// the binary marshaling encoding of fixed modulus fields, one of be,
+// be-minimal, le and le-minimal.
+func GenField(out string, bitSize int, modulus string, opt string, sswu, ell2, encoding string) error {
 
 	var limbSize int
 	var fixedModulus bool
@@ -81,16 +88,54 @@ func GenField(out string, bitSize int, modulus string, opt string) error {
 	arithmeticDeclerationsCode := pkg("fp") + arithmeticDeclerations(limbSize, fixedModulus)
 	fieldElementImplCode := pkg("fp") + fieldElementImpl(limbSize)
 	fieldImplCode := pkg("fp") + fieldImpl(limbSize, modulusBig)
+	hashToFieldCode := pkg("fp") + hashToFieldImpl(fixedModulus)
+	mapToCurveCode := pkg("fp") + mapToCurveNonFixedModulus
+	var sswuConstants, ell2Constants []*big.Int
+	if fixedModulus {
+		var err error
+		if sswuConstants, err = parseMapConstants(sswu, modulusBig); err != nil {
+			return err
+		}
+		if ell2Constants, err = parseMapConstants(ell2, modulusBig); err != nil {
+			return err
+		}
+		code, err := mapToCurveImpl(limbSize, modulusBig, sswuConstants, ell2Constants)
+		if err != nil {
+			return err
+		}
+		mapToCurveCode = pkg("fp") + code
+	} else if sswu != "" || ell2 != "" {
+		return fmt.Errorf("Map to curve constants require a fixed modulus\n")
+	}
+	if !fixedModulus && encoding != "" {
+		return fmt.Errorf("Byte encoding option requires a fixed modulus\n")
+	}
+	encodingName, err := parseByteEncoding(encoding)
+	if err != nil {
+		return err
+	}
 	testCode := ""
 	if fixedModulus {
-		testCode = fieldTestFixedModulus
+		testCode = fieldTestFixedModulus + fieldFuzzFixedModulus
 	} else {
-		testCode = fieldTestNonFixedModulus
+		testCode = fieldTestNonFixedModulus + fieldFuzzNonFixedModulus
 	}
 	writeToFile(arithmeticDeclerationsCode, filepath.Join(outDir, "arithmetic_decl.go"))
 	writeToFile(fieldElementImplCode, filepath.Join(outDir, "field_element.go"))
 	writeToFile(fieldImplCode, filepath.Join(outDir, "field.go"))
+	writeToFile(hashToFieldCode, filepath.Join(outDir, "hash_to_field.go"))
+	writeToFile(mapToCurveCode, filepath.Join(outDir, "map_to_curve.go"))
+	writeToFile(pkg("fp")+encodingImpl(fixedModulus, encodingName), filepath.Join(outDir, "encoding.go"))
+	writeToFile(pkg("fp")+nttImpl(fixedModulus), filepath.Join(outDir, "ntt.go"))
 	writeToFile(pkg("fp")+testCode, filepath.Join(outDir, "field_test.go"))
+	writeToFile(pkg("fp")+hashToFieldTest(fixedModulus), filepath.Join(outDir, "hash_to_field_test.go"))
+	writeToFile(pkg("fp")+mapToCurveTest(fixedModulus, sswuConstants, ell2Constants), filepath.Join(outDir, "map_to_curve_test.go"))
+	writeToFile(pkg("fp")+encodingTest(fixedModulus), filepath.Join(outDir, "encoding_test.go"))
+	writeToFile(pkg("fp")+nttTest(fixedModulus), filepath.Join(outDir, "ntt_test.go"))
+	writeToFile(pkg("fp")+vectorsTest(fixedModulus), filepath.Join(outDir, "vectors_test.go"))
+	writeToFile(pkg("fp")+ctTest(fixedModulus), filepath.Join(outDir, "ct_test.go"))
+	writeToFile(pkg("fp")+uintImpl, filepath.Join(outDir, "uint.go"))
+	writeToFile(pkg("fp")+uintTest, filepath.Join(outDir, "uint_test.go"))
 	return nil
 }
 
@@ -0,0 +1,420 @@
+package gocode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+)
+
+// GenGLV generates GLV scalar decomposition for a fixed modulus field where
+// modulus is the group order n and lambda is the eigenvalue of an efficient
+// endomorphism, such as phi(x, y) = (beta * x, y) of a = 0 curves. Scalars
+// are split as k = k1 + k2 * lambda mod n where k1 and k2 are about half the
+// size of n.
+func GenGLV(out string, modulus string, lambda string) error {
+	if len(modulus) < 2 || modulus[:2] != "0x" {
+		return fmt.Errorf("Bad format for modulus\n")
+	}
+	bts, err := hex.DecodeString(modulus[2:])
+	if err != nil {
+		return err
+	}
+	limbSize := resolveBitSize(len(bts)) / 64
+	n := new(big.Int).SetBytes(bts)
+	l, ok := new(big.Int).SetString(strings.TrimSpace(lambda), 0)
+	if !ok {
+		return fmt.Errorf("bad endomorphism eigenvalue %s\n", lambda)
+	}
+	if l.Sign() <= 0 || l.Cmp(n) >= 0 {
+		return fmt.Errorf("endomorphism eigenvalue should be in (0, n)\n")
+	}
+	g, err := newGLVParams(n, l, limbSize)
+	if err != nil {
+		return err
+	}
+	outDir := filepath.Clean(out)
+	writeToFile(pkg("fp")+glvImpl(g), filepath.Join(outDir, "glv.go"))
+	writeToFile(pkg("fp")+glvTest, filepath.Join(outDir, "glv_test.go"))
+	return nil
+}
+
+type glvParams struct {
+	n, lambda *big.Int
+	limbSize  int
+	// short lattice basis (a1, b1), (a2, b2) with a_i + b_i * lambda = 0 mod n
+	// and a1 * b2 - a2 * b1 = n
+	a1, b1, a2, b2 *big.Int
+	// bound of magnitudes of halves
+	bound *big.Int
+	// limb sizes of magnitudes of halves and of rounding constants
+	halfLimbSize  int
+	roundLimbSize int
+	// g1 = round(2^m * |b2| / n), g2 = round(2^m * |b1| / n) with m = 64 *
+	// (limbSize + 1)
+	g1, g2 *big.Int
+	// k1 = k + c1 * t[0][0] + c2 * t[0][1], k2 = c1 * t[1][0] + c2 * t[1][1]
+	// where c1, c2 are rounded with g1 and g2
+	t [2][2]*big.Int
+}
+
+// glvBasis runs extended euclidean algorithm on n and lambda where remainders
+// r_i = s_i * n + t_i * lambda and picks the short vectors around sqrt(n).
+// Guide to Elliptic Curve Cryptography, algorithm 3.74
+func glvBasis(n, lambda *big.Int) (a1, b1, a2, b2 *big.Int) {
+	sqrt := new(big.Int).Sqrt(n)
+	r0, r1 := new(big.Int).Set(n), new(big.Int).Set(lambda)
+	t0, t1 := big.NewInt(0), big.NewInt(1)
+	step := func() (*big.Int, *big.Int) {
+		q := new(big.Int).Div(r0, r1)
+		r := new(big.Int).Sub(r0, new(big.Int).Mul(q, r1))
+		t := new(big.Int).Sub(t0, new(big.Int).Mul(q, t1))
+		return r, t
+	}
+	for r1.Cmp(sqrt) >= 0 {
+		r, t := step()
+		r0, r1, t0, t1 = r1, r, t1, t
+	}
+	r2, t2 := step()
+	a1, b1 = r1, new(big.Int).Neg(t1)
+	norm := func(r, t *big.Int) *big.Int {
+		x := new(big.Int).Mul(r, r)
+		return x.Add(x, new(big.Int).Mul(t, t))
+	}
+	if norm(r0, t0).Cmp(norm(r2, t2)) <= 0 {
+		a2, b2 = r0, new(big.Int).Neg(t0)
+	} else {
+		a2, b2 = r2, new(big.Int).Neg(t2)
+	}
+	return a1, b1, a2, b2
+}
+
+func newGLVParams(n, lambda *big.Int, limbSize int) (*glvParams, error) {
+	g := &glvParams{n: n, lambda: lambda, limbSize: limbSize}
+	g.a1, g.b1, g.a2, g.b2 = glvBasis(n, lambda)
+	det := new(big.Int).Mul(g.a1, g.b2)
+	det.Sub(det, new(big.Int).Mul(g.a2, g.b1))
+	if det.Sign() < 0 {
+		g.a2.Neg(g.a2)
+		g.b2.Neg(g.b2)
+		det.Neg(det)
+	}
+	if det.Cmp(n) != 0 {
+		return nil, fmt.Errorf("lattice basis does not span the kernel, modulus should be prime\n")
+	}
+	// Babai rounding leaves at most half of each basis vector, rounding with
+	// g1 and g2 may be off by one only when exact value is about a half.
+	abs := func(x *big.Int) *big.Int { return new(big.Int).Abs(x) }
+	s0 := new(big.Int).Add(abs(g.a1), abs(g.a2))
+	s1 := new(big.Int).Add(abs(g.b1), abs(g.b2))
+	if s1.Cmp(s0) > 0 {
+		s0 = s1
+	}
+	g.bound = s0.Rsh(s0, 1).Add(s0, big.NewInt(1))
+	g.halfLimbSize = (g.bound.BitLen() + 63) / 64
+	if g.halfLimbSize > limbSize {
+		return nil, fmt.Errorf("endomorphism does not shorten scalars\n")
+	}
+	m := uint(64 * (limbSize + 1))
+	round := func(x *big.Int) *big.Int {
+		r := new(big.Int).Lsh(abs(x), m+1)
+		r.Add(r, n)
+		return r.Div(r, new(big.Int).Lsh(n, 1))
+	}
+	g.g1, g.g2 = round(g.b2), round(g.b1)
+	g.roundLimbSize = (g.g1.BitLen() + 63) / 64
+	if size := (g.g2.BitLen() + 63) / 64; size > g.roundLimbSize {
+		g.roundLimbSize = size
+	}
+	if g.roundLimbSize == 0 {
+		g.roundLimbSize = 1
+	}
+	// c1 = round(k * b2 / n) and c2 = round(-k * b1 / n) are computed as
+	// magnitudes so that signs are folded into the basis
+	s := [2]int{g.b2.Sign(), -g.b1.Sign()}
+	for i, v := range [2][2]*big.Int{{g.a1, g.a2}, {g.b1, g.b2}} {
+		for j := range v {
+			g.t[i][j] = new(big.Int).Mul(v[j], big.NewInt(int64(-s[j])))
+		}
+	}
+	return g, nil
+}
+
+// limbsLiteral returns little endian limbs of x mod 2^(64 * size) as a
+// composite literal body
+func limbsLiteral(x *big.Int, size int) string {
+	mod := new(big.Int).Lsh(big.NewInt(1), uint(64*size))
+	x = new(big.Int).Mod(x, mod)
+	mask := new(big.Int).SetUint64(^uint64(0))
+	code := "{"
+	for i := 0; i < size; i++ {
+		w := new(big.Int).Rsh(x, uint(64*i))
+		code += fmt.Sprintf("0x%16.16x, ", w.And(w, mask).Uint64())
+	}
+	return code[:len(code)-2] + "}"
+}
+
+func glvImpl(g *glvParams) string {
+	code := glvImports
+	code += fmt.Sprintf("const glvLimbSize = %d\n\n", g.halfLimbSize)
+	code += fmt.Sprintf("const glvRoundLimbSize = %d\n\n", g.roundLimbSize)
+	code += encodeBigMont("glvLambda", g.limbSize, g.lambda, g.n)
+	bigVar := func(name string, x *big.Int) string {
+		return fmt.Sprintf("var %s, _ = new(big.Int).SetString(\"%s\", 10)\n\n", name, x.String())
+	}
+	code += bigVar("glvLambdaBig", g.lambda)
+	code += "// short lattice basis (a1, b1), (a2, b2) with a_i + b_i * lambda = 0 mod n\n"
+	code += bigVar("glvA1", g.a1)
+	code += bigVar("glvB1", g.b1)
+	code += bigVar("glvA2", g.a2)
+	code += bigVar("glvB2", g.b2)
+	code += "// glvBound bounds magnitudes of both halves\n"
+	code += bigVar("glvBound", g.bound)
+	code += "// round(2^m * |b2| / n) and round(2^m * |b1| / n) where m = 64 * (limbSize + 1)\n"
+	code += fmt.Sprintf("var glvG1 = [glvRoundLimbSize]uint64%s\n\n", limbsLiteral(g.g1, g.roundLimbSize))
+	code += fmt.Sprintf("var glvG2 = [glvRoundLimbSize]uint64%s\n\n", limbsLiteral(g.g2, g.roundLimbSize))
+	code += "// basis with signs of rounded coefficients, in two's complement\n"
+	code += "var glvT = [2][2][glvLimbSize + 1]uint64{\n"
+	for i := 0; i < 2; i++ {
+		code += fmt.Sprintf("{%s, %s},\n", limbsLiteral(g.t[i][0], g.halfLimbSize+1), limbsLiteral(g.t[i][1], g.halfLimbSize+1))
+	}
+	code += "}\n"
+	return code + glvCommon
+}
+
+const glvImports = `
+import (
+	"math/big"
+	"math/bits"
+)
+
+`
+
+const glvCommon = `
+// glvScalar is a signed half length scalar given as little endian magnitude
+// and sign.
+type glvScalar struct {
+	abs [glvLimbSize]uint64
+	neg bool
+}
+
+func (k *glvScalar) toBig() *big.Int {
+	b := make([]byte, glvLimbSize*8)
+	for i := 0; i < glvLimbSize; i++ {
+		for j := 0; j < 8; j++ {
+			b[len(b)-1-i*8-j] = byte(k.abs[i] >> uint(8*j))
+		}
+	}
+	r := new(big.Int).SetBytes(b)
+	if k.neg {
+		r.Neg(r)
+	}
+	return r
+}
+
+// glvRound returns x / n rounded to the nearest integer
+func glvRound(x *big.Int) *big.Int {
+	r := new(big.Int).Lsh(x, 1)
+	r.Add(r, pbig)
+	return r.Div(r, new(big.Int).Lsh(pbig, 1))
+}
+
+// glvSplitVartime returns k1 and k2 such that k = k1 + k2 * lambda mod n with
+// Babai rounding of (k, 0) to the lattice, it is not constant time.
+func glvSplitVartime(k *big.Int) (*big.Int, *big.Int) {
+	k = new(big.Int).Mod(k, pbig)
+	c1 := glvRound(new(big.Int).Mul(k, glvB2))
+	c2 := glvRound(new(big.Int).Mul(k, new(big.Int).Neg(glvB1)))
+	k1 := new(big.Int).Sub(k, new(big.Int).Mul(c1, glvA1))
+	k1.Sub(k1, new(big.Int).Mul(c2, glvA2))
+	k2 := new(big.Int).Mul(c1, glvB1)
+	k2.Add(k2, new(big.Int).Mul(c2, glvB2))
+	return k1, k2.Neg(k2)
+}
+
+// glvSplit sets k1 and k2 such that k = k1 + k2 * lambda mod n in constant
+// time. Rounded coefficients are computed as (k * g + 2^(m - 1)) >> m and
+// halves are computed in two's complement with one more limb for the sign.
+func glvSplit(k1, k2 *glvScalar, k *fieldElement) {
+	e := newFieldElement()
+	fromMont(e, k)
+	c1, c2 := glvMulShift(e, &glvG1), glvMulShift(e, &glvG2)
+	var r1, r2 [glvLimbSize + 1]uint64
+	for i := 0; i < glvLimbSize+1 && i < limbSize; i++ {
+		r1[i] = e[i]
+	}
+	r1 = glvAdd(r1, glvMulLow(c1, glvT[0][0]))
+	r1 = glvAdd(r1, glvMulLow(c2, glvT[0][1]))
+	r2 = glvAdd(glvMulLow(c1, glvT[1][0]), glvMulLow(c2, glvT[1][1]))
+	glvFromSigned(k1, r1)
+	glvFromSigned(k2, r2)
+}
+
+// glvSplitPair splits a pair of exponents as in a * P + b * Q, which then
+// becomes a sum of four half length multiplications sharing doublings.
+func glvSplitPair(a, b *fieldElement) (sa, sb [2]glvScalar) {
+	glvSplit(&sa[0], &sa[1], a)
+	glvSplit(&sb[0], &sb[1], b)
+	return sa, sb
+}
+
+// glvMulShift returns low limbs of (e * g + 2^(m - 1)) >> m
+func glvMulShift(e *fieldElement, g *[glvRoundLimbSize]uint64) [glvLimbSize + 1]uint64 {
+	var w [limbSize + glvRoundLimbSize]uint64
+	for i := 0; i < limbSize; i++ {
+		var carry uint64
+		for j := 0; j < glvRoundLimbSize; j++ {
+			hi, lo := bits.Mul64(e[i], g[j])
+			var c uint64
+			lo, c = bits.Add64(lo, w[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			w[i+j], carry = lo, hi
+		}
+		w[i+glvRoundLimbSize] = carry
+	}
+	var carry uint64
+	w[limbSize], carry = bits.Add64(w[limbSize], 1<<63, 0)
+	for i := limbSize + 1; i < len(w); i++ {
+		w[i], carry = bits.Add64(w[i], 0, carry)
+	}
+	var r [glvLimbSize + 1]uint64
+	for i := 0; i < len(r) && limbSize+1+i < len(w); i++ {
+		r[i] = w[limbSize+1+i]
+	}
+	return r
+}
+
+// glvMulLow returns a * b mod 2^(64 * (glvLimbSize + 1))
+func glvMulLow(a, b [glvLimbSize + 1]uint64) [glvLimbSize + 1]uint64 {
+	var r [glvLimbSize + 1]uint64
+	for i := 0; i < len(r); i++ {
+		var carry uint64
+		for j := 0; i+j < len(r); j++ {
+			hi, lo := bits.Mul64(a[i], b[j])
+			var c uint64
+			lo, c = bits.Add64(lo, r[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			r[i+j], carry = lo, hi
+		}
+	}
+	return r
+}
+
+// glvAdd returns a + b mod 2^(64 * (glvLimbSize + 1))
+func glvAdd(a, b [glvLimbSize + 1]uint64) [glvLimbSize + 1]uint64 {
+	var carry uint64
+	for i := 0; i < len(a); i++ {
+		a[i], carry = bits.Add64(a[i], b[i], carry)
+	}
+	return a
+}
+
+// glvFromSigned sets k to two's complement value x without branches
+func glvFromSigned(k *glvScalar, x [glvLimbSize + 1]uint64) {
+	s := x[glvLimbSize] >> 63
+	mask, carry := -s, s
+	for i := 0; i < glvLimbSize; i++ {
+		k.abs[i], carry = bits.Add64(x[i]^mask, 0, carry)
+	}
+	k.neg = s == 1
+}
+`
+
+const glvTest = `
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func glvCheck(t *testing.T, k, k1, k2 *big.Int) {
+	t.Helper()
+	r := new(big.Int).Mul(k2, glvLambdaBig)
+	r.Add(r, k1).Sub(r, k).Mod(r, pbig)
+	if r.Sign() != 0 {
+		t.Fatalf("k1 + k2 * lambda != k, k: %s", k)
+	}
+	if new(big.Int).Abs(k1).Cmp(glvBound) > 0 || new(big.Int).Abs(k2).Cmp(glvBound) > 0 {
+		t.Fatalf("halves are out of bound, k: %s", k)
+	}
+}
+
+func glvTestScalars(t *testing.T) []*big.Int {
+	nMinusOne := new(big.Int).Sub(pbig, big.NewInt(1))
+	half := new(big.Int).Rsh(pbig, 1)
+	scalars := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		nMinusOne,
+		half,
+		new(big.Int).Add(half, big.NewInt(1)),
+		new(big.Int).Set(glvLambdaBig),
+		new(big.Int).Sub(pbig, glvLambdaBig),
+		new(big.Int).Abs(glvA1),
+		new(big.Int).Abs(glvB2),
+	}
+	for i := 0; i < pbig.BitLen(); i += 7 {
+		scalars = append(scalars, new(big.Int).Lsh(big.NewInt(1), uint(i)))
+	}
+	for i := 0; i < fuz; i++ {
+		e, _ := randFieldElement(rand.Reader)
+		scalars = append(scalars, toBig(e))
+	}
+	return scalars
+}
+
+func TestGLVBasis(t *testing.T) {
+	l := new(big.Int)
+	for _, v := range [][2]*big.Int{{glvA1, glvB1}, {glvA2, glvB2}} {
+		l.Mul(v[1], glvLambdaBig).Add(l, v[0]).Mod(l, pbig)
+		if l.Sign() != 0 {
+			t.Fatalf("basis vector is not in the kernel")
+		}
+	}
+	det := new(big.Int).Mul(glvA1, glvB2)
+	det.Sub(det, new(big.Int).Mul(glvA2, glvB1))
+	if det.Cmp(pbig) != 0 {
+		t.Fatalf("bad basis determinant")
+	}
+	if toBig(glvLambda).Cmp(glvLambdaBig) != 0 {
+		t.Fatalf("bad lambda")
+	}
+	if glvBound.BitLen() > glvLimbSize*64 {
+		t.Fatalf("bound does not fit into half limbs")
+	}
+}
+
+func TestGLVSplit(t *testing.T) {
+	for _, k := range glvTestScalars(t) {
+		k1, k2 := glvSplitVartime(k)
+		glvCheck(t, k, k1, k2)
+		e, err := newFieldElementFromBig(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s1, s2 glvScalar
+		glvSplit(&s1, &s2, e)
+		glvCheck(t, k, s1.toBig(), s2.toBig())
+		if s1.toBig().Sign() == 0 && s1.neg || s2.toBig().Sign() == 0 && s2.neg {
+			t.Fatalf("zero should not be negative")
+		}
+	}
+}
+
+func TestGLVSplitPair(t *testing.T) {
+	scalars := glvTestScalars(t)
+	for i := 0; i+1 < len(scalars); i++ {
+		a, _ := newFieldElementFromBig(scalars[i])
+		b, _ := newFieldElementFromBig(scalars[i+1])
+		sa, sb := glvSplitPair(a, b)
+		glvCheck(t, scalars[i], sa[0].toBig(), sa[1].toBig())
+		glvCheck(t, scalars[i+1], sb[0].toBig(), sb[1].toBig())
+	}
+}
+`
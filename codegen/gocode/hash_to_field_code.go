@@ -0,0 +1,444 @@
+package gocode
+
+func hashToFieldImpl(fixedModulus bool) string {
+	if fixedModulus {
+		return hashToFieldImports + hashToFieldExpanders + hashToFieldFixedModulus
+	}
+	return hashToFieldImports + hashToFieldExpanders + hashToFieldNonFixedModulus
+}
+
+func hashToFieldTest(fixedModulus bool) string {
+	if fixedModulus {
+		return hashToFieldTestImports + hashToFieldTestVectors + hashToFieldTestFixedModulus
+	}
+	return hashToFieldTestImports + hashToFieldTestVectors + hashToFieldTestNonFixedModulus
+}
+
+const hashToFieldImports = `
+import (
+	"crypto/sha256"
+	"errors"
+	"hash"
+
+	"golang.org/x/crypto/sha3"
+)
+`
+
+const hashToFieldExpanders = `
+
+// expander implements expand_message variants of RFC 9380, section 5.3
+type expander interface {
+	expand(msg, dst []byte, lenInBytes int) ([]byte, error)
+}
+
+var oversizeDSTPrefix = []byte("H2C-OVERSIZE-DST-")
+
+type expanderXMD struct {
+	h func() hash.Hash
+}
+
+// newExpanderXMD returns expand_message_xmd with given hash function
+// such as sha256.New or sha512.New
+func newExpanderXMD(h func() hash.Hash) expander {
+	return &expanderXMD{h}
+}
+
+func (e *expanderXMD) expand(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	h := e.h()
+	bInBytes, sInBytes := h.Size(), h.BlockSize()
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	if ell > 255 || lenInBytes > 65535 {
+		return nil, errors.New("requested output length is too large")
+	}
+	if len(dst) > 255 {
+		h.Write(oversizeDSTPrefix)
+		h.Write(dst)
+		dst = h.Sum(nil)
+		h.Reset()
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+	// b0 = H(Z_pad || msg || l_i_b_str || 0 || DST_prime)
+	h.Write(make([]byte, sInBytes))
+	h.Write(msg)
+	h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+	// b1 = H(b0 || 1 || DST_prime)
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+	out := make([]byte, 0, ell*bInBytes)
+	out = append(out, bi...)
+	// bi = H(b0 ^ b(i-1) || i || DST_prime)
+	for i := 2; i <= ell; i++ {
+		for j := 0; j < bInBytes; j++ {
+			bi[j] ^= b0[j]
+		}
+		h.Reset()
+		h.Write(bi)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+		out = append(out, bi...)
+	}
+	return out[:lenInBytes], nil
+}
+
+type expanderXOF struct {
+	h func() sha3.ShakeHash
+	k int
+}
+
+// newExpanderXOF returns expand_message_xof with given extendable output
+// function such as sha3.NewShake128. k is the target security level in bits
+// and is only used to shorten oversized domain separation tags.
+func newExpanderXOF(h func() sha3.ShakeHash, k int) expander {
+	return &expanderXOF{h, k}
+}
+
+func (e *expanderXOF) expand(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	if lenInBytes > 65535 {
+		return nil, errors.New("requested output length is too large")
+	}
+	h := e.h()
+	if len(dst) > 255 {
+		h.Write(oversizeDSTPrefix)
+		h.Write(dst)
+		dst = make([]byte, (2*e.k+7)/8)
+		h.Read(dst)
+		h.Reset()
+	}
+	// H(msg || l_i_b_str || DST_prime, len_in_bytes)
+	h.Write(msg)
+	h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes)})
+	h.Write(dst)
+	h.Write([]byte{byte(len(dst))})
+	out := make([]byte, lenInBytes)
+	h.Read(out)
+	return out, nil
+}
+`
+
+const hashToFieldFixedModulus = `
+// hashToField hashes a message to count field elements as in RFC 9380,
+// section 5.2. It uses expand_message_xmd with SHA-256 and targets 128 bits
+// of security which is what the standard suites over prime fields do.
+func hashToField(msg, dst []byte, count int) ([]*fieldElement, error) {
+	return hashToFieldWithExpander(newExpanderXMD(sha256.New), 128, msg, dst, count)
+}
+
+// hashToFieldWithExpander is hashToField with a custom expander and security
+// level k in bits. Each element is derived from ceil((ceil(log2(p)) + k) / 8)
+// uniform bytes which are reduced with fromBytesWide so that the bias is
+// negligible.
+func hashToFieldWithExpander(e expander, k int, msg, dst []byte, count int) ([]*fieldElement, error) {
+	if count < 1 {
+		return nil, errors.New("count must be positive")
+	}
+	L := (pbig.BitLen() + k + 7) / 8
+	uniformBytes, err := e.expand(msg, dst, count*L)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*fieldElement, count)
+	for i := 0; i < count; i++ {
+		out[i] = fromBytesWide(uniformBytes[i*L : (i+1)*L])
+	}
+	return out, nil
+}
+`
+
+const hashToFieldNonFixedModulus = `
+// hashToField hashes a message to count field elements as in RFC 9380,
+// section 5.2. It uses expand_message_xmd with SHA-256 and targets 128 bits
+// of security which is what the standard suites over prime fields do.
+func (f *field) hashToField(msg, dst []byte, count int) ([]*fieldElement, error) {
+	return f.hashToFieldWithExpander(newExpanderXMD(sha256.New), 128, msg, dst, count)
+}
+
+// hashToFieldWithExpander is hashToField with a custom expander and security
+// level k in bits. Each element is derived from ceil((ceil(log2(p)) + k) / 8)
+// uniform bytes which are reduced with fromBytesWide so that the bias is
+// negligible.
+func (f *field) hashToFieldWithExpander(e expander, k int, msg, dst []byte, count int) ([]*fieldElement, error) {
+	if count < 1 {
+		return nil, errors.New("count must be positive")
+	}
+	L := (f.pbig.BitLen() + k + 7) / 8
+	uniformBytes, err := e.expand(msg, dst, count*L)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*fieldElement, count)
+	for i := 0; i < count; i++ {
+		out[i] = f.fromBytesWide(uniformBytes[i*L : (i+1)*L])
+	}
+	return out, nil
+}
+`
+
+const hashToFieldTestImports = `
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+`
+
+const hashToFieldTestVectors = `
+
+type expanderVector struct {
+	msg          string
+	lenInBytes   int
+	uniformBytes string
+}
+
+// test vectors from RFC 9380, appendix K
+var expanderTestSuites = []struct {
+	expander expander
+	dst      string
+	vectors  []expanderVector
+}{
+	{
+		newExpanderXMD(sha256.New),
+		"QUUX-V01-CS02-with-expander-SHA256-128",
+		[]expanderVector{
+			{"", 32, "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+			{"abc", 32, "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+			{"", 128, "af84c27ccfd45d41914fdff5df25293e221afc53d8ad2ac06d5e3e29485dadbee0d121587713a3e0dd4d5e69e93eb7cd4f5df4cd103e188cf60cb02edc3edf18eda8576c412b18ffb658e3dd6ec849469b979d444cf7b26911a08e63cf31f9dcc541708d3491184472c2c29bb749d4286b004ceb5ee6b9a7fa5b646c993f0ced"},
+			{"abc", 128, "abba86a6129e366fc877aab32fc4ffc70120d8996c88aee2fe4b32d6c7b6437a647e6c3163d40b76a73cf6a5674ef1d890f95b664ee0afa5359a5c4e07985635bbecbac65d747d3d2da7ec2b8221b17b0ca9dc8a1ac1c07ea6a1e60583e2cb00058e77b7b72a298425cd1b941ad4ec65e8afc50303a22c0f99b0509b4c895f40"},
+		},
+	},
+	{
+		newExpanderXMD(sha256.New),
+		"QUUX-V01-CS02-with-expander-SHA256-128-long-DST-" + strings.Repeat("1", 208),
+		[]expanderVector{
+			{"", 32, "e8dc0c8b686b7ef2074086fbdd2f30e3f8bfbd3bdf177f73f04b97ce618a3ed3"},
+			{"abc", 32, "52dbf4f36cf560fca57dedec2ad924ee9c266341d8f3d6afe5171733b16bbb12"},
+			{"", 128, "14604d85432c68b757e485c8894db3117992fc57e0e136f71ad987f789a0abc287c47876978e2388a02af86b1e8d1342e5ce4f7aaa07a87321e691f6fba7e0072eecc1218aebb89fb14a0662322d5edbd873f0eb35260145cd4e64f748c5dfe60567e126604bcab1a3ee2dc0778102ae8a5cfd1429ebc0fa6bf1a53c36f55dfc"},
+			{"abc", 128, "1a30a5e36fbdb87077552b9d18b9f0aee16e80181d5b951d0471d55b66684914aef87dbb3626eaabf5ded8cd0686567e503853e5c84c259ba0efc37f71c839da2129fe81afdaec7fbdc0ccd4c794727a17c0d20ff0ea55e1389d6982d1241cb8d165762dbc39fb0cee4474d2cbbd468a835ae5b2f20e4f959f56ab24cd6fe267"},
+		},
+	},
+	{
+		newExpanderXMD(sha512.New),
+		"QUUX-V01-CS02-with-expander-SHA512-256",
+		[]expanderVector{
+			{"", 32, "6b9a7312411d92f921c6f68ca0b6380730a1a4d982c507211a90964c394179ba"},
+			{"abc", 32, "0da749f12fbe5483eb066a5f595055679b976e93abe9be6f0f6318bce7aca8dc"},
+			{"", 128, "41b037d1734a5f8df225dd8c7de38f851efdb45c372887be655212d07251b921b052b62eaed99b46f72f2ef4cc96bfaf254ebbbec091e1a3b9e4fb5e5b619d2e0c5414800a1d882b62bb5cd1778f098b8eb6cb399d5d9d18f5d5842cf5d13d7eb00a7cff859b605da678b318bd0e65ebff70bec88c753b159a805d2c89c55961"},
+			{"abc", 128, "7f1dddd13c08b543f2e2037b14cefb255b44c83cc397c1786d975653e36a6b11bdd7732d8b38adb4a0edc26a0cef4bb45217135456e58fbca1703cd6032cb1347ee720b87972d63fbf232587043ed2901bce7f22610c0419751c065922b488431851041310ad659e4b23520e1772ab29dcdeb2002222a363f0c2b1c972b3efe1"},
+		},
+	},
+	{
+		newExpanderXOF(sha3.NewShake128, 128),
+		"QUUX-V01-CS02-with-expander-SHAKE128",
+		[]expanderVector{
+			{"", 32, "86518c9cd86581486e9485aa74ab35ba150d1c75c88e26b7043e44e2acd735a2"},
+			{"abc", 32, "8696af52a4d862417c0763556073f47bc9b9ba43c99b505305cb1ec04a9ab468"},
+			{"", 128, "7314ff1a155a2fb99a0171dc71b89ab6e3b2b7d59e38e64419b8b6294d03ffee42491f11370261f436220ef787f8f76f5b26bdcd850071920ce023f3ac46847744f4612b8714db8f5db83205b2e625d95afd7d7b4d3094d3bdde815f52850bb41ead9822e08f22cf41d615a303b0d9dde73263c049a7b9898208003a739a2e57"},
+			{"abc", 128, "c952f0c8e529ca8824acc6a4cab0e782fc3648c563ddb00da7399f2ae35654f4860ec671db2356ba7baa55a34a9d7f79197b60ddae6e64768a37d699a78323496db3878c8d64d909d0f8a7de4927dcab0d3dbbc26cb20a49eceb0530b431cdf47bc8c0fa3e0d88f53b318b6739fbed7d7634974f1b5c386d6230c76260d5337a"},
+		},
+	},
+	{
+		newExpanderXOF(sha3.NewShake128, 128),
+		"QUUX-V01-CS02-with-expander-SHAKE128-long-DST-" + strings.Repeat("1", 210),
+		[]expanderVector{
+			{"", 32, "827c6216330a122352312bccc0c8d6e7a146c5257a776dbd9ad9d75cd880fc53"},
+			{"abc", 32, "690c8d82c7213b4282c6cb41c00e31ea1d3e2005f93ad19bbf6da40f15790c5c"},
+			{"", 128, "3890dbab00a2830be398524b71c2713bbef5f4884ac2e6f070b092effdb19208c7df943dc5dcbaee3094a78c267ef276632ee2c8ea0c05363c94b6348500fae4208345dd3475fe0c834c2beac7fa7bc181692fb728c0a53d809fc8111495222ce0f38468b11becb15b32060218e285c57a60162c2c8bb5b6bded13973cd41819"},
+			{"abc", 128, "41b7ffa7a301b5c1441495ebb9774e2a53dbbf4e54b9a1af6a20fd41eafd69ef7b9418599c5545b1ee422f363642b01d4a53449313f68da3e49dddb9cd25b97465170537d45dcbdf92391b5bdff344db4bd06311a05bca7dcd360b6caec849c299133e5c9194f4e15e3e23cfaab4003fab776f6ac0bfae9144c6e2e1c62e7d57"},
+		},
+	},
+	{
+		newExpanderXOF(sha3.NewShake256, 256),
+		"QUUX-V01-CS02-with-expander-SHAKE256",
+		[]expanderVector{
+			{"", 32, "2ffc05c48ed32b95d72e807f6eab9f7530dd1c2f013914c8fed38c5ccc15ad76"},
+			{"abc", 32, "b39e493867e2767216792abce1f2676c197c0692aed061560ead251821808e07"},
+			{"", 128, "7a1361d2d7d82d79e035b8880c5a3c86c5afa719478c007d96e6c88737a3f631dd74a2c88df79a4cb5e5d9f7504957c70d669ec6bfedc31e01e2bacc4ff3fdf9b6a00b17cc18d9d72ace7d6b81c2e481b4f73f34f9a7505dccbe8f5485f3d20c5409b0310093d5d6492dea4e18aa6979c23c8ea5de01582e9689612afbb353df"},
+			{"abc", 128, "a54303e6b172909783353ab05ef08dd435a558c3197db0c132134649708e0b9b4e34fb99b92a9e9e28fc1f1d8860d85897a8e021e6382f3eea10577f968ff6df6c45fe624ce65ca25932f679a42a404bc3681efe03fcd45ef73bb3a8f79ba784f80f55ea8a3c367408f30381299617f50c8cf8fbb21d0f1e1d70b0131a7b6fbe"},
+		},
+	},
+}
+
+type hashToFieldVector struct {
+	msg string
+	u   []string
+}
+
+// test vectors from RFC 9380, appendix J
+var hashToFieldTestSuites = []struct {
+	modulus string
+	dst     string
+	vectors []hashToFieldVector
+}{
+	{
+		"ffffffff00000001000000000000000000000000ffffffffffffffffffffffff",
+		"QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_",
+		[]hashToFieldVector{
+			{"", []string{
+				"ad5342c66a6dd0ff080df1da0ea1c04b96e0330dd89406465eeba11582515009",
+				"8c0f1d43204bd6f6ea70ae8013070a1518b43873bcd850aafa0a9e220e2eea5a",
+			}},
+			{"abc", []string{
+				"afe47f2ea2b10465cc26ac403194dfb68b7f5ee865cda61e9f3e07a537220af1",
+				"379a27833b0bfe6f7bdca08e1e83c760bf9a338ab335542704edcd69ce9e46e0",
+			}},
+			{"abcdef0123456789", []string{
+				"0fad9d125a9477d55cf9357105b0eb3a5c4259809bf87180aa01d651f53d312c",
+				"b68597377392cd3419d8fcc7d7660948c8403b19ea78bbca4b133c9d2196c0fb",
+			}},
+		},
+	},
+	{
+		"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab",
+		"QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_",
+		[]hashToFieldVector{
+			{"", []string{
+				"0ba14bd907ad64a016293ee7c2d276b8eae71f25a4b941eece7b0d89f17f75cb3ae5438a614fb61d6835ad59f29c564f",
+				"019b9bd7979f12657976de2884c7cce192b82c177c80e0ec604436a7f538d231552f0d96d9f7babe5fa3b19b3ff25ac9",
+			}},
+			{"abc", []string{
+				"0d921c33f2bad966478a03ca35d05719bdf92d347557ea166e5bba579eea9b83e9afa5c088573c2281410369fbd32951",
+				"003574a00b109ada2f26a37a91f9d1e740dffd8d69ec0c35e1e9f4652c7dba61123e9dd2e76c655d956e2b3462611139",
+			}},
+			{"abcdef0123456789", []string{
+				"062d1865eb80ebfa73dcfc45db1ad4266b9f3a93219976a3790ab8d52d3e5f1e62f3b01795e36834b17b70e7b76246d4",
+				"0cdc3e2f271f29c4ff75020857ce6c5d36008c9b48385ea2f2bf6f96f428a3deb798aa033cd482d1cdc8b30178b08e3a",
+			}},
+		},
+	},
+	{
+		"fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f",
+		"QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_",
+		[]hashToFieldVector{
+			{"", []string{
+				"6b0f9910dd2ba71c78f2ee9f04d73b5f4c5f7fc773a701abea1e573cab002fb3",
+				"1ae6c212e08fe1a5937f6202f929a2cc8ef4ee5b9782db68b0d5799fd8f09e16",
+			}},
+			{"abc", []string{
+				"128aab5d3679a1f7601e3bdf94ced1f43e491f544767e18a4873f397b08a2b61",
+				"5897b65da3b595a813d0fdcc75c895dc531be76a03518b044daaa0f2e4689e00",
+			}},
+		},
+	},
+}
+
+func TestExpandMessage(t *testing.T) {
+	for _, suite := range expanderTestSuites {
+		for _, v := range suite.vectors {
+			out, err := suite.expander.expand([]byte(v.msg), []byte(suite.dst), v.lenInBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected, _ := hex.DecodeString(v.uniformBytes)
+			if !bytes.Equal(out, expected) {
+				t.Fatalf("bad expand message, dst: %s, msg: %q, len: %d", suite.dst, v.msg, v.lenInBytes)
+			}
+		}
+	}
+}
+`
+
+const hashToFieldTestFixedModulus = `
+func TestHashToField(t *testing.T) {
+	for _, suite := range hashToFieldTestSuites {
+		p, _ := new(big.Int).SetString(suite.modulus, 16)
+		if p.Cmp(pbig) != 0 {
+			continue
+		}
+		for _, v := range suite.vectors {
+			u, err := hashToField([]byte(v.msg), []byte(suite.dst), len(v.u))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < len(v.u); i++ {
+				if hex.EncodeToString(padBytes(toBig(u[i]).Bytes(), len(suite.modulus)/2)) != v.u[i] {
+					t.Fatalf("bad hash to field, dst: %s, msg: %q, u%d: %s", suite.dst, v.msg, i, toString(u[i]))
+				}
+			}
+		}
+	}
+}
+
+func TestHashToFieldCrossAgainstBigInt(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	L := (pbig.BitLen() + 128 + 7) / 8
+	for i := 0; i < fuz; i++ {
+		msg := randBytes(pbig)
+		u, err := hashToField(msg, dst, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		uniformBytes, err := newExpanderXMD(sha256.New).expand(msg, dst, 2*L)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < 2; j++ {
+			expected := new(big.Int).SetBytes(uniformBytes[j*L : (j+1)*L])
+			expected.Mod(expected, pbig)
+			if toBig(u[j]).Cmp(expected) != 0 {
+				t.Fatalf("bad hash to field, u%d", j)
+			}
+		}
+	}
+}
+`
+
+const hashToFieldTestNonFixedModulus = `
+func TestHashToField(t *testing.T) {
+	for _, suite := range hashToFieldTestSuites {
+		p, _ := hex.DecodeString(suite.modulus)
+		if len(p) != byteSize {
+			continue
+		}
+		field, err := newField(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range suite.vectors {
+			u, err := field.hashToField([]byte(v.msg), []byte(suite.dst), len(v.u))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < len(v.u); i++ {
+				if hex.EncodeToString(padBytes(field.toBig(u[i]).Bytes(), len(p))) != v.u[i] {
+					t.Fatalf("bad hash to field, dst: %s, msg: %q, u%d: %s", suite.dst, v.msg, i, field.toString(u[i]))
+				}
+			}
+		}
+	}
+}
+
+func TestHashToFieldCrossAgainstBigInt(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	for i := 0; i < fuz; i++ {
+		field := randField()
+		L := (field.pbig.BitLen() + 128 + 7) / 8
+		msg := randBytes(field.pbig)
+		u, err := field.hashToField(msg, dst, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		uniformBytes, err := newExpanderXMD(sha256.New).expand(msg, dst, 2*L)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < 2; j++ {
+			expected := new(big.Int).SetBytes(uniformBytes[j*L : (j+1)*L])
+			expected.Mod(expected, field.pbig)
+			if field.toBig(u[j]).Cmp(expected) != 0 {
+				t.Fatalf("bad hash to field, u%d", j)
+			}
+		}
+	}
+}
+`
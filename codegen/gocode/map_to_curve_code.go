@@ -0,0 +1,803 @@
+package gocode
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// parseMapConstants parses comma separated constants such as "-3,0x5ac6,-10"
+// and reduces them with modulus.
+func parseMapConstants(in string, modulus *big.Int) ([]*big.Int, error) {
+	if in == "" {
+		return nil, nil
+	}
+	parts := strings.Split(in, ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("expected three constants, have %d\n", len(parts))
+	}
+	out := make([]*big.Int, 3)
+	for i, part := range parts {
+		c, ok := new(big.Int).SetString(strings.TrimSpace(part), 0)
+		if !ok {
+			return nil, fmt.Errorf("bad constant %s\n", part)
+		}
+		out[i] = c.Mod(c, modulus)
+	}
+	return out, nil
+}
+
+func isSquareBig(a, modulus *big.Int) bool {
+	e := new(big.Int).Rsh(modulus, 1)
+	t := new(big.Int).Exp(a, e, modulus)
+	return t.Cmp(big.NewInt(1)) == 0 || t.Sign() == 0
+}
+
+// findNonSquare returns first non square in 2, -2, 3, -3, ...
+func findNonSquare(modulus *big.Int) *big.Int {
+	for i := int64(2); ; i++ {
+		z := big.NewInt(i)
+		if !isSquareBig(z, modulus) {
+			return z
+		}
+		z.Sub(modulus, z)
+		if !isSquareBig(z, modulus) {
+			return z
+		}
+	}
+}
+
+func encodeBigMont(name string, limbSize int, b, modulus *big.Int) string {
+	R := new(big.Int).SetBit(new(big.Int), limbSize*64, 1)
+	return encodeBig(name, limbSize, new(big.Int).Mod(R.Mul(R, b), modulus), true)
+}
+
+// mapToCurveImpl returns sqrtRatio implementation for a fixed modulus. SSWU
+// and elligator 2 maps are added if their constants are given in
+// (A, B, Z) and (J, K, Z) order respectively. sqrtRatio is bound to SSWU Z
+// since the map depends on it, elligator 2 only needs square roots.
+func mapToCurveImpl(limbSize int, modulus *big.Int, sswu, ell2 []*big.Int) (string, error) {
+	var z *big.Int
+	if sswu != nil {
+		if sswu[0].Sign() == 0 || sswu[1].Sign() == 0 {
+			return "", fmt.Errorf("SSWU constants A and B should be non zero\n")
+		}
+		if isSquareBig(sswu[2], modulus) {
+			return "", fmt.Errorf("SSWU constant Z should be a non square\n")
+		}
+		z = sswu[2]
+	}
+	if ell2 != nil {
+		if ell2[1].Sign() == 0 {
+			return "", fmt.Errorf("Elligator 2 constant K should be non zero\n")
+		}
+		if isSquareBig(ell2[2], modulus) {
+			return "", fmt.Errorf("Elligator 2 constant Z should be a non square\n")
+		}
+		if z == nil {
+			z = ell2[2]
+		}
+	}
+	if z == nil {
+		z = findNonSquare(modulus)
+	}
+
+	one := big.NewInt(1)
+	pMinusOne := new(big.Int).Sub(modulus, one)
+	c1 := pMinusOne.TrailingZeroBits()
+	c2 := new(big.Int).Rsh(pMinusOne, c1)
+	c3 := new(big.Int).Rsh(c2, 1)
+	c4 := new(big.Int).Sub(new(big.Int).Lsh(one, c1), one)
+	c5 := new(big.Int).Lsh(one, c1-1)
+	c6 := new(big.Int).Exp(z, c2, modulus)
+	c7 := new(big.Int).Exp(z, new(big.Int).Rsh(new(big.Int).Add(c2, one), 1), modulus)
+
+	code := mapToCurveFixedModulus0
+	code += encodeBigMont("sqrtRatioZ", limbSize, z, modulus)
+	code += fmt.Sprintf("var sqrtRatioC1 = %d\n\n", c1)
+	code += fmt.Sprintf("var sqrtRatioC3, _ = new(big.Int).SetString(\"%s\", 10)\n\n", c3.String())
+	code += fmt.Sprintf("var sqrtRatioC4, _ = new(big.Int).SetString(\"%s\", 10)\n\n", c4.String())
+	code += fmt.Sprintf("var sqrtRatioC5, _ = new(big.Int).SetString(\"%s\", 10)\n\n", c5.String())
+	code += encodeBigMont("sqrtRatioC6", limbSize, c6, modulus)
+	code += encodeBigMont("sqrtRatioC7", limbSize, c7, modulus)
+	code += mapToCurveFixedModulus1
+	if sswu != nil {
+		code += encodeBigMont("sswuA", limbSize, sswu[0], modulus)
+		code += encodeBigMont("sswuB", limbSize, sswu[1], modulus)
+		code += encodeBigMont("sswuZ", limbSize, sswu[2], modulus)
+		code += mapToCurveFixedModulusSSWU
+	}
+	if ell2 != nil {
+		kInv := new(big.Int).ModInverse(ell2[1], modulus)
+		a := new(big.Int).Mul(ell2[0], kInv)
+		a.Mod(a, modulus)
+		b := new(big.Int).Mul(kInv, kInv)
+		b.Mod(b, modulus)
+		code += encodeBigMont("ell2J", limbSize, ell2[0], modulus)
+		code += encodeBigMont("ell2K", limbSize, ell2[1], modulus)
+		code += encodeBigMont("ell2Z", limbSize, ell2[2], modulus)
+		code += encodeBigMont("ell2A", limbSize, a, modulus)
+		code += encodeBigMont("ell2NegA", limbSize, new(big.Int).Mod(new(big.Int).Neg(a), modulus), modulus)
+		code += encodeBigMont("ell2B", limbSize, b, modulus)
+		code += mapToCurveFixedModulusElligator2
+	}
+	return code, nil
+}
+
+func mapToCurveTest(fixedModulus bool, sswu, ell2 []*big.Int) string {
+	if !fixedModulus {
+		return mapToCurveTestImports + mapToCurveTestVectors + mapToCurveTestNonFixedModulus
+	}
+	code := mapToCurveTestImports + mapToCurveTestVectors + mapToCurveTestFixedModulus
+	if sswu != nil {
+		code += mapToCurveTestFixedModulusSSWU
+	}
+	if ell2 != nil {
+		code += mapToCurveTestFixedModulusElligator2
+	}
+	return code
+}
+
+const mapToCurveFixedModulus0 = `
+import (
+	"math/big"
+)
+
+`
+
+const mapToCurveFixedModulus1 = `
+// sgn0 returns the sign of a field element as in RFC 9380, section 4.1
+func sgn0(a *fieldElement) bool {
+	t := newFieldElement()
+	fromMont(t, a)
+	return t[0]&1 == 1
+}
+
+// isSquare returns true if a is zero or a quadratic residue
+func isSquare(a *fieldElement) bool {
+	t := newFieldElement()
+	exp(t, a, new(big.Int).Rsh(pbig, 1))
+	return isOne(t) || isZero(t)
+}
+
+// sqrtRatio sets y to sqrt(u/v) and returns true if u/v is square. Otherwise
+// y is set to sqrt(z*u/v) and false is returned where z is sqrtRatioZ. v must
+// be non zero. Implements sqrt_ratio of RFC 9380, appendix F.2.1.1
+func sqrtRatio(y, u, v *fieldElement) bool {
+	tv1, tv2, tv3, tv4, tv5 := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	tv1.set(sqrtRatioC6)
+	exp(tv2, v, sqrtRatioC4)
+	mul(tv3, tv2, tv2)
+	mul(tv3, tv3, v)
+	mul(tv5, u, tv3)
+	exp(tv5, tv5, sqrtRatioC3)
+	mul(tv5, tv5, tv2)
+	mul(tv2, tv5, v)
+	mul(tv3, tv5, u)
+	mul(tv4, tv3, tv2)
+	exp(tv5, tv4, sqrtRatioC5)
+	// straight line procedure would report zero as a non square
+	isQR := isOne(tv5) || isZero(tv4)
+	mul(tv2, tv3, sqrtRatioC7)
+	mul(tv5, tv4, tv1)
+	cmov(tv3, tv2, tv3, isQR)
+	cmov(tv4, tv5, tv4, isQR)
+	for i := sqrtRatioC1; i >= 2; i-- {
+		tv5.set(tv4)
+		for j := 0; j < i-2; j++ {
+			mul(tv5, tv5, tv5)
+		}
+		e1 := isOne(tv5)
+		mul(tv2, tv3, tv1)
+		mul(tv1, tv1, tv1)
+		mul(tv5, tv4, tv1)
+		cmov(tv3, tv2, tv3, e1)
+		cmov(tv4, tv5, tv4, e1)
+	}
+	y.set(tv3)
+	return isQR
+}
+
+`
+
+const mapToCurveFixedModulusSSWU = `
+// mapToCurveSSWU maps u to a point of curve y^2 = x^3 + sswuA * x + sswuB and
+// returns its affine coordinates. Implements the straight line simplified
+// shallue van de woestijne ulas method of RFC 9380, appendix F.2
+func mapToCurveSSWU(u *fieldElement) (*fieldElement, *fieldElement) {
+	tv1, tv2, tv3, tv4, tv5, tv6 := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	x, y, y1 := newFieldElement(), newFieldElement(), newFieldElement()
+	mul(tv1, u, u)
+	mul(tv1, sswuZ, tv1)
+	mul(tv2, tv1, tv1)
+	add(tv2, tv2, tv1)
+	add(tv3, tv2, one)
+	mul(tv3, sswuB, tv3)
+	neg(tv4, tv2)
+	cmov(tv4, sswuZ, tv4, !isZero(tv2))
+	mul(tv4, sswuA, tv4)
+	mul(tv2, tv3, tv3)
+	mul(tv6, tv4, tv4)
+	mul(tv5, sswuA, tv6)
+	add(tv2, tv2, tv5)
+	mul(tv2, tv2, tv3)
+	mul(tv6, tv6, tv4)
+	mul(tv5, sswuB, tv6)
+	add(tv2, tv2, tv5)
+	mul(x, tv1, tv3)
+	isGx1Square := sqrtRatio(y1, tv2, tv6)
+	mul(y, tv1, u)
+	mul(y, y, y1)
+	cmov(x, x, tv3, isGx1Square)
+	cmov(y, y, y1, isGx1Square)
+	if sgn0(u) != sgn0(y) {
+		neg(y, y)
+	}
+	inverse(tv4, tv4)
+	mul(x, x, tv4)
+	return x, y
+}
+
+`
+
+const mapToCurveFixedModulusElligator2 = `
+// mapToCurveElligator2 maps u to a point of montgomery curve
+// ell2K * t^2 = s^3 + ell2J * s^2 + s and returns its affine coordinates.
+// Implements elligator 2 method of RFC 9380, section 6.7.1
+func mapToCurveElligator2(u *fieldElement) (*fieldElement, *fieldElement) {
+	x1, x2, gx1, gx2, t := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	// x1 = -a / (1 + z * u^2)
+	mul(t, u, u)
+	mul(t, t, ell2Z)
+	add(t, t, one)
+	inverse(t, t)
+	mul(x1, ell2NegA, t)
+	cmov(x1, x1, ell2NegA, isZero(x1))
+	// gx1 = x1^3 + a * x1^2 + b * x1
+	add(gx1, x1, ell2A)
+	mul(gx1, gx1, x1)
+	add(gx1, gx1, ell2B)
+	mul(gx1, gx1, x1)
+	// x2 = -x1 - a
+	sub(x2, ell2NegA, x1)
+	// gx2 = x2^3 + a * x2^2 + b * x2
+	add(gx2, x2, ell2A)
+	mul(gx2, gx2, x2)
+	add(gx2, gx2, ell2B)
+	mul(gx2, gx2, x2)
+
+	x, y := newFieldElement(), newFieldElement()
+	e := isSquare(gx1)
+	cmov(x, x2, x1, e)
+	cmov(t, gx2, gx1, e)
+	sqrtRatio(y, t, one)
+	if sgn0(y) != e {
+		neg(y, y)
+	}
+	// s = x * k, t = y * k
+	mul(x, x, ell2K)
+	mul(y, y, ell2K)
+	return x, y
+}
+`
+
+const mapToCurveNonFixedModulus = `
+import (
+	"math/big"
+)
+
+// sgn0 returns the sign of a field element as in RFC 9380, section 4.1
+func (f *field) sgn0(a *fieldElement) bool {
+	t := f.newFieldElement()
+	f.fromMont(t, a)
+	return t[0]&1 == 1
+}
+
+// isSquare returns true if a is zero or a quadratic residue
+func (f *field) isSquare(a *fieldElement) bool {
+	t := f.newFieldElement()
+	f.exp(t, a, new(big.Int).Rsh(f.pbig, 1))
+	return f.isOne(t) || f.isZero(t)
+}
+
+// sqrtRatio sets y to sqrt(u/v) and returns true if u/v is square. Otherwise
+// y is set to sqrt(z*u/v) and false is returned. z must be a non square and
+// v must be non zero. Implements sqrt_ratio of RFC 9380, appendix F.2.1.1
+func (f *field) sqrtRatio(y, u, v, z *fieldElement) bool {
+	// c1, largest integer such that 2^c1 divides p - 1
+	pMinusOne := new(big.Int).Sub(f.pbig, big.NewInt(1))
+	c1 := int(pMinusOne.TrailingZeroBits())
+	// c2 = (p - 1) / (2^c1)
+	c2 := new(big.Int).Rsh(pMinusOne, uint(c1))
+	// c3 = (c2 - 1) / 2
+	c3 := new(big.Int).Rsh(c2, 1)
+	// c4 = 2^c1 - 1
+	c4 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(c1)), big.NewInt(1))
+	// c5 = 2^(c1 - 1)
+	c5 := new(big.Int).Lsh(big.NewInt(1), uint(c1-1))
+	// c6 = z^c2
+	c6 := f.newFieldElement()
+	f.exp(c6, z, c2)
+	// c7 = z^((c2 + 1) / 2)
+	c7 := f.newFieldElement()
+	f.exp(c7, z, new(big.Int).Rsh(new(big.Int).Add(c2, big.NewInt(1)), 1))
+
+	tv1, tv2, tv3, tv4, tv5 := f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement()
+	tv1.set(c6)
+	f.exp(tv2, v, c4)
+	f.mul(tv3, tv2, tv2)
+	f.mul(tv3, tv3, v)
+	f.mul(tv5, u, tv3)
+	f.exp(tv5, tv5, c3)
+	f.mul(tv5, tv5, tv2)
+	f.mul(tv2, tv5, v)
+	f.mul(tv3, tv5, u)
+	f.mul(tv4, tv3, tv2)
+	f.exp(tv5, tv4, c5)
+	// straight line procedure would report zero as a non square
+	isQR := f.isOne(tv5) || f.isZero(tv4)
+	f.mul(tv2, tv3, c7)
+	f.mul(tv5, tv4, tv1)
+	f.cmov(tv3, tv2, tv3, isQR)
+	f.cmov(tv4, tv5, tv4, isQR)
+	for i := c1; i >= 2; i-- {
+		tv5.set(tv4)
+		for j := 0; j < i-2; j++ {
+			f.mul(tv5, tv5, tv5)
+		}
+		e1 := f.isOne(tv5)
+		f.mul(tv2, tv3, tv1)
+		f.mul(tv1, tv1, tv1)
+		f.mul(tv5, tv4, tv1)
+		f.cmov(tv3, tv2, tv3, e1)
+		f.cmov(tv4, tv5, tv4, e1)
+	}
+	y.set(tv3)
+	return isQR
+}
+
+// mapToCurveSSWU maps u to a point of curve y^2 = x^3 + a * x + b and returns
+// its affine coordinates. a and b must be non zero and z must be a non square
+// satisfying requirements of RFC 9380, section 6.6.2. Implements the straight
+// line simplified shallue van de woestijne ulas method of appendix F.2
+func (f *field) mapToCurveSSWU(u, a, b, z *fieldElement) (*fieldElement, *fieldElement) {
+	tv1, tv2, tv3, tv4, tv5, tv6 := f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement()
+	x, y, y1 := f.newFieldElement(), f.newFieldElement(), f.newFieldElement()
+	f.mul(tv1, u, u)
+	f.mul(tv1, z, tv1)
+	f.mul(tv2, tv1, tv1)
+	f.add(tv2, tv2, tv1)
+	f.add(tv3, tv2, f.one)
+	f.mul(tv3, b, tv3)
+	f.neg(tv4, tv2)
+	f.cmov(tv4, z, tv4, !f.isZero(tv2))
+	f.mul(tv4, a, tv4)
+	f.mul(tv2, tv3, tv3)
+	f.mul(tv6, tv4, tv4)
+	f.mul(tv5, a, tv6)
+	f.add(tv2, tv2, tv5)
+	f.mul(tv2, tv2, tv3)
+	f.mul(tv6, tv6, tv4)
+	f.mul(tv5, b, tv6)
+	f.add(tv2, tv2, tv5)
+	f.mul(x, tv1, tv3)
+	isGx1Square := f.sqrtRatio(y1, tv2, tv6, z)
+	f.mul(y, tv1, u)
+	f.mul(y, y, y1)
+	f.cmov(x, x, tv3, isGx1Square)
+	f.cmov(y, y, y1, isGx1Square)
+	if f.sgn0(u) != f.sgn0(y) {
+		f.neg(y, y)
+	}
+	f.inverse(tv4, tv4)
+	f.mul(x, x, tv4)
+	return x, y
+}
+
+// mapToCurveElligator2 maps u to a point of montgomery curve
+// k * t^2 = s^3 + j * s^2 + s and returns its affine coordinates. z must be
+// a non square. Implements elligator 2 method of RFC 9380, section 6.7.1
+func (f *field) mapToCurveElligator2(u, j, k, z *fieldElement) (*fieldElement, *fieldElement) {
+	// a = j / k, b = 1 / k^2
+	a, b := f.newFieldElement(), f.newFieldElement()
+	f.inverse(b, k)
+	f.mul(a, j, b)
+	f.mul(b, b, b)
+	negA := f.newFieldElement()
+	f.neg(negA, a)
+
+	x1, x2, gx1, gx2, t := f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement()
+	// x1 = -a / (1 + z * u^2)
+	f.mul(t, u, u)
+	f.mul(t, t, z)
+	f.add(t, t, f.one)
+	f.inverse(t, t)
+	f.mul(x1, negA, t)
+	f.cmov(x1, x1, negA, f.isZero(x1))
+	// gx1 = x1^3 + a * x1^2 + b * x1
+	f.add(gx1, x1, a)
+	f.mul(gx1, gx1, x1)
+	f.add(gx1, gx1, b)
+	f.mul(gx1, gx1, x1)
+	// x2 = -x1 - a
+	f.sub(x2, negA, x1)
+	// gx2 = x2^3 + a * x2^2 + b * x2
+	f.add(gx2, x2, a)
+	f.mul(gx2, gx2, x2)
+	f.add(gx2, gx2, b)
+	f.mul(gx2, gx2, x2)
+
+	x, y := f.newFieldElement(), f.newFieldElement()
+	e := f.isSquare(gx1)
+	f.cmov(x, x2, x1, e)
+	f.cmov(t, gx2, gx1, e)
+	f.sqrtRatio(y, t, f.one, z)
+	if f.sgn0(y) != e {
+		f.neg(y, y)
+	}
+	// s = x * k, t = y * k
+	f.mul(x, x, k)
+	f.mul(y, y, k)
+	return x, y
+}
+`
+
+const mapToCurveTestImports = `
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+`
+
+const mapToCurveTestVectors = `
+type mapToCurveVector struct {
+	u, x, y string
+}
+
+// test vectors from RFC 9380, appendix J. Q0 and Q1 of edwards25519 suite are
+// mapped back to montgomery coordinates of curve25519.
+var mapToCurveTestSuites = []struct {
+	name    string
+	modulus string
+	sswu    bool
+	a, b, z string
+	vectors []mapToCurveVector
+}{
+	{
+		"P256_XMD:SHA-256_SSWU_RO_",
+		"0xffffffff00000001000000000000000000000000ffffffffffffffffffffffff",
+		true,
+		"-3", "0x5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b", "-10",
+		[]mapToCurveVector{
+			{"ad5342c66a6dd0ff080df1da0ea1c04b96e0330dd89406465eeba11582515009", "ab640a12220d3ff283510ff3f4b1953d09fad35795140b1c5d64f313967934d5", "dccb558863804a881d4fff3455716c836cef230e5209594ddd33d85c565b19b1"},
+			{"8c0f1d43204bd6f6ea70ae8013070a1518b43873bcd850aafa0a9e220e2eea5a", "51cce63c50d972a6e51c61334f0f4875c9ac1cd2d3238412f84e31da7d980ef5", "b45d1a36d00ad90e5ec7840a60a4de411917fbe7c82c3949a6e699e5a1b66aac"},
+			{"afe47f2ea2b10465cc26ac403194dfb68b7f5ee865cda61e9f3e07a537220af1", "5219ad0ddef3cc49b714145e91b2f7de6ce0a7a7dc7406c7726c7e373c58cb48", "7950144e52d30acbec7b624c203b1996c99617d0b61c2442354301b191d93ecf"},
+			{"379a27833b0bfe6f7bdca08e1e83c760bf9a338ab335542704edcd69ce9e46e0", "019b7cb4efcfeaf39f738fe638e31d375ad6837f58a852d032ff60c69ee3875f", "589a62d2b22357fed5449bc38065b760095ebe6aeac84b01156ee4252715446e"},
+			{"0fad9d125a9477d55cf9357105b0eb3a5c4259809bf87180aa01d651f53d312c", "a17bdf2965eb88074bc01157e644ed409dac97cfcf0c61c998ed0fa45e79e4a2", "4f1bc80c70d411a3cc1d67aeae6e726f0f311639fee560c7f5a664554e3c9c2e"},
+			{"b68597377392cd3419d8fcc7d7660948c8403b19ea78bbca4b133c9d2196c0fb", "7da48bb67225c1a17d452c983798113f47e438e4202219dd0715f8419b274d66", "b765696b2913e36db3016c47edb99e24b1da30e761a8a3215dc0ec4d8f96e6f9"},
+		},
+	},
+	{
+		"curve25519_XMD:SHA-512_ELL2_RO_",
+		"0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed",
+		false,
+		"486662", "1", "2",
+		[]mapToCurveVector{
+			{"03fef4813c8cb5f98c6eef88fae174e6e7d5380de2b007799ac7ee712d203f3a", "2d992b0d270477caef253461ac55d374b0d94e920fffc9829c2eb56c0e86eaa1", "4c98c9144dd04dd6a214b9bd81c6e331d42f4e7c2442d1d6d71f4c210271a10b"},
+			{"780bdddd137290c8f589dc687795aafae35f6b674668d92bf92ae793e6a60c75", "79da2830189fbdf6e2ffee5abad1fd1f392e6128b8c7916e9ff3d361bed10776", "15104017c5efdedb26081ab16fbbbde5b8a2417b57491f92d394f70b86254d3b"},
+			{"5081955c4141e4e7d02ec0e36becffaa1934df4d7a270f70679c78f9bd57c227", "4a23d6be3bebabedc0532c41e6ea72d94049bbfcb2b902e7f6298b5c0a07fa40", "7d863635af1f2f10a13dff747d8b1f694d66d4aa636a63da367cded0a32583dc"},
+			{"005bdc17a9b378b6272573a31b04361f21c371b256252ae5463119aa0b925b76", "5183cb30e4bb924e884075fa7c14e01cecc1b19d99abfd085b68b2466ccd0951", "1fc455718fffd9209d73aa84bd0095f09df7234a9d0004b50b28713fb75e8ea8"},
+			{"285ebaa3be701b79871bcb6e225ecc9b0b32dff2d60424b4c50642636a78d5b3", "25ae496af08995bf1638d4a308857117f0384e3e78a54729d16308c29179e01b", "6923f22bfdc667ffbaede31c8a7fd2dfe5035085735110f41e6efa8e81ff3b39"},
+			{"2e253e6a0ef658fedb8e4bd6a62d1544fd6547922acb3598ec6b369760b81b31", "59beccbca1709115f923f8890c5d83e1a7b10f9cbdd25f61c590cf745102f09c", "01fb9ac02f2352b0cb16fe32bc3ed3ad2be69a2581e64a539da0929c74ecbda6"},
+		},
+	},
+}
+
+`
+
+const mapToCurveTestFixedModulus = `
+func newFieldElementFromConstant(t *testing.T, s string) *fieldElement {
+	c, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		t.Fatalf("bad constant %s", s)
+	}
+	fe, err := newFieldElementFromBig(c.Mod(c, pbig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fe
+}
+
+func randNonZero() *fieldElement {
+	for {
+		a, _ := randFieldElement(rand.Reader)
+		if !isZero(a) {
+			return a
+		}
+	}
+}
+
+func TestSqrtRatio(t *testing.T) {
+	if isSquare(sqrtRatioZ) {
+		t.Fatalf("z should be a non square")
+	}
+	for i := 0; i < fuz; i++ {
+		u, _ := randFieldElement(rand.Reader)
+		v := randNonZero()
+		y, t0, t1 := newFieldElement(), newFieldElement(), newFieldElement()
+		isQR := sqrtRatio(y, u, v)
+		// u / v is square iff u * v is square
+		mul(t0, u, v)
+		if isQR != isSquare(t0) {
+			t.Fatalf("bad square detection")
+		}
+		mul(t0, y, y)
+		mul(t0, t0, v)
+		t1.set(u)
+		if !isQR {
+			mul(t1, t1, sqrtRatioZ)
+		}
+		if !t0.equal(t1) {
+			t.Fatalf("y^2 * v == u or y^2 * v == z * u")
+		}
+	}
+	y := newFieldElement()
+	if !sqrtRatio(y, zero, one) || !isZero(y) {
+		t.Fatalf("sqrt(0 / 1) == 0")
+	}
+}
+`
+
+const mapToCurveTestFixedModulusSSWU = `
+func TestMapToCurveSSWU(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		u, _ := randFieldElement(rand.Reader)
+		x, y := mapToCurveSSWU(u)
+		// y^2 == x^3 + a * x + b
+		lhs, rhs := newFieldElement(), newFieldElement()
+		mul(lhs, y, y)
+		mul(rhs, x, x)
+		add(rhs, rhs, sswuA)
+		mul(rhs, rhs, x)
+		add(rhs, rhs, sswuB)
+		if !lhs.equal(rhs) {
+			t.Fatalf("point is not on curve")
+		}
+		if sgn0(u) != sgn0(y) {
+			t.Fatalf("sgn0(u) == sgn0(y)")
+		}
+	}
+}
+
+func TestMapToCurveSSWUVectors(t *testing.T) {
+	for _, suite := range mapToCurveTestSuites {
+		p, _ := new(big.Int).SetString(suite.modulus, 0)
+		if !suite.sswu || p.Cmp(pbig) != 0 {
+			continue
+		}
+		if !newFieldElementFromConstant(t, suite.a).equal(sswuA) ||
+			!newFieldElementFromConstant(t, suite.b).equal(sswuB) ||
+			!newFieldElementFromConstant(t, suite.z).equal(sswuZ) {
+			continue
+		}
+		for _, v := range suite.vectors {
+			u, err := newFieldElementFromString(v.u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			x, y := mapToCurveSSWU(u)
+			if toString(x) != v.x || toString(y) != v.y {
+				t.Fatalf("bad map to curve, %s, u: %s", suite.name, v.u)
+			}
+		}
+	}
+}
+`
+
+const mapToCurveTestFixedModulusElligator2 = `
+func TestMapToCurveElligator2(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		u, _ := randFieldElement(rand.Reader)
+		s, v := mapToCurveElligator2(u)
+		// k * t^2 == s^3 + j * s^2 + s
+		lhs, rhs := newFieldElement(), newFieldElement()
+		mul(lhs, v, v)
+		mul(lhs, lhs, ell2K)
+		add(rhs, s, ell2J)
+		mul(rhs, rhs, s)
+		add(rhs, rhs, one)
+		mul(rhs, rhs, s)
+		if !lhs.equal(rhs) {
+			t.Fatalf("point is not on curve")
+		}
+		if isZero(s) {
+			t.Fatalf("point should not be of order two")
+		}
+	}
+}
+
+func TestMapToCurveElligator2Vectors(t *testing.T) {
+	for _, suite := range mapToCurveTestSuites {
+		p, _ := new(big.Int).SetString(suite.modulus, 0)
+		if suite.sswu || p.Cmp(pbig) != 0 {
+			continue
+		}
+		if !newFieldElementFromConstant(t, suite.a).equal(ell2J) ||
+			!newFieldElementFromConstant(t, suite.b).equal(ell2K) ||
+			!newFieldElementFromConstant(t, suite.z).equal(ell2Z) {
+			continue
+		}
+		for _, v := range suite.vectors {
+			u, err := newFieldElementFromString(v.u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			x, y := mapToCurveElligator2(u)
+			if toString(x) != v.x || toString(y) != v.y {
+				t.Fatalf("bad map to curve, %s, u: %s", suite.name, v.u)
+			}
+		}
+	}
+}
+`
+
+const mapToCurveTestNonFixedModulus = `
+func (f *field) newFieldElementFromConstant(t *testing.T, s string) *fieldElement {
+	c, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		t.Fatalf("bad constant %s", s)
+	}
+	fe, err := f.newFieldElementFromBig(c.Mod(c, f.pbig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fe
+}
+
+func (f *field) randNonSquare() *fieldElement {
+	for {
+		z, _ := f.randFieldElement(rand.Reader)
+		if !f.isSquare(z) {
+			return z
+		}
+	}
+}
+
+func (f *field) randNonZero() *fieldElement {
+	for {
+		a, _ := f.randFieldElement(rand.Reader)
+		if !f.isZero(a) {
+			return a
+		}
+	}
+}
+
+func TestSqrtRatio(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		field := randField()
+		z := field.randNonSquare()
+		u, _ := field.randFieldElement(rand.Reader)
+		v := field.randNonZero()
+		y, t0, t1 := field.newFieldElement(), field.newFieldElement(), field.newFieldElement()
+		isQR := field.sqrtRatio(y, u, v, z)
+		// u / v is square iff u * v is square
+		field.mul(t0, u, v)
+		if isQR != field.isSquare(t0) {
+			t.Fatalf("bad square detection")
+		}
+		field.mul(t0, y, y)
+		field.mul(t0, t0, v)
+		t1.set(u)
+		if !isQR {
+			field.mul(t1, t1, z)
+		}
+		if !t0.equal(t1) {
+			t.Fatalf("y^2 * v == u or y^2 * v == z * u")
+		}
+		if !field.sqrtRatio(y, field.zero, field.one, z) || !field.isZero(y) {
+			t.Fatalf("sqrt(0 / 1) == 0")
+		}
+	}
+}
+
+func TestMapToCurveSSWU(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		field := randField()
+		a, b, z := field.randNonZero(), field.randNonZero(), field.randNonSquare()
+		u, _ := field.randFieldElement(rand.Reader)
+		x, y := field.mapToCurveSSWU(u, a, b, z)
+		// y^2 == x^3 + a * x + b
+		lhs, rhs := field.newFieldElement(), field.newFieldElement()
+		field.mul(lhs, y, y)
+		field.mul(rhs, x, x)
+		field.add(rhs, rhs, a)
+		field.mul(rhs, rhs, x)
+		field.add(rhs, rhs, b)
+		if !lhs.equal(rhs) {
+			t.Fatalf("point is not on curve")
+		}
+		if field.sgn0(u) != field.sgn0(y) {
+			t.Fatalf("sgn0(u) == sgn0(y)")
+		}
+	}
+}
+
+func TestMapToCurveElligator2(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		field := randField()
+		j, _ := field.randFieldElement(rand.Reader)
+		k, z := field.randNonZero(), field.randNonSquare()
+		u, _ := field.randFieldElement(rand.Reader)
+		s, v := field.mapToCurveElligator2(u, j, k, z)
+		// k * t^2 == s^3 + j * s^2 + s
+		lhs, rhs := field.newFieldElement(), field.newFieldElement()
+		field.mul(lhs, v, v)
+		field.mul(lhs, lhs, k)
+		field.add(rhs, s, j)
+		field.mul(rhs, rhs, s)
+		field.add(rhs, rhs, field.one)
+		field.mul(rhs, rhs, s)
+		if !lhs.equal(rhs) {
+			t.Fatalf("point is not on curve")
+		}
+	}
+}
+
+func TestMapToCurveVectors(t *testing.T) {
+	for _, suite := range mapToCurveTestSuites {
+		p, _ := new(big.Int).SetString(suite.modulus, 0)
+		if (p.BitLen()+63)/64 != limbSize {
+			continue
+		}
+		field, err := newField(padBytes(p.Bytes(), byteSize))
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := field.newFieldElementFromConstant(t, suite.a)
+		b := field.newFieldElementFromConstant(t, suite.b)
+		z := field.newFieldElementFromConstant(t, suite.z)
+		for _, v := range suite.vectors {
+			u, err := field.newFieldElementFromString(v.u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var x, y *fieldElement
+			if suite.sswu {
+				x, y = field.mapToCurveSSWU(u, a, b, z)
+			} else {
+				x, y = field.mapToCurveElligator2(u, a, b, z)
+			}
+			if field.toString(x) != v.x || field.toString(y) != v.y {
+				t.Fatalf("bad map to curve, %s, u: %s", suite.name, v.u)
+			}
+		}
+	}
+}
+`
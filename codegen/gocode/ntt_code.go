@@ -0,0 +1,558 @@
+package gocode
+
+func nttImpl(fixedModulus bool) string {
+	if fixedModulus {
+		return nttImports + nttFixedModulus
+	}
+	return nttImports + nttNonFixedModulus
+}
+
+func nttTest(fixedModulus bool) string {
+	if fixedModulus {
+		return nttTestImports + nttTestFixedModulus
+	}
+	return nttTestImports + nttTestNonFixedModulus
+}
+
+const nttImports = `
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+`
+
+const nttFixedModulus = `
+// twoAdicity returns largest s such that 2^s divides p - 1.
+func twoAdicity() int {
+	pMinusOne := new(big.Int).Sub(pbig, big.NewInt(1))
+	return int(pMinusOne.TrailingZeroBits())
+}
+
+// rootOfUnity returns a primitive 2^logN-th root of unity. It is derived from
+// the first non square g as g^((p - 1) / 2^s) which generates the 2-sylow
+// subgroup of order 2^s and squared down to the desired order.
+func rootOfUnity(logN int) (*fieldElement, error) {
+	s := twoAdicity()
+	if logN < 0 || logN > s {
+		return nil, fmt.Errorf("no root of unity of order 2^%d, two adicity is %d", logN, s)
+	}
+	g := newFieldElement()
+	g.set(one)
+	for {
+		add(g, g, one)
+		if !isSquare(g) {
+			break
+		}
+	}
+	e := new(big.Int).Sub(pbig, big.NewInt(1))
+	e.Rsh(e, uint(s))
+	w := newFieldElement()
+	exp(w, g, e)
+	for i := s; i > logN; i-- {
+		mul(w, w, w)
+	}
+	return w, nil
+}
+
+// domain is the multiplicative subgroup of order n = 2^logN with its twiddle
+// tables and a coset shift g such that g * domain is disjoint from domain.
+type domain struct {
+	logN     int
+	n        int
+	omega    *fieldElement
+	omegaInv *fieldElement
+	nInv     *fieldElement
+	// w^i and w^-i for i < n / 2
+	twiddles    []*fieldElement
+	twiddlesInv []*fieldElement
+	cosetGen    *fieldElement
+	cosetGenInv *fieldElement
+}
+
+func newDomain(logN int) (*domain, error) {
+	omega, err := rootOfUnity(logN)
+	if err != nil {
+		return nil, err
+	}
+	d := &domain{logN: logN, n: 1 << uint(logN), omega: omega}
+	d.omegaInv = newFieldElement()
+	inverse(d.omegaInv, omega)
+	d.nInv = newFieldElement()
+	n, _ := newFieldElementFromBig(big.NewInt(int64(d.n)))
+	inverse(d.nInv, n)
+	d.twiddles = d.powers(omega, d.n/2)
+	d.twiddlesInv = d.powers(d.omegaInv, d.n/2)
+	// smallest g > 1 with g^n != 1 is not in the domain
+	d.cosetGen = newFieldElement()
+	d.cosetGen.set(one)
+	t := newFieldElement()
+	for {
+		add(d.cosetGen, d.cosetGen, one)
+		exp(t, d.cosetGen, big.NewInt(int64(d.n)))
+		if !isOne(t) {
+			break
+		}
+	}
+	d.cosetGenInv = newFieldElement()
+	inverse(d.cosetGenInv, d.cosetGen)
+	return d, nil
+}
+
+// powers returns a^i for i < n.
+func (d *domain) powers(a *fieldElement, n int) []*fieldElement {
+	out := make([]*fieldElement, n)
+	if n == 0 {
+		return out
+	}
+	out[0] = newFieldElement()
+	out[0].set(one)
+	for i := 1; i < n; i++ {
+		out[i] = newFieldElement()
+		mul(out[i], out[i-1], a)
+	}
+	return out
+}
+
+func (d *domain) bitReverse(a []*fieldElement) {
+	shift := uint(64 - d.logN)
+	for i := 0; i < d.n; i++ {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+}
+
+// butterflies runs iterative cooley tukey stages on bit reversed input.
+func (d *domain) butterflies(a []*fieldElement, twiddles []*fieldElement) {
+	t := newFieldElement()
+	for m := 1; m < d.n; m <<= 1 {
+		step := d.n / (2 * m)
+		for k := 0; k < d.n; k += 2 * m {
+			for j := 0; j < m; j++ {
+				mul(t, a[k+j+m], twiddles[j*step])
+				sub(a[k+j+m], a[k+j], t)
+				add(a[k+j], a[k+j], t)
+			}
+		}
+	}
+}
+
+// ntt evaluates the polynomial with coefficients a at w^i in place.
+func (d *domain) ntt(a []*fieldElement) {
+	if len(a) != d.n {
+		panic(fmt.Sprintf("bad input size %d, expected %d", len(a), d.n))
+	}
+	d.bitReverse(a)
+	d.butterflies(a, d.twiddles)
+}
+
+// inverseNTT interpolates evaluations at w^i back to coefficients in place.
+func (d *domain) inverseNTT(a []*fieldElement) {
+	if len(a) != d.n {
+		panic(fmt.Sprintf("bad input size %d, expected %d", len(a), d.n))
+	}
+	d.bitReverse(a)
+	d.butterflies(a, d.twiddlesInv)
+	for i := 0; i < d.n; i++ {
+		mul(a[i], a[i], d.nInv)
+	}
+}
+
+// cosetNTT evaluates the polynomial with coefficients a at g * w^i in place.
+func (d *domain) cosetNTT(a []*fieldElement) {
+	d.scale(a, d.cosetGen)
+	d.ntt(a)
+}
+
+// inverseCosetNTT interpolates evaluations at g * w^i back to coefficients.
+func (d *domain) inverseCosetNTT(a []*fieldElement) {
+	d.inverseNTT(a)
+	d.scale(a, d.cosetGenInv)
+}
+
+// scale multiplies a_i with g^i.
+func (d *domain) scale(a []*fieldElement, g *fieldElement) {
+	t := newFieldElement()
+	t.set(one)
+	for i := 0; i < len(a); i++ {
+		mul(a[i], a[i], t)
+		mul(t, t, g)
+	}
+}
+`
+
+const nttNonFixedModulus = `
+// twoAdicity returns largest s such that 2^s divides p - 1.
+func (f *field) twoAdicity() int {
+	pMinusOne := new(big.Int).Sub(f.pbig, big.NewInt(1))
+	return int(pMinusOne.TrailingZeroBits())
+}
+
+// rootOfUnity returns a primitive 2^logN-th root of unity. It is derived from
+// the first non square g as g^((p - 1) / 2^s) which generates the 2-sylow
+// subgroup of order 2^s and squared down to the desired order.
+func (f *field) rootOfUnity(logN int) (*fieldElement, error) {
+	s := f.twoAdicity()
+	if logN < 0 || logN > s {
+		return nil, fmt.Errorf("no root of unity of order 2^%d, two adicity is %d", logN, s)
+	}
+	g := f.newFieldElement()
+	g.set(f.one)
+	for {
+		f.add(g, g, f.one)
+		if !f.isSquare(g) {
+			break
+		}
+	}
+	e := new(big.Int).Sub(f.pbig, big.NewInt(1))
+	e.Rsh(e, uint(s))
+	w := f.newFieldElement()
+	f.exp(w, g, e)
+	for i := s; i > logN; i-- {
+		f.mul(w, w, w)
+	}
+	return w, nil
+}
+
+// domain is the multiplicative subgroup of order n = 2^logN with its twiddle
+// tables and a coset shift g such that g * domain is disjoint from domain.
+type domain struct {
+	f        *field
+	logN     int
+	n        int
+	omega    *fieldElement
+	omegaInv *fieldElement
+	nInv     *fieldElement
+	// w^i and w^-i for i < n / 2
+	twiddles    []*fieldElement
+	twiddlesInv []*fieldElement
+	cosetGen    *fieldElement
+	cosetGenInv *fieldElement
+}
+
+func (f *field) newDomain(logN int) (*domain, error) {
+	omega, err := f.rootOfUnity(logN)
+	if err != nil {
+		return nil, err
+	}
+	d := &domain{f: f, logN: logN, n: 1 << uint(logN), omega: omega}
+	d.omegaInv = f.newFieldElement()
+	f.inverse(d.omegaInv, omega)
+	d.nInv = f.newFieldElement()
+	n, _ := f.newFieldElementFromBig(big.NewInt(int64(d.n)))
+	f.inverse(d.nInv, n)
+	d.twiddles = d.powers(omega, d.n/2)
+	d.twiddlesInv = d.powers(d.omegaInv, d.n/2)
+	// smallest g > 1 with g^n != 1 is not in the domain
+	d.cosetGen = f.newFieldElement()
+	d.cosetGen.set(f.one)
+	t := f.newFieldElement()
+	for {
+		f.add(d.cosetGen, d.cosetGen, f.one)
+		f.exp(t, d.cosetGen, big.NewInt(int64(d.n)))
+		if !f.isOne(t) {
+			break
+		}
+	}
+	d.cosetGenInv = f.newFieldElement()
+	f.inverse(d.cosetGenInv, d.cosetGen)
+	return d, nil
+}
+
+// powers returns a^i for i < n.
+func (d *domain) powers(a *fieldElement, n int) []*fieldElement {
+	out := make([]*fieldElement, n)
+	if n == 0 {
+		return out
+	}
+	out[0] = d.f.newFieldElement()
+	out[0].set(d.f.one)
+	for i := 1; i < n; i++ {
+		out[i] = d.f.newFieldElement()
+		d.f.mul(out[i], out[i-1], a)
+	}
+	return out
+}
+
+func (d *domain) bitReverse(a []*fieldElement) {
+	shift := uint(64 - d.logN)
+	for i := 0; i < d.n; i++ {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+}
+
+// butterflies runs iterative cooley tukey stages on bit reversed input.
+func (d *domain) butterflies(a []*fieldElement, twiddles []*fieldElement) {
+	f := d.f
+	t := f.newFieldElement()
+	for m := 1; m < d.n; m <<= 1 {
+		step := d.n / (2 * m)
+		for k := 0; k < d.n; k += 2 * m {
+			for j := 0; j < m; j++ {
+				f.mul(t, a[k+j+m], twiddles[j*step])
+				f.sub(a[k+j+m], a[k+j], t)
+				f.add(a[k+j], a[k+j], t)
+			}
+		}
+	}
+}
+
+// ntt evaluates the polynomial with coefficients a at w^i in place.
+func (d *domain) ntt(a []*fieldElement) {
+	if len(a) != d.n {
+		panic(fmt.Sprintf("bad input size %d, expected %d", len(a), d.n))
+	}
+	d.bitReverse(a)
+	d.butterflies(a, d.twiddles)
+}
+
+// inverseNTT interpolates evaluations at w^i back to coefficients in place.
+func (d *domain) inverseNTT(a []*fieldElement) {
+	if len(a) != d.n {
+		panic(fmt.Sprintf("bad input size %d, expected %d", len(a), d.n))
+	}
+	d.bitReverse(a)
+	d.butterflies(a, d.twiddlesInv)
+	for i := 0; i < d.n; i++ {
+		d.f.mul(a[i], a[i], d.nInv)
+	}
+}
+
+// cosetNTT evaluates the polynomial with coefficients a at g * w^i in place.
+func (d *domain) cosetNTT(a []*fieldElement) {
+	d.scale(a, d.cosetGen)
+	d.ntt(a)
+}
+
+// inverseCosetNTT interpolates evaluations at g * w^i back to coefficients.
+func (d *domain) inverseCosetNTT(a []*fieldElement) {
+	d.inverseNTT(a)
+	d.scale(a, d.cosetGenInv)
+}
+
+// scale multiplies a_i with g^i.
+func (d *domain) scale(a []*fieldElement, g *fieldElement) {
+	t := d.f.newFieldElement()
+	t.set(d.f.one)
+	for i := 0; i < len(a); i++ {
+		d.f.mul(a[i], a[i], t)
+		d.f.mul(t, t, g)
+	}
+}
+`
+
+const nttTestImports = `
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+`
+
+const nttTestFixedModulus = `
+// evalNaive evaluates polynomial with coefficients a at x
+func evalNaive(a []*fieldElement, x *fieldElement) *fieldElement {
+	acc := newFieldElement()
+	for i := len(a) - 1; i >= 0; i-- {
+		mul(acc, acc, x)
+		add(acc, acc, a[i])
+	}
+	return acc
+}
+
+func TestRootOfUnity(t *testing.T) {
+	s := twoAdicity()
+	minusOne := newFieldElement()
+	neg(minusOne, one)
+	u := newFieldElement()
+	for logN := 0; logN <= s; logN++ {
+		w, err := rootOfUnity(logN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp(u, w, new(big.Int).Lsh(big.NewInt(1), uint(logN)))
+		if !isOne(u) {
+			t.Fatalf("w^(2^%d) == 1", logN)
+		}
+		if logN > 0 {
+			exp(u, w, new(big.Int).Lsh(big.NewInt(1), uint(logN-1)))
+			if !u.equal(minusOne) {
+				t.Fatalf("w^(2^%d) == -1", logN-1)
+			}
+		}
+	}
+	if _, err := rootOfUnity(s + 1); err == nil {
+		t.Fatalf("root of unity of order larger than two adicity should be rejected")
+	}
+}
+
+func TestNTT(t *testing.T) {
+	maxLogN := twoAdicity()
+	if maxLogN > 6 {
+		maxLogN = 6
+	}
+	for i := 0; i < fuz; i++ {
+		for logN := 0; logN <= maxLogN; logN++ {
+			d, err := newDomain(logN)
+			if err != nil {
+				t.Fatal(err)
+			}
+			coeffs := make([]*fieldElement, d.n)
+			a := make([]*fieldElement, d.n)
+			for j := 0; j < d.n; j++ {
+				coeffs[j], _ = randFieldElement(rand.Reader)
+				a[j] = new(fieldElement).set(coeffs[j])
+			}
+			// forward
+			d.ntt(a)
+			x := new(fieldElement).set(one)
+			for j := 0; j < d.n; j++ {
+				if !a[j].equal(evalNaive(coeffs, x)) {
+					t.Fatalf("bad ntt, n: %d", d.n)
+				}
+				mul(x, x, d.omega)
+			}
+			// inverse
+			d.inverseNTT(a)
+			for j := 0; j < d.n; j++ {
+				if !a[j].equal(coeffs[j]) {
+					t.Fatalf("bad inverse ntt, n: %d", d.n)
+				}
+			}
+			// coset
+			d.cosetNTT(a)
+			x.set(d.cosetGen)
+			for j := 0; j < d.n; j++ {
+				if !a[j].equal(evalNaive(coeffs, x)) {
+					t.Fatalf("bad coset ntt, n: %d", d.n)
+				}
+				mul(x, x, d.omega)
+			}
+			d.inverseCosetNTT(a)
+			for j := 0; j < d.n; j++ {
+				if !a[j].equal(coeffs[j]) {
+					t.Fatalf("bad inverse coset ntt, n: %d", d.n)
+				}
+			}
+		}
+	}
+}
+`
+
+const nttTestNonFixedModulus = `
+// randFFTField returns a random field with modulus k * 2^s + 1
+func randFFTField(s int) *field {
+	bitSize := byteSize * 8
+	for {
+		k, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), uint(bitSize-s-1)))
+		if err != nil {
+			panic(err)
+		}
+		k.SetBit(k, bitSize-s-1, 1)
+		p := new(big.Int).Lsh(k, uint(s))
+		p.Add(p, big.NewInt(1))
+		if p.ProbablyPrime(20) {
+			field, err := newField(padBytes(p.Bytes(), byteSize))
+			if err != nil {
+				panic(err)
+			}
+			return field
+		}
+	}
+}
+
+// evalNaive evaluates polynomial with coefficients a at x
+func (f *field) evalNaive(a []*fieldElement, x *fieldElement) *fieldElement {
+	acc := f.newFieldElement()
+	for i := len(a) - 1; i >= 0; i-- {
+		f.mul(acc, acc, x)
+		f.add(acc, acc, a[i])
+	}
+	return acc
+}
+
+func TestRootOfUnity(t *testing.T) {
+	field := randFFTField(12)
+	s := field.twoAdicity()
+	if s < 12 {
+		t.Fatalf("bad two adicity")
+	}
+	minusOne := field.newFieldElement()
+	field.neg(minusOne, field.one)
+	u := field.newFieldElement()
+	for logN := 0; logN <= s; logN++ {
+		w, err := field.rootOfUnity(logN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		field.exp(u, w, new(big.Int).Lsh(big.NewInt(1), uint(logN)))
+		if !field.isOne(u) {
+			t.Fatalf("w^(2^%d) == 1", logN)
+		}
+		if logN > 0 {
+			field.exp(u, w, new(big.Int).Lsh(big.NewInt(1), uint(logN-1)))
+			if !u.equal(minusOne) {
+				t.Fatalf("w^(2^%d) == -1", logN-1)
+			}
+		}
+	}
+	if _, err := field.rootOfUnity(s + 1); err == nil {
+		t.Fatalf("root of unity of order larger than two adicity should be rejected")
+	}
+}
+
+func TestNTT(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		field := randFFTField(8)
+		for logN := 0; logN <= 6; logN++ {
+			d, err := field.newDomain(logN)
+			if err != nil {
+				t.Fatal(err)
+			}
+			coeffs := make([]*fieldElement, d.n)
+			a := make([]*fieldElement, d.n)
+			for j := 0; j < d.n; j++ {
+				coeffs[j], _ = field.randFieldElement(rand.Reader)
+				a[j] = new(fieldElement).set(coeffs[j])
+			}
+			// forward
+			d.ntt(a)
+			x := new(fieldElement).set(field.one)
+			for j := 0; j < d.n; j++ {
+				if !a[j].equal(field.evalNaive(coeffs, x)) {
+					t.Fatalf("bad ntt, n: %d", d.n)
+				}
+				field.mul(x, x, d.omega)
+			}
+			// inverse
+			d.inverseNTT(a)
+			for j := 0; j < d.n; j++ {
+				if !a[j].equal(coeffs[j]) {
+					t.Fatalf("bad inverse ntt, n: %d", d.n)
+				}
+			}
+			// coset
+			d.cosetNTT(a)
+			x.set(d.cosetGen)
+			for j := 0; j < d.n; j++ {
+				if !a[j].equal(field.evalNaive(coeffs, x)) {
+					t.Fatalf("bad coset ntt, n: %d", d.n)
+				}
+				field.mul(x, x, d.omega)
+			}
+			d.inverseCosetNTT(a)
+			for j := 0; j < d.n; j++ {
+				if !a[j].equal(coeffs[j]) {
+					t.Fatalf("bad inverse coset ntt, n: %d", d.n)
+				}
+			}
+		}
+	}
+}
+`
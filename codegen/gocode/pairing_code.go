@@ -0,0 +1,2391 @@
+package gocode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+)
+
+// GenPairing generates optimal ate pairing of a BN or BLS12 curve over a fixed
+// modulus field. Parameters are given as "bn,x" or "bls12,x" where x is the
+// curve parameter of the family, base field modulus should be the one
+// returned by PairingModulus. G1 is generated as a short weierstrass curve
+// with derived parameters.
+func GenPairing(out string, params string) error {
+	c, err := newPairingParams(params)
+	if err != nil {
+		return err
+	}
+	limbSize := resolveBitSize((c.p.BitLen()+7)/8) / 64
+	kat, err := c.referencePairing()
+	if err != nil {
+		return err
+	}
+	g1 := &weierstrassParams{a: new(big.Int), b: c.b, gx: c.g1.x[0], gy: c.g1.y[0], n: c.r, h: c.h1}
+	outDir := filepath.Clean(out)
+	writeToFile(pkg("fp")+weierstrassImpl(limbSize, c.p, g1), filepath.Join(outDir, "weierstrass.go"))
+	writeToFile(pkg("fp")+weierstrassTest, filepath.Join(outDir, "weierstrass_test.go"))
+	writeToFile(pkg("fp")+pairingImpl(limbSize, c), filepath.Join(outDir, "pairing.go"))
+	writeToFile(pkg("fp")+pairingTest(c, kat), filepath.Join(outDir, "pairing_test.go"))
+	return nil
+}
+
+// PairingModulus returns hex encoded base field modulus of a BN or BLS12 curve
+// given as in GenPairing.
+func PairingModulus(params string) (string, error) {
+	c, err := newPairingParams(params)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(c.p.Bytes()), nil
+}
+
+// ext2 is an element a0 + a1 * u of quadratic extension u^2 = beta used for
+// parameter derivation and reference pairing at generation time.
+type ext2 [2]*big.Int
+
+// affine2 is an affine point over quadratic extension, inf marks the point at
+// infinity.
+type affine2 struct {
+	x, y ext2
+	inf  bool
+}
+
+type pairingParams struct {
+	bn bool
+	x  *big.Int
+	// p is base field modulus, r is prime subgroup order and t is trace
+	p, r, t *big.Int
+	// G1 curve is y^2 = x^3 + b with cofactor h1
+	b  *big.Int
+	h1 *big.Int
+	// Fp2 = Fp[u] / (u^2 - beta), Fp6 = Fp2[v] / (v^3 - xi), Fp12 = Fp6[w] / (w^2 - v)
+	beta *big.Int
+	xi   ext2
+	// G2 curve is sextic twist y^2 = x^3 + b2 with cofactor h2. It is D type
+	// if b2 = b / xi and M type if b2 = b * xi.
+	dType bool
+	b2    ext2
+	h2    *big.Int
+	g1    *affine2
+	g2    *affine2
+}
+
+func parsePairingParams(in string) (bool, *big.Int, error) {
+	parts := strings.Split(in, ",")
+	if len(parts) != 2 {
+		return false, nil, fmt.Errorf("expected pairing family and curve parameter, have %d values\n", len(parts))
+	}
+	var bn bool
+	switch strings.TrimSpace(parts[0]) {
+	case "bn":
+		bn = true
+	case "bls12":
+		bn = false
+	default:
+		return false, nil, fmt.Errorf("unknown pairing family %s\n", parts[0])
+	}
+	x, ok := new(big.Int).SetString(strings.TrimSpace(parts[1]), 0)
+	if !ok || x.Sign() == 0 {
+		return false, nil, fmt.Errorf("bad curve parameter %s\n", parts[1])
+	}
+	return bn, x, nil
+}
+
+// newPairingParams derives field, curve and twist parameters from the family
+// polynomials of x.
+func newPairingParams(in string) (*pairingParams, error) {
+	bn, x, err := parsePairingParams(in)
+	if err != nil {
+		return nil, err
+	}
+	c := &pairingParams{bn: bn, x: x}
+	poly := func(coeffs ...int64) *big.Int {
+		// horner with highest degree first
+		acc := new(big.Int)
+		for _, a := range coeffs {
+			acc.Mul(acc, x).Add(acc, big.NewInt(a))
+		}
+		return acc
+	}
+	if bn {
+		c.p = poly(36, 36, 24, 6, 1)
+		c.r = poly(36, 36, 18, 6, 1)
+		c.t = poly(6, 0, 1)
+		c.h1 = big.NewInt(1)
+	} else {
+		if new(big.Int).Mod(poly(1, -1), big.NewInt(3)).Sign() != 0 {
+			return nil, fmt.Errorf("x - 1 should be divisible by 3 for BLS12 family\n")
+		}
+		c.r = poly(1, 0, -1, 0, 1)
+		c.h1 = new(big.Int).Div(poly(1, -2, 1), big.NewInt(3))
+		c.p = new(big.Int).Mul(c.h1, c.r)
+		c.p.Add(c.p, x)
+		c.t = poly(1, 1)
+	}
+	if c.p.Sign() <= 0 || !c.p.ProbablyPrime(20) {
+		return nil, fmt.Errorf("base field modulus is not prime\n")
+	}
+	if c.r.Sign() <= 0 || !c.r.ProbablyPrime(20) {
+		return nil, fmt.Errorf("subgroup order is not prime\n")
+	}
+	if new(big.Int).Mod(c.p, big.NewInt(6)).Int64() != 1 {
+		return nil, fmt.Errorf("modulus should be 1 mod 6\n")
+	}
+	if err := c.findCurve(); err != nil {
+		return nil, err
+	}
+	if err := c.findTower(); err != nil {
+		return nil, err
+	}
+	if err := c.findTwist(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// findCurve finds smallest b for which y^2 = x^3 + b has a point of order r
+// and sets G1 generator as cofactor cleared point with smallest x.
+func (c *pairingParams) findCurve() error {
+	f := &ext2Field{p: c.p, beta: new(big.Int).Sub(c.p, big.NewInt(1))}
+	for b := int64(1); b < 1000; b++ {
+		bb := f.fromBase(big.NewInt(b))
+		g := f.findPoint(bb, false, c.h1)
+		if g == nil {
+			continue
+		}
+		if !f.mulPoint(g, c.r).inf {
+			continue
+		}
+		c.b, c.g1 = big.NewInt(b), g
+		return nil
+	}
+	return fmt.Errorf("no curve found with a subgroup of order r\n")
+}
+
+// findTower sets non residues of extension tower. u^2 = -1 is preferred, xi is
+// k + u for smallest k where xi is neither a square nor a cube.
+func (c *pairingParams) findTower() error {
+	if new(big.Int).Mod(c.p, big.NewInt(4)).Int64() == 3 {
+		c.beta = new(big.Int).Sub(c.p, big.NewInt(1))
+	} else {
+		for k := int64(2); ; k++ {
+			beta := new(big.Int).Sub(c.p, big.NewInt(k))
+			if !isSquareBig(beta, c.p) {
+				c.beta = beta
+				break
+			}
+		}
+	}
+	f := c.ext2Field()
+	p2 := new(big.Int).Mul(c.p, c.p)
+	p2.Sub(p2, big.NewInt(1))
+	e2 := new(big.Int).Div(p2, big.NewInt(2))
+	e3 := new(big.Int).Div(p2, big.NewInt(3))
+	for k := int64(1); k < 1000; k++ {
+		xi := ext2{big.NewInt(k), big.NewInt(1)}
+		if f.isOne(f.exp(xi, e2)) || f.isOne(f.exp(xi, e3)) {
+			continue
+		}
+		c.xi = xi
+		return nil
+	}
+	return fmt.Errorf("no cubic and quadratic non residue found\n")
+}
+
+// findTwist finds sextic twist of order divisible by r and sets G2 generator
+// as cofactor cleared point with smallest x in base field.
+func (c *pairingParams) findTwist() error {
+	p := c.p
+	// trace over Fp2 and t2^2 - 4p^2 = -3f2^2
+	t2 := new(big.Int).Mul(c.t, c.t)
+	t2.Sub(t2, new(big.Int).Lsh(p, 1))
+	f2 := new(big.Int).Mul(p, p)
+	f2.Lsh(f2, 2).Sub(f2, new(big.Int).Mul(t2, t2)).Div(f2, big.NewInt(3))
+	f2.Sqrt(f2)
+	f3 := new(big.Int).Mul(f2, big.NewInt(3))
+	var n2 *big.Int
+	for _, s := range [][2]int{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}} {
+		tw := new(big.Int).Mul(t2, big.NewInt(int64(s[0])))
+		tw.Add(tw, new(big.Int).Mul(f3, big.NewInt(int64(s[1])))).Rsh(tw, 1)
+		n := new(big.Int).Mul(p, p)
+		n.Add(n, big.NewInt(1)).Sub(n, tw)
+		if new(big.Int).Mod(n, c.r).Sign() == 0 {
+			n2 = n
+			break
+		}
+	}
+	if n2 == nil {
+		return fmt.Errorf("no sextic twist with order divisible by r\n")
+	}
+	c.h2 = new(big.Int).Div(n2, c.r)
+	f := c.ext2Field()
+	b := f.fromBase(c.b)
+	for _, dType := range []bool{true, false} {
+		var b2 ext2
+		if dType {
+			b2 = f.mul(b, f.inverse(c.xi))
+		} else {
+			b2 = f.mul(b, c.xi)
+		}
+		g := f.findPoint(b2, true, c.h2)
+		if g == nil || !f.mulPoint(g, c.r).inf {
+			continue
+		}
+		c.dType, c.b2, c.g2 = dType, b2, g
+		return nil
+	}
+	return fmt.Errorf("no twist found with a subgroup of order r\n")
+}
+
+func (c *pairingParams) ext2Field() *ext2Field {
+	return &ext2Field{p: c.p, beta: c.beta}
+}
+
+// loop returns miller loop scalar which is 6x + 2 for BN and x for BLS12
+// family
+func (c *pairingParams) loop() *big.Int {
+	if c.bn {
+		s := new(big.Int).Mul(c.x, big.NewInt(6))
+		return s.Add(s, big.NewInt(2))
+	}
+	return new(big.Int).Set(c.x)
+}
+
+// loopNAF returns little endian non adjacent form of absolute value of the
+// miller loop scalar
+func (c *pairingParams) loopNAF() []int8 {
+	e := new(big.Int).Abs(c.loop())
+	var naf []int8
+	for e.Sign() > 0 {
+		if e.Bit(0) == 1 {
+			d := 2 - int8(e.Bits()[0]&3)
+			naf = append(naf, d)
+			e.Sub(e, big.NewInt(int64(d)))
+		} else {
+			naf = append(naf, 0)
+		}
+		e.Rsh(e, 1)
+	}
+	return naf
+}
+
+// twistFrobenius returns coefficients of frobenius endomorphism on twist
+// points as (conj(x) * cx, conj(y) * cy)
+func (c *pairingParams) twistFrobenius() (ext2, ext2) {
+	f := c.ext2Field()
+	e := new(big.Int).Sub(c.p, big.NewInt(1))
+	cx := f.exp(c.xi, new(big.Int).Div(e, big.NewInt(3)))
+	cy := f.exp(c.xi, new(big.Int).Div(e, big.NewInt(2)))
+	if !c.dType {
+		cx, cy = f.inverse(cx), f.inverse(cy)
+	}
+	return cx, cy
+}
+
+// frobeniusCoeffs returns xi^(i * (p^k - 1) / 6) for coefficients of w^i
+func (c *pairingParams) frobeniusCoeffs(k int) [6]ext2 {
+	f := c.ext2Field()
+	e := new(big.Int).Exp(c.p, big.NewInt(int64(k)), nil)
+	e.Sub(e, big.NewInt(1)).Div(e, big.NewInt(6))
+	var out [6]ext2
+	for i := 0; i < 6; i++ {
+		out[i] = f.exp(c.xi, new(big.Int).Mul(e, big.NewInt(int64(i))))
+	}
+	return out
+}
+
+// ext2Field is big.Int arithmetic of Fp[u] / (u^2 - beta)
+type ext2Field struct {
+	p, beta *big.Int
+}
+
+func (f *ext2Field) fromBase(a *big.Int) ext2 {
+	return ext2{new(big.Int).Mod(a, f.p), new(big.Int)}
+}
+
+func (f *ext2Field) isZero(a ext2) bool {
+	return a[0].Sign() == 0 && a[1].Sign() == 0
+}
+
+func (f *ext2Field) isOne(a ext2) bool {
+	return a[0].Cmp(big.NewInt(1)) == 0 && a[1].Sign() == 0
+}
+
+func (f *ext2Field) equal(a, b ext2) bool {
+	return a[0].Cmp(b[0]) == 0 && a[1].Cmp(b[1]) == 0
+}
+
+func (f *ext2Field) add(a, b ext2) ext2 {
+	c0 := new(big.Int).Add(a[0], b[0])
+	c1 := new(big.Int).Add(a[1], b[1])
+	return ext2{c0.Mod(c0, f.p), c1.Mod(c1, f.p)}
+}
+
+func (f *ext2Field) sub(a, b ext2) ext2 {
+	c0 := new(big.Int).Sub(a[0], b[0])
+	c1 := new(big.Int).Sub(a[1], b[1])
+	return ext2{c0.Mod(c0, f.p), c1.Mod(c1, f.p)}
+}
+
+func (f *ext2Field) neg(a ext2) ext2 {
+	return f.sub(ext2{new(big.Int), new(big.Int)}, a)
+}
+
+func (f *ext2Field) mul(a, b ext2) ext2 {
+	c0 := new(big.Int).Mul(a[1], b[1])
+	c0.Mul(c0, f.beta).Add(c0, new(big.Int).Mul(a[0], b[0]))
+	c1 := new(big.Int).Mul(a[0], b[1])
+	c1.Add(c1, new(big.Int).Mul(a[1], b[0]))
+	return ext2{c0.Mod(c0, f.p), c1.Mod(c1, f.p)}
+}
+
+func (f *ext2Field) mulBase(a ext2, b int64) ext2 {
+	return f.mul(a, f.fromBase(big.NewInt(b)))
+}
+
+func (f *ext2Field) conjugate(a ext2) ext2 {
+	return ext2{new(big.Int).Set(a[0]), new(big.Int).Mod(new(big.Int).Neg(a[1]), f.p)}
+}
+
+// norm returns a * conj(a) = a0^2 - beta * a1^2
+func (f *ext2Field) norm(a ext2) *big.Int {
+	return f.mul(a, f.conjugate(a))[0]
+}
+
+func (f *ext2Field) inverse(a ext2) ext2 {
+	n := new(big.Int).ModInverse(f.norm(a), f.p)
+	t := f.conjugate(a)
+	return ext2{t[0].Mul(t[0], n).Mod(t[0], f.p), t[1].Mul(t[1], n).Mod(t[1], f.p)}
+}
+
+func (f *ext2Field) exp(a ext2, e *big.Int) ext2 {
+	z := f.fromBase(big.NewInt(1))
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		z = f.mul(z, z)
+		if e.Bit(i) == 1 {
+			z = f.mul(z, a)
+		}
+	}
+	return z
+}
+
+// sqrt returns a square root of a with complex method, second return value is
+// false if a is not a square
+func (f *ext2Field) sqrt(a ext2) (ext2, bool) {
+	if !isSquareBig(f.norm(a), f.p) && f.norm(a).Sign() != 0 {
+		return ext2{}, false
+	}
+	var r ext2
+	if a[1].Sign() == 0 {
+		if s := new(big.Int).ModSqrt(a[0], f.p); s != nil {
+			r = ext2{s, new(big.Int)}
+		} else {
+			t := new(big.Int).ModInverse(f.beta, f.p)
+			t.Mul(t, a[0]).Mod(t, f.p)
+			r = ext2{new(big.Int), new(big.Int).ModSqrt(t, f.p)}
+		}
+	} else {
+		s := new(big.Int).ModSqrt(f.norm(a), f.p)
+		half := new(big.Int).ModInverse(big.NewInt(2), f.p)
+		x0 := new(big.Int).Add(a[0], s)
+		x0.Mul(x0, half).Mod(x0, f.p)
+		if !isSquareBig(x0, f.p) {
+			x0.Sub(a[0], s).Mul(x0, half).Mod(x0, f.p)
+		}
+		x0.ModSqrt(x0, f.p)
+		x1 := new(big.Int).ModInverse(new(big.Int).Lsh(x0, 1), f.p)
+		x1.Mul(x1, a[1]).Mod(x1, f.p)
+		r = ext2{x0, x1}
+	}
+	if !f.equal(f.mul(r, r), a) {
+		return ext2{}, false
+	}
+	return r, true
+}
+
+// findPoint returns h * (x, y) on y^2 = x^3 + b for smallest x = 1, 2, ...
+// where result is not at infinity. y is picked as the smaller root if curve is
+// over base field.
+func (f *ext2Field) findPoint(b ext2, ext bool, h *big.Int) *affine2 {
+	for i := int64(1); i < 1000; i++ {
+		x := f.fromBase(big.NewInt(i))
+		rhs := f.add(f.mul(f.mul(x, x), x), b)
+		if !ext {
+			if !isSquareBig(rhs[0], f.p) {
+				continue
+			}
+		}
+		y, ok := f.sqrt(rhs)
+		if !ok {
+			continue
+		}
+		if !ext {
+			if n := new(big.Int).Sub(f.p, y[0]); n.Cmp(y[0]) < 0 {
+				y[0] = n
+			}
+		}
+		g := f.mulPoint(&affine2{x: x, y: y}, h)
+		if !g.inf {
+			return g
+		}
+	}
+	return nil
+}
+
+func (f *ext2Field) addPoint(p, q *affine2) *affine2 {
+	if p.inf {
+		return q
+	}
+	if q.inf {
+		return p
+	}
+	var l ext2
+	if f.equal(p.x, q.x) {
+		if f.isZero(f.add(p.y, q.y)) {
+			return &affine2{inf: true}
+		}
+		l = f.mul(f.mulBase(f.mul(p.x, p.x), 3), f.inverse(f.mulBase(p.y, 2)))
+	} else {
+		l = f.mul(f.sub(q.y, p.y), f.inverse(f.sub(q.x, p.x)))
+	}
+	x := f.sub(f.sub(f.mul(l, l), p.x), q.x)
+	y := f.sub(f.mul(l, f.sub(p.x, x)), p.y)
+	return &affine2{x: x, y: y}
+}
+
+func (f *ext2Field) mulPoint(p *affine2, e *big.Int) *affine2 {
+	r := &affine2{inf: true}
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = f.addPoint(r, r)
+		if e.Bit(i) == 1 {
+			r = f.addPoint(r, p)
+		}
+	}
+	return r
+}
+
+// ext12 is an element of Fp12 = Fp[w] / ((w^6 - xi0)^2 - beta * xi1^2) as a
+// polynomial in w. This representation is independent of the generated tower
+// and is used to compute reference pairing values.
+type ext12 [12]*big.Int
+
+type ext12Field struct {
+	f2      *ext2Field
+	p, beta *big.Int
+	xi      ext2
+}
+
+func (f *ext12Field) newExt12() ext12 {
+	var a ext12
+	for i := range a {
+		a[i] = new(big.Int)
+	}
+	return a
+}
+
+// monomial returns a * w^k for a in base field
+func (f *ext12Field) monomial(a *big.Int, k int) ext12 {
+	r := f.newExt12()
+	r[k].Mod(a, f.p)
+	return r
+}
+
+// embed returns a0 + a1 * u where u = (w^6 - xi0) / xi1
+func (f *ext12Field) embed(a ext2) ext12 {
+	xi1Inv := new(big.Int).ModInverse(f.xi[1], f.p)
+	r := f.newExt12()
+	r[6].Mul(a[1], xi1Inv).Mod(r[6], f.p)
+	r[0].Mul(r[6], f.xi[0]).Sub(a[0], r[0]).Mod(r[0], f.p)
+	return r
+}
+
+// tower returns coefficients in Fp2 of w^i
+func (f *ext12Field) tower(a ext12) [6]ext2 {
+	var out [6]ext2
+	for i := 0; i < 6; i++ {
+		c1 := new(big.Int).Mul(a[i+6], f.xi[1])
+		c0 := new(big.Int).Mul(a[i+6], f.xi[0])
+		c0.Add(c0, a[i])
+		out[i] = ext2{c0.Mod(c0, f.p), c1.Mod(c1, f.p)}
+	}
+	return out
+}
+
+func (f *ext12Field) add(a, b ext12) ext12 {
+	r := f.newExt12()
+	for i := range r {
+		r[i].Add(a[i], b[i]).Mod(r[i], f.p)
+	}
+	return r
+}
+
+func (f *ext12Field) sub(a, b ext12) ext12 {
+	r := f.newExt12()
+	for i := range r {
+		r[i].Sub(a[i], b[i]).Mod(r[i], f.p)
+	}
+	return r
+}
+
+func (f *ext12Field) mul(a, b ext12) ext12 {
+	var t [23]*big.Int
+	for i := range t {
+		t[i] = new(big.Int)
+	}
+	for i := 0; i < 12; i++ {
+		for j := 0; j < 12; j++ {
+			t[i+j].Add(t[i+j], new(big.Int).Mul(a[i], b[j]))
+		}
+	}
+	// w^12 = 2 * xi0 * w^6 - (xi0^2 - beta * xi1^2)
+	c6 := new(big.Int).Lsh(f.xi[0], 1)
+	c0 := new(big.Int).Mul(f.xi[1], f.xi[1])
+	c0.Mul(c0, f.beta).Sub(new(big.Int).Mul(f.xi[0], f.xi[0]), c0)
+	for i := 22; i >= 12; i-- {
+		t[i].Mod(t[i], f.p)
+		t[i-6].Add(t[i-6], new(big.Int).Mul(t[i], c6))
+		t[i-12].Sub(t[i-12], new(big.Int).Mul(t[i], c0))
+	}
+	r := f.newExt12()
+	for i := range r {
+		r[i].Mod(t[i], f.p)
+	}
+	return r
+}
+
+func (f *ext12Field) exp(a ext12, e *big.Int) ext12 {
+	z := f.monomial(big.NewInt(1), 0)
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		z = f.mul(z, z)
+		if e.Bit(i) == 1 {
+			z = f.mul(z, a)
+		}
+	}
+	return z
+}
+
+func (f *ext12Field) isOne(a ext12) bool {
+	return f.equal(a, f.monomial(big.NewInt(1), 0))
+}
+
+func (f *ext12Field) equal(a, b ext12) bool {
+	for i := range a {
+		if a[i].Cmp(b[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// untwist returns a * w^k for D type and a * w^-k for M type twist
+func (c *pairingParams) untwist(f *ext12Field, a ext2, k int) ext12 {
+	if c.dType {
+		return f.mul(f.embed(a), f.monomial(big.NewInt(1), k))
+	}
+	// w^-k = w^(6 - k) / xi
+	return f.mul(f.embed(f.f2.mul(a, f.f2.inverse(c.xi))), f.monomial(big.NewInt(1), 6-k))
+}
+
+// line returns line through twist points t and q evaluated at untwisted g1
+// generator, vertical lines are omitted since they are eliminated by final
+// exponentiation. Second return value is t + q.
+func (c *pairingParams) line(f *ext12Field, t, q *affine2) (ext12, *affine2) {
+	f2 := f.f2
+	var l ext2
+	if f2.equal(t.x, q.x) {
+		l = f2.mul(f2.mulBase(f2.mul(t.x, t.x), 3), f2.inverse(f2.mulBase(t.y, 2)))
+	} else {
+		l = f2.mul(f2.sub(q.y, t.y), f2.inverse(f2.sub(q.x, t.x)))
+	}
+	// y_p - y_t - lambda * (x_p - x_t)
+	xp := f.monomial(c.g1.x[0], 0)
+	yp := f.monomial(c.g1.y[0], 0)
+	r := f.sub(xp, c.untwist(f, t.x, 2))
+	r = f.mul(r, c.untwist(f, l, 1))
+	r = f.sub(f.sub(yp, c.untwist(f, t.y, 3)), r)
+	return r, f2.addPoint(t, q)
+}
+
+// referencePairing returns pairing of generators computed with affine
+// coordinates, plain Fp12 polynomial arithmetic and plain final exponentiation.
+// Result is given as Fp2 coefficients of w^i in generated tower.
+func (c *pairingParams) referencePairing() ([6]ext2, error) {
+	f2 := c.ext2Field()
+	f := &ext12Field{f2: f2, p: c.p, beta: c.beta, xi: c.xi}
+	q := c.g2
+	s := c.loop()
+	k := new(big.Int).Abs(s)
+	m := f.monomial(big.NewInt(1), 0)
+	t := q
+	for i := k.BitLen() - 2; i >= 0; i-- {
+		var l ext12
+		l, t = c.line(f, t, t)
+		m = f.mul(f.mul(m, m), l)
+		if k.Bit(i) == 1 {
+			l, t = c.line(f, t, q)
+			m = f.mul(m, l)
+		}
+	}
+	// (p^12 - 1) / r
+	e := new(big.Int).Exp(c.p, big.NewInt(12), nil)
+	e.Sub(e, big.NewInt(1)).Div(e, c.r)
+	res := f.exp(m, e)
+	if s.Sign() < 0 {
+		// f_{-k} = 1 / f_k up to vertical lines
+		res = f.exp(res, new(big.Int).Sub(c.r, big.NewInt(1)))
+		t = &affine2{x: t.x, y: f2.neg(t.y)}
+	}
+	if c.bn {
+		cx, cy := c.twistFrobenius()
+		frob := func(a *affine2) *affine2 {
+			return &affine2{x: f2.mul(f2.conjugate(a.x), cx), y: f2.mul(f2.conjugate(a.y), cy)}
+		}
+		q1 := frob(q)
+		// check twist frobenius against frobenius of untwisted point
+		if !f.equal(c.untwist(f, q1.x, 2), f.exp(c.untwist(f, q.x, 2), c.p)) ||
+			!f.equal(c.untwist(f, q1.y, 3), f.exp(c.untwist(f, q.y, 3), c.p)) {
+			return [6]ext2{}, fmt.Errorf("bad twist frobenius coefficients\n")
+		}
+		q2 := frob(q1)
+		q2.y = f2.neg(q2.y)
+		l1, t := c.line(f, t, q1)
+		l2, _ := c.line(f, t, q2)
+		res = f.mul(res, f.exp(f.mul(l1, l2), e))
+	} else {
+		res = f.exp(res, big.NewInt(3))
+	}
+	if f.isOne(res) || !f.isOne(f.exp(res, c.r)) {
+		return [6]ext2{}, fmt.Errorf("reference pairing is degenerate\n")
+	}
+	return f.tower(res), nil
+}
+
+func encodeExt2Mont(limbSize int, a ext2, modulus *big.Int) string {
+	return fmt.Sprintf("fe2{\n%s,\n%s,\n}", montLiteral(limbSize, a[0], modulus), montLiteral(limbSize, a[1], modulus))
+}
+
+// montLiteral returns fieldElement literal of b in montgomery form
+func montLiteral(limbSize int, b, modulus *big.Int) string {
+	R := new(big.Int).SetBit(new(big.Int), limbSize*64, 1)
+	R.Mul(R, b).Mod(R, modulus)
+	byteSize := limbSize * 8
+	bts := padBytes(R.Bytes(), byteSize)
+	out := "fieldElement{\n"
+	for i := 0; i < limbSize; i++ {
+		out += fmt.Sprintf("0x%16.16x,\n", bts[byteSize-(i+1)*8:byteSize-i*8])
+	}
+	return out + "}"
+}
+
+// pairingImpl returns tower, G2 and pairing implementation. Multiplication
+// by non residues, line placement and miller loop tail are picked at
+// generation time.
+func pairingImpl(limbSize int, c *pairingParams) string {
+	code := pairingImports
+	code += fmt.Sprintf("// pairingX is the curve parameter of %s family\n", map[bool]string{true: "BN", false: "BLS12"}[c.bn])
+	code += fmt.Sprintf("var pairingX, _ = new(big.Int).SetString(\"%s\", 10)\n\n", c.x.String())
+	naf := c.loopNAF()
+	digits := make([]string, len(naf))
+	for i, d := range naf {
+		digits[i] = fmt.Sprintf("%d", d)
+	}
+	code += "// ateLoop is little endian non adjacent form of absolute value of the\n"
+	code += "// miller loop scalar\n"
+	code += fmt.Sprintf("var ateLoop = []int8{%s}\n\n", strings.Join(digits, ", "))
+	code += fmt.Sprintf("const ateLoopNegative = %v\n\n", c.loop().Sign() < 0)
+	code += fmt.Sprintf("var fe2Xi = &%s\n\n", encodeExt2Mont(limbSize, c.xi, c.p))
+	code += fmt.Sprintf("var g2B = &%s\n\n", encodeExt2Mont(limbSize, c.b2, c.p))
+	f := c.ext2Field()
+	code += fmt.Sprintf("var g2B3 = &%s\n\n", encodeExt2Mont(limbSize, f.mulBase(c.b2, 3), c.p))
+	code += fmt.Sprintf("var g2Gx = &%s\n\n", encodeExt2Mont(limbSize, c.g2.x, c.p))
+	code += fmt.Sprintf("var g2Gy = &%s\n\n", encodeExt2Mont(limbSize, c.g2.y, c.p))
+	code += fmt.Sprintf("var g2Cofactor, _ = new(big.Int).SetString(\"%s\", 10)\n\n", c.h2.String())
+	cx, cy := c.twistFrobenius()
+	code += fmt.Sprintf("var g2FrobeniusX = &%s\n\n", encodeExt2Mont(limbSize, cx, c.p))
+	code += fmt.Sprintf("var g2FrobeniusY = &%s\n\n", encodeExt2Mont(limbSize, cy, c.p))
+	code += "// fe12FrobeniusCoeffs[k-1][i] is xi^(i * (p^k - 1) / 6)\n"
+	code += "var fe12FrobeniusCoeffs = [3][6]fe2{\n"
+	for k := 1; k <= 3; k++ {
+		code += "{\n"
+		for _, a := range c.frobeniusCoeffs(k) {
+			code += encodeExt2Mont(limbSize, a, c.p) + ",\n"
+		}
+		code += "},\n"
+	}
+	code += "}\n\n"
+	if c.beta.Cmp(new(big.Int).Sub(c.p, big.NewInt(1))) == 0 {
+		code += fe2MulByBetaMinusOne
+	} else {
+		code += fmt.Sprintf("var fe2Beta = &%s\n\n", montLiteral(limbSize, c.beta, c.p))
+		code += fe2MulByBetaGeneric
+	}
+	code += pairingTower
+	code += pairingG2
+	if c.dType {
+		code += pairingLineDType
+	} else {
+		code += pairingLineMType
+	}
+	if c.bn {
+		code += strings.Replace(pairingMillerLoop, pairingMillerLoopTailMarker, pairingMillerLoopTailBN, 1)
+		code += pairingFinalExpBN
+	} else {
+		code += strings.Replace(pairingMillerLoop, pairingMillerLoopTailMarker, "", 1)
+		code += pairingFinalExpBLS12
+	}
+	return code
+}
+
+const pairingImports = `
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+`
+
+const fe2MulByBetaMinusOne = `
+// fe2MulByBeta sets c to beta * a where u^2 = beta = -1
+func fe2MulByBeta(c, a *fieldElement) {
+	neg(c, a)
+}
+`
+
+const fe2MulByBetaGeneric = `
+// fe2MulByBeta sets c to beta * a where u^2 = beta
+func fe2MulByBeta(c, a *fieldElement) {
+	mul(c, a, fe2Beta)
+}
+`
+
+const pairingTower = `
+// fe2 is an element a0 + a1 * u of Fp2 = Fp[u] / (u^2 - beta)
+type fe2 [2]fieldElement
+
+// fe6 is an element a0 + a1 * v + a2 * v^2 of Fp6 = Fp2[v] / (v^3 - xi)
+type fe6 [3]fe2
+
+// fe12 is an element a0 + a1 * w of Fp12 = Fp6[w] / (w^2 - v)
+type fe12 [2]fe6
+
+func (e *fe2) set(a *fe2) *fe2 {
+	e[0].set(&a[0])
+	e[1].set(&a[1])
+	return e
+}
+
+func (e *fe2) zero() *fe2 {
+	e[0].set(zero)
+	e[1].set(zero)
+	return e
+}
+
+func (e *fe2) one() *fe2 {
+	e[0].set(one)
+	e[1].set(zero)
+	return e
+}
+
+func (e *fe2) isZero() bool {
+	return isZero(&e[0]) && isZero(&e[1])
+}
+
+func (e *fe2) isOne() bool {
+	return isOne(&e[0]) && isZero(&e[1])
+}
+
+func (e *fe2) equal(a *fe2) bool {
+	return e[0].equal(&a[0]) && e[1].equal(&a[1])
+}
+
+func fe2Add(c, a, b *fe2) {
+	add(&c[0], &a[0], &b[0])
+	add(&c[1], &a[1], &b[1])
+}
+
+func fe2Double(c, a *fe2) {
+	double(&c[0], &a[0])
+	double(&c[1], &a[1])
+}
+
+func fe2Sub(c, a, b *fe2) {
+	sub(&c[0], &a[0], &b[0])
+	sub(&c[1], &a[1], &b[1])
+}
+
+func fe2Neg(c, a *fe2) {
+	neg(&c[0], &a[0])
+	neg(&c[1], &a[1])
+}
+
+// fe2Conjugate sets c to a0 - a1 * u which is also a^p
+func fe2Conjugate(c, a *fe2) {
+	c[0].set(&a[0])
+	neg(&c[1], &a[1])
+}
+
+func fe2Mul(c, a, b *fe2) {
+	t0, t1, t2, t3 := newFieldElement(), newFieldElement(), newFieldElement(), newFieldElement()
+	mul(t0, &a[0], &b[0])
+	mul(t1, &a[1], &b[1])
+	add(t2, &a[0], &a[1])
+	add(t3, &b[0], &b[1])
+	// c1 = (a0 + a1) * (b0 + b1) - a0 * b0 - a1 * b1
+	mul(t2, t2, t3)
+	sub(t2, t2, t0)
+	sub(&c[1], t2, t1)
+	// c0 = a0 * b0 + beta * a1 * b1
+	fe2MulByBeta(t1, t1)
+	add(&c[0], t0, t1)
+}
+
+func fe2Square(c, a *fe2) {
+	t0, t1, t2 := newFieldElement(), newFieldElement(), newFieldElement()
+	mul(t0, &a[0], &a[1])
+	mul(t1, &a[1], &a[1])
+	mul(t2, &a[0], &a[0])
+	fe2MulByBeta(t1, t1)
+	add(&c[0], t2, t1)
+	double(&c[1], t0)
+}
+
+// fe2MulByFp sets c to b * a for b in base field
+func fe2MulByFp(c, a *fe2, b *fieldElement) {
+	mul(&c[0], &a[0], b)
+	mul(&c[1], &a[1], b)
+}
+
+// fe2MulByXi sets c to xi * a
+func fe2MulByXi(c, a *fe2) {
+	fe2Mul(c, a, fe2Xi)
+}
+
+func fe2Inverse(c, a *fe2) {
+	t0, t1 := newFieldElement(), newFieldElement()
+	// 1 / (a0^2 - beta * a1^2)
+	mul(t0, &a[0], &a[0])
+	mul(t1, &a[1], &a[1])
+	fe2MulByBeta(t1, t1)
+	sub(t0, t0, t1)
+	inverse(t0, t0)
+	mul(&c[0], &a[0], t0)
+	mul(t0, &a[1], t0)
+	neg(&c[1], t0)
+}
+
+func (e *fe6) set(a *fe6) *fe6 {
+	e[0].set(&a[0])
+	e[1].set(&a[1])
+	e[2].set(&a[2])
+	return e
+}
+
+func (e *fe6) zero() *fe6 {
+	e[0].zero()
+	e[1].zero()
+	e[2].zero()
+	return e
+}
+
+func (e *fe6) one() *fe6 {
+	e[0].one()
+	e[1].zero()
+	e[2].zero()
+	return e
+}
+
+func (e *fe6) isZero() bool {
+	return e[0].isZero() && e[1].isZero() && e[2].isZero()
+}
+
+func (e *fe6) isOne() bool {
+	return e[0].isOne() && e[1].isZero() && e[2].isZero()
+}
+
+func (e *fe6) equal(a *fe6) bool {
+	return e[0].equal(&a[0]) && e[1].equal(&a[1]) && e[2].equal(&a[2])
+}
+
+func fe6Add(c, a, b *fe6) {
+	fe2Add(&c[0], &a[0], &b[0])
+	fe2Add(&c[1], &a[1], &b[1])
+	fe2Add(&c[2], &a[2], &b[2])
+}
+
+func fe6Double(c, a *fe6) {
+	fe2Double(&c[0], &a[0])
+	fe2Double(&c[1], &a[1])
+	fe2Double(&c[2], &a[2])
+}
+
+func fe6Sub(c, a, b *fe6) {
+	fe2Sub(&c[0], &a[0], &b[0])
+	fe2Sub(&c[1], &a[1], &b[1])
+	fe2Sub(&c[2], &a[2], &b[2])
+}
+
+func fe6Neg(c, a *fe6) {
+	fe2Neg(&c[0], &a[0])
+	fe2Neg(&c[1], &a[1])
+	fe2Neg(&c[2], &a[2])
+}
+
+func fe6Mul(c, a, b *fe6) {
+	t0, t1, t2, t3, t4, t5, t6 := new(fe2), new(fe2), new(fe2), new(fe2), new(fe2), new(fe2), new(fe2)
+	fe2Mul(t0, &a[0], &b[0])
+	fe2Mul(t1, &a[1], &b[1])
+	fe2Mul(t2, &a[2], &b[2])
+	// c0 = ((a1 + a2) * (b1 + b2) - t1 - t2) * xi + t0
+	fe2Add(t3, &a[1], &a[2])
+	fe2Add(t4, &b[1], &b[2])
+	fe2Mul(t3, t3, t4)
+	fe2Sub(t3, t3, t1)
+	fe2Sub(t3, t3, t2)
+	fe2MulByXi(t3, t3)
+	fe2Add(t3, t3, t0)
+	// c1 = (a0 + a1) * (b0 + b1) - t0 - t1 + xi * t2
+	fe2Add(t4, &a[0], &a[1])
+	fe2Add(t5, &b[0], &b[1])
+	fe2Mul(t4, t4, t5)
+	fe2Sub(t4, t4, t0)
+	fe2Sub(t4, t4, t1)
+	fe2MulByXi(t5, t2)
+	fe2Add(t4, t4, t5)
+	// c2 = (a0 + a2) * (b0 + b2) - t0 - t2 + t1
+	fe2Add(t5, &a[0], &a[2])
+	fe2Add(t6, &b[0], &b[2])
+	fe2Mul(t5, t5, t6)
+	fe2Sub(t5, t5, t0)
+	fe2Sub(t5, t5, t2)
+	fe2Add(t5, t5, t1)
+	c[0].set(t3)
+	c[1].set(t4)
+	c[2].set(t5)
+}
+
+func fe6Square(c, a *fe6) {
+	fe6Mul(c, a, a)
+}
+
+// fe6MulByNonResidue sets c to v * a
+func fe6MulByNonResidue(c, a *fe6) {
+	t := new(fe2)
+	fe2MulByXi(t, &a[2])
+	c[2].set(&a[1])
+	c[1].set(&a[0])
+	c[0].set(t)
+}
+
+// fe6MulByFe2 sets c to b * a for b in Fp2
+func fe6MulByFe2(c, a *fe6, b *fe2) {
+	fe2Mul(&c[0], &a[0], b)
+	fe2Mul(&c[1], &a[1], b)
+	fe2Mul(&c[2], &a[2], b)
+}
+
+// fe6MulBy01 sets c to a * (b0 + b1 * v)
+func fe6MulBy01(c, a *fe6, b0, b1 *fe2) {
+	t0, t1, t2, t3, t4 := new(fe2), new(fe2), new(fe2), new(fe2), new(fe2)
+	fe2Mul(t0, &a[0], b0)
+	fe2Mul(t1, &a[1], b1)
+	// c0 = a0 * b0 + xi * a2 * b1
+	fe2Mul(t2, &a[2], b1)
+	fe2MulByXi(t2, t2)
+	fe2Add(t2, t2, t0)
+	// c1 = (a0 + a1) * (b0 + b1) - a0 * b0 - a1 * b1
+	fe2Add(t3, &a[0], &a[1])
+	fe2Add(t4, b0, b1)
+	fe2Mul(t3, t3, t4)
+	fe2Sub(t3, t3, t0)
+	fe2Sub(t3, t3, t1)
+	// c2 = a1 * b1 + a2 * b0
+	fe2Mul(t0, &a[2], b0)
+	fe2Add(&c[2], t0, t1)
+	c[0].set(t2)
+	c[1].set(t3)
+}
+
+// fe6MulBy1 sets c to a * b1 * v
+func fe6MulBy1(c, a *fe6, b1 *fe2) {
+	t := new(fe2)
+	fe2Mul(t, &a[2], b1)
+	fe2MulByXi(t, t)
+	fe2Mul(&c[2], &a[1], b1)
+	fe2Mul(&c[1], &a[0], b1)
+	c[0].set(t)
+}
+
+func fe6Inverse(c, a *fe6) {
+	t0, t1, t2, t3, t4 := new(fe2), new(fe2), new(fe2), new(fe2), new(fe2)
+	// t0 = a0^2 - xi * a1 * a2
+	fe2Square(t0, &a[0])
+	fe2Mul(t3, &a[1], &a[2])
+	fe2MulByXi(t3, t3)
+	fe2Sub(t0, t0, t3)
+	// t1 = xi * a2^2 - a0 * a1
+	fe2Square(t1, &a[2])
+	fe2MulByXi(t1, t1)
+	fe2Mul(t3, &a[0], &a[1])
+	fe2Sub(t1, t1, t3)
+	// t2 = a1^2 - a0 * a2
+	fe2Square(t2, &a[1])
+	fe2Mul(t3, &a[0], &a[2])
+	fe2Sub(t2, t2, t3)
+	// 1 / (a0 * t0 + xi * (a2 * t1 + a1 * t2))
+	fe2Mul(t3, &a[2], t1)
+	fe2Mul(t4, &a[1], t2)
+	fe2Add(t3, t3, t4)
+	fe2MulByXi(t3, t3)
+	fe2Mul(t4, &a[0], t0)
+	fe2Add(t3, t3, t4)
+	fe2Inverse(t3, t3)
+	fe2Mul(&c[0], t0, t3)
+	fe2Mul(&c[1], t1, t3)
+	fe2Mul(&c[2], t2, t3)
+}
+
+func (e *fe12) set(a *fe12) *fe12 {
+	e[0].set(&a[0])
+	e[1].set(&a[1])
+	return e
+}
+
+func (e *fe12) one() *fe12 {
+	e[0].one()
+	e[1].zero()
+	return e
+}
+
+func (e *fe12) isOne() bool {
+	return e[0].isOne() && e[1].isZero()
+}
+
+func (e *fe12) equal(a *fe12) bool {
+	return e[0].equal(&a[0]) && e[1].equal(&a[1])
+}
+
+func fe12Add(c, a, b *fe12) {
+	fe6Add(&c[0], &a[0], &b[0])
+	fe6Add(&c[1], &a[1], &b[1])
+}
+
+func fe12Sub(c, a, b *fe12) {
+	fe6Sub(&c[0], &a[0], &b[0])
+	fe6Sub(&c[1], &a[1], &b[1])
+}
+
+// fe12Conjugate sets c to a0 - a1 * w which is also a^(p^6)
+func fe12Conjugate(c, a *fe12) {
+	c[0].set(&a[0])
+	fe6Neg(&c[1], &a[1])
+}
+
+func fe12Mul(c, a, b *fe12) {
+	t0, t1, t2, t3 := new(fe6), new(fe6), new(fe6), new(fe6)
+	fe6Mul(t0, &a[0], &b[0])
+	fe6Mul(t1, &a[1], &b[1])
+	// c1 = (a0 + a1) * (b0 + b1) - t0 - t1
+	fe6Add(t2, &a[0], &a[1])
+	fe6Add(t3, &b[0], &b[1])
+	fe6Mul(t2, t2, t3)
+	fe6Sub(t2, t2, t0)
+	fe6Sub(&c[1], t2, t1)
+	// c0 = t0 + v * t1
+	fe6MulByNonResidue(t1, t1)
+	fe6Add(&c[0], t0, t1)
+}
+
+func fe12Square(c, a *fe12) {
+	t0, t1, t2 := new(fe6), new(fe6), new(fe6)
+	fe6Mul(t0, &a[0], &a[1])
+	// c0 = (a0 + a1) * (a0 + v * a1) - t0 - v * t0
+	fe6MulByNonResidue(t1, &a[1])
+	fe6Add(t1, t1, &a[0])
+	fe6Add(t2, &a[0], &a[1])
+	fe6Mul(t1, t1, t2)
+	fe6Sub(t1, t1, t0)
+	fe6MulByNonResidue(t2, t0)
+	fe6Sub(&c[0], t1, t2)
+	// c1 = 2 * a0 * a1
+	fe6Double(&c[1], t0)
+}
+
+func fe12Inverse(c, a *fe12) {
+	t0, t1 := new(fe6), new(fe6)
+	// 1 / (a0^2 - v * a1^2)
+	fe6Square(t0, &a[0])
+	fe6Square(t1, &a[1])
+	fe6MulByNonResidue(t1, t1)
+	fe6Sub(t0, t0, t1)
+	fe6Inverse(t0, t0)
+	fe6Mul(&c[0], &a[0], t0)
+	fe6Mul(t0, &a[1], t0)
+	fe6Neg(&c[1], t0)
+}
+
+// fe12Exp sets c to a^e, a should be non zero if e is negative
+func fe12Exp(c, a *fe12, e *big.Int) {
+	k := new(big.Int).Abs(e)
+	z := new(fe12).one()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		fe12Square(z, z)
+		if k.Bit(i) == 1 {
+			fe12Mul(z, z, a)
+		}
+	}
+	if e.Sign() < 0 {
+		fe12Inverse(z, z)
+	}
+	c.set(z)
+}
+
+// fe12MulBy034 sets c to a * (d0 + d3 * w + d4 * w^3) which is the sparse form
+// of line evaluations over D type twist
+func fe12MulBy034(c, a *fe12, d0, d3, d4 *fe2) {
+	t0, t1, t2 := new(fe6), new(fe6), new(fe6)
+	d := new(fe2)
+	fe6MulByFe2(t0, &a[0], d0)
+	fe6MulBy01(t1, &a[1], d3, d4)
+	// c1 = (a0 + a1) * (d0 + d3 + d4 * v) - t0 - t1
+	fe6Add(t2, &a[0], &a[1])
+	fe2Add(d, d0, d3)
+	fe6MulBy01(t2, t2, d, d4)
+	fe6Sub(t2, t2, t0)
+	fe6Sub(&c[1], t2, t1)
+	// c0 = t0 + v * t1
+	fe6MulByNonResidue(t1, t1)
+	fe6Add(&c[0], t0, t1)
+}
+
+// fe12MulBy014 sets c to a * (d0 + d1 * w^2 + d4 * w^3) which is the sparse
+// form of line evaluations over M type twist
+func fe12MulBy014(c, a *fe12, d0, d1, d4 *fe2) {
+	t0, t1, t2 := new(fe6), new(fe6), new(fe6)
+	d := new(fe2)
+	fe6MulBy01(t0, &a[0], d0, d1)
+	fe6MulBy1(t1, &a[1], d4)
+	// c1 = (a0 + a1) * (d0 + (d1 + d4) * v) - t0 - t1
+	fe6Add(t2, &a[0], &a[1])
+	fe2Add(d, d1, d4)
+	fe6MulBy01(t2, t2, d0, d)
+	fe6Sub(t2, t2, t0)
+	fe6Sub(&c[1], t2, t1)
+	// c0 = t0 + v * t1
+	fe6MulByNonResidue(t1, t1)
+	fe6Add(&c[0], t0, t1)
+}
+
+// fe12Frobenius sets c to a^(p^power) for power 1, 2 or 3. Coefficient of w^i
+// is raised to p^power and multiplied with xi^(i * (p^power - 1) / 6).
+func fe12Frobenius(c, a *fe12, power int) {
+	if power < 1 || power > 3 {
+		panic(fmt.Sprintf("bad frobenius power %d", power))
+	}
+	for i := 0; i < 6; i++ {
+		r, s := &c[i%2][i/2], &a[i%2][i/2]
+		if power%2 == 1 {
+			fe2Conjugate(r, s)
+		} else {
+			r.set(s)
+		}
+		fe2Mul(r, r, &fe12FrobeniusCoeffs[power-1][i])
+	}
+}
+
+// fe12CyclotomicSquare sets c to a^2 for a in cyclotomic subgroup.
+// Granger, Scott 2010, section 3.2
+func fe12CyclotomicSquare(c, a *fe12) {
+	t := [9]fe2{}
+	fe2Square(&t[0], &a[1][1])
+	fe2Square(&t[1], &a[0][0])
+	// t6 = 2 * a00 * a11
+	fe2Add(&t[6], &a[1][1], &a[0][0])
+	fe2Square(&t[6], &t[6])
+	fe2Sub(&t[6], &t[6], &t[0])
+	fe2Sub(&t[6], &t[6], &t[1])
+	fe2Square(&t[2], &a[0][2])
+	fe2Square(&t[3], &a[1][0])
+	// t7 = 2 * a02 * a10
+	fe2Add(&t[7], &a[0][2], &a[1][0])
+	fe2Square(&t[7], &t[7])
+	fe2Sub(&t[7], &t[7], &t[2])
+	fe2Sub(&t[7], &t[7], &t[3])
+	fe2Square(&t[4], &a[1][2])
+	fe2Square(&t[5], &a[0][1])
+	// t8 = 2 * xi * a01 * a12
+	fe2Add(&t[8], &a[1][2], &a[0][1])
+	fe2Square(&t[8], &t[8])
+	fe2Sub(&t[8], &t[8], &t[4])
+	fe2Sub(&t[8], &t[8], &t[5])
+	fe2MulByXi(&t[8], &t[8])
+	// a11^2 * xi + a00^2, a02^2 * xi + a10^2, a12^2 * xi + a01^2
+	fe2MulByXi(&t[0], &t[0])
+	fe2Add(&t[0], &t[0], &t[1])
+	fe2MulByXi(&t[2], &t[2])
+	fe2Add(&t[2], &t[2], &t[3])
+	fe2MulByXi(&t[4], &t[4])
+	fe2Add(&t[4], &t[4], &t[5])
+	for i, s := range []*fe2{&t[0], &t[2], &t[4]} {
+		// 3 * s - 2 * a0i
+		r := &c[0][i]
+		fe2Sub(r, s, &a[0][i])
+		fe2Double(r, r)
+		fe2Add(r, r, s)
+	}
+	for i, s := range []*fe2{&t[8], &t[6], &t[7]} {
+		// 3 * s + 2 * a1i
+		r := &c[1][i]
+		fe2Add(r, s, &a[1][i])
+		fe2Double(r, r)
+		fe2Add(r, r, s)
+	}
+}
+
+// fe12CyclotomicExp sets c to a^e for a in cyclotomic subgroup where inverse
+// is conjugation
+func fe12CyclotomicExp(c, a *fe12, e *big.Int) {
+	k := new(big.Int).Abs(e)
+	z := new(fe12).one()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		fe12CyclotomicSquare(z, z)
+		if k.Bit(i) == 1 {
+			fe12Mul(z, z, a)
+		}
+	}
+	if e.Sign() < 0 {
+		fe12Conjugate(z, z)
+	}
+	c.set(z)
+}
+
+// expByX sets c to a^x for a in cyclotomic subgroup
+func expByX(c, a *fe12) {
+	fe12CyclotomicExp(c, a, pairingX)
+}
+`
+
+const pairingG2 = `
+// g2Point is a point of sextic twist y^2 = x^3 + b' over Fp2 in homogeneous
+// projective coordinates (X : Y : Z). Point at infinity is (0 : 1 : 0).
+type g2Point [3]fe2
+
+// newG2Point returns point at infinity
+func newG2Point() *g2Point {
+	return new(g2Point).zero()
+}
+
+// newG2PointFromAffine returns point (x, y) if it is on curve
+func newG2PointFromAffine(x, y *fe2) (*g2Point, error) {
+	p := &g2Point{}
+	p[0].set(x)
+	p[1].set(y)
+	p[2].one()
+	if !g2IsOnCurve(p) {
+		return nil, errors.New("point is not on curve")
+	}
+	return p, nil
+}
+
+// g2Generator returns a new copy of G2 generator
+func g2Generator() *g2Point {
+	p := &g2Point{}
+	p[0].set(g2Gx)
+	p[1].set(g2Gy)
+	p[2].one()
+	return p
+}
+
+func (p *g2Point) set(q *g2Point) *g2Point {
+	p[0].set(&q[0])
+	p[1].set(&q[1])
+	p[2].set(&q[2])
+	return p
+}
+
+func (p *g2Point) zero() *g2Point {
+	p[0].zero()
+	p[1].one()
+	p[2].zero()
+	return p
+}
+
+func (p *g2Point) isZero() bool {
+	return p[2].isZero()
+}
+
+func (p *g2Point) equal(q *g2Point) bool {
+	if p.isZero() || q.isZero() {
+		return p.isZero() && q.isZero()
+	}
+	t0, t1 := new(fe2), new(fe2)
+	fe2Mul(t0, &p[0], &q[2])
+	fe2Mul(t1, &q[0], &p[2])
+	if !t0.equal(t1) {
+		return false
+	}
+	fe2Mul(t0, &p[1], &q[2])
+	fe2Mul(t1, &q[1], &p[2])
+	return t0.equal(t1)
+}
+
+// g2Affine sets r to p with Z = 1 unless p is at infinity
+func g2Affine(r, p *g2Point) {
+	if p.isZero() {
+		r.zero()
+		return
+	}
+	zInv := new(fe2)
+	fe2Inverse(zInv, &p[2])
+	fe2Mul(&r[0], &p[0], zInv)
+	fe2Mul(&r[1], &p[1], zInv)
+	r[2].one()
+}
+
+// g2IsOnCurve checks Y^2 * Z = X^3 + b' * Z^3
+func g2IsOnCurve(p *g2Point) bool {
+	if p.isZero() {
+		return true
+	}
+	lhs, rhs, t := new(fe2), new(fe2), new(fe2)
+	fe2Square(lhs, &p[1])
+	fe2Mul(lhs, lhs, &p[2])
+	fe2Square(t, &p[2])
+	fe2Mul(t, t, &p[2])
+	fe2Mul(rhs, t, g2B)
+	fe2Square(t, &p[0])
+	fe2Mul(t, t, &p[0])
+	fe2Add(rhs, rhs, t)
+	return lhs.equal(rhs)
+}
+
+// g2IsInSubgroup checks if p is on curve and r * p is at infinity
+func g2IsInSubgroup(p *g2Point) bool {
+	if !g2IsOnCurve(p) {
+		return false
+	}
+	t := newG2Point()
+	g2MulScalar(t, p, curveOrder)
+	return t.isZero()
+}
+
+func g2Neg(r, p *g2Point) {
+	r[0].set(&p[0])
+	fe2Neg(&r[1], &p[1])
+	r[2].set(&p[2])
+}
+
+func g2Sub(r, p, q *g2Point) {
+	t := newG2Point()
+	g2Neg(t, q)
+	g2Add(r, p, t)
+}
+
+// g2Add sets r to p + q with complete formulas for a = 0.
+// Renes, Costello, Batina 2015, algorithm 7
+func g2Add(r, p, q *g2Point) {
+	t0, t1, t2, t3, t4 := new(fe2), new(fe2), new(fe2), new(fe2), new(fe2)
+	X3, Y3, Z3 := new(fe2), new(fe2), new(fe2)
+	X1, Y1, Z1 := &p[0], &p[1], &p[2]
+	X2, Y2, Z2 := &q[0], &q[1], &q[2]
+	fe2Mul(t0, X1, X2)
+	fe2Mul(t1, Y1, Y2)
+	fe2Mul(t2, Z1, Z2)
+	fe2Add(t3, X1, Y1)
+	fe2Add(t4, X2, Y2)
+	fe2Mul(t3, t3, t4)
+	fe2Add(t4, t0, t1)
+	fe2Sub(t3, t3, t4)
+	fe2Add(t4, Y1, Z1)
+	fe2Add(X3, Y2, Z2)
+	fe2Mul(t4, t4, X3)
+	fe2Add(X3, t1, t2)
+	fe2Sub(t4, t4, X3)
+	fe2Add(X3, X1, Z1)
+	fe2Add(Y3, X2, Z2)
+	fe2Mul(X3, X3, Y3)
+	fe2Add(Y3, t0, t2)
+	fe2Sub(Y3, X3, Y3)
+	fe2Double(X3, t0)
+	fe2Add(t0, X3, t0)
+	fe2Mul(t2, g2B3, t2)
+	fe2Add(Z3, t1, t2)
+	fe2Sub(t1, t1, t2)
+	fe2Mul(Y3, g2B3, Y3)
+	fe2Mul(X3, t4, Y3)
+	fe2Mul(t2, t3, t1)
+	fe2Sub(X3, t2, X3)
+	fe2Mul(Y3, Y3, t0)
+	fe2Mul(t1, t1, Z3)
+	fe2Add(Y3, t1, Y3)
+	fe2Mul(t0, t0, t3)
+	fe2Mul(Z3, Z3, t4)
+	fe2Add(Z3, Z3, t0)
+	r[0].set(X3)
+	r[1].set(Y3)
+	r[2].set(Z3)
+}
+
+// g2Double sets r to 2 * p for a = 0.
+// Renes, Costello, Batina 2015, algorithm 9
+func g2Double(r, p *g2Point) {
+	t0, t1, t2 := new(fe2), new(fe2), new(fe2)
+	X3, Y3, Z3 := new(fe2), new(fe2), new(fe2)
+	X, Y, Z := &p[0], &p[1], &p[2]
+	fe2Square(t0, Y)
+	fe2Double(Z3, t0)
+	fe2Double(Z3, Z3)
+	fe2Double(Z3, Z3)
+	fe2Mul(t1, Y, Z)
+	fe2Square(t2, Z)
+	fe2Mul(t2, g2B3, t2)
+	fe2Mul(X3, t2, Z3)
+	fe2Add(Y3, t0, t2)
+	fe2Mul(Z3, t1, Z3)
+	fe2Double(t1, t2)
+	fe2Add(t2, t1, t2)
+	fe2Sub(t0, t0, t2)
+	fe2Mul(Y3, t0, Y3)
+	fe2Add(Y3, X3, Y3)
+	fe2Mul(t1, X, Y)
+	fe2Mul(X3, t0, t1)
+	fe2Double(X3, X3)
+	r[0].set(X3)
+	r[1].set(Y3)
+	r[2].set(Z3)
+}
+
+// g2MulScalar sets r to e * p with double and add, it is not constant time
+func g2MulScalar(r, p *g2Point, e *big.Int) {
+	q, k := newG2Point(), new(big.Int).Abs(e)
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		g2Double(q, q)
+		if k.Bit(i) == 1 {
+			g2Add(q, q, p)
+		}
+	}
+	if e.Sign() < 0 {
+		g2Neg(q, q)
+	}
+	r.set(q)
+}
+
+// g2ClearCofactor sets r to h2 * p
+func g2ClearCofactor(r, p *g2Point) {
+	g2MulScalar(r, p, g2Cofactor)
+}
+
+// g2Frobenius sets r to untwist-frobenius-twist endomorphism of p. It acts as
+// multiplication by p on G2.
+func g2Frobenius(r, p *g2Point) {
+	fe2Conjugate(&r[0], &p[0])
+	fe2Conjugate(&r[1], &p[1])
+	fe2Conjugate(&r[2], &p[2])
+	fe2Mul(&r[0], &r[0], g2FrobeniusX)
+	fe2Mul(&r[1], &r[1], g2FrobeniusY)
+}
+`
+
+const pairingLineDType = `
+// mulByLine sets f to f * l where line coefficients are evaluated at affine p.
+// Over D type twist line is l0 * y_p + l1 * x_p * w + l2 * w^3.
+func mulByLine(f *fe12, l *[3]fe2, p *point) {
+	d0, d1 := new(fe2), new(fe2)
+	fe2MulByFp(d0, &l[0], &p[1])
+	fe2MulByFp(d1, &l[1], &p[0])
+	fe12MulBy034(f, f, d0, d1, &l[2])
+}
+`
+
+const pairingLineMType = `
+// mulByLine sets f to f * l where line coefficients are evaluated at affine p.
+// Over M type twist line is scaled by w^3 to l2 + l1 * x_p * w^2 + l0 * y_p * w^3.
+func mulByLine(f *fe12, l *[3]fe2, p *point) {
+	d0, d1 := new(fe2), new(fe2)
+	fe2MulByFp(d0, &l[0], &p[1])
+	fe2MulByFp(d1, &l[1], &p[0])
+	fe12MulBy014(f, f, &l[2], d1, d0)
+}
+`
+
+const pairingMillerLoopTailMarker = "\t// miller loop tail\n"
+
+const pairingMillerLoop = `
+// lineDouble sets t to 2 * t and l to coefficients of tangent line at t.
+// Resulting point is scaled by 4 to avoid halving.
+// Costello, Lange, Naehrig 2010, section 5
+func lineDouble(t *g2Point, l *[3]fe2) {
+	A, B, C, E, F, G, H, J := new(fe2), new(fe2), new(fe2), new(fe2), new(fe2), new(fe2), new(fe2), new(fe2)
+	X, Y, Z := &t[0], &t[1], &t[2]
+	fe2Mul(A, X, Y)
+	fe2Square(B, Y)
+	fe2Square(C, Z)
+	fe2Mul(E, C, g2B3)
+	fe2Double(F, E)
+	fe2Add(F, F, E)
+	fe2Add(G, B, F)
+	// H = (Y + Z)^2 - B - C = 2 * Y * Z
+	fe2Add(H, Y, Z)
+	fe2Square(H, H)
+	fe2Sub(H, H, B)
+	fe2Sub(H, H, C)
+	fe2Square(J, X)
+	// l = (-H, 3 * J, E - B)
+	fe2Neg(&l[0], H)
+	fe2Double(&l[1], J)
+	fe2Add(&l[1], &l[1], J)
+	fe2Sub(&l[2], E, B)
+	// X3 = 2 * A * (B - F)
+	fe2Sub(X, B, F)
+	fe2Mul(X, X, A)
+	fe2Double(X, X)
+	// Y3 = G^2 - 12 * E^2
+	fe2Square(E, E)
+	fe2Double(C, E)
+	fe2Add(E, C, E)
+	fe2Double(E, E)
+	fe2Double(E, E)
+	fe2Square(Y, G)
+	fe2Sub(Y, Y, E)
+	// Z3 = 4 * B * H
+	fe2Mul(Z, B, H)
+	fe2Double(Z, Z)
+	fe2Double(Z, Z)
+}
+
+// lineAdd sets t to t + q for affine q and l to coefficients of line through
+// t and q. t and q should not be equal or opposite.
+func lineAdd(t, q *g2Point, l *[3]fe2) {
+	O, L, C, D, E, F, G, H := new(fe2), new(fe2), new(fe2), new(fe2), new(fe2), new(fe2), new(fe2), new(fe2)
+	X, Y, Z := &t[0], &t[1], &t[2]
+	// O = Y - y_q * Z, L = X - x_q * Z
+	fe2Mul(O, &q[1], Z)
+	fe2Sub(O, Y, O)
+	fe2Mul(L, &q[0], Z)
+	fe2Sub(L, X, L)
+	fe2Square(C, O)
+	fe2Square(D, L)
+	fe2Mul(E, L, D)
+	fe2Mul(F, Z, C)
+	fe2Mul(G, X, D)
+	// H = E + F - 2 * G
+	fe2Add(H, E, F)
+	fe2Sub(H, H, G)
+	fe2Sub(H, H, G)
+	// l = (L, -O, O * x_q - L * y_q)
+	l[0].set(L)
+	fe2Neg(&l[1], O)
+	fe2Mul(&l[2], O, &q[0])
+	fe2Mul(C, L, &q[1])
+	fe2Sub(&l[2], &l[2], C)
+	// X3 = L * H, Y3 = O * (G - H) - Y * E, Z3 = Z * E
+	fe2Mul(X, L, H)
+	fe2Sub(G, G, H)
+	fe2Mul(G, G, O)
+	fe2Mul(Y, Y, E)
+	fe2Sub(Y, G, Y)
+	fe2Mul(Z, Z, E)
+}
+
+// millerLoop returns product of miller loop values f_{s, q_i}(p_i) with
+// lines of all pairs accumulated in a single loop. Pairs with a point at
+// infinity are skipped.
+func millerLoop(ps []*point, qs []*g2Point) (*fe12, error) {
+	if len(ps) != len(qs) {
+		return nil, fmt.Errorf("bad input size %d, expected %d", len(qs), len(ps))
+	}
+	var pa []*point
+	var qa, qn, ts []*g2Point
+	for i := range ps {
+		if ps[i].isZero() || qs[i].isZero() {
+			continue
+		}
+		p, q, n := newPoint(), newG2Point(), newG2Point()
+		pointAffine(p, ps[i])
+		g2Affine(q, qs[i])
+		g2Neg(n, q)
+		pa, qa, qn = append(pa, p), append(qa, q), append(qn, n)
+		ts = append(ts, newG2Point().set(q))
+	}
+	f := new(fe12).one()
+	l := new([3]fe2)
+	for i := len(ateLoop) - 2; i >= 0; i-- {
+		fe12Square(f, f)
+		for j := range pa {
+			lineDouble(ts[j], l)
+			mulByLine(f, l, pa[j])
+		}
+		switch ateLoop[i] {
+		case 1:
+			for j := range pa {
+				lineAdd(ts[j], qa[j], l)
+				mulByLine(f, l, pa[j])
+			}
+		case -1:
+			for j := range pa {
+				lineAdd(ts[j], qn[j], l)
+				mulByLine(f, l, pa[j])
+			}
+		}
+	}
+	if ateLoopNegative {
+		fe12Conjugate(f, f)
+	}
+	// miller loop tail
+	return f, nil
+}
+
+// finalExponentiationEasy sets c to f^((p^6 - 1) * (p^2 + 1)) which is in
+// cyclotomic subgroup
+func finalExponentiationEasy(c, f *fe12) {
+	t0, t1 := new(fe12), new(fe12)
+	fe12Conjugate(t0, f)
+	fe12Inverse(t1, f)
+	fe12Mul(t0, t0, t1)
+	fe12Frobenius(t1, t0, 2)
+	fe12Mul(c, t0, t1)
+}
+
+// pairing returns optimal ate pairing of p and q
+func pairing(p *point, q *g2Point) *fe12 {
+	f, _ := millerLoop([]*point{p}, []*g2Point{q})
+	return finalExponentiation(f)
+}
+
+// pairingCheck returns true if product of pairings of p_i and q_i is one
+func pairingCheck(ps []*point, qs []*g2Point) (bool, error) {
+	f, err := millerLoop(ps, qs)
+	if err != nil {
+		return false, err
+	}
+	return finalExponentiation(f).isOne(), nil
+}
+`
+
+const pairingMillerLoopTailBN = `	// lines through t = [6x + 2] * q, pi(q) and -pi^2(q)
+	q1, q2 := newG2Point(), newG2Point()
+	for j := range pa {
+		if ateLoopNegative {
+			g2Neg(ts[j], ts[j])
+		}
+		g2Frobenius(q1, qa[j])
+		g2Frobenius(q2, q1)
+		g2Neg(q2, q2)
+		lineAdd(ts[j], q1, l)
+		mulByLine(f, l, pa[j])
+		lineAdd(ts[j], q2, l)
+		mulByLine(f, l, pa[j])
+	}
+`
+
+const pairingFinalExpBN = `
+// finalExponentiation returns f^((p^12 - 1) / r). Hard part follows
+// Scott, Benger, Charlemagne, Perez, Kachisa 2009, section 4.
+func finalExponentiation(f *fe12) *fe12 {
+	m := new(fe12)
+	finalExponentiationEasy(m, f)
+	fp, fp2, fp3 := new(fe12), new(fe12), new(fe12)
+	fe12Frobenius(fp, m, 1)
+	fe12Frobenius(fp2, m, 2)
+	fe12Frobenius(fp3, m, 3)
+	fu, fu2, fu3 := new(fe12), new(fe12), new(fe12)
+	expByX(fu, m)
+	expByX(fu2, fu)
+	expByX(fu3, fu2)
+	y0, y1, y2, y3, y4, y5, y6 := new(fe12), new(fe12), new(fe12), new(fe12), new(fe12), new(fe12), new(fe12)
+	// y0 = m^(p + p^2 + p^3)
+	fe12Mul(y0, fp, fp2)
+	fe12Mul(y0, y0, fp3)
+	fe12Conjugate(y1, m)
+	fe12Frobenius(y2, fu2, 2)
+	fe12Frobenius(y3, fu, 1)
+	fe12Conjugate(y3, y3)
+	fe12Frobenius(y4, fu2, 1)
+	fe12Mul(y4, y4, fu)
+	fe12Conjugate(y4, y4)
+	fe12Conjugate(y5, fu2)
+	fe12Frobenius(y6, fu3, 1)
+	fe12Mul(y6, y6, fu3)
+	fe12Conjugate(y6, y6)
+	t0, t1 := new(fe12), new(fe12)
+	fe12CyclotomicSquare(t0, y6)
+	fe12Mul(t0, t0, y4)
+	fe12Mul(t0, t0, y5)
+	fe12Mul(t1, y3, y5)
+	fe12Mul(t1, t1, t0)
+	fe12Mul(t0, t0, y2)
+	fe12CyclotomicSquare(t1, t1)
+	fe12Mul(t1, t1, t0)
+	fe12CyclotomicSquare(t1, t1)
+	fe12Mul(t0, t1, y1)
+	fe12Mul(t1, t1, y0)
+	fe12CyclotomicSquare(t0, t0)
+	fe12Mul(t0, t0, t1)
+	return t0
+}
+`
+
+const pairingFinalExpBLS12 = `
+// finalExponentiation returns f^(3 * (p^12 - 1) / r) where hard part uses
+// 3 * (p^4 - p^2 + 1) / r = (x - 1)^2 * (x + p) * (x^2 + p^2 - 1) + 3.
+// Hayashida, Hayasaka, Teruya 2020
+func finalExponentiation(f *fe12) *fe12 {
+	m := new(fe12)
+	finalExponentiationEasy(m, f)
+	t0, t1, t2 := new(fe12), new(fe12), new(fe12)
+	// t0 = m^((x - 1)^2)
+	expByX(t0, m)
+	fe12Conjugate(t1, m)
+	fe12Mul(t0, t0, t1)
+	expByX(t1, t0)
+	fe12Conjugate(t0, t0)
+	fe12Mul(t0, t0, t1)
+	// t0 = t0^(x + p)
+	expByX(t1, t0)
+	fe12Frobenius(t2, t0, 1)
+	fe12Mul(t0, t1, t2)
+	// t0 = t0^(x^2 + p^2 - 1)
+	expByX(t1, t0)
+	expByX(t1, t1)
+	fe12Frobenius(t2, t0, 2)
+	fe12Mul(t1, t1, t2)
+	fe12Conjugate(t0, t0)
+	fe12Mul(t0, t0, t1)
+	// m^3
+	fe12CyclotomicSquare(t1, m)
+	fe12Mul(t1, t1, m)
+	fe12Mul(t0, t0, t1)
+	return t0
+}
+`
+
+// pairingTest returns tests of tower, G2 and pairing where known answer is
+// the reference pairing of generators. Curves with an entry in pairingVectors
+// are also checked against an external known answer.
+func pairingTest(c *pairingParams, kat [6]ext2) string {
+	code := pairingTestImports
+	code += "// pairingKAT is pairing of generators computed at generation time with an\n"
+	code += "// affine miller loop over Fp12 as polynomials in w and plain final\n"
+	code += "// exponentiation. Coefficients are given in tower order.\n"
+	code += "var pairingKAT = [12]string{\n"
+	for k := 0; k < 2; k++ {
+		for j := 0; j < 3; j++ {
+			a := kat[2*j+k]
+			code += fmt.Sprintf("\"0x%s\",\n\"0x%s\",\n", a[0].Text(16), a[1].Text(16))
+		}
+	}
+	code += "}\n\n"
+	if c.bn {
+		code += pairingTestParamsBN
+	} else {
+		code += pairingTestParamsBLS12
+	}
+	code += pairingTestBody
+	if v, ok := pairingVectors[c.id()]; ok {
+		code += pairingVectorTest(c, v)
+	}
+	return code
+}
+
+// id returns family and curve parameter as given to GenPairing with x in
+// decimal.
+func (c *pairingParams) id() string {
+	return fmt.Sprintf("%s,%s", map[bool]string{true: "bn", false: "bls12"}[c.bn], c.x.String())
+}
+
+// pairingVector is pairing of standard generators computed with an
+// independent implementation. Values are decimal, G2 and GT coefficients are
+// in tower order of generated code.
+type pairingVector struct {
+	source string
+	// convention explains exponent relation between source output and
+	// generated pairing
+	convention []string
+	// exponent k where source output is e(g1, g2)^k of generated pairing e
+	exponent string
+	g1       [2]string
+	g2       [4]string
+	gt       [12]string
+}
+
+// pairingVectors are external known answers keyed by pairingParams.id. Both
+// curves use the same Fp2/Fp6/Fp12 tower and twist that are derived by
+// newPairingParams.
+var pairingVectors = map[string]*pairingVector{
+	// BN254
+	"bn,4965661367192848881": {
+		source: "gnark-crypto v0.22.0 bn254.Pair(bn254.Generators())",
+		convention: []string{
+			"Generated BN pairing is f^((p^12 - 1) / r) of the optimal ate miller",
+			"function. gnark-crypto final exponentiation raises to s * (p^12 - 1) / r",
+			"where s = 2x(6x^2 + 3x + 1) so expected value is e(g1, g2)^s.",
+		},
+		exponent: "1469306990098747947464455738335385361638823152381947992820",
+		g1:       [2]string{"1", "2"},
+		g2: [4]string{
+			"10857046999023057135944570762232829481370756359578518086990519993285655852781",
+			"11559732032986387107991004021392285783925812861821192530917403151452391805634",
+			"8495653923123431417604973247489272438418190587263600148770280649306958101930",
+			"4082367875863433681332203403145435568316851327593401208105741076214120093531",
+		},
+		gt: [12]string{
+			"17264119758069723980713015158403419364912226240334615592005620718956030922389",
+			"1300711225518851207585954685848229181392358478699795190245709208408267917898",
+			"8894217292938489450175280157304813535227569267786222825147475294561798790624",
+			"1829859855596098509359522796979920150769875799037311140071969971193843357227",
+			"4968700049505451466697923764727215585075098085662966862137174841375779106779",
+			"12814315002058128940449527172080950701976819591738376253772993495204862218736",
+			"4233474252585134102088637248223601499779641130562251948384759786370563844606",
+			"9420544134055737381096389798327244442442230840902787283326002357297404128074",
+			"13457906610892676317612909831857663099224588803620954529514857102808143524905",
+			"5122435115068592725432309312491733755581898052459744089947319066829791570839",
+			"8891987925005301465158626530377582234132838601606565363865129986128301774627",
+			"440796048150724096437130979851431985500142692666486515369083499585648077975",
+		},
+	},
+	// BLS12-381
+	"bls12,-15132376222941642752": {
+		source: "gnark-crypto v0.22.0 bls12381.Pair(bls12381.Generators())",
+		convention: []string{
+			"Generated BLS12 pairing is the cube e = (f^((p^12 - 1) / r))^3 of the",
+			"optimal ate miller function. gnark-crypto final exponentiation raises to",
+			"3 * (p^12 - 1) / r as well so expected value is e(g1, g2). circl v1.6.5",
+			"bls12381.Pair returns the same value.",
+		},
+		exponent: "1",
+		g1: [2]string{
+			"3685416753713387016781088315183077757961620795782546409894578378688607592378376318836054947676345821548104185464507",
+			"1339506544944476473020471379941921221584933875938349620426543736416511423956333506472724655353366534992391756441569",
+		},
+		g2: [4]string{
+			"352701069587466618187139116011060144890029952792775240219908644239793785735715026873347600343865175952761926303160",
+			"3059144344244213709971259814753781636986470325476647558659373206291635324768958432433509563104347017837885763365758",
+			"1985150602287291935568054521177171638300868978215655730859378665066344726373823718423869104263333984641494340347905",
+			"927553665492332455747201965776037880757740193453592970025027978793976877002675564980949289727957565575433344219582",
+		},
+		gt: [12]string{
+			"2819105605953691245277803056322684086884703000473961065716485506033588504203831029066448642358042597501014294104502",
+			"1323968232986996742571315206151405965104242542339680722164220900812303524334628370163366153839984196298685227734799",
+			"2987335049721312504428602988447616328830341722376962214011674875969052835043875658579425548512925634040144704192135",
+			"3879723582452552452538684314479081967502111497413076598816163759028842927668327542875108457755966417881797966271311",
+			"261508182517997003171385743374653339186059518494239543139839025878870012614975302676296704930880982238308326681253",
+			"231488992246460459663813598342448669854473942105054381511346786719005883340876032043606739070883099647773793170614",
+			"3993582095516422658773669068931361134188738159766715576187490305611759126554796569868053818105850661142222948198557",
+			"1074773511698422344502264006159859710502164045911412750831641680783012525555872467108249271286757399121183508900634",
+			"2727588299083545686739024317998512740561167011046940249988557419323068809019137624943703910267790601287073339193943",
+			"493643299814437640914745677854369670041080344349607504656543355799077485536288866009245028091988146107059514546594",
+			"734401332196641441839439105942623141234148957972407782257355060229193854324927417865401895596108124443575283868655",
+			"2348330098288556420918672502923664952620152483128593484301759394583320358354186482723629999370241674973832318248497",
+		},
+	},
+}
+
+// pairingVectorTest returns a test that checks pairing of standard generators
+// against an external known answer
+func pairingVectorTest(c *pairingParams, v *pairingVector) string {
+	hexString := func(in string) string {
+		b, _ := new(big.Int).SetString(in, 10)
+		return fmt.Sprintf("\"0x%0*x\"", 2*len(c.p.Bytes()), b)
+	}
+	code := fmt.Sprintf("\n// externalPairingKAT is pairing of standard generators computed with\n// %s.\n", v.source)
+	for _, line := range v.convention {
+		code += "// " + line + "\n"
+	}
+	code += "var externalPairingKAT = [12]string{\n"
+	for _, a := range v.gt {
+		code += hexString(a) + ",\n"
+	}
+	code += "}\n\n"
+	code += "// externalPairingGenerators are affine coordinates of standard g1 and g2\n"
+	code += "var externalPairingGenerators = [6]string{\n"
+	for _, a := range append(v.g1[:], v.g2[:]...) {
+		code += hexString(a) + ",\n"
+	}
+	code += "}\n\n"
+	code += "// externalPairingExponent is k where known answer is e(g1, g2)^k\n"
+	code += fmt.Sprintf("var externalPairingExponent, _ = new(big.Int).SetString(\"%s\", 10)\n", v.exponent)
+	return code + pairingVectorTestBody
+}
+
+const pairingVectorTestBody = `
+func TestPairingExternalKAT(t *testing.T) {
+	var c [6]fieldElement
+	for i, s := range externalPairingGenerators {
+		fe, err := newFieldElementFromString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c[i].set(fe)
+	}
+	p, err := newPointFromAffine(&c[0], &c[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := newG2PointFromAffine(&fe2{c[2], c[3]}, &fe2{c[4], c[5]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g2IsInSubgroup(q) {
+		t.Fatalf("standard g2 should be in subgroup")
+	}
+	e := pairing(p, q)
+	fe12Exp(e, e, externalPairingExponent)
+	if !e.equal(fe12FromStrings(t, externalPairingKAT)) {
+		t.Fatalf("pairing of standard generators does not match external known answer")
+	}
+}
+`
+
+const pairingTestImports = `
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+`
+
+const pairingTestParamsBN = `
+// pairingFamily returns p, r and miller loop scalar of BN family
+func pairingFamily() (*big.Int, *big.Int, *big.Int) {
+	x := pairingX
+	p, r := big.NewInt(36), big.NewInt(36)
+	for _, a := range []int64{36, 24, 6, 1} {
+		p.Mul(p, x).Add(p, big.NewInt(a))
+	}
+	for _, a := range []int64{36, 18, 6, 1} {
+		r.Mul(r, x).Add(r, big.NewInt(a))
+	}
+	s := new(big.Int).Mul(x, big.NewInt(6))
+	return p, r, s.Add(s, big.NewInt(2))
+}
+`
+
+const pairingTestParamsBLS12 = `
+// pairingFamily returns p, r and miller loop scalar of BLS12 family
+func pairingFamily() (*big.Int, *big.Int, *big.Int) {
+	x := pairingX
+	x2 := new(big.Int).Mul(x, x)
+	r := new(big.Int).Mul(x2, x2)
+	r.Sub(r, x2).Add(r, big.NewInt(1))
+	p := new(big.Int).Sub(x, big.NewInt(1))
+	p.Mul(p, p).Mul(p, r).Div(p, big.NewInt(3)).Add(p, x)
+	return p, r, new(big.Int).Set(x)
+}
+`
+
+const pairingTestBody = `
+func fe12FromStrings(t *testing.T, in [12]string) *fe12 {
+	e := new(fe12)
+	for i := 0; i < 12; i++ {
+		b, ok := new(big.Int).SetString(in[i], 0)
+		if !ok {
+			t.Fatalf("bad string %s", in[i])
+		}
+		fe, err := newFieldElementFromBig(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e[i/6][(i/2)%3][i%2].set(fe)
+	}
+	return e
+}
+
+func randFe2(t *testing.T) *fe2 {
+	e := new(fe2)
+	for i := range e {
+		a, err := randFieldElement(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e[i].set(a)
+	}
+	return e
+}
+
+func randFe6(t *testing.T) *fe6 {
+	return &fe6{*randFe2(t), *randFe2(t), *randFe2(t)}
+}
+
+func randFe12(t *testing.T) *fe12 {
+	return &fe12{*randFe6(t), *randFe6(t)}
+}
+
+func randG1(t *testing.T) *point {
+	p := newPoint()
+	pointMulScalar(p, generator(), randScalar(t))
+	return p
+}
+
+func randG2(t *testing.T) *g2Point {
+	q := newG2Point()
+	g2MulScalar(q, g2Generator(), randScalar(t))
+	return q
+}
+
+func TestPairingParams(t *testing.T) {
+	p, r, s := pairingFamily()
+	if p.Cmp(pbig) != 0 {
+		t.Fatalf("bad modulus")
+	}
+	if r.Cmp(curveOrder) != 0 {
+		t.Fatalf("bad subgroup order")
+	}
+	k := new(big.Int)
+	for i := len(ateLoop) - 1; i >= 0; i-- {
+		k.Lsh(k, 1).Add(k, big.NewInt(int64(ateLoop[i])))
+	}
+	if ateLoopNegative {
+		k.Neg(k)
+	}
+	if k.Cmp(s) != 0 {
+		t.Fatalf("bad miller loop scalar")
+	}
+	for i := 0; i+1 < len(ateLoop); i++ {
+		if ateLoop[i] != 0 && ateLoop[i+1] != 0 {
+			t.Fatalf("miller loop scalar is not in non adjacent form")
+		}
+	}
+	g := g2Generator()
+	if g.isZero() || !g2IsOnCurve(g) || !g2IsInSubgroup(g) {
+		t.Fatalf("bad G2 generator")
+	}
+	if !pointIsInSubgroup(generator()) {
+		t.Fatalf("bad G1 generator")
+	}
+}
+
+func TestFe2(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		a, b, c := randFe2(t), randFe2(t), randFe2(t)
+		t0, t1 := new(fe2), new(fe2)
+		fe2Mul(t0, a, b)
+		fe2Mul(t1, b, a)
+		if !t0.equal(t1) {
+			t.Fatalf("a * b == b * a")
+		}
+		fe2Mul(t0, t0, c)
+		fe2Mul(t1, b, c)
+		fe2Mul(t1, a, t1)
+		if !t0.equal(t1) {
+			t.Fatalf("(a * b) * c == a * (b * c)")
+		}
+		fe2Add(t0, a, b)
+		fe2Mul(t0, t0, c)
+		fe2Mul(t1, a, c)
+		fe2Mul(c, b, c)
+		fe2Add(t1, t1, c)
+		if !t0.equal(t1) {
+			t.Fatalf("(a + b) * c == a * c + b * c")
+		}
+		fe2Square(t0, a)
+		fe2Mul(t1, a, a)
+		if !t0.equal(t1) {
+			t.Fatalf("bad squaring")
+		}
+		fe2Inverse(t0, a)
+		fe2Mul(t0, t0, a)
+		if !t0.isOne() {
+			t.Fatalf("bad inversion")
+		}
+		// u^2 = beta
+		u := new(fe2)
+		u[1].set(one)
+		fe2Square(t0, u)
+		t1.one()
+		fe2MulByBeta(&t1[0], &t1[0])
+		if !t0.equal(t1) {
+			t.Fatalf("bad non residue")
+		}
+		fe2MulByXi(t0, a)
+		fe2Mul(t1, a, fe2Xi)
+		if !t0.equal(t1) {
+			t.Fatalf("bad multiplication by xi")
+		}
+		// a * conj(a) is in base field
+		fe2Conjugate(t0, a)
+		fe2Mul(t0, t0, a)
+		if !isZero(&t0[1]) {
+			t.Fatalf("bad conjugation")
+		}
+	}
+}
+
+func TestFe6(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		a, b, c := randFe6(t), randFe6(t), randFe6(t)
+		t0, t1 := new(fe6), new(fe6)
+		fe6Mul(t0, a, b)
+		fe6Mul(t1, b, a)
+		if !t0.equal(t1) {
+			t.Fatalf("a * b == b * a")
+		}
+		fe6Mul(t0, t0, c)
+		fe6Mul(t1, b, c)
+		fe6Mul(t1, a, t1)
+		if !t0.equal(t1) {
+			t.Fatalf("(a * b) * c == a * (b * c)")
+		}
+		fe6Square(t0, a)
+		fe6Mul(t1, a, a)
+		if !t0.equal(t1) {
+			t.Fatalf("bad squaring")
+		}
+		fe6Inverse(t0, a)
+		fe6Mul(t0, t0, a)
+		if !t0.isOne() {
+			t.Fatalf("bad inversion")
+		}
+		// v^3 = xi
+		v := new(fe6).zero()
+		v[1].one()
+		fe6Mul(t0, v, v)
+		fe6Mul(t0, t0, v)
+		t1.zero()
+		t1[0].set(fe2Xi)
+		if !t0.equal(t1) {
+			t.Fatalf("bad non residue")
+		}
+		fe6MulByNonResidue(t0, a)
+		fe6Mul(t1, a, v)
+		if !t0.equal(t1) {
+			t.Fatalf("bad multiplication by non residue")
+		}
+		s := &fe6{b[0], b[1], fe2{}}
+		fe6MulBy01(t0, a, &b[0], &b[1])
+		fe6Mul(t1, a, s)
+		if !t0.equal(t1) {
+			t.Fatalf("bad sparse multiplication")
+		}
+		s = &fe6{fe2{}, b[1], fe2{}}
+		fe6MulBy1(t0, a, &b[1])
+		fe6Mul(t1, a, s)
+		if !t0.equal(t1) {
+			t.Fatalf("bad sparse multiplication")
+		}
+		s = &fe6{b[0], fe2{}, fe2{}}
+		fe6MulByFe2(t0, a, &b[0])
+		fe6Mul(t1, a, s)
+		if !t0.equal(t1) {
+			t.Fatalf("bad multiplication by Fp2 element")
+		}
+	}
+}
+
+func TestFe12(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		a, b, c := randFe12(t), randFe12(t), randFe12(t)
+		t0, t1 := new(fe12), new(fe12)
+		fe12Mul(t0, a, b)
+		fe12Mul(t1, b, a)
+		if !t0.equal(t1) {
+			t.Fatalf("a * b == b * a")
+		}
+		fe12Mul(t0, t0, c)
+		fe12Mul(t1, b, c)
+		fe12Mul(t1, a, t1)
+		if !t0.equal(t1) {
+			t.Fatalf("(a * b) * c == a * (b * c)")
+		}
+		fe12Square(t0, a)
+		fe12Mul(t1, a, a)
+		if !t0.equal(t1) {
+			t.Fatalf("bad squaring")
+		}
+		fe12Inverse(t0, a)
+		fe12Mul(t0, t0, a)
+		if !t0.isOne() {
+			t.Fatalf("bad inversion")
+		}
+		e := new(big.Int)
+		for k := 1; k <= 3; k++ {
+			fe12Frobenius(t0, a, k)
+			fe12Exp(t1, a, e.Exp(pbig, big.NewInt(int64(k)), nil))
+			if !t0.equal(t1) {
+				t.Fatalf("bad frobenius map, power: %d", k)
+			}
+		}
+		fe12Conjugate(t0, a)
+		fe12Frobenius(t1, a, 3)
+		fe12Frobenius(t1, t1, 3)
+		if !t0.equal(t1) {
+			t.Fatalf("bad conjugation")
+		}
+		d0, d1, d2 := randFe2(t), randFe2(t), randFe2(t)
+		fe12MulBy034(t0, a, d0, d1, d2)
+		s := &fe12{fe6{*d0, fe2{}, fe2{}}, fe6{*d1, *d2, fe2{}}}
+		fe12Mul(t1, a, s)
+		if !t0.equal(t1) {
+			t.Fatalf("bad sparse multiplication")
+		}
+		fe12MulBy014(t0, a, d0, d1, d2)
+		s = &fe12{fe6{*d0, *d1, fe2{}}, fe6{fe2{}, *d2, fe2{}}}
+		fe12Mul(t1, a, s)
+		if !t0.equal(t1) {
+			t.Fatalf("bad sparse multiplication")
+		}
+		// elements of cyclotomic subgroup
+		finalExponentiationEasy(b, a)
+		fe12CyclotomicSquare(t0, b)
+		fe12Square(t1, b)
+		if !t0.equal(t1) {
+			t.Fatalf("bad cyclotomic squaring")
+		}
+		expByX(t0, b)
+		fe12Exp(t1, b, pairingX)
+		if !t0.equal(t1) {
+			t.Fatalf("bad exponentiation by x")
+		}
+		fe12Conjugate(t0, b)
+		fe12Mul(t0, t0, b)
+		if !t0.isOne() {
+			t.Fatalf("inverse should be conjugate in cyclotomic subgroup")
+		}
+	}
+}
+
+func TestG2Point(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		a, b := randScalar(t), randScalar(t)
+		p, q, r, s := newG2Point(), newG2Point(), newG2Point(), newG2Point()
+		g2MulScalar(p, g2Generator(), a)
+		g2MulScalar(q, g2Generator(), b)
+		// scale Z to exercise projective coordinates
+		z := randFe2(t)
+		for j := 0; j < 3; j++ {
+			fe2Mul(&q[j], &q[j], z)
+		}
+		g2Add(r, p, q)
+		g2MulScalar(s, g2Generator(), new(big.Int).Add(a, b))
+		if !r.equal(s) || !g2IsOnCurve(r) {
+			t.Fatalf("bad point addition")
+		}
+		g2Sub(r, r, q)
+		if !r.equal(p) {
+			t.Fatalf("bad point subtraction")
+		}
+		g2Double(r, q)
+		g2Add(s, q, q)
+		if !r.equal(s) {
+			t.Fatalf("bad point doubling")
+		}
+		g2Neg(r, p)
+		g2Add(r, r, p)
+		if !r.isZero() {
+			t.Fatalf("p - p should be at infinity")
+		}
+		g2Add(r, p, newG2Point())
+		if !r.equal(p) {
+			t.Fatalf("p + 0 == p")
+		}
+		g2MulScalar(r, p, curveOrder)
+		if !r.isZero() {
+			t.Fatalf("r * p should be at infinity")
+		}
+		// frobenius acts as multiplication by p
+		g2Frobenius(r, q)
+		g2MulScalar(s, q, pbig)
+		if !r.equal(s) {
+			t.Fatalf("bad frobenius endomorphism")
+		}
+		g2ClearCofactor(r, p)
+		if !g2IsInSubgroup(r) {
+			t.Fatalf("cofactor cleared point should be in subgroup")
+		}
+		g2Affine(r, q)
+		if !r[2].isOne() || !r.equal(q) {
+			t.Fatalf("bad affine conversion")
+		}
+		if _, err := newG2PointFromAffine(&r[0], &r[1]); err != nil {
+			t.Fatal(err)
+		}
+		fe2Add(&r[1], &r[1], new(fe2).one())
+		if _, err := newG2PointFromAffine(&r[0], &r[1]); err == nil {
+			t.Fatalf("point not on curve should be rejected")
+		}
+	}
+}
+
+func TestPairingKAT(t *testing.T) {
+	e := pairing(generator(), g2Generator())
+	if !e.equal(fe12FromStrings(t, pairingKAT)) {
+		t.Fatalf("bad pairing of generators")
+	}
+	fe12Exp(e, e, curveOrder)
+	if !e.isOne() {
+		t.Fatalf("pairing should be in subgroup of order r")
+	}
+}
+
+func TestPairingBilinearity(t *testing.T) {
+	g1, g2 := generator(), g2Generator()
+	e := pairing(g1, g2)
+	if e.isOne() {
+		t.Fatalf("pairing should be non degenerate")
+	}
+	for i := 0; i < fuz; i++ {
+		a, b := randScalar(t), randScalar(t)
+		p, q := newPoint(), newG2Point()
+		pointMulScalar(p, g1, a)
+		g2MulScalar(q, g2, b)
+		// e(a * g1, b * g2) == e(g1, g2)^(a * b)
+		e0, e1 := pairing(p, q), new(fe12)
+		fe12Exp(e1, e, new(big.Int).Mul(a, b))
+		if !e0.equal(e1) {
+			t.Fatalf("bad pairing, e(a * g1, b * g2) == e(g1, g2)^(a * b)")
+		}
+		// e(a * b * g1, g2) == e(g1, a * b * g2)
+		pointMulScalar(p, p, b)
+		g2MulScalar(q, g2, new(big.Int).Mul(a, b))
+		if !pairing(p, g2).equal(pairing(g1, q)) {
+			t.Fatalf("bad pairing, e(a * b * g1, g2) == e(g1, a * b * g2)")
+		}
+		// e(p0 + p1, q) == e(p0, q) * e(p1, q)
+		p0, p1, q0 := randG1(t), randG1(t), randG2(t)
+		pointAdd(p, p0, p1)
+		fe12Mul(e1, pairing(p0, q0), pairing(p1, q0))
+		if !pairing(p, q0).equal(e1) {
+			t.Fatalf("bad pairing, e(p0 + p1, q) == e(p0, q) * e(p1, q)")
+		}
+	}
+}
+
+func TestPairingCheck(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		a := randScalar(t)
+		p, q, n := newPoint(), newG2Point(), newPoint()
+		pointMulScalar(p, generator(), a)
+		g2MulScalar(q, g2Generator(), a)
+		pointNeg(n, generator())
+		// e(a * g1, g2) * e(-g1, a * g2) == 1
+		ok, err := pairingCheck([]*point{p, n}, []*g2Point{g2Generator(), q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatalf("pairing check should pass")
+		}
+		ok, err = pairingCheck([]*point{p, generator()}, []*g2Point{g2Generator(), q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			t.Fatalf("pairing check should fail")
+		}
+		// pairs with infinity are ignored
+		ok, err = pairingCheck([]*point{p, n, newPoint()}, []*g2Point{g2Generator(), q, g2Generator()})
+		if err != nil || !ok {
+			t.Fatalf("pairs with point at infinity should be ignored")
+		}
+	}
+	if !pairing(newPoint(), g2Generator()).isOne() || !pairing(generator(), newG2Point()).isOne() {
+		t.Fatalf("pairing with point at infinity should be one")
+	}
+	if _, err := pairingCheck([]*point{generator()}, nil); err == nil {
+		t.Fatalf("size mismatch should be rejected")
+	}
+}
+`
@@ -0,0 +1,319 @@
+package gocode
+
+const uintImpl = `
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// uintN is a fixed width unsigned integer of limbSize little endian limbs. It
+// is built on the non modular assembly primitives, arithmetic wraps modulo
+// 2^(64 * limbSize) and carries, borrows and shifted out bits are returned so
+// that operations can be chained.
+type uintN [limbSize]uint64
+
+// newUintNFromBig returns b if it fits into limbSize limbs
+func newUintNFromBig(b *big.Int) (*uintN, error) {
+	if b.Sign() < 0 || b.BitLen() > limbSize*64 {
+		return nil, fmt.Errorf("value does not fit into %d limbs", limbSize)
+	}
+	a := new(uintN)
+	bts := b.Bytes()
+	for i := range bts {
+		a[i/8] |= uint64(bts[len(bts)-1-i]) << uint(8*(i%8))
+	}
+	return a, nil
+}
+
+func (a *uintN) toBig() *big.Int {
+	return (*fieldElement)(a).toBig()
+}
+
+func (a *uintN) fe() *fieldElement {
+	return (*fieldElement)(a)
+}
+
+func (a *uintN) set(b *uintN) *uintN {
+	*a = *b
+	return a
+}
+
+func (a *uintN) setUint64(x uint64) *uintN {
+	*a = uintN{}
+	a[0] = x
+	return a
+}
+
+func (a *uintN) isZero() bool {
+	var acc uint64
+	for i := 0; i < limbSize; i++ {
+		acc |= a[i]
+	}
+	return acc == 0
+}
+
+// cmp returns 1 if a > b, -1 if a < b and 0 otherwise
+func (a *uintN) cmp(b *uintN) int8 {
+	return cmp(a.fe(), b.fe())
+}
+
+func (a *uintN) equal(b *uintN) bool {
+	return *a == *b
+}
+
+// add sets a to a + b and returns the carry
+func (a *uintN) add(b *uintN) uint64 {
+	return addn(a.fe(), b.fe())
+}
+
+// sub sets a to a - b and returns the borrow
+func (a *uintN) sub(b *uintN) uint64 {
+	return subn(a.fe(), b.fe())
+}
+
+// addUint64 sets a to a + x and returns the carry. It can take carry of
+// another addition.
+func (a *uintN) addUint64(x uint64) uint64 {
+	for i := 0; i < limbSize; i++ {
+		a[i], x = bits.Add64(a[i], x, 0)
+	}
+	return x
+}
+
+// subUint64 sets a to a - x and returns the borrow
+func (a *uintN) subUint64(x uint64) uint64 {
+	for i := 0; i < limbSize; i++ {
+		a[i], x = bits.Sub64(a[i], x, 0)
+	}
+	return x
+}
+
+// lsh1 sets a to 2 * a and returns the shifted out bit
+func (a *uintN) lsh1() uint64 {
+	return mul_two(a.fe())
+}
+
+// rsh1 sets a to a / 2 and returns the shifted out bit
+func (a *uintN) rsh1() uint64 {
+	b := a[0] & 1
+	div_two(a.fe())
+	return b
+}
+
+// lsh sets a to a * 2^n mod 2^(64 * limbSize)
+func (a *uintN) lsh(n uint) *uintN {
+	w, s := int(n/64), n%64
+	for i := limbSize - 1; i >= 0; i-- {
+		var x uint64
+		if j := i - w; j >= 0 {
+			x = a[j] << s
+			if j > 0 && s != 0 {
+				x |= a[j-1] >> (64 - s)
+			}
+		}
+		a[i] = x
+	}
+	return a
+}
+
+// rsh sets a to a / 2^n
+func (a *uintN) rsh(n uint) *uintN {
+	w, s := int(n/64), n%64
+	for i := 0; i < limbSize; i++ {
+		var x uint64
+		if j := i + w; j < limbSize {
+			x = a[j] >> s
+			if j+1 < limbSize && s != 0 {
+				x |= a[j+1] << (64 - s)
+			}
+		}
+		a[i] = x
+	}
+	return a
+}
+
+// bit returns i-th bit of a, bits out of width are zero
+func (a *uintN) bit(i int) uint64 {
+	if i < 0 || i >= limbSize*64 {
+		return 0
+	}
+	return (a[i/64] >> uint(i%64)) & 1
+}
+
+// setBit sets i-th bit of a to the lowest bit of b
+func (a *uintN) setBit(i int, b uint64) *uintN {
+	m := uint64(1) << uint(i%64)
+	a[i/64] = a[i/64]&^m | (b&1)<<uint(i%64)
+	return a
+}
+
+func (a *uintN) bitLen() int {
+	for i := limbSize - 1; i >= 0; i-- {
+		if a[i] != 0 {
+			return i*64 + bits.Len64(a[i])
+		}
+	}
+	return 0
+}
+
+// mulWide sets hi and lo to high and low halves of a * b
+func mulWide(hi, lo, a, b *uintN) {
+	var w [2 * limbSize]uint64
+	for i := 0; i < limbSize; i++ {
+		var carry uint64
+		for j := 0; j < limbSize; j++ {
+			h, l := bits.Mul64(a[i], b[j])
+			var c uint64
+			l, c = bits.Add64(l, w[i+j], 0)
+			h += c
+			l, c = bits.Add64(l, carry, 0)
+			h += c
+			w[i+j], carry = l, h
+		}
+		w[i+limbSize] = carry
+	}
+	copy(lo[:], w[:limbSize])
+	copy(hi[:], w[limbSize:])
+}
+
+// divWord sets a to a / d and returns the remainder. d should be non zero.
+func (a *uintN) divWord(d uint64) uint64 {
+	var r uint64
+	for i := limbSize - 1; i >= 0; i-- {
+		a[i], r = bits.Div64(r, a[i], d)
+	}
+	return r
+}
+`
+
+const uintTest = `
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// randUintN returns random values with edge cases of limb patterns
+func randUintN(t *testing.T) *uintN {
+	max := new(big.Int).Lsh(big.NewInt(1), limbSize*64)
+	b, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := newUintNFromBig(b)
+	switch b.Bit(0) + 2*b.Bit(1) {
+	case 0:
+		for i := range a {
+			a[i] = ^uint64(0)
+		}
+	case 1:
+		a[limbSize-1] = 0
+	}
+	return a
+}
+
+func TestUintN(t *testing.T) {
+	width := uint(limbSize * 64)
+	mod := new(big.Int).Lsh(big.NewInt(1), width)
+	wrap := func(x *big.Int) *big.Int {
+		return x.Mod(x, mod)
+	}
+	negative := func(x *big.Int) uint64 {
+		if x.Sign() < 0 {
+			return 1
+		}
+		return 0
+	}
+	for i := 0; i < fuz*20; i++ {
+		a, b := randUintN(t), randUintN(t)
+		A, B := a.toBig(), b.toBig()
+		if back, err := newUintNFromBig(A); err != nil || !back.equal(a) {
+			t.Fatalf("bad conversion")
+		}
+		c := new(uintN).set(a)
+		carry := c.add(b)
+		sum := new(big.Int).Add(A, B)
+		if c.toBig().Cmp(wrap(new(big.Int).Set(sum))) != 0 || carry != uint64(sum.Bit(int(width))) {
+			t.Fatalf("bad addition")
+		}
+		c.set(a)
+		borrow := c.sub(b)
+		diff := new(big.Int).Sub(A, B)
+		if c.toBig().Cmp(wrap(new(big.Int).Set(diff))) != 0 || borrow != negative(diff) {
+			t.Fatalf("bad subtraction")
+		}
+		x := A.Uint64()
+		c.set(a)
+		carry = c.addUint64(x)
+		sum.Add(A, new(big.Int).SetUint64(x))
+		if c.toBig().Cmp(wrap(new(big.Int).Set(sum))) != 0 || carry != uint64(sum.Bit(int(width))) {
+			t.Fatalf("bad word addition")
+		}
+		c.set(a)
+		borrow = c.subUint64(^x)
+		diff.Sub(A, new(big.Int).SetUint64(^x))
+		if c.toBig().Cmp(wrap(new(big.Int).Set(diff))) != 0 || borrow != negative(diff) {
+			t.Fatalf("bad word subtraction")
+		}
+		if int(a.cmp(b)) != A.Cmp(B) || a.cmp(a) != 0 || !a.equal(new(uintN).set(a)) {
+			t.Fatalf("bad comparison")
+		}
+		c.set(a)
+		if c.lsh1() != uint64(A.Bit(int(width)-1)) || c.toBig().Cmp(wrap(new(big.Int).Lsh(A, 1))) != 0 {
+			t.Fatalf("bad shift left by one")
+		}
+		c.set(a)
+		if c.rsh1() != uint64(A.Bit(0)) || c.toBig().Cmp(new(big.Int).Rsh(A, 1)) != 0 {
+			t.Fatalf("bad shift right by one")
+		}
+		for _, n := range []uint{0, 1, 63, 64, 65, 127, width - 1, width, width + 1, uint(x % uint64(width))} {
+			if c.set(a).lsh(n).toBig().Cmp(wrap(new(big.Int).Lsh(A, n))) != 0 {
+				t.Fatalf("bad shift left by %d", n)
+			}
+			if c.set(a).rsh(n).toBig().Cmp(new(big.Int).Rsh(A, n)) != 0 {
+				t.Fatalf("bad shift right by %d", n)
+			}
+		}
+		for j := -1; j < int(width)+1; j++ {
+			var expected uint64
+			if j >= 0 {
+				expected = uint64(A.Bit(j))
+			}
+			if a.bit(j) != expected {
+				t.Fatalf("bad bit %d", j)
+			}
+		}
+		j := int(x % uint64(width))
+		if c.set(a).setBit(j, 1).toBig().Cmp(new(big.Int).SetBit(A, j, 1)) != 0 ||
+			c.set(a).setBit(j, 0).toBig().Cmp(new(big.Int).SetBit(A, j, 0)) != 0 {
+			t.Fatalf("bad bit setting")
+		}
+		if a.bitLen() != A.BitLen() || a.isZero() != (A.Sign() == 0) {
+			t.Fatalf("bad bit length")
+		}
+		hi, lo := new(uintN), new(uintN)
+		mulWide(hi, lo, a, b)
+		prod := new(big.Int).Mul(A, B)
+		if lo.toBig().Cmp(wrap(new(big.Int).Set(prod))) != 0 || hi.toBig().Cmp(prod.Rsh(prod, width)) != 0 {
+			t.Fatalf("bad wide multiplication")
+		}
+		for _, d := range []uint64{1, 3, x | 1, ^uint64(0)} {
+			q, r := new(big.Int).QuoRem(A, new(big.Int).SetUint64(d), new(big.Int))
+			c.set(a)
+			if c.divWord(d) != r.Uint64() || c.toBig().Cmp(q) != 0 {
+				t.Fatalf("bad division by word %d", d)
+			}
+		}
+	}
+	if _, err := newUintNFromBig(mod); err == nil {
+		t.Fatalf("too large value should be rejected")
+	}
+	if _, err := newUintNFromBig(big.NewInt(-1)); err == nil {
+		t.Fatalf("negative value should be rejected")
+	}
+	if c := new(uintN).setUint64(7); c.toBig().Cmp(big.NewInt(7)) != 0 {
+		t.Fatalf("bad word conversion")
+	}
+}
+`
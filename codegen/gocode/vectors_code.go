@@ -0,0 +1,218 @@
+package gocode
+
+func vectorsTest(fixedModulus bool) string {
+	if fixedModulus {
+		return vectorsTestCommon + vectorsTestFixedModulus
+	}
+	return vectorsTestCommon + vectorsTestNonFixedModulus
+}
+
+const vectorsTestCommon = `
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+)
+
+var vectorsFile = flag.String("vectors", "testdata/vectors.json", "known answer vectors produced by codegen/vectors")
+
+type knownAnswerVector struct {
+	Op  string   ` + "`json:\"op\"`" + `
+	In  []string ` + "`json:\"in\"`" + `
+	Out *string  ` + "`json:\"out\"`" + `
+}
+
+type knownAnswerVectors struct {
+	Modulus  string              ` + "`json:\"modulus\"`" + `
+	ByteSize int                 ` + "`json:\"byte_size\"`" + `
+	Vectors  []knownAnswerVector ` + "`json:\"vectors\"`" + `
+}
+
+// loadKnownAnswerVectors reads the vector file, test is skipped if there is
+// no such file
+func loadKnownAnswerVectors(t *testing.T) *knownAnswerVectors {
+	b, err := ioutil.ReadFile(*vectorsFile)
+	if os.IsNotExist(err) {
+		t.Skipf("no vector file %s", *vectorsFile)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := new(knownAnswerVectors)
+	if err := json.Unmarshal(b, v); err != nil {
+		t.Fatal(err)
+	}
+	if v.ByteSize != byteSize {
+		t.Fatalf("vectors are for %d byte field, have %d", v.ByteSize, byteSize)
+	}
+	return v
+}
+
+func vectorBig(t *testing.T, s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		t.Fatalf("bad value %s", s)
+	}
+	return v
+}
+
+func vectorBytes(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// checkKnownAnswer compares result of a vector where nil stands for a
+// rejected input or a non square
+func checkKnownAnswer(t *testing.T, i int, vec knownAnswerVector, have *big.Int) {
+	t.Helper()
+	var want *big.Int
+	if vec.Out != nil {
+		want = vectorBig(t, *vec.Out)
+	}
+	if (have == nil) != (want == nil) || have != nil && have.Cmp(want) != 0 {
+		t.Errorf("vector %d, %s %v\nhave %#x\nwant %#x", i, vec.Op, vec.In, have, want)
+	}
+}
+`
+
+const vectorsTestFixedModulus = `
+func TestKnownAnswerVectors(t *testing.T) {
+	v := loadKnownAnswerVectors(t)
+	if vectorBig(t, v.Modulus).Cmp(pbig) != 0 {
+		t.Fatalf("vectors are for modulus %s", v.Modulus)
+	}
+	element := func(s string) *fieldElement {
+		fe, err := newFieldElementFromBig(vectorBig(t, s))
+		if err != nil || vectorBig(t, s).Cmp(pbig) >= 0 {
+			t.Fatalf("bad field element %s", s)
+		}
+		return fe
+	}
+	for i, vec := range v.Vectors {
+		var have *big.Int
+		c := newFieldElement()
+		switch vec.Op {
+		case "add":
+			add(c, element(vec.In[0]), element(vec.In[1]))
+			have = toBig(c)
+		case "sub":
+			sub(c, element(vec.In[0]), element(vec.In[1]))
+			have = toBig(c)
+		case "mul":
+			mul(c, element(vec.In[0]), element(vec.In[1]))
+			have = toBig(c)
+		case "square":
+			a := element(vec.In[0])
+			mul(c, a, a)
+			have = toBig(c)
+		case "exp":
+			exp(c, element(vec.In[0]), vectorBig(t, vec.In[1]))
+			have = toBig(c)
+		case "inverse":
+			inverse(c, element(vec.In[0]))
+			have = toBig(c)
+		case "sqrt":
+			// even root is the expected one
+			if sqrtRatio(c, element(vec.In[0]), one) {
+				if sgn0(c) {
+					neg(c, c)
+				}
+				have = toBig(c)
+			}
+		case "serialization":
+			in := vectorBytes(t, vec.In[0])
+			if fe, err := newFieldElementFromBytes(in); err == nil {
+				if !bytes.Equal(toBytes(fe), in) {
+					t.Errorf("vector %d, bad serialization %x", i, in)
+				}
+				have = toBig(fe)
+			}
+		default:
+			t.Fatalf("vector %d, unknown operation %s", i, vec.Op)
+		}
+		checkKnownAnswer(t, i, vec, have)
+	}
+}
+`
+
+const vectorsTestNonFixedModulus = `
+func TestKnownAnswerVectors(t *testing.T) {
+	v := loadKnownAnswerVectors(t)
+	pbig := vectorBig(t, v.Modulus)
+	if pbig.BitLen() > byteSize*8 {
+		t.Fatalf("modulus %s does not fit in %d bytes", v.Modulus, byteSize)
+	}
+	field, err := newField(padBytes(pbig.Bytes(), byteSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	element := func(s string) *fieldElement {
+		fe, err := field.newFieldElementFromBig(vectorBig(t, s))
+		if err != nil || vectorBig(t, s).Cmp(pbig) >= 0 {
+			t.Fatalf("bad field element %s", s)
+		}
+		return fe
+	}
+	// smallest non square for square roots
+	var z *fieldElement
+	for k := int64(2); ; k++ {
+		z = element(big.NewInt(k).String())
+		if !field.isSquare(z) {
+			break
+		}
+	}
+	for i, vec := range v.Vectors {
+		var have *big.Int
+		c := field.newFieldElement()
+		switch vec.Op {
+		case "add":
+			field.add(c, element(vec.In[0]), element(vec.In[1]))
+			have = field.toBig(c)
+		case "sub":
+			field.sub(c, element(vec.In[0]), element(vec.In[1]))
+			have = field.toBig(c)
+		case "mul":
+			field.mul(c, element(vec.In[0]), element(vec.In[1]))
+			have = field.toBig(c)
+		case "square":
+			a := element(vec.In[0])
+			field.mul(c, a, a)
+			have = field.toBig(c)
+		case "exp":
+			field.exp(c, element(vec.In[0]), vectorBig(t, vec.In[1]))
+			have = field.toBig(c)
+		case "inverse":
+			field.inverse(c, element(vec.In[0]))
+			have = field.toBig(c)
+		case "sqrt":
+			// even root is the expected one
+			if field.sqrtRatio(c, element(vec.In[0]), field.one, z) {
+				if field.sgn0(c) {
+					field.neg(c, c)
+				}
+				have = field.toBig(c)
+			}
+		case "serialization":
+			in := vectorBytes(t, vec.In[0])
+			if fe, err := field.newFieldElementFromBytes(in); err == nil {
+				if !bytes.Equal(field.toBytes(fe), in) {
+					t.Errorf("vector %d, bad serialization %x", i, in)
+				}
+				have = field.toBig(fe)
+			}
+		default:
+			t.Fatalf("vector %d, unknown operation %s", i, vec.Op)
+		}
+		checkKnownAnswer(t, i, vec, have)
+	}
+}
+`
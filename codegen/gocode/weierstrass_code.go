@@ -0,0 +1,889 @@
+package gocode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+)
+
+// GenWeierstrass generates short weierstrass curve y^2 = x^3 + a*x + b group
+// over a fixed modulus field. Curve parameters are comma separated and given
+// as "a,b,gx,gy,n,h" where (gx, gy) generates subgroup of order n and h is
+// the cofactor.
+func GenWeierstrass(out string, modulus string, params string) error {
+	if len(modulus) < 2 || modulus[:2] != "0x" {
+		return fmt.Errorf("Bad format for modulus\n")
+	}
+	bts, err := hex.DecodeString(modulus[2:])
+	if err != nil {
+		return err
+	}
+	limbSize := resolveBitSize(len(bts)) / 64
+	modulusBig := new(big.Int).SetBytes(bts)
+	c, err := parseWeierstrassParams(params, modulusBig)
+	if err != nil {
+		return err
+	}
+	outDir := filepath.Clean(out)
+	writeToFile(pkg("fp")+weierstrassImpl(limbSize, modulusBig, c), filepath.Join(outDir, "weierstrass.go"))
+	writeToFile(pkg("fp")+weierstrassTest, filepath.Join(outDir, "weierstrass_test.go"))
+	return nil
+}
+
+type weierstrassParams struct {
+	a, b, gx, gy, n, h *big.Int
+}
+
+func parseWeierstrassParams(in string, modulus *big.Int) (*weierstrassParams, error) {
+	parts := strings.Split(in, ",")
+	if len(parts) != 6 {
+		return nil, fmt.Errorf("expected six curve parameters, have %d\n", len(parts))
+	}
+	v := make([]*big.Int, 6)
+	for i, part := range parts {
+		c, ok := new(big.Int).SetString(strings.TrimSpace(part), 0)
+		if !ok {
+			return nil, fmt.Errorf("bad curve parameter %s\n", part)
+		}
+		v[i] = c
+	}
+	for i := 0; i < 4; i++ {
+		v[i].Mod(v[i], modulus)
+	}
+	c := &weierstrassParams{v[0], v[1], v[2], v[3], v[4], v[5]}
+	if c.n.Sign() <= 0 || c.h.Sign() <= 0 {
+		return nil, fmt.Errorf("order and cofactor should be positive\n")
+	}
+	// 4a^3 + 27b^2 != 0
+	d := new(big.Int).Exp(c.a, big.NewInt(3), modulus)
+	d.Mul(d, big.NewInt(4))
+	t := new(big.Int).Mul(c.b, c.b)
+	d.Add(d, t.Mul(t, big.NewInt(27)))
+	if d.Mod(d, modulus).Sign() == 0 {
+		return nil, fmt.Errorf("curve is singular\n")
+	}
+	// gy^2 == gx^3 + a*gx + b
+	lhs := new(big.Int).Mul(c.gy, c.gy)
+	rhs := new(big.Int).Mul(c.gx, c.gx)
+	rhs.Add(rhs, c.a).Mul(rhs, c.gx).Add(rhs, c.b)
+	if lhs.Sub(lhs, rhs).Mod(lhs, modulus).Sign() != 0 {
+		return nil, fmt.Errorf("generator is not on curve\n")
+	}
+	return c, nil
+}
+
+// weierstrassImpl picks addition and doubling formulas of Renes, Costello
+// and Batina for a = 0, a = -3 or any a at generation time.
+func weierstrassImpl(limbSize int, modulus *big.Int, c *weierstrassParams) string {
+	code := weierstrassImports
+	code += encodeBigMont("curveA", limbSize, c.a, modulus)
+	code += encodeBigMont("curveB", limbSize, c.b, modulus)
+	code += encodeBigMont("curveB3", limbSize, new(big.Int).Mod(new(big.Int).Mul(c.b, big.NewInt(3)), modulus), modulus)
+	code += encodeBigMont("curveGx", limbSize, c.gx, modulus)
+	code += encodeBigMont("curveGy", limbSize, c.gy, modulus)
+	code += fmt.Sprintf("var curveOrder, _ = new(big.Int).SetString(\"%s\", 10)\n\n", c.n.String())
+	code += fmt.Sprintf("var curveCofactor, _ = new(big.Int).SetString(\"%s\", 10)\n\n", c.h.String())
+	code += weierstrassCommon
+	minusThree := new(big.Int).Sub(modulus, big.NewInt(3))
+	switch {
+	case c.a.Sign() == 0:
+		code += weierstrassAZero
+	case c.a.Cmp(minusThree) == 0:
+		code += weierstrassAMinusThree
+	default:
+		code += weierstrassAGeneric
+	}
+	return code
+}
+
+const weierstrassImports = `
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+`
+
+const weierstrassCommon = `
+// point is a short weierstrass curve point in homogeneous projective
+// coordinates (X : Y : Z) representing affine (X/Z, Y/Z). Point at infinity
+// is (0 : 1 : 0).
+type point [3]fieldElement
+
+// newPoint returns point at infinity
+func newPoint() *point {
+	return new(point).zero()
+}
+
+// newPointFromAffine returns point (x, y) if it is on curve
+func newPointFromAffine(x, y *fieldElement) (*point, error) {
+	p := &point{}
+	p[0].set(x)
+	p[1].set(y)
+	p[2].set(one)
+	if !pointIsOnCurve(p) {
+		return nil, errors.New("point is not on curve")
+	}
+	return p, nil
+}
+
+// generator returns a new copy of subgroup generator
+func generator() *point {
+	p := &point{}
+	p[0].set(curveGx)
+	p[1].set(curveGy)
+	p[2].set(one)
+	return p
+}
+
+func (p *point) set(q *point) *point {
+	p[0].set(&q[0])
+	p[1].set(&q[1])
+	p[2].set(&q[2])
+	return p
+}
+
+func (p *point) zero() *point {
+	p[0].set(zero)
+	p[1].set(one)
+	p[2].set(zero)
+	return p
+}
+
+func (p *point) isZero() bool {
+	return isZero(&p[2])
+}
+
+func (p *point) equal(q *point) bool {
+	if p.isZero() || q.isZero() {
+		return p.isZero() && q.isZero()
+	}
+	t0, t1 := newFieldElement(), newFieldElement()
+	mul(t0, &p[0], &q[2])
+	mul(t1, &q[0], &p[2])
+	if !t0.equal(t1) {
+		return false
+	}
+	mul(t0, &p[1], &q[2])
+	mul(t1, &q[1], &p[2])
+	return t0.equal(t1)
+}
+
+// pointAffine sets r to p with Z = 1 unless p is at infinity
+func pointAffine(r, p *point) {
+	if p.isZero() {
+		r.zero()
+		return
+	}
+	zInv := newFieldElement()
+	inverse(zInv, &p[2])
+	mul(&r[0], &p[0], zInv)
+	mul(&r[1], &p[1], zInv)
+	r[2].set(one)
+}
+
+// pointIsOnCurve checks Y^2 * Z = X^3 + a * X * Z^2 + b * Z^3
+func pointIsOnCurve(p *point) bool {
+	if p.isZero() {
+		return true
+	}
+	lhs, rhs, t := newFieldElement(), newFieldElement(), newFieldElement()
+	X, Y, Z := &p[0], &p[1], &p[2]
+	mul(lhs, Y, Y)
+	mul(lhs, lhs, Z)
+	mul(t, Z, Z)
+	mul(rhs, curveA, t)
+	mul(rhs, rhs, X)
+	mul(t, t, Z)
+	mul(t, t, curveB)
+	add(rhs, rhs, t)
+	mul(t, X, X)
+	mul(t, t, X)
+	add(rhs, rhs, t)
+	return lhs.equal(rhs)
+}
+
+// pointIsInSubgroup checks if p is on curve and n * p is at infinity
+func pointIsInSubgroup(p *point) bool {
+	if !pointIsOnCurve(p) {
+		return false
+	}
+	t := newPoint()
+	pointMulScalar(t, p, curveOrder)
+	return t.isZero()
+}
+
+func pointNeg(r, p *point) {
+	r[0].set(&p[0])
+	neg(&r[1], &p[1])
+	r[2].set(&p[2])
+}
+
+func pointSub(r, p, q *point) {
+	t := newPoint()
+	pointNeg(t, q)
+	pointAdd(r, p, t)
+}
+
+// pointMulScalar sets r to e * p with double and add, it is not constant time
+func pointMulScalar(r, p *point, e *big.Int) {
+	q, k := newPoint(), new(big.Int).Abs(e)
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		pointDouble(q, q)
+		if k.Bit(i) == 1 {
+			pointAdd(q, q, p)
+		}
+	}
+	if e.Sign() < 0 {
+		pointNeg(q, q)
+	}
+	r.set(q)
+}
+
+// pointClearCofactor sets r to h * p
+func pointClearCofactor(r, p *point) {
+	pointMulScalar(r, p, curveCofactor)
+}
+
+// pointToBytes returns SEC1 encoding of p. Point at infinity is a single zero
+// byte, compressed encoding is 0x02 or 0x03 with parity of y followed by x and
+// uncompressed encoding is 0x04 followed by x and y.
+func pointToBytes(p *point, compressed bool) []byte {
+	if p.isZero() {
+		return []byte{0x00}
+	}
+	a := newPoint()
+	pointAffine(a, p)
+	x := encode(&a[0], bigEndianMinimal)
+	if compressed {
+		out := []byte{0x02}
+		if sgn0(&a[1]) {
+			out[0] = 0x03
+		}
+		return append(out, x...)
+	}
+	return append(append([]byte{0x04}, x...), encode(&a[1], bigEndianMinimal)...)
+}
+
+// pointFromBytes decodes SEC1 encoded point. Decoded point is on curve but
+// subgroup membership should be checked with pointIsInSubgroup if cofactor
+// is not one.
+func pointFromBytes(in []byte) (*point, error) {
+	if len(in) == 0 {
+		return nil, errors.New("empty input")
+	}
+	switch in[0] {
+	case 0x00:
+		if len(in) != 1 {
+			return nil, fmt.Errorf("bad input size %d, expected %d", len(in), 1)
+		}
+		return newPoint(), nil
+	case 0x02, 0x03:
+		if len(in) != 1+modulusByteSize {
+			return nil, fmt.Errorf("bad input size %d, expected %d", len(in), 1+modulusByteSize)
+		}
+		x, err := decode(in[1:], bigEndianMinimal)
+		if err != nil {
+			return nil, err
+		}
+		// y^2 = x^3 + a * x + b
+		y, t := newFieldElement(), newFieldElement()
+		mul(t, x, x)
+		add(t, t, curveA)
+		mul(t, t, x)
+		add(t, t, curveB)
+		if !sqrtRatio(y, t, one) {
+			return nil, errors.New("point is not on curve")
+		}
+		if sgn0(y) != (in[0] == 0x03) {
+			neg(y, y)
+		}
+		return newPointFromAffine(x, y)
+	case 0x04:
+		if len(in) != 1+2*modulusByteSize {
+			return nil, fmt.Errorf("bad input size %d, expected %d", len(in), 1+2*modulusByteSize)
+		}
+		x, err := decode(in[1:1+modulusByteSize], bigEndianMinimal)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(in[1+modulusByteSize:], bigEndianMinimal)
+		if err != nil {
+			return nil, err
+		}
+		return newPointFromAffine(x, y)
+	}
+	return nil, fmt.Errorf("bad point encoding prefix %#x", in[0])
+}
+`
+
+const weierstrassAGeneric = `
+// pointAdd sets r to p + q with complete formulas for any a.
+// Renes, Costello, Batina 2015, algorithm 1
+func pointAdd(r, p, q *point) {
+	t0, t1, t2, t3, t4, t5 := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	X3, Y3, Z3 := newFieldElement(), newFieldElement(), newFieldElement()
+	X1, Y1, Z1 := &p[0], &p[1], &p[2]
+	X2, Y2, Z2 := &q[0], &q[1], &q[2]
+	mul(t0, X1, X2)
+	mul(t1, Y1, Y2)
+	mul(t2, Z1, Z2)
+	add(t3, X1, Y1)
+	add(t4, X2, Y2)
+	mul(t3, t3, t4)
+	add(t4, t0, t1)
+	sub(t3, t3, t4)
+	add(t4, X1, Z1)
+	add(t5, X2, Z2)
+	mul(t4, t4, t5)
+	add(t5, t0, t2)
+	sub(t4, t4, t5)
+	add(t5, Y1, Z1)
+	add(X3, Y2, Z2)
+	mul(t5, t5, X3)
+	add(X3, t1, t2)
+	sub(t5, t5, X3)
+	mul(Z3, curveA, t4)
+	mul(X3, curveB3, t2)
+	add(Z3, X3, Z3)
+	sub(X3, t1, Z3)
+	add(Z3, t1, Z3)
+	mul(Y3, X3, Z3)
+	double(t1, t0)
+	add(t1, t1, t0)
+	mul(t2, curveA, t2)
+	mul(t4, curveB3, t4)
+	add(t1, t1, t2)
+	sub(t2, t0, t2)
+	mul(t2, curveA, t2)
+	add(t4, t4, t2)
+	mul(t0, t1, t4)
+	add(Y3, Y3, t0)
+	mul(t0, t5, t4)
+	mul(X3, X3, t3)
+	sub(X3, X3, t0)
+	mul(t0, t3, t1)
+	mul(Z3, t5, Z3)
+	add(Z3, Z3, t0)
+	r[0].set(X3)
+	r[1].set(Y3)
+	r[2].set(Z3)
+}
+
+// pointDouble sets r to 2 * p for any a.
+// Renes, Costello, Batina 2015, algorithm 3
+func pointDouble(r, p *point) {
+	t0, t1, t2, t3 := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	X3, Y3, Z3 := newFieldElement(), newFieldElement(), newFieldElement()
+	X, Y, Z := &p[0], &p[1], &p[2]
+	mul(t0, X, X)
+	mul(t1, Y, Y)
+	mul(t2, Z, Z)
+	mul(t3, X, Y)
+	double(t3, t3)
+	mul(Z3, X, Z)
+	double(Z3, Z3)
+	mul(X3, curveA, Z3)
+	mul(Y3, curveB3, t2)
+	add(Y3, X3, Y3)
+	sub(X3, t1, Y3)
+	add(Y3, t1, Y3)
+	mul(Y3, X3, Y3)
+	mul(X3, t3, X3)
+	mul(Z3, curveB3, Z3)
+	mul(t2, curveA, t2)
+	sub(t3, t0, t2)
+	mul(t3, curveA, t3)
+	add(t3, t3, Z3)
+	double(Z3, t0)
+	add(t0, Z3, t0)
+	add(t0, t0, t2)
+	mul(t0, t0, t3)
+	add(Y3, Y3, t0)
+	mul(t2, Y, Z)
+	double(t2, t2)
+	mul(t0, t2, t3)
+	sub(X3, X3, t0)
+	mul(Z3, t2, t1)
+	double(Z3, Z3)
+	double(Z3, Z3)
+	r[0].set(X3)
+	r[1].set(Y3)
+	r[2].set(Z3)
+}
+`
+
+const weierstrassAMinusThree = `
+// pointAdd sets r to p + q with complete formulas for a = -3.
+// Renes, Costello, Batina 2015, algorithm 4
+func pointAdd(r, p, q *point) {
+	t0, t1, t2, t3, t4 := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	X3, Y3, Z3 := newFieldElement(), newFieldElement(), newFieldElement()
+	X1, Y1, Z1 := &p[0], &p[1], &p[2]
+	X2, Y2, Z2 := &q[0], &q[1], &q[2]
+	mul(t0, X1, X2)
+	mul(t1, Y1, Y2)
+	mul(t2, Z1, Z2)
+	add(t3, X1, Y1)
+	add(t4, X2, Y2)
+	mul(t3, t3, t4)
+	add(t4, t0, t1)
+	sub(t3, t3, t4)
+	add(t4, Y1, Z1)
+	add(X3, Y2, Z2)
+	mul(t4, t4, X3)
+	add(X3, t1, t2)
+	sub(t4, t4, X3)
+	add(X3, X1, Z1)
+	add(Y3, X2, Z2)
+	mul(X3, X3, Y3)
+	add(Y3, t0, t2)
+	sub(Y3, X3, Y3)
+	mul(Z3, curveB, t2)
+	sub(X3, Y3, Z3)
+	double(Z3, X3)
+	add(X3, X3, Z3)
+	sub(Z3, t1, X3)
+	add(X3, t1, X3)
+	mul(Y3, curveB, Y3)
+	double(t1, t2)
+	add(t2, t1, t2)
+	sub(Y3, Y3, t2)
+	sub(Y3, Y3, t0)
+	double(t1, Y3)
+	add(Y3, t1, Y3)
+	double(t1, t0)
+	add(t0, t1, t0)
+	sub(t0, t0, t2)
+	mul(t1, t4, Y3)
+	mul(t2, t0, Y3)
+	mul(Y3, X3, Z3)
+	add(Y3, Y3, t2)
+	mul(X3, t3, X3)
+	sub(X3, X3, t1)
+	mul(Z3, t4, Z3)
+	mul(t1, t3, t0)
+	add(Z3, Z3, t1)
+	r[0].set(X3)
+	r[1].set(Y3)
+	r[2].set(Z3)
+}
+
+// pointDouble sets r to 2 * p for a = -3.
+// Renes, Costello, Batina 2015, algorithm 6
+func pointDouble(r, p *point) {
+	t0, t1, t2, t3 := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	X3, Y3, Z3 := newFieldElement(), newFieldElement(), newFieldElement()
+	X, Y, Z := &p[0], &p[1], &p[2]
+	mul(t0, X, X)
+	mul(t1, Y, Y)
+	mul(t2, Z, Z)
+	mul(t3, X, Y)
+	double(t3, t3)
+	mul(Z3, X, Z)
+	double(Z3, Z3)
+	mul(Y3, curveB, t2)
+	sub(Y3, Y3, Z3)
+	double(X3, Y3)
+	add(Y3, X3, Y3)
+	sub(X3, t1, Y3)
+	add(Y3, t1, Y3)
+	mul(Y3, X3, Y3)
+	mul(X3, X3, t3)
+	double(t3, t2)
+	add(t2, t2, t3)
+	mul(Z3, curveB, Z3)
+	sub(Z3, Z3, t2)
+	sub(Z3, Z3, t0)
+	double(t3, Z3)
+	add(Z3, Z3, t3)
+	double(t3, t0)
+	add(t0, t3, t0)
+	sub(t0, t0, t2)
+	mul(t0, t0, Z3)
+	add(Y3, Y3, t0)
+	mul(t0, Y, Z)
+	double(t0, t0)
+	mul(Z3, t0, Z3)
+	sub(X3, X3, Z3)
+	mul(Z3, t0, t1)
+	double(Z3, Z3)
+	double(Z3, Z3)
+	r[0].set(X3)
+	r[1].set(Y3)
+	r[2].set(Z3)
+}
+`
+
+const weierstrassAZero = `
+// pointAdd sets r to p + q with complete formulas for a = 0.
+// Renes, Costello, Batina 2015, algorithm 7
+func pointAdd(r, p, q *point) {
+	t0, t1, t2, t3, t4 := newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement(),
+		newFieldElement()
+	X3, Y3, Z3 := newFieldElement(), newFieldElement(), newFieldElement()
+	X1, Y1, Z1 := &p[0], &p[1], &p[2]
+	X2, Y2, Z2 := &q[0], &q[1], &q[2]
+	mul(t0, X1, X2)
+	mul(t1, Y1, Y2)
+	mul(t2, Z1, Z2)
+	add(t3, X1, Y1)
+	add(t4, X2, Y2)
+	mul(t3, t3, t4)
+	add(t4, t0, t1)
+	sub(t3, t3, t4)
+	add(t4, Y1, Z1)
+	add(X3, Y2, Z2)
+	mul(t4, t4, X3)
+	add(X3, t1, t2)
+	sub(t4, t4, X3)
+	add(X3, X1, Z1)
+	add(Y3, X2, Z2)
+	mul(X3, X3, Y3)
+	add(Y3, t0, t2)
+	sub(Y3, X3, Y3)
+	double(X3, t0)
+	add(t0, X3, t0)
+	mul(t2, curveB3, t2)
+	add(Z3, t1, t2)
+	sub(t1, t1, t2)
+	mul(Y3, curveB3, Y3)
+	mul(X3, t4, Y3)
+	mul(t2, t3, t1)
+	sub(X3, t2, X3)
+	mul(Y3, Y3, t0)
+	mul(t1, t1, Z3)
+	add(Y3, t1, Y3)
+	mul(t0, t0, t3)
+	mul(Z3, Z3, t4)
+	add(Z3, Z3, t0)
+	r[0].set(X3)
+	r[1].set(Y3)
+	r[2].set(Z3)
+}
+
+// pointDouble sets r to 2 * p for a = 0.
+// Renes, Costello, Batina 2015, algorithm 9
+func pointDouble(r, p *point) {
+	t0, t1, t2 := newFieldElement(), newFieldElement(), newFieldElement()
+	X3, Y3, Z3 := newFieldElement(), newFieldElement(), newFieldElement()
+	X, Y, Z := &p[0], &p[1], &p[2]
+	mul(t0, Y, Y)
+	double(Z3, t0)
+	double(Z3, Z3)
+	double(Z3, Z3)
+	mul(t1, Y, Z)
+	mul(t2, Z, Z)
+	mul(t2, curveB3, t2)
+	mul(X3, t2, Z3)
+	add(Y3, t0, t2)
+	mul(Z3, t1, Z3)
+	double(t1, t2)
+	add(t2, t1, t2)
+	sub(t0, t0, t2)
+	mul(Y3, t0, Y3)
+	add(Y3, X3, Y3)
+	mul(t1, X, Y)
+	mul(X3, t0, t1)
+	double(X3, X3)
+	r[0].set(X3)
+	r[1].set(Y3)
+	r[2].set(Z3)
+}
+`
+
+const weierstrassTest = `
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// refPoint is an affine point for big.Int reference arithmetic, nil is the
+// point at infinity
+type refPoint []*big.Int
+
+func refAdd(p, q refPoint) refPoint {
+	if p == nil {
+		return q
+	}
+	if q == nil {
+		return p
+	}
+	l := new(big.Int)
+	if p[0].Cmp(q[0]) == 0 {
+		if l.Add(p[1], q[1]).Mod(l, pbig).Sign() == 0 {
+			return nil
+		}
+		// (3x^2 + a) / 2y
+		l.Mul(p[0], p[0]).Mul(l, big.NewInt(3)).Add(l, toBig(curveA))
+		l.Mul(l, new(big.Int).ModInverse(new(big.Int).Lsh(p[1], 1), pbig))
+	} else {
+		// (y2 - y1) / (x2 - x1)
+		l.Sub(q[1], p[1])
+		l.Mul(l, new(big.Int).ModInverse(new(big.Int).Mod(new(big.Int).Sub(q[0], p[0]), pbig), pbig))
+	}
+	l.Mod(l, pbig)
+	x := new(big.Int).Mul(l, l)
+	x.Sub(x, p[0]).Sub(x, q[0]).Mod(x, pbig)
+	y := new(big.Int).Sub(p[0], x)
+	y.Mul(y, l).Sub(y, p[1]).Mod(y, pbig)
+	return refPoint{x, y}
+}
+
+func refMul(p refPoint, e *big.Int) refPoint {
+	var r refPoint
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = refAdd(r, r)
+		if e.Bit(i) == 1 {
+			r = refAdd(r, p)
+		}
+	}
+	return r
+}
+
+func toRef(p *point) refPoint {
+	if p.isZero() {
+		return nil
+	}
+	a := newPoint()
+	pointAffine(a, p)
+	return refPoint{toBig(&a[0]), toBig(&a[1])}
+}
+
+func fromRef(t *testing.T, p refPoint) *point {
+	if p == nil {
+		return newPoint()
+	}
+	x, _ := newFieldElementFromBig(p[0])
+	y, _ := newFieldElementFromBig(p[1])
+	q, err := newPointFromAffine(x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func refEqual(p, q refPoint) bool {
+	if p == nil || q == nil {
+		return p == nil && q == nil
+	}
+	return p[0].Cmp(q[0]) == 0 && p[1].Cmp(q[1]) == 0
+}
+
+// randCurvePoint returns a random point on curve which is not necessarily in
+// the subgroup
+func randCurvePoint() *point {
+	for {
+		x, _ := randFieldElement(rand.Reader)
+		in := append([]byte{0x02}, encode(x, bigEndianMinimal)...)
+		if p, err := pointFromBytes(in); err == nil {
+			return p
+		}
+	}
+}
+
+func randScalar(t *testing.T) *big.Int {
+	e, err := rand.Int(rand.Reader, curveOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestPointGenerator(t *testing.T) {
+	g := generator()
+	if !pointIsOnCurve(g) {
+		t.Fatalf("generator is not on curve")
+	}
+	if !pointIsInSubgroup(g) {
+		t.Fatalf("generator is not in subgroup")
+	}
+	if !newPoint().equal(newPoint()) || !pointIsOnCurve(newPoint()) {
+		t.Fatalf("bad point at infinity")
+	}
+}
+
+func TestPointAdd(t *testing.T) {
+	g := toRef(generator())
+	for i := 0; i < fuz; i++ {
+		a, b := refMul(g, randScalar(t)), refMul(g, randScalar(t))
+		p, q, r := fromRef(t, a), fromRef(t, b), newPoint()
+		// scale Z to exercise projective coordinates
+		z, _ := randFieldElement(rand.Reader)
+		for j := 0; j < 3; j++ {
+			mul(&q[j], &q[j], z)
+		}
+		pointAdd(r, p, q)
+		if !refEqual(toRef(r), refAdd(a, b)) {
+			t.Fatalf("bad point addition")
+		}
+		pointSub(r, r, q)
+		if !r.equal(p) {
+			t.Fatalf("bad point subtraction")
+		}
+		pointAdd(r, p, p)
+		if !refEqual(toRef(r), refAdd(a, a)) {
+			t.Fatalf("bad addition of equal points")
+		}
+		pointDouble(r, q)
+		if !refEqual(toRef(r), refAdd(b, b)) {
+			t.Fatalf("bad point doubling")
+		}
+		pointNeg(r, p)
+		pointAdd(r, r, p)
+		if !r.isZero() {
+			t.Fatalf("p - p should be at infinity")
+		}
+		pointAdd(r, p, newPoint())
+		if !r.equal(p) {
+			t.Fatalf("p + 0 == p")
+		}
+		pointDouble(r, newPoint())
+		if !r.isZero() {
+			t.Fatalf("2 * 0 == 0")
+		}
+		// complete formulas hold for points out of the subgroup
+		c := randCurvePoint()
+		pointAdd(r, c, p)
+		if !pointIsOnCurve(r) || !refEqual(toRef(r), refAdd(toRef(c), a)) {
+			t.Fatalf("bad addition out of subgroup")
+		}
+	}
+}
+
+func TestPointMulScalar(t *testing.T) {
+	g := generator()
+	for i := 0; i < fuz; i++ {
+		e := randScalar(t)
+		r := newPoint()
+		pointMulScalar(r, g, e)
+		if !refEqual(toRef(r), refMul(toRef(g), e)) {
+			t.Fatalf("bad scalar multiplication")
+		}
+		// (n - e) * g == - e * g
+		q := newPoint()
+		pointMulScalar(q, g, new(big.Int).Sub(curveOrder, e))
+		pointAdd(q, q, r)
+		if !q.isZero() {
+			t.Fatalf("bad scalar multiplication")
+		}
+	}
+}
+
+func TestPointSubgroup(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		p, r := randCurvePoint(), newPoint()
+		pointClearCofactor(r, p)
+		if !pointIsInSubgroup(r) {
+			t.Fatalf("cofactor cleared point should be in subgroup")
+		}
+		if curveCofactor.Cmp(big.NewInt(1)) == 0 && !pointIsInSubgroup(p) {
+			t.Fatalf("points should be in subgroup when cofactor is one")
+		}
+	}
+}
+
+func TestPointEncoding(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		p := randCurvePoint()
+		z, _ := randFieldElement(rand.Reader)
+		for j := 0; j < 3; j++ {
+			mul(&p[j], &p[j], z)
+		}
+		for _, compressed := range []bool{true, false} {
+			b := pointToBytes(p, compressed)
+			if compressed && len(b) != 1+modulusByteSize || !compressed && len(b) != 1+2*modulusByteSize {
+				t.Fatalf("bad encoding size")
+			}
+			q, err := pointFromBytes(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !q.equal(p) {
+				t.Fatalf("bad point encoding, compressed: %v", compressed)
+			}
+		}
+		// sec1 y parity
+		a := toRef(p)
+		if b := pointToBytes(p, true); b[0] != 0x02+byte(a[1].Bit(0)) {
+			t.Fatalf("bad compressed point prefix")
+		}
+		if b := pointToBytes(p, false); !bytes.Equal(b[1+modulusByteSize:], padBytes(a[1].Bytes(), modulusByteSize)) {
+			t.Fatalf("bad uncompressed point")
+		}
+	}
+	if b := pointToBytes(newPoint(), true); !bytes.Equal(b, []byte{0x00}) {
+		t.Fatalf("bad encoding of infinity")
+	}
+	if p, err := pointFromBytes([]byte{0x00}); err != nil || !p.isZero() {
+		t.Fatalf("bad decoding of infinity")
+	}
+}
+
+func TestPointEncodingErrors(t *testing.T) {
+	b := pointToBytes(generator(), false)
+	for _, in := range [][]byte{
+		{},
+		{0x00, 0x00},
+		b[:len(b)-1],
+		append(append([]byte{}, b...), 0x00),
+		append([]byte{0x05}, b[1:]...),
+		append([]byte{0x02}, b[1:]...),
+	} {
+		if _, err := pointFromBytes(in); err == nil {
+			t.Fatalf("bad input should be rejected %x", in)
+		}
+	}
+	// off curve
+	c := append([]byte{}, b...)
+	c[len(c)-1] ^= 1
+	if _, err := pointFromBytes(c); err == nil {
+		t.Fatalf("point not on curve should be rejected")
+	}
+	// x not smaller than modulus
+	c = append([]byte{0x02}, padBytes(pbig.Bytes(), modulusByteSize)...)
+	if _, err := pointFromBytes(c); err == nil {
+		t.Fatalf("non canonical x should be rejected")
+	}
+	// x with no square rhs
+	for {
+		x, _ := randFieldElement(rand.Reader)
+		in := append([]byte{0x02}, encode(x, bigEndianMinimal)...)
+		y, rhs := newFieldElement(), newFieldElement()
+		mul(rhs, x, x)
+		add(rhs, rhs, curveA)
+		mul(rhs, rhs, x)
+		add(rhs, rhs, curveB)
+		if sqrtRatio(y, rhs, one) {
+			continue
+		}
+		if _, err := pointFromBytes(in); err == nil {
+			t.Fatalf("x with no corresponding y should be rejected")
+		}
+		break
+	}
+}
+`
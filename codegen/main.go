@@ -3,6 +3,8 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io/ioutil"
+	"math/big"
 	"os"
 	"path/filepath"
 
@@ -25,12 +27,32 @@ D : Generate all implemented backends only
 	var modulus string
 	var opt string
 	var arch string
+	var sswu string
+	var ell2 string
+	var encoding string
+	var weierstrass string
+	var edwards string
+	var pairing string
+	var glv string
+	var emulate int
+	var peephole bool
+	var report string
 
 	flag.StringVar(&output, "output", "tmp", "output directory")
 	flag.IntVar(&bitSize, "bit", 0, "bit size of the field")
 	flag.StringVar(&modulus, "modulus", "", "bit size of the field")
 	flag.StringVar(&opt, "opt", "", options)
 	flag.StringVar(&arch, "arch", "", "")
+	flag.StringVar(&sswu, "sswu", "", "SSWU map constants A,B,Z for fixed modulus fields")
+	flag.StringVar(&ell2, "ell2", "", "Elligator 2 map constants J,K,Z for fixed modulus fields")
+	flag.StringVar(&encoding, "encoding", "", "binary marshaling encoding of fixed modulus fields: be, be-minimal, le or le-minimal")
+	flag.StringVar(&weierstrass, "weierstrass", "", "short weierstrass curve parameters a,b,gx,gy,n,h for fixed modulus fields")
+	flag.StringVar(&edwards, "edwards", "", "twisted edwards curve parameters a,d,gx,gy,n,h for fixed modulus fields")
+	flag.StringVar(&pairing, "pairing", "", "BN or BLS12 curve given as bn,x or bls12,x for optimal ate pairing, modulus is derived from x")
+	flag.StringVar(&glv, "glv", "", "endomorphism eigenvalue lambda for GLV scalar decomposition, modulus is the group order")
+	flag.IntVar(&emulate, "emulate", 0, "verify generated assembly in x86 emulator with given # of random inputs per kernel")
+	flag.BoolVar(&peephole, "peephole", false, "run peephole optimiser and scheduler over generated kernels and print instruction count report")
+	flag.StringVar(&report, "report", "", "write register allocation and spill report of generated kernels as JSON to given file")
 	flag.Parse()
 
 	output = filepath.Clean(output)
@@ -49,21 +71,69 @@ D : Generate all implemented backends only
 		}
 	}
 
+	if (weierstrass != "" || edwards != "" || pairing != "" || glv != "") && opt != "A" {
+		panic("curve parameters require option A")
+	}
+	if pairing != "" {
+		if weierstrass != "" {
+			panic("pairing generates its own short weierstrass curve")
+		}
+		derived, err := gocode.PairingModulus(pairing)
+		if err != nil {
+			panic(err)
+		}
+		if modulus == "" {
+			modulus = derived
+		}
+		m, _ := new(big.Int).SetString(modulus, 0)
+		d, _ := new(big.Int).SetString(derived, 0)
+		if m == nil || m.Cmp(d) != 0 {
+			panic("modulus does not match pairing parameters")
+		}
+	}
+
+	if peephole {
+		x86.EnablePeephole()
+	}
+	if report != "" {
+		x86.EnableAllocReport()
+	}
 	var fixedmod bool
 	switch opt {
 	case "A":
-		err := gocode.GenField(output, bitSize, modulus, opt)
+		err := gocode.GenField(output, bitSize, modulus, opt, sswu, ell2, encoding)
 		if err != nil {
 			panic(err)
 		}
+		if weierstrass != "" {
+			if err := gocode.GenWeierstrass(output, modulus, weierstrass); err != nil {
+				panic(err)
+			}
+		}
+		if edwards != "" {
+			if err := gocode.GenEdwards(output, modulus, edwards); err != nil {
+				panic(err)
+			}
+		}
+		if glv != "" {
+			if err := gocode.GenGLV(output, modulus, glv); err != nil {
+				panic(err)
+			}
+		}
+		if pairing != "" {
+			if err := gocode.GenPairing(output, pairing); err != nil {
+				panic(err)
+			}
+		}
 		fixedmod := true
 		single := true
 		err = x86.GenX86(output, bitSize, arch, fixedmod, single)
 		if err != nil {
 			panic(err)
 		}
+		emulateKernels(filepath.Join(output, "arithmetic.s"), bitSize/64, emulate)
 	case "B":
-		err := gocode.GenField(output, bitSize, modulus, opt)
+		err := gocode.GenField(output, bitSize, modulus, opt, sswu, ell2, encoding)
 		if err != nil {
 			panic(err)
 		}
@@ -73,8 +143,9 @@ D : Generate all implemented backends only
 		if err != nil {
 			panic(err)
 		}
+		emulateKernels(filepath.Join(output, "arithmetic.s"), bitSize/64, emulate)
 	case "C":
-		err := gocode.GenField(output, bitSize, modulus, opt)
+		err := gocode.GenField(output, bitSize, modulus, opt, sswu, ell2, encoding)
 		if err != nil {
 			panic(err)
 		}
@@ -84,6 +155,7 @@ D : Generate all implemented backends only
 		if err != nil {
 			panic(err)
 		}
+		emulateKernels(filepath.Join(output, "arithmetic.s"), bitSize/64, emulate)
 	case "D":
 		var supportedLimbSizes = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
 		gocode.GenDeclerationsForMultiple(output, supportedLimbSizes)
@@ -91,7 +163,30 @@ D : Generate all implemented backends only
 		if err != nil {
 			panic(err)
 		}
+		emulateKernels(filepath.Join(output, "x86_arithmetic.s"), 1, emulate)
 	default:
 		panic(fmt.Sprintf("no such option %s\n" + opt))
 	}
+	if peephole {
+		fmt.Print(x86.PeepholeReport())
+	}
+	if report != "" {
+		b, err := x86.AllocReport()
+		if err != nil {
+			panic(err)
+		}
+		if err := ioutil.WriteFile(report, b, 0600); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func emulateKernels(file string, limbSize int, iter int) {
+	if iter == 0 {
+		return
+	}
+	if err := x86.VerifyKernels(file, limbSize, iter); err != nil {
+		panic(err)
+	}
+	fmt.Printf("kernels in %s are verified\n", file)
 }
@@ -1,6 +1,9 @@
 #!/bin/bash -e
-N_FUZZ=5
+N_ITER=5
 GEN_DIR='./generated'
+# constant time tests keep the default threshold, more measurements
+# than usual keep them stable on a shared machine
+CT_FLAGS='-ct 20000'
 
 field_sizes=(\
 128 192 256 320 384 448 512 \
@@ -16,12 +19,12 @@ do
   echo 'B' $BIT_SIZE $ARCH
   go run . -output $GEN_DIR -opt B -bit $BIT_SIZE -arch $ARCH
   goreturns -w -p $GEN_DIR
-  go test ./generated -fuzz $N_FUZZ
+  go test ./generated -iter $N_ITER $CT_FLAGS
   # option C, non fixed modulus
   echo 'C' $BIT_SIZE $ARCH
   go run . -output $GEN_DIR -opt C -bit $BIT_SIZE -arch $ARCH
   goreturns -w -p $GEN_DIR
-  go test ./generated -fuzz $N_FUZZ
+  go test ./generated -iter $N_ITER $CT_FLAGS
 done
 
 # non ADX backend
@@ -31,10 +34,83 @@ do
   echo 'B' $BIT_SIZE $ARCH fixed
   go run . -output $GEN_DIR -opt B -bit $BIT_SIZE
   goreturns -w -p $GEN_DIR
-  go test ./generated -fuzz $N_FUZZ
+  go test ./generated -iter $N_ITER $CT_FLAGS
   # option C, non fixed modulus
   echo 'C' $BIT_SIZE $ARCH fixed
   go run . -output $GEN_DIR -opt C -bit $BIT_SIZE
   goreturns -w -p $GEN_DIR
-  go test ./generated -fuzz $N_FUZZ
+  go test ./generated -iter $N_ITER $CT_FLAGS
 done
+
+# pairing of BN254 and BLS12-381 with external known answers
+go run . -output $GEN_DIR -bit 256 -opt A -pairing bn,4965661367192848881
+goreturns -w -p $GEN_DIR
+go test ./generated -iter $N_ITER
+go run . -output $GEN_DIR -bit 384 -opt A -pairing bls12,-0xd201000000010000
+goreturns -w -p $GEN_DIR
+go test ./generated -iter $N_ITER
+
+# curve specific suites of option A with known answers, output is
+# cleared before each run so that suites of previous curves do not linger
+P256=0xffffffff00000001000000000000000000000000ffffffffffffffffffffffff
+P25519=0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed
+BLS12_381=0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab
+SECP256K1=0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f
+SECP256K1_GX=0x79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798
+SECP256K1_GY=0x483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8
+SECP256K1_N=0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141
+ED25519_D=0x52036cee2b6ffe738cc740797779e89800700a4d4141d8ab75eb4dca135978a3
+ED25519_GX=0x216936d3cd6e53fec0a4e231fdd6dc5c692cc7609525a7b2c9562d608f25d51a
+ED25519_GY=0x6666666666666666666666666666666666666666666666666666666666666658
+ED25519_L=0x1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed
+BANDERSNATCH_D=0x6389c12633c267cbc66e3bf86be3b6d8cb66677177e54f92b369f2f5188d58e7
+BANDERSNATCH_GX=0x29c132cc2c0b34c5743711777bbe42f32b79c022ad998465e1e71866a252ae18
+BANDERSNATCH_GY=0x2a6c669eda123e0f157d8b50badcd586358cad81eee464605e3167b6cc974166
+BANDERSNATCH_N=0x1cfb69d4ca675f520cce760202687600ff8f87007419047174fd06b52876e7e1
+BN254_R=0x30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001
+BLS12_381_R=0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001
+
+# hash to field and map to curve of RFC 9380
+rm -rf $GEN_DIR
+go run . -output $GEN_DIR -bit 256 -opt A -modulus $P256 -sswu -3,0x5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b,-10
+goreturns -w -p $GEN_DIR
+go test ./generated -iter $N_ITER
+rm -rf $GEN_DIR
+go run . -output $GEN_DIR -bit 256 -opt A -modulus $P25519 -ell2 486662,1,2
+goreturns -w -p $GEN_DIR
+go test ./generated -iter $N_ITER
+rm -rf $GEN_DIR
+go run . -output $GEN_DIR -bit 384 -opt A -modulus $BLS12_381
+goreturns -w -p $GEN_DIR
+go test ./generated -iter $N_ITER
+# secp256k1 group, hash to field of RFC 9380
+rm -rf $GEN_DIR
+go run . -output $GEN_DIR -bit 256 -opt A -modulus $SECP256K1 -weierstrass 0,7,$SECP256K1_GX,$SECP256K1_GY,$SECP256K1_N,1
+goreturns -w -p $GEN_DIR
+go test ./generated -iter $N_ITER
+# edwards25519 group with RFC 8032 vectors
+rm -rf $GEN_DIR
+go run . -output $GEN_DIR -bit 256 -opt A -modulus $P25519 -edwards -1,$ED25519_D,$ED25519_GX,$ED25519_GY,$ED25519_L,8
+goreturns -w -p $GEN_DIR
+go test ./generated -iter $N_ITER
+# Bandersnatch group with dedicated addition
+rm -rf $GEN_DIR
+go run . -output $GEN_DIR -bit 256 -opt A -modulus $BLS12_381_R -edwards -5,$BANDERSNATCH_D,$BANDERSNATCH_GX,$BANDERSNATCH_GY,$BANDERSNATCH_N,4
+goreturns -w -p $GEN_DIR
+go test ./generated -iter $N_ITER
+# GLV decomposition in scalar fields of secp256k1, BN254 and BLS12-381
+rm -rf $GEN_DIR
+go run . -output $GEN_DIR -bit 256 -opt A -modulus $SECP256K1_N -glv 0x5363ad4cc05c30e0a5261c028812645a122e22ea20816678df02967c1b23bd72
+goreturns -w -p $GEN_DIR
+go test ./generated -iter $N_ITER
+rm -rf $GEN_DIR
+go run . -output $GEN_DIR -bit 256 -opt A -modulus $BN254_R -glv 0xb3c4d79d41a917585bfc41088d8daaa78b17ea66b99c90dd
+goreturns -w -p $GEN_DIR
+go test ./generated -iter $N_ITER
+rm -rf $GEN_DIR
+go run . -output $GEN_DIR -bit 256 -opt A -modulus $BLS12_381_R -glv 0xac45a4010001a40200000000ffffffff
+goreturns -w -p $GEN_DIR
+go test ./generated -iter $N_ITER
+
+# parallel helpers and MSM of generic package under race detector
+go test -race -run 'Parallel|WorkerPool|MSM' ../generic/
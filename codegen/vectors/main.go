@@ -0,0 +1,230 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+)
+
+// vectors writes deterministic known answer test vectors of field arithmetic
+// for a modulus. Expected values are computed with math/big only so that
+// vectors can be checked against other implementations. Generated field tests
+// replay them with -vectors flag.
+
+type vector struct {
+	Op  string   `json:"op"`
+	In  []string `json:"in"`
+	Out *string  `json:"out"`
+}
+
+type vectorFile struct {
+	Modulus  string   `json:"modulus"`
+	ByteSize int      `json:"byte_size"`
+	Seed     string   `json:"seed"`
+	Vectors  []vector `json:"vectors"`
+}
+
+type generator struct {
+	p        *big.Int
+	byteSize int
+	seed     string
+	vectors  []vector
+}
+
+func hexBig(a *big.Int) string {
+	return fmt.Sprintf("0x%x", a)
+}
+
+func hexBytes(in []byte) string {
+	return fmt.Sprintf("0x%x", in)
+}
+
+func padBytes(in []byte, size int) []byte {
+	out := make([]byte, size)
+	copy(out[size-len(in):], in)
+	return out
+}
+
+// stream expands seed, op and index into n pseudo random bytes
+func (g *generator) stream(op string, i int, n int) []byte {
+	var out []byte
+	for ctr := uint32(0); len(out) < n; ctr++ {
+		h := sha256.New()
+		h.Write([]byte(g.seed))
+		h.Write([]byte{0})
+		h.Write([]byte(op))
+		var b [12]byte
+		binary.BigEndian.PutUint64(b[:8], uint64(i))
+		binary.BigEndian.PutUint32(b[8:], ctr)
+		h.Write(b[:])
+		out = h.Sum(out)
+	}
+	return out[:n]
+}
+
+// element returns a deterministic field element with 128 bits of extra
+// randomness before reduction
+func (g *generator) element(op string, i int) *big.Int {
+	v := new(big.Int).SetBytes(g.stream(op, i, g.byteSize+16))
+	return v.Mod(v, g.p)
+}
+
+// edges returns zero, one, p - 1 and all ones limbs reduced into the field
+func (g *generator) edges() []*big.Int {
+	one := big.NewInt(1)
+	ones := new(big.Int).Sub(new(big.Int).Lsh(one, uint(g.byteSize*8)), one)
+	return []*big.Int{new(big.Int), big.NewInt(1), new(big.Int).Sub(g.p, one), ones.Mod(ones, g.p)}
+}
+
+// samples returns edge cases followed by n deterministic elements
+func (g *generator) samples(op string, n int) []*big.Int {
+	samples := g.edges()
+	for i := 0; i < n; i++ {
+		samples = append(samples, g.element(op, i))
+	}
+	return samples
+}
+
+func (g *generator) add(op string, out *big.Int, in ...string) {
+	v := vector{Op: op, In: in}
+	if out != nil {
+		s := hexBig(out)
+		v.Out = &s
+	}
+	g.vectors = append(g.vectors, v)
+}
+
+func (g *generator) unary(op string, n int, f func(a *big.Int) *big.Int) {
+	for _, a := range g.samples(op, n) {
+		g.add(op, f(a), hexBig(a))
+	}
+}
+
+func (g *generator) binary(op string, n int, f func(a, b *big.Int) *big.Int) {
+	edges := g.edges()
+	for _, a := range edges {
+		for _, b := range edges {
+			g.add(op, f(a, b), hexBig(a), hexBig(b))
+		}
+	}
+	for i := 0; i < n; i++ {
+		a, b := g.element(op, 2*i), g.element(op, 2*i+1)
+		g.add(op, f(a, b), hexBig(a), hexBig(b))
+	}
+}
+
+func (g *generator) generate(n int) {
+	p := g.p
+	g.binary("add", n, func(a, b *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Add(a, b), p)
+	})
+	g.binary("sub", n, func(a, b *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Sub(a, b), p)
+	})
+	g.binary("mul", n, func(a, b *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Mul(a, b), p)
+	})
+	g.unary("square", n, func(a *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Mul(a, a), p)
+	})
+	// exponents are edge cases, p - 2, p and random exponents of field size
+	exponents := append(g.edges(), new(big.Int).Sub(p, big.NewInt(2)), new(big.Int).Set(p))
+	for i, a := range g.samples("exp", n) {
+		r := new(big.Int).SetBytes(g.stream("exp_e", i, g.byteSize))
+		for _, e := range append(exponents[:len(exponents):len(exponents)], r) {
+			g.add("exp", new(big.Int).Exp(a, e, p), hexBig(a), hexBig(e))
+		}
+	}
+	// inverse of zero is zero
+	g.unary("inverse", n, func(a *big.Int) *big.Int {
+		if a.Sign() == 0 {
+			return new(big.Int)
+		}
+		return new(big.Int).ModInverse(a, p)
+	})
+	// square root is the even one of two roots and null for non squares.
+	// squares of samples are included so that half of vectors have roots
+	g.unary("sqrt", n, sqrt(p))
+	for i := 0; i < n; i++ {
+		a := g.element("sqrt_square", i)
+		a.Mul(a, a).Mod(a, p)
+		g.add("sqrt", sqrt(p)(a), hexBig(a))
+	}
+	// serialization of big endian bytes, out is null for rejected inputs
+	var inputs [][]byte
+	for _, a := range g.edges() {
+		inputs = append(inputs, padBytes(a.Bytes(), g.byteSize))
+	}
+	ones := make([]byte, g.byteSize)
+	for i := range ones {
+		ones[i] = 0xff
+	}
+	inputs = append(inputs, ones, padBytes(p.Bytes(), g.byteSize), []byte{1}, padBytes([]byte{1}, g.byteSize+1))
+	for i := 0; i < n; i++ {
+		inputs = append(inputs, padBytes(g.element("serialization", i).Bytes(), g.byteSize))
+	}
+	for _, in := range inputs {
+		var out *big.Int
+		if v := new(big.Int).SetBytes(in); len(in) == g.byteSize && v.Cmp(p) < 0 {
+			out = v
+		}
+		g.add("serialization", out, hexBytes(in))
+	}
+}
+
+func sqrt(p *big.Int) func(a *big.Int) *big.Int {
+	return func(a *big.Int) *big.Int {
+		r := new(big.Int).ModSqrt(a, p)
+		if r == nil {
+			return nil
+		}
+		if r.Bit(0) == 1 {
+			r.Sub(p, r)
+		}
+		return r
+	}
+}
+
+func main() {
+	var modulus string
+	var bitSize int
+	var n int
+	var seed string
+	var out string
+
+	flag.StringVar(&modulus, "modulus", "", "prime modulus in hex with 0x prefix")
+	flag.IntVar(&bitSize, "bit", 0, "bit size of the field, defaults to modulus size rounded up to 64 bits")
+	flag.IntVar(&n, "n", 16, "# of random vectors per operation in addition to edge cases")
+	flag.StringVar(&seed, "seed", "fp known answer vectors", "seed of deterministic inputs")
+	flag.StringVar(&out, "out", "", "output file, defaults to stdout")
+	flag.Parse()
+
+	p, ok := new(big.Int).SetString(modulus, 0)
+	if !ok || p.Cmp(big.NewInt(3)) < 0 || !p.ProbablyPrime(20) {
+		panic(fmt.Sprintf("bad modulus %s", modulus))
+	}
+	if bitSize == 0 {
+		bitSize = (p.BitLen() + 63) / 64 * 64
+	}
+	if bitSize%64 != 0 || bitSize < p.BitLen() {
+		panic(fmt.Sprintf("bad bit size %d", bitSize))
+	}
+	g := &generator{p: p, byteSize: bitSize / 8, seed: seed}
+	g.generate(n)
+	b, err := json.MarshalIndent(vectorFile{hexBig(p), g.byteSize, seed, g.vectors}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	if out == "" {
+		os.Stdout.Write(append(b, '\n'))
+		return
+	}
+	if err := ioutil.WriteFile(out, append(b, '\n'), 0600); err != nil {
+		panic(err)
+	}
+}
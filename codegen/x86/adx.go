@@ -3,7 +3,7 @@ package x86
 import (
 	"fmt"
 
-	. "github.com/mmcloughlin/avo/build"
+	"github.com/mmcloughlin/avo/attr"
 	. "github.com/mmcloughlin/avo/operand"
 )
 
@@ -15,9 +15,9 @@ func genMontMulADX(size int, fixedmod bool, single bool) {
 		modulusName = fmt.Sprintf("%s%d", modulusName, size)
 	}
 	if fixedmod {
-		TEXT(funcName, NOSPLIT, fmt.Sprintf("func(c, a, b *[%d]uint64)", size))
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, b *[%d]uint64)", size))
 	} else {
-		TEXT(funcName, NOSPLIT, fmt.Sprintf("func(c, a, b, p *[%d]uint64, inp uint64)", size))
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, b, p *[%d]uint64, inp uint64)", size))
 	}
 	commentHeader("inputs")
 	tape := newTape(_NO_SWAP, ax.s, bx.s, dx.s)
@@ -163,7 +163,7 @@ func modularReduction(tape *tape, W *repr, lastBit *limb) (*repr, *repr) {
 			i != 0,
 		)
 	}
-	SBBQ(U32(0), lastBit.s)
+	ctx.SBBQ(U32(0), lastBit.s)
 	return T, Red
 }
 
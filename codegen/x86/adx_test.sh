@@ -1,5 +1,5 @@
 #!/bin/bash -e
-N_FUZZ=100
+N_ITER=100
 
 
 limb_sizes=(2 3 4 5 6 7 8 9 10 11 12 13 14 15 16)
@@ -8,14 +8,14 @@ for LIMB_SIZE in "${limb_sizes[@]}"
 do
   echo fixedmod $LIMB_SIZE
   go run ./test/ -limb $LIMB_SIZE -fixed
-  go test ./debug/ -run Hard -fuzz $N_FUZZ -v
+  go test ./debug/ -run Hard -iter $N_ITER -v
 done
 
 for LIMB_SIZE in "${limb_sizes[@]}"
 do
   echo $LIMB_SIZE
   go run ./test/ -limb $LIMB_SIZE
-  go test ./debug/ -run Hard -fuzz $N_FUZZ -v
+  go test ./debug/ -run Hard -iter $N_ITER -v
 done
 
 
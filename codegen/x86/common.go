@@ -3,7 +3,7 @@ package x86
 import (
 	"fmt"
 
-	. "github.com/mmcloughlin/avo/build"
+	"github.com/mmcloughlin/avo/attr"
 	. "github.com/mmcloughlin/avo/operand"
 	. "github.com/mmcloughlin/avo/reg"
 )
@@ -13,15 +13,15 @@ func generateDiv2(size int, single bool) {
 	if !single {
 		funcName = fmt.Sprintf("%s_%d", funcName, size)
 	}
-	TEXT(funcName, NOSPLIT, fmt.Sprintf("func(a *[%d]uint64)", size))
+	text(funcName, attr.NOSPLIT, fmt.Sprintf("func(a *[%d]uint64)", size))
 	tape := newTape(nil)
 	A := tape.newReprAtParam(size, "a", tape.di(), 0)
 	tape.ax().xorself()
 	A.previous()
 	for i := 0; i < size; i++ {
-		RCRQ(Imm(1), A.previous().s)
+		ctx.RCRQ(Imm(1), A.previous().s)
 	}
-	RET()
+	ctx.RET()
 }
 
 func generateMul2(size int, single bool) {
@@ -29,15 +29,15 @@ func generateMul2(size int, single bool) {
 	if !single {
 		funcName = fmt.Sprintf("%s_%d", funcName, size)
 	}
-	TEXT(funcName, NOSPLIT, fmt.Sprintf("func(a *[%d]uint64) uint64", size))
+	text(funcName, attr.NOSPLIT, fmt.Sprintf("func(a *[%d]uint64) uint64", size))
 	tape := newTape(nil)
 	A := tape.newReprAtParam(size, "a", tape.di(), 0)
 	tape.ax().xorself()
 	for i := 0; i < size; i++ {
-		RCLQ(Imm(1), A.next().s)
+		ctx.RCLQ(Imm(1), A.next().s)
 	}
-	RCLQ(Imm(1), RAX)
-	Store(RAX, ReturnIndex(0))
+	ctx.RCLQ(Imm(1), RAX)
+	ctx.Store(RAX, ctx.ReturnIndex(0))
 	tape.ret()
 }
 
@@ -46,21 +46,21 @@ func generateEq(size int, single bool) {
 	if !single {
 		funcName = fmt.Sprintf("%s%d", funcName, size)
 	}
-	TEXT(funcName, NOSPLIT, fmt.Sprintf("func(a, b *[%d]uint64) bool", size))
+	text(funcName, attr.NOSPLIT, fmt.Sprintf("func(a, b *[%d]uint64) bool", size))
 	tape := newTape(nil)
 	A := tape.newReprAtParam(size, "a", tape.di(), 0)
 	B := tape.newReprAtParam(size, "b", tape.si(), 0)
 	r := NewParamAddr("ret", 16)
 	t := newLimb(R8)
-	MOVB(U8(0), r)
+	ctx.MOVB(U8(0), r)
 	for i := 0; i < size; i++ {
 		A.next().moveTo(t, _NO_ASSIGN)
 		B.next().cmp(t)
-		JNE(LabelRef("ret"))
+		ctx.JNE(LabelRef("ret"))
 	}
-	MOVB(U8(1), r)
-	Label("ret")
-	RET()
+	ctx.MOVB(U8(1), r)
+	ctx.Label("ret")
+	ctx.RET()
 }
 
 func generateCopy(size int, single bool) {
@@ -68,7 +68,7 @@ func generateCopy(size int, single bool) {
 	if !single {
 		funcName = fmt.Sprintf("%s%d", funcName, size)
 	}
-	TEXT(funcName, NOSPLIT, fmt.Sprintf("func(dst, src *[%d]uint64)", size))
+	text(funcName, attr.NOSPLIT, fmt.Sprintf("func(dst, src *[%d]uint64)", size))
 	tape := newTape(nil)
 	A := tape.newReprAtParam(size, "dst", tape.di(), 0)
 	B := tape.newReprAtParam(size, "src", tape.si(), 0)
@@ -77,7 +77,7 @@ func generateCopy(size int, single bool) {
 		B.next().moveTo(t, _NO_ASSIGN)
 		A.next().load(t, nil)
 	}
-	RET()
+	ctx.RET()
 }
 
 func generateCmp(size int, single bool) {
@@ -85,7 +85,7 @@ func generateCmp(size int, single bool) {
 	if !single {
 		funcName = fmt.Sprintf("%s%d", funcName, size)
 	}
-	TEXT(funcName, NOSPLIT, fmt.Sprintf("func(a, b *[%d]uint64) int8", size))
+	text(funcName, attr.NOSPLIT, fmt.Sprintf("func(a, b *[%d]uint64) int8", size))
 	tape := newTape(nil)
 	A := tape.newReprAtParam(size, "a", tape.di(), 0)
 	B := tape.newReprAtParam(size, "b", tape.si(), 0)
@@ -96,18 +96,18 @@ func generateCmp(size int, single bool) {
 	for i := 0; i < size; i++ {
 		A.previous().moveTo(t, _NO_ASSIGN)
 		B.previous().cmp(t)
-		JB(LabelRef("gt"))
-		JA(LabelRef("lt"))
-	}
-	MOVB(U8(0), r)
-	JMP(LabelRef("ret"))
-	Label("gt")
-	MOVB(U8(1), r)
-	JMP(LabelRef("ret"))
-	Label("lt")
-	MOVB(U8(0xff), r)
-	Label("ret")
-	RET()
+		ctx.JB(LabelRef("gt"))
+		ctx.JA(LabelRef("lt"))
+	}
+	ctx.MOVB(U8(0), r)
+	ctx.JMP(LabelRef("ret"))
+	ctx.Label("gt")
+	ctx.MOVB(U8(1), r)
+	ctx.JMP(LabelRef("ret"))
+	ctx.Label("lt")
+	ctx.MOVB(U8(0xff), r)
+	ctx.Label("ret")
+	ctx.RET()
 }
 
 func generateAdd(size int, fixedmod bool, single bool) {
@@ -116,17 +116,17 @@ func generateAdd(size int, fixedmod bool, single bool) {
 		funcName = fmt.Sprintf("%s%d", funcName, size)
 	}
 	if fixedmod {
-		TEXT(funcName, NOSPLIT, fmt.Sprintf("func(c, a, b *[%d]uint64)", size))
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, b *[%d]uint64)", size))
 	} else {
-		TEXT(funcName, NOSPLIT, fmt.Sprintf("func(c, a, b, p *[%d]uint64)", size))
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, b, p *[%d]uint64)", size))
 	}
-	Commentf("|")
+	ctx.Commentf("|")
 	tape := newTape(RBX, RAX)
 	A := tape.newReprAtParam(size, "a", tape.di(), 0)
 	B := tape.newReprAtParam(size, "b", tape.si(), 0)
 	C_sum := tape.newReprAlloc(size).setSwap(tape.bx())
 	tape.ax().xorself()
-	Commentf("|")
+	ctx.Commentf("|")
 	for i := 0; i < size; i++ {
 		C_sum.next().loadAdd(
 			A.next(),
@@ -136,7 +136,7 @@ func generateAdd(size int, fixedmod bool, single bool) {
 	}
 	reduceAdded(tape, C_sum, fixedmod, single)
 	tape.ret()
-	RET()
+	ctx.RET()
 }
 
 func generateAddNoCar(size int, single bool) {
@@ -144,14 +144,14 @@ func generateAddNoCar(size int, single bool) {
 	if !single {
 		funcName = fmt.Sprintf("%s%d", funcName, size)
 	}
-	TEXT(funcName, NOSPLIT, fmt.Sprintf("func(a, b *[%d]uint64) uint64", size))
-	Commentf("|")
+	text(funcName, attr.NOSPLIT, fmt.Sprintf("func(a, b *[%d]uint64) uint64", size))
+	ctx.Commentf("|")
 	tape := newTape(RBX, RAX)
 	A := tape.newReprAtParam(size, "a", tape.di(), 0)
 	B := tape.newReprAtParam(size, "b", tape.si(), 0)
 	C_sum := tape.newReprAlloc(size).setSwap(tape.bx())
-	MOVQ(RAX, RAX)
-	Commentf("|")
+	tape.ax().xorself()
+	ctx.Commentf("|")
 	for i := 0; i < size; i++ {
 		C_sum.next().loadAdd(
 			A.next(),
@@ -159,14 +159,14 @@ func generateAddNoCar(size int, single bool) {
 			i != 0,
 		)
 	}
-	ADCQ(Imm(0), RAX)
-	Commentf("|")
+	ctx.ADCQ(Imm(0), RAX)
+	ctx.Commentf("|")
 	for i := 0; i < size; i++ {
 		C_sum.next().moveTo(A.next(), _NO_ASSIGN)
 	}
-	Store(RAX, ReturnIndex(0))
+	ctx.Store(RAX, ctx.ReturnIndex(0))
 	tape.ret()
-	RET()
+	ctx.RET()
 }
 
 func generateDouble(size int, fixedmod bool, single bool) {
@@ -175,11 +175,11 @@ func generateDouble(size int, fixedmod bool, single bool) {
 		funcName = fmt.Sprintf("%s%d", funcName, size)
 	}
 	if fixedmod {
-		TEXT(funcName, NOSPLIT, fmt.Sprintf("func(c, a *[%d]uint64)", size))
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a *[%d]uint64)", size))
 	} else {
-		TEXT(funcName, NOSPLIT, fmt.Sprintf("func(c, a, p *[%d]uint64)", size))
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, p *[%d]uint64)", size))
 	}
-	Commentf("|")
+	ctx.Commentf("|")
 	tape := newTape(RBX, RAX)
 	if !fixedmod {
 		tape.alloc(tape.si())
@@ -192,7 +192,7 @@ func generateDouble(size int, fixedmod bool, single bool) {
 	}
 	reduceAdded(tape, C_sum, fixedmod, single)
 	tape.ret()
-	RET()
+	ctx.RET()
 }
 
 func reduceAdded(tape *tape, C_sum *repr, fixedmod bool, single bool) {
@@ -201,8 +201,8 @@ func reduceAdded(tape *tape, C_sum *repr, fixedmod bool, single bool) {
 	if !single {
 		modulusName = fmt.Sprintf("%s%d", modulusName, size)
 	}
-	ADCQ(Imm(0), RAX)
-	Commentf("|")
+	ctx.ADCQ(Imm(0), RAX)
+	ctx.Commentf("|")
 	var modulus *repr
 	if fixedmod {
 		modulus = tape.newReprAtMemory(size, NewDataAddr(Symbol{Name: modulusName}, 0), 0)
@@ -214,8 +214,8 @@ func reduceAdded(tape *tape, C_sum *repr, fixedmod bool, single bool) {
 	for i := 0; i < size; i++ {
 		C_red.next().loadSubSafe(C_sum.next(), modulus.next(), i != 0)
 	}
-	SBBQ(Imm(0), RAX)
-	Commentf("|")
+	ctx.SBBQ(Imm(0), RAX)
+	ctx.Commentf("|")
 	C := tape.newReprAtParam(size, "c", tape.di(), 0)
 	for i := 0; i < size; i++ {
 		C_red.next().moveIfNotCFAux(C_sum.next(), C.next())
@@ -230,11 +230,11 @@ func generateSub(size int, fixedmod bool, single bool) {
 		modulusName = fmt.Sprintf("%s%d", modulusName, size)
 	}
 	if fixedmod {
-		TEXT(funcName, NOSPLIT, fmt.Sprintf("func(c, a, b *[%d]uint64)", size))
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, b *[%d]uint64)", size))
 	} else {
-		TEXT(funcName, NOSPLIT, fmt.Sprintf("func(c, a, b, p *[%d]uint64)", size))
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, b, p *[%d]uint64)", size))
 	}
-	Commentf("|")
+	ctx.Commentf("|")
 	tape := newTape(RBX, RAX)
 	A := tape.newReprAtParam(size, "a", tape.di(), 0)
 	B := tape.newReprAtParam(size, "b", tape.si(), 0)
@@ -247,7 +247,7 @@ func generateSub(size int, fixedmod bool, single bool) {
 	for i := 0; i < size; i++ {
 		C_sub.next().loadSub(A.next(), B.next(), i != 0)
 	}
-	Commentf("|")
+	ctx.Commentf("|")
 	var modulus *repr
 	if fixedmod {
 		tape.free(B.base)
@@ -259,13 +259,13 @@ func generateSub(size int, fixedmod bool, single bool) {
 	for i := 0; i < size; i++ {
 		zero.next().moveIfNotCFAux(modulus.next(), C_mod.next())
 	}
-	Commentf("|")
+	ctx.Commentf("|")
 	C := tape.newReprAtParam(size, "c", tape.di(), 0).setSwap(tape.bx())
 	for i := 0; i < size; i++ {
 		C.next().loadAdd(C_sub.next(), C_mod.next(), i != 0)
 	}
 	tape.ret()
-	RET()
+	ctx.RET()
 }
 
 func generateSubNoCar(size int, single bool) {
@@ -273,25 +273,25 @@ func generateSubNoCar(size int, single bool) {
 	if !single {
 		funcName = fmt.Sprintf("%s%d", funcName, size)
 	}
-	TEXT(funcName, NOSPLIT, fmt.Sprintf("func(a, b *[%d]uint64) uint64", size))
-	Commentf("|")
+	text(funcName, attr.NOSPLIT, fmt.Sprintf("func(a, b *[%d]uint64) uint64", size))
+	ctx.Commentf("|")
 	tape := newTape(RBX, RAX)
 	A := tape.newReprAtParam(size, "a", tape.di(), 0)
 	B := tape.newReprAtParam(size, "b", tape.si(), 0)
 	C_sum := tape.newReprAlloc(size).setSwap(tape.bx())
 	tape.ax().xorself()
-	Commentf("|")
+	ctx.Commentf("|")
 	for i := 0; i < size; i++ {
 		C_sum.next().loadSub(A.next(), B.next(), i != 0)
 	}
-	ADCQ(Imm(0), RAX)
-	Commentf("|")
+	ctx.ADCQ(Imm(0), RAX)
+	ctx.Commentf("|")
 	for i := 0; i < size; i++ {
 		C_sum.next().moveTo(A.next(), _NO_ASSIGN)
 	}
-	Store(RAX, ReturnIndex(0))
+	ctx.Store(RAX, ctx.ReturnIndex(0))
 	tape.ret()
-	RET()
+	ctx.RET()
 }
 
 func generateNeg(size int, fixedmod bool, single bool) {
@@ -301,15 +301,18 @@ func generateNeg(size int, fixedmod bool, single bool) {
 		funcName = fmt.Sprintf("%s%d", funcName, size)
 		modulusName = fmt.Sprintf("%s%d", modulusName, size)
 	}
-	TEXT(funcName, NOSPLIT, fmt.Sprintf("func(c, a, p *[%d]uint64)", size))
-	Commentf("|")
+	text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, p *[%d]uint64)", size))
+	ctx.Commentf("|")
 	tape := newTape(RBX, RAX)
 	A := tape.newReprAtParam(size, "a", tape.di(), 0)
 	if !fixedmod {
 		tape.alloc(tape.si())
 	}
 	C_sub := tape.newReprAlloc(size).setSwap(tape.bx())
-	Commentf("|")
+	ctx.Commentf("|")
+	// mask is zero if a is zero so that zero is negated to zero
+	mask := nonZeroMask(A, tape.ax())
+	ctx.Commentf("|")
 	var modulus *repr
 	if fixedmod {
 		modulus = tape.newReprAtMemory(size, NewDataAddr(Symbol{Name: modulusName}, 0), 0)
@@ -319,11 +322,197 @@ func generateNeg(size int, fixedmod bool, single bool) {
 	for i := 0; i < size; i++ {
 		C_sub.next().loadSub(modulus.next(), A.next(), i != 0)
 	}
-	Commentf("|")
+	ctx.Commentf("|")
 	C := tape.newReprAtParam(size, "c", tape.di(), 0)
 	for i := 0; i < size; i++ {
-		C_sub.next().moveTo(C.next(), _NO_ASSIGN)
+		ci := C_sub.next()
+		ctx.ANDQ(mask.s, ci.s)
+		ci.moveTo(C.next(), _NO_ASSIGN)
+	}
+	tape.ret()
+	ctx.RET()
+}
+
+// nonZeroMask sets dst to all ones if A is not zero and to zero otherwise
+func nonZeroMask(A *repr, dst *limb) *limb {
+	ctx.MOVQ(A.at(0).s, dst.s)
+	for i := 1; i < A.size; i++ {
+		ctx.ORQ(A.at(i).s, dst.s)
+	}
+	ctx.NEGQ(dst.s)
+	ctx.SBBQ(dst.s, dst.s)
+	return dst
+}
+
+// condMask sets CF if cond parameter is not zero and leaves dst all ones if
+// CF is set and zero otherwise
+func condMask(dst *limb) *limb {
+	ctx.Load(ctx.Param("cond"), dst.s.(Register))
+	ctx.NEGQ(dst.s)
+	ctx.SBBQ(dst.s, dst.s)
+	return dst
+}
+
+func generateCmov(size int, single bool) {
+	funcName := "_cmov"
+	if !single {
+		funcName = fmt.Sprintf("cmov%d", size)
+	}
+	text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, b *[%d]uint64, cond uint64)", size))
+	ctx.Commentf("| c = b if cond != 0 else a")
+	tape := newTape(nil)
+	A := tape.newReprAtParam(size, "a", tape.di(), 0)
+	B := tape.newReprAtParam(size, "b", tape.si(), 0)
+	C := tape.newReprAtParam(size, "c", tape.bx(), 0)
+	t := newLimb(R8)
+	// moves below do not clobber CF set by condMask
+	condMask(tape.ax())
+	for i := 0; i < size; i++ {
+		A.next().moveTo(t, _NO_ASSIGN)
+		ctx.CMOVQCS(B.next().s, t.s)
+		t.moveTo(C.next(), _NO_ASSIGN)
+	}
+	ctx.RET()
+}
+
+func generateCswap(size int, single bool) {
+	funcName := "_cswap"
+	if !single {
+		funcName = fmt.Sprintf("cswap%d", size)
+	}
+	text(funcName, attr.NOSPLIT, fmt.Sprintf("func(a, b *[%d]uint64, cond uint64)", size))
+	ctx.Commentf("| a, b = b, a if cond != 0")
+	tape := newTape(nil)
+	A := tape.newReprAtParam(size, "a", tape.di(), 0)
+	B := tape.newReprAtParam(size, "b", tape.si(), 0)
+	ta, tb, t := newLimb(R8), newLimb(R9), newLimb(R10)
+	condMask(tape.ax())
+	for i := 0; i < size; i++ {
+		ai, bi := A.next(), B.next()
+		ai.moveTo(ta, _NO_ASSIGN)
+		bi.moveTo(tb, _NO_ASSIGN)
+		ctx.MOVQ(ta.s, t.s)
+		ctx.CMOVQCS(tb.s, ta.s)
+		ctx.CMOVQCS(t.s, tb.s)
+		ta.moveTo(ai, _NO_ASSIGN)
+		tb.moveTo(bi, _NO_ASSIGN)
+	}
+	ctx.RET()
+}
+
+func generateCondNeg(size int, fixedmod bool, single bool) {
+	funcName := "_condNeg"
+	modulusName := "·modulus"
+	if !single {
+		funcName = fmt.Sprintf("condNeg%d", size)
+		modulusName = fmt.Sprintf("%s%d", modulusName, size)
+	}
+	if fixedmod {
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a *[%d]uint64, cond uint64)", size))
+	} else {
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, p *[%d]uint64, cond uint64)", size))
+	}
+	ctx.Commentf("| c = -a if cond != 0 else a")
+	tape := newTape(nil)
+	A := tape.newReprAtParam(size, "a", tape.di(), 0)
+	var modulus *repr
+	if fixedmod {
+		modulus = tape.newReprAtMemory(size, NewDataAddr(Symbol{Name: modulusName}, 0), 0)
+	} else {
+		modulus = tape.newReprAtParam(size, "p", tape.si(), 0)
+	}
+	C := tape.newReprAtParam(size, "c", tape.bx(), 0)
+	// mask is all ones if cond is not zero and a is not zero
+	mask := nonZeroMask(A, tape.ax())
+	borrow := condMask(tape.dx())
+	ctx.ANDQ(borrow.s, mask.s)
+	ctx.Commentf("|")
+	// borrow of p - a is saved in a register since selection clobbers flags
+	t, ai := newLimb(R8), newLimb(R9)
+	for i := 0; i < size; i++ {
+		a := A.next()
+		modulus.next().moveTo(t, _NO_ASSIGN)
+		if i == 0 {
+			ctx.SUBQ(a.s, t.s)
+		} else {
+			ctx.ADDQ(borrow.s, borrow.s)
+			ctx.SBBQ(a.s, t.s)
+		}
+		if i != size-1 {
+			ctx.SBBQ(borrow.s, borrow.s)
+		}
+		// c = a ^ ((a ^ (p - a)) & mask)
+		a.moveTo(ai, _NO_ASSIGN)
+		ctx.XORQ(ai.s, t.s)
+		ctx.ANDQ(mask.s, t.s)
+		ctx.XORQ(t.s, ai.s)
+		ai.moveTo(C.next(), _NO_ASSIGN)
+	}
+	ctx.RET()
+}
+
+func generateLookup(size int, single bool) {
+	funcName := "_lookup"
+	if !single {
+		funcName = fmt.Sprintf("lookup%d", size)
+	}
+	// single size field takes the table as a slice of field elements, while
+	// table of multiple limb size package is a pointer to its first entry
+	if single {
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(dst *[%d]uint64, table [][%d]uint64, idx uint64)", size, size))
+	} else {
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(dst, table *[%d]uint64, n, idx uint64)", size))
+	}
+	ctx.Commentf("| dst = table[idx] where every entry of table is read")
+	i, n, idx, mask, t := newLimb(R8), newLimb(RCX), newLimb(RDX), newLimb(RAX), newLimb(R9)
+	tape := newTape(i.s, n.s, idx.s, mask.s, t.s)
+	var table *repr
+	if single {
+		table = tape.newReprAtComponent(size, ctx.Param("table").Base(), tape.si(), 0)
+	} else {
+		table = tape.newReprAtParam(size, "table", tape.si(), 0)
+	}
+	dst := tape.newReprAtParam(size, "dst", tape.di(), 0)
+	// entry is accumulated apart from dst so that dst can be an entry of table
+	acc := tape.newReprAlloc(size).setSwap(t)
+	for j := 0; j < size; j++ {
+		if l := acc.next(); l.atReg() {
+			l.xorself()
+		} else {
+			ctx.MOVQ(U32(0), l.s)
+		}
+	}
+	if single {
+		ctx.Load(ctx.Param("table").Len(), n.s.(Register))
+	} else {
+		ctx.Load(ctx.Param("n"), n.s.(Register))
+	}
+	ctx.Load(ctx.Param("idx"), idx.s.(Register))
+	i.xorself()
+	ctx.TESTQ(n.s, n.s)
+	ctx.JEQ(LabelRef("done"))
+	ctx.Commentf("|")
+	ctx.Label("loop")
+	// mask is all ones if i == idx
+	ctx.MOVQ(i.s, mask.s)
+	ctx.XORQ(idx.s, mask.s)
+	ctx.NEGQ(mask.s)
+	ctx.SBBQ(mask.s, mask.s)
+	ctx.NOTQ(mask.s)
+	for j := 0; j < size; j++ {
+		ctx.MOVQ(table.at(j).s, t.s)
+		ctx.ANDQ(mask.s, t.s)
+		ctx.ORQ(t.s, acc.next().s)
+	}
+	ctx.ADDQ(U32(size*8), table.base.s)
+	ctx.ADDQ(U8(1), i.s)
+	ctx.CMPQ(i.s, n.s)
+	ctx.JNE(LabelRef("loop"))
+	ctx.Commentf("|")
+	ctx.Label("done")
+	for j := 0; j < size; j++ {
+		acc.next().moveTo(dst.next(), _NO_ASSIGN)
 	}
 	tape.ret()
-	RET()
+	ctx.RET()
 }
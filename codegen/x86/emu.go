@@ -0,0 +1,733 @@
+package x86
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/bits"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Emulator for the instruction subset that the generator emits. It runs go
+// assembly text without executing it on the host cpu so that ADX kernels
+// can be verified on machines without ADX and BMI2. Reading a register, a
+// flag or a memory word that is not defined is an error, so kernels that
+// depend on garbage are caught as well as wrong results.
+
+var emuRegs = map[string]int{
+	"AX": 0, "CX": 1, "DX": 2, "BX": 3, "SP": 4, "BP": 5, "SI": 6, "DI": 7,
+	"R8": 8, "R9": 9, "R10": 10, "R11": 11, "R12": 12, "R13": 13, "R14": 14, "R15": 15,
+}
+
+const (
+	opReg = iota
+	opImm
+	opMem
+	opLabel
+)
+
+const (
+	flagCF = 1 << iota
+	flagOF
+	flagZF
+	flagSF
+)
+
+// base addresses of memory regions
+const (
+	emuArgBase   uint64 = 0x10000
+	emuStackBase uint64 = 0x20000
+	emuSymBase   uint64 = 0x100000
+	emuHeapBase  uint64 = 0x1000000
+)
+
+type emuOperand struct {
+	kind  int
+	reg   int
+	imm   uint64
+	base  string
+	sym   string
+	off   int64
+	label string
+}
+
+type emuInstruction struct {
+	op   string
+	args []emuOperand
+	line int
+}
+
+type emuFunc struct {
+	name   string
+	frame  int
+	args   int
+	code   []emuInstruction
+	labels map[string]int
+}
+
+type emuProgram struct {
+	funcs map[string]*emuFunc
+	order []string
+}
+
+var emuMemRe = regexp.MustCompile(`^(·?[A-Za-z_][A-Za-z0-9_]*)?\+?(-?[0-9]+)?\(([A-Z0-9]+)\)$`)
+var emuTextRe = regexp.MustCompile(`^TEXT\s+·([A-Za-z0-9_]+)\(SB\)\s*,\s*[A-Z|$0-9]+\s*,\s*\$([0-9]+)-([0-9]+)$`)
+var emuLabelRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func loadProgram(filename string) (*emuProgram, error) {
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return parseProgram(string(src))
+}
+
+func stripComments(src string) string {
+	var out strings.Builder
+	for {
+		i := strings.Index(src, "/*")
+		if i < 0 {
+			out.WriteString(src)
+			break
+		}
+		out.WriteString(src[:i])
+		j := strings.Index(src[i:], "*/")
+		if j < 0 {
+			break
+		}
+		// keep line numbers
+		out.WriteString(strings.Repeat("\n", strings.Count(src[i:i+j], "\n")))
+		src = src[i+j+2:]
+	}
+	return out.String()
+}
+
+func parseProgram(src string) (*emuProgram, error) {
+	p := &emuProgram{funcs: make(map[string]*emuFunc)}
+	var f *emuFunc
+	for n, line := range strings.Split(stripComments(src), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#include") {
+			continue
+		}
+		if strings.HasPrefix(line, "TEXT") {
+			m := emuTextRe.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("line %d: bad function header %q", n+1, line)
+			}
+			frame, _ := strconv.Atoi(m[2])
+			args, _ := strconv.Atoi(m[3])
+			f = &emuFunc{name: m[1], frame: frame, args: args, labels: make(map[string]int)}
+			if _, ok := p.funcs[f.name]; ok {
+				return nil, fmt.Errorf("line %d: function %s is redefined", n+1, f.name)
+			}
+			p.funcs[f.name] = f
+			p.order = append(p.order, f.name)
+			continue
+		}
+		if f == nil {
+			return nil, fmt.Errorf("line %d: instruction out of function", n+1)
+		}
+		if strings.HasSuffix(line, ":") {
+			f.labels[strings.TrimSuffix(line, ":")] = len(f.code)
+			continue
+		}
+		ins, err := parseInstruction(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n+1, err)
+		}
+		ins.line = n + 1
+		f.code = append(f.code, ins)
+	}
+	for _, f := range p.funcs {
+		for _, ins := range f.code {
+			for _, arg := range ins.args {
+				if _, ok := f.labels[arg.label]; arg.kind == opLabel && !ok {
+					return nil, fmt.Errorf("line %d: no such label %s", ins.line, arg.label)
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+func parseInstruction(line string) (emuInstruction, error) {
+	var ins emuInstruction
+	fields := strings.SplitN(line, " ", 2)
+	ins.op = strings.TrimSpace(fields[0])
+	if len(fields) == 1 {
+		return ins, nil
+	}
+	for _, s := range strings.Split(fields[1], ",") {
+		arg, err := parseOperand(strings.TrimSpace(s))
+		if err != nil {
+			return ins, err
+		}
+		ins.args = append(ins.args, arg)
+	}
+	return ins, nil
+}
+
+func parseOperand(s string) (emuOperand, error) {
+	if r, ok := emuRegs[s]; ok {
+		return emuOperand{kind: opReg, reg: r}, nil
+	}
+	if strings.HasPrefix(s, "$") {
+		v, err := strconv.ParseInt(s[1:], 0, 64)
+		if err != nil {
+			u, err := strconv.ParseUint(s[1:], 0, 64)
+			if err != nil {
+				return emuOperand{}, fmt.Errorf("bad immediate %q", s)
+			}
+			return emuOperand{kind: opImm, imm: u}, nil
+		}
+		return emuOperand{kind: opImm, imm: uint64(v)}, nil
+	}
+	if m := emuMemRe.FindStringSubmatch(s); m != nil {
+		var off int64
+		if m[2] != "" {
+			off, _ = strconv.ParseInt(m[2], 10, 64)
+		}
+		arg := emuOperand{kind: opMem, sym: m[1], off: off, base: m[3]}
+		switch arg.base {
+		case "FP", "SB":
+			if arg.sym == "" {
+				return emuOperand{}, fmt.Errorf("%s operand without symbol %q", arg.base, s)
+			}
+		default:
+			if _, ok := emuRegs[arg.base]; !ok {
+				return emuOperand{}, fmt.Errorf("bad base register %q", s)
+			}
+		}
+		return arg, nil
+	}
+	if emuLabelRe.MatchString(s) {
+		return emuOperand{kind: opLabel, label: s}, nil
+	}
+	return emuOperand{}, fmt.Errorf("bad operand %q", s)
+}
+
+// emuTrace records control flow and memory addresses of a run. Two runs of
+// a constant time kernel must have the same trace.
+type emuTrace struct {
+	branches []int
+	addrs    []uint64
+}
+
+func (t *emuTrace) equal(other *emuTrace) bool {
+	if len(t.branches) != len(other.branches) || len(t.addrs) != len(other.addrs) {
+		return false
+	}
+	for i := range t.branches {
+		if t.branches[i] != other.branches[i] {
+			return false
+		}
+	}
+	for i := range t.addrs {
+		if t.addrs[i] != other.addrs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type machine struct {
+	regs    [16]uint64
+	defined [16]bool
+	flags   int
+	fdef    int
+	mem     map[uint64]uint64
+	syms    map[string]uint64
+	heap    uint64
+	steps   int
+	trace   *emuTrace
+}
+
+func newMachine() *machine {
+	return &machine{
+		mem:  make(map[uint64]uint64),
+		syms: make(map[string]uint64),
+		heap: emuHeapBase,
+	}
+}
+
+// alloc places words in memory and returns the address
+func (m *machine) alloc(words []uint64) uint64 {
+	addr := m.heap
+	for i, w := range words {
+		m.mem[addr+uint64(i)*8] = w
+	}
+	// leave a gap so that overruns read undefined memory
+	m.heap += uint64(len(words)+1) * 8
+	return addr
+}
+
+// allocUndefined reserves n words that are undefined until written
+func (m *machine) allocUndefined(n int) uint64 {
+	addr := m.heap
+	m.heap += uint64(n+1) * 8
+	return addr
+}
+
+func (m *machine) load(addr uint64, n int) []uint64 {
+	words := make([]uint64, n)
+	for i := range words {
+		words[i] = m.mem[addr+uint64(i)*8]
+	}
+	return words
+}
+
+// setSymbol defines a global symbol such as ·modulus
+func (m *machine) setSymbol(name string, words []uint64) {
+	addr := emuSymBase + uint64(len(m.syms))*0x10000
+	for i, w := range words {
+		m.mem[addr+uint64(i)*8] = w
+	}
+	m.syms[name] = addr
+}
+
+// call runs f with args laid out as consecutive words of the argument
+// frame and returns the words of results that follow.
+func (m *machine) call(f *emuFunc, args []uint64, results int) ([]uint64, error) {
+	if len(args)*8 > f.args {
+		return nil, fmt.Errorf("%s: too many arguments", f.name)
+	}
+	for i := range m.defined {
+		m.defined[i] = false
+	}
+	m.fdef = 0
+	for addr := range m.mem {
+		if addr >= emuArgBase && addr < emuSymBase {
+			delete(m.mem, addr)
+		}
+	}
+	for i, a := range args {
+		m.mem[emuArgBase+uint64(i)*8] = a
+	}
+	for i := 0; i < results; i++ {
+		m.mem[emuArgBase+uint64(len(args)+i)*8] = 0
+	}
+	m.regs[4], m.defined[4] = emuStackBase, true
+	if err := m.run(f); err != nil {
+		return nil, err
+	}
+	return m.load(emuArgBase+uint64(len(args))*8, results), nil
+}
+
+func (m *machine) run(f *emuFunc) error {
+	pc := 0
+	for pc < len(f.code) {
+		m.steps++
+		ins := f.code[pc]
+		next, err := m.exec(f, ins, pc)
+		if err != nil {
+			return fmt.Errorf("%s, line %d, %s: %s", f.name, ins.line, ins.op, err)
+		}
+		if next < 0 {
+			return nil
+		}
+		pc = next
+	}
+	return fmt.Errorf("%s: no return", f.name)
+}
+
+func (m *machine) addr(f *emuFunc, a emuOperand) (uint64, error) {
+	var addr uint64
+	switch a.base {
+	case "FP":
+		addr = emuArgBase + uint64(a.off)
+	case "SB":
+		base, ok := m.syms[a.sym]
+		if !ok {
+			return 0, fmt.Errorf("undefined symbol %s", a.sym)
+		}
+		addr = base + uint64(a.off)
+	case "SP":
+		if a.off < 0 || int(a.off) >= f.frame {
+			return 0, fmt.Errorf("stack access out of frame, %d", a.off)
+		}
+		addr = emuStackBase + uint64(a.off)
+	default:
+		r := emuRegs[a.base]
+		if !m.defined[r] {
+			return 0, fmt.Errorf("undefined base register %s", a.base)
+		}
+		addr = m.regs[r] + uint64(a.off)
+	}
+	if m.trace != nil {
+		m.trace.addrs = append(m.trace.addrs, addr)
+	}
+	return addr, nil
+}
+
+func (m *machine) read(f *emuFunc, a emuOperand) (uint64, error) {
+	switch a.kind {
+	case opReg:
+		if !m.defined[a.reg] {
+			return 0, fmt.Errorf("read of undefined register")
+		}
+		return m.regs[a.reg], nil
+	case opImm:
+		return a.imm, nil
+	case opMem:
+		addr, err := m.addr(f, a)
+		if err != nil {
+			return 0, err
+		}
+		if addr%8 != 0 {
+			return 0, fmt.Errorf("unaligned read at %#x", addr)
+		}
+		v, ok := m.mem[addr]
+		if !ok {
+			return 0, fmt.Errorf("read of undefined memory at %#x", addr)
+		}
+		return v, nil
+	}
+	return 0, fmt.Errorf("bad source operand")
+}
+
+func (m *machine) write(f *emuFunc, a emuOperand, v uint64) error {
+	switch a.kind {
+	case opReg:
+		m.regs[a.reg], m.defined[a.reg] = v, true
+		return nil
+	case opMem:
+		addr, err := m.addr(f, a)
+		if err != nil {
+			return err
+		}
+		if addr%8 != 0 {
+			return fmt.Errorf("unaligned write at %#x", addr)
+		}
+		m.mem[addr] = v
+		return nil
+	}
+	return fmt.Errorf("bad destination operand")
+}
+
+func (m *machine) writeByte(f *emuFunc, a emuOperand, v uint64) error {
+	if a.kind != opMem {
+		return fmt.Errorf("byte write to non memory operand")
+	}
+	addr, err := m.addr(f, a)
+	if err != nil {
+		return err
+	}
+	word, shift := addr&^7, 8*(addr&7)
+	w, ok := m.mem[word]
+	if !ok {
+		return fmt.Errorf("byte write to undefined memory at %#x", addr)
+	}
+	m.mem[word] = w&^(0xff<<shift) | (v&0xff)<<shift
+	return nil
+}
+
+func (m *machine) flag(fl int) (uint64, error) {
+	if m.fdef&fl == 0 {
+		return 0, fmt.Errorf("read of undefined flag")
+	}
+	if m.flags&fl != 0 {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (m *machine) setFlag(fl int, v uint64) {
+	m.fdef |= fl
+	if v != 0 {
+		m.flags |= fl
+	} else {
+		m.flags &^= fl
+	}
+}
+
+func (m *machine) setResultFlags(r uint64) {
+	m.setFlag(flagZF, b2u(r == 0))
+	m.setFlag(flagSF, r>>63)
+}
+
+func b2u(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (m *machine) operands(ins emuInstruction, n int) error {
+	if len(ins.args) != n {
+		return fmt.Errorf("expected %d operands, have %d", n, len(ins.args))
+	}
+	return nil
+}
+
+// binary reads both operands of a two operand instruction
+func (m *machine) binary(f *emuFunc, ins emuInstruction) (uint64, uint64, error) {
+	if err := m.operands(ins, 2); err != nil {
+		return 0, 0, err
+	}
+	if ins.args[0].kind == opMem && ins.args[1].kind == opMem {
+		return 0, 0, fmt.Errorf("memory to memory operation")
+	}
+	s, err := m.read(f, ins.args[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	d, err := m.read(f, ins.args[1])
+	return s, d, err
+}
+
+func (m *machine) condition(op string) (bool, error) {
+	var cf, zf uint64
+	var err error
+	switch op {
+	case "JB", "JCS", "JAE", "JCC", "JA", "JHI", "JLS", "CMOVQCC", "CMOVQCS":
+		if cf, err = m.flag(flagCF); err != nil {
+			return false, err
+		}
+	}
+	switch op {
+	case "JA", "JHI", "JLS", "JNE", "JNZ", "JEQ", "JE", "JZ":
+		if zf, err = m.flag(flagZF); err != nil {
+			return false, err
+		}
+	}
+	switch op {
+	case "JB", "JCS", "CMOVQCS":
+		return cf == 1, nil
+	case "JAE", "JCC", "CMOVQCC":
+		return cf == 0, nil
+	case "JA", "JHI":
+		return cf == 0 && zf == 0, nil
+	case "JLS":
+		return cf == 1 || zf == 1, nil
+	case "JNE", "JNZ":
+		return zf == 0, nil
+	case "JEQ", "JE", "JZ":
+		return zf == 1, nil
+	case "JMP":
+		return true, nil
+	}
+	return false, fmt.Errorf("unknown condition")
+}
+
+// exec runs a single instruction and returns the next pc or -1 on return
+func (m *machine) exec(f *emuFunc, ins emuInstruction, pc int) (int, error) {
+	switch ins.op {
+	case "RET":
+		return -1, nil
+	case "JMP", "JB", "JCS", "JAE", "JCC", "JA", "JHI", "JLS", "JNE", "JNZ", "JEQ", "JE", "JZ":
+		if err := m.operands(ins, 1); err != nil {
+			return 0, err
+		}
+		taken, err := m.condition(ins.op)
+		if err != nil {
+			return 0, err
+		}
+		next := pc + 1
+		if taken {
+			next = f.labels[ins.args[0].label]
+		}
+		if m.trace != nil {
+			m.trace.branches = append(m.trace.branches, next)
+		}
+		return next, nil
+	case "MOVQ":
+		if err := m.operands(ins, 2); err != nil {
+			return 0, err
+		}
+		if ins.args[0].kind == opMem && ins.args[1].kind == opMem {
+			return 0, fmt.Errorf("memory to memory move")
+		}
+		v, err := m.read(f, ins.args[0])
+		if err != nil {
+			return 0, err
+		}
+		return pc + 1, m.write(f, ins.args[1], v)
+	case "MOVB":
+		if err := m.operands(ins, 2); err != nil {
+			return 0, err
+		}
+		v, err := m.read(f, ins.args[0])
+		if err != nil {
+			return 0, err
+		}
+		return pc + 1, m.writeByte(f, ins.args[1], v)
+	case "CMOVQCC", "CMOVQCS":
+		if err := m.operands(ins, 2); err != nil {
+			return 0, err
+		}
+		if ins.args[1].kind != opReg {
+			return 0, fmt.Errorf("conditional move to non register")
+		}
+		// source is read regardless of the condition
+		s, err := m.read(f, ins.args[0])
+		if err != nil {
+			return 0, err
+		}
+		move, err := m.condition(ins.op)
+		if err != nil {
+			return 0, err
+		}
+		if move {
+			return pc + 1, m.write(f, ins.args[1], s)
+		}
+		if _, err := m.read(f, ins.args[1]); err != nil {
+			return 0, err
+		}
+		return pc + 1, nil
+	case "ADDQ", "ADCQ", "ADCXQ", "ADOXQ":
+		s, d, err := m.binary(f, ins)
+		if err != nil {
+			return 0, err
+		}
+		var carry uint64
+		switch ins.op {
+		case "ADCQ", "ADCXQ":
+			if carry, err = m.flag(flagCF); err != nil {
+				return 0, err
+			}
+		case "ADOXQ":
+			if carry, err = m.flag(flagOF); err != nil {
+				return 0, err
+			}
+		}
+		r, c := bits.Add64(d, s, carry)
+		switch ins.op {
+		case "ADCXQ":
+			m.setFlag(flagCF, c)
+		case "ADOXQ":
+			m.setFlag(flagOF, c)
+		default:
+			m.setFlag(flagCF, c)
+			m.setFlag(flagOF, ((d^r)&(s^r))>>63)
+			m.setResultFlags(r)
+		}
+		return pc + 1, m.write(f, ins.args[1], r)
+	case "SUBQ", "SBBQ", "CMPQ":
+		s, d, err := m.binary(f, ins)
+		if err != nil {
+			return 0, err
+		}
+		var borrow uint64
+		if ins.op == "SBBQ" {
+			if borrow, err = m.flag(flagCF); err != nil {
+				return 0, err
+			}
+		}
+		if ins.op == "CMPQ" {
+			// go assembler compares the first operand to the second
+			s, d = d, s
+		}
+		r, b := bits.Sub64(d, s, borrow)
+		m.setFlag(flagCF, b)
+		m.setFlag(flagOF, ((d^s)&(d^r))>>63)
+		m.setResultFlags(r)
+		if ins.op == "CMPQ" {
+			return pc + 1, nil
+		}
+		return pc + 1, m.write(f, ins.args[1], r)
+	case "XORQ", "ANDQ", "ORQ", "TESTQ":
+		if a := ins.args; ins.op == "XORQ" && len(a) == 2 && a[0].kind == opReg && a[1].kind == opReg && a[0].reg == a[1].reg {
+			// zeroing idiom does not depend on the register
+			m.defined[a[0].reg] = true
+		}
+		s, d, err := m.binary(f, ins)
+		if err != nil {
+			return 0, err
+		}
+		var r uint64
+		switch ins.op {
+		case "XORQ":
+			r = d ^ s
+		case "ORQ":
+			r = d | s
+		default:
+			r = d & s
+		}
+		m.setFlag(flagCF, 0)
+		m.setFlag(flagOF, 0)
+		m.setResultFlags(r)
+		if ins.op == "TESTQ" {
+			return pc + 1, nil
+		}
+		return pc + 1, m.write(f, ins.args[1], r)
+	case "NOTQ", "NEGQ":
+		if err := m.operands(ins, 1); err != nil {
+			return 0, err
+		}
+		d, err := m.read(f, ins.args[0])
+		if err != nil {
+			return 0, err
+		}
+		r := ^d
+		if ins.op == "NEGQ" {
+			r = -d
+			m.setFlag(flagCF, b2u(d != 0))
+			m.setFlag(flagOF, b2u(d == 1<<63))
+			m.setResultFlags(r)
+		}
+		return pc + 1, m.write(f, ins.args[0], r)
+	case "RCLQ", "RCRQ":
+		s, d, err := m.binary(f, ins)
+		if err != nil {
+			return 0, err
+		}
+		if s != 1 {
+			return 0, fmt.Errorf("rotation count other than one")
+		}
+		c, err := m.flag(flagCF)
+		if err != nil {
+			return 0, err
+		}
+		var r uint64
+		if ins.op == "RCLQ" {
+			r = d<<1 | c
+			m.setFlag(flagCF, d>>63)
+			m.setFlag(flagOF, (r>>63)^(d>>63))
+		} else {
+			r = d>>1 | c<<63
+			m.setFlag(flagCF, d&1)
+			m.setFlag(flagOF, (r>>63)^(r>>62&1))
+		}
+		return pc + 1, m.write(f, ins.args[1], r)
+	case "MULXQ":
+		if err := m.operands(ins, 3); err != nil {
+			return 0, err
+		}
+		if !m.defined[2] {
+			return 0, fmt.Errorf("read of undefined register DX")
+		}
+		s, err := m.read(f, ins.args[0])
+		if err != nil {
+			return 0, err
+		}
+		hi, lo := bits.Mul64(m.regs[2], s)
+		if err := m.write(f, ins.args[1], lo); err != nil {
+			return 0, err
+		}
+		return pc + 1, m.write(f, ins.args[2], hi)
+	case "MULQ":
+		if err := m.operands(ins, 1); err != nil {
+			return 0, err
+		}
+		if !m.defined[0] {
+			return 0, fmt.Errorf("read of undefined register AX")
+		}
+		s, err := m.read(f, ins.args[0])
+		if err != nil {
+			return 0, err
+		}
+		hi, lo := bits.Mul64(m.regs[0], s)
+		m.regs[0], m.regs[2] = lo, hi
+		m.defined[0], m.defined[2] = true, true
+		m.setFlag(flagCF, b2u(hi != 0))
+		m.setFlag(flagOF, b2u(hi != 0))
+		m.fdef &^= flagZF | flagSF
+		return pc + 1, nil
+	}
+	return 0, fmt.Errorf("unsupported instruction")
+}
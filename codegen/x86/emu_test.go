@@ -0,0 +1,136 @@
+package x86
+
+import (
+	"flag"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var iter = flag.Int("iter", 10, "# of random inputs per kernel")
+
+func runSnippet(t *testing.T, body string, args []uint64, results int) ([]uint64, error) {
+	t.Helper()
+	src := "TEXT ·f(SB), NOSPLIT, $16-64\n" + body + "\n\tRET\n"
+	prog, err := parseProgram(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newMachine()
+	return m.call(prog.funcs["f"], args, results)
+}
+
+func TestEmulator(t *testing.T) {
+	for _, c := range []struct {
+		body     string
+		args     []uint64
+		expected uint64
+	}{
+		// carry chain
+		{"MOVQ a+0(FP), AX\n ADDQ b+8(FP), AX\n MOVQ $0x00, AX\n ADCQ $0x00, AX\n MOVQ AX, ret+16(FP)", []uint64{^uint64(0), 1}, 1},
+		// go assembler compares first operand to second
+		{"MOVQ a+0(FP), AX\n CMPQ AX, b+8(FP)\n MOVQ $0x00, AX\n JB lt\n MOVQ $0x01, AX\nlt:\n MOVQ AX, ret+16(FP)", []uint64{1, 2}, 0},
+		{"MOVQ a+0(FP), AX\n CMPQ AX, b+8(FP)\n MOVQ $0x00, AX\n JB lt\n MOVQ $0x01, AX\nlt:\n MOVQ AX, ret+16(FP)", []uint64{2, 1}, 1},
+		// y -= x
+		{"MOVQ a+0(FP), AX\n SUBQ b+8(FP), AX\n MOVQ AX, ret+16(FP)", []uint64{5, 3}, 2},
+		{"MOVQ a+0(FP), AX\n SUBQ b+8(FP), AX\n SBBQ AX, AX\n MOVQ AX, ret+16(FP)", []uint64{3, 5}, ^uint64(0)},
+		// independent carry chains
+		{"MOVQ a+0(FP), AX\n MOVQ AX, BX\n XORQ CX, CX\n ADCXQ AX, AX\n ADOXQ BX, BX\n ADCXQ CX, CX\n ADOXQ CX, CX\n MOVQ CX, ret+16(FP)", []uint64{1 << 63, 0}, 3},
+		// high word of dx * src
+		{"MOVQ a+0(FP), DX\n MULXQ b+8(FP), AX, BX\n MOVQ BX, ret+16(FP)", []uint64{1 << 63, 4}, 2},
+		{"MOVQ a+0(FP), AX\n MULQ b+8(FP)\n MOVQ DX, ret+16(FP)", []uint64{^uint64(0), ^uint64(0)}, ^uint64(1)},
+		{"MOVQ a+0(FP), AX\n MOVQ b+8(FP), BX\n SUBQ BX, AX\n CMOVQCC BX, AX\n MOVQ AX, ret+16(FP)", []uint64{5, 3}, 3},
+		{"MOVQ a+0(FP), AX\n MOVQ b+8(FP), BX\n SUBQ BX, AX\n CMOVQCS BX, AX\n MOVQ AX, ret+16(FP)", []uint64{5, 3}, 2},
+		// rotations through carry
+		{"MOVQ a+0(FP), AX\n XORQ BX, BX\n RCLQ $1, AX\n RCLQ $1, BX\n MOVQ BX, ret+16(FP)", []uint64{1 << 63, 0}, 1},
+		{"MOVQ a+0(FP), AX\n MOVQ $0x00, 8(SP)\n ADDQ AX, AX\n RCRQ $1, 8(SP)\n MOVQ 8(SP), AX\n MOVQ AX, ret+16(FP)", []uint64{1 << 63, 0}, 1 << 63},
+		{"MOVB $0x01, ret+16(FP)\n MOVQ a+0(FP), AX\n TESTQ $1, AX\n JNZ ret\n MOVB $0xff, ret+17(FP)\nret:", []uint64{2, 0}, 0xff01},
+	} {
+		ret, err := runSnippet(t, c.body, c.args, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ret[0] != c.expected {
+			t.Fatalf("have %#x, want %#x\n%s", ret[0], c.expected, c.body)
+		}
+	}
+}
+
+func TestEmulatorUndefined(t *testing.T) {
+	for _, body := range []string{
+		"MOVQ AX, ret+16(FP)",
+		"MOVQ a+0(FP), AX\n ADCQ AX, AX",
+		"MOVQ a+0(FP), AX\n ADOXQ AX, AX",
+		"MOVQ 8(SP), AX",
+		"MOVQ a+0(FP), DI\n MOVQ 8(DI), AX",
+		"MOVQ 16(SP), AX",
+		"MOVQ ·modulus+0(SB), AX",
+		"MULXQ a+0(FP), AX, BX",
+		"PUSHQ AX",
+	} {
+		if _, err := runSnippet(t, body, []uint64{1, 2}, 1); err == nil {
+			t.Fatalf("expected error\n%s", body)
+		}
+	}
+	if _, err := parseProgram("TEXT ·f(SB), NOSPLIT, $0-8\n JMP nowhere\n RET\n"); err == nil {
+		t.Fatalf("expected missing label error")
+	}
+}
+
+// TestKernels runs generated kernels of all limb sizes of both ADX and non
+// ADX backends in the emulator.
+func TestKernels(t *testing.T) {
+	dir := t.TempDir()
+	allocReport = true
+	defer func() { allocReport = false }()
+	if err := GenX86All(dir); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "x86_arithmetic.s")
+	prog, err := loadProgram(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var adx, noadx int
+	for _, name := range prog.order {
+		if strings.HasPrefix(name, "mul_no_adx_bmi2") {
+			noadx++
+		} else if base, _, _ := kernelName(name, 0); base == "mul" {
+			adx++
+		}
+	}
+	if adx != 16 || noadx != 16 {
+		t.Fatalf("missing multiplication kernels, adx: %d, non adx: %d", adx, noadx)
+	}
+	// mul1, mul_no_adx_bmi2_1 and is_even are appended as text
+	if len(allocReports) != len(prog.order)-3 {
+		t.Fatalf("have %d allocation reports, want %d", len(allocReports), len(prog.order)-3)
+	}
+	for _, r := range allocReports {
+		if r.PeakGPR == 0 || r.PeakGPR > r.GPRs || r.CriticalPath == 0 {
+			t.Fatalf("bad allocation report %+v", r)
+		}
+	}
+	// is_even is the only kernel without limb size suffix
+	if err := VerifyKernels(file, 1, *iter); err != nil {
+		t.Fatal(err)
+	}
+	// same kernels after the peephole pass
+	f, err := ctx.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := optimiseFile(f)
+	for _, s := range stats {
+		if s.after > s.before {
+			t.Fatalf("%s has more instructions after peephole pass", s.name)
+		}
+	}
+	file = filepath.Join(dir, "peephole.s")
+	if err := writeAsm(f, file); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyKernels(file, 1, *iter); err != nil {
+		t.Fatal(err)
+	}
+}
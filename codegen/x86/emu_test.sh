@@ -0,0 +1,19 @@
+#!/bin/bash -e
+N_ITER=100
+
+
+limb_sizes=(2 3 4 5 6 7 8 9 10 11 12 13 14 15 16)
+
+for LIMB_SIZE in "${limb_sizes[@]}"
+do
+  echo fixedmod $LIMB_SIZE
+  go run ./test/ -limb $LIMB_SIZE -fixed -emulate $N_ITER
+  go run ./test/ -limb $LIMB_SIZE -fixed -noadx -emulate $N_ITER
+done
+
+for LIMB_SIZE in "${limb_sizes[@]}"
+do
+  echo $LIMB_SIZE
+  go run ./test/ -limb $LIMB_SIZE -emulate $N_ITER
+  go run ./test/ -limb $LIMB_SIZE -noadx -emulate $N_ITER
+done
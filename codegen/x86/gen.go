@@ -1,7 +1,6 @@
 package x86
 
 import (
-	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -10,11 +9,18 @@ import (
 	"strconv"
 	"strings"
 
-	. "github.com/mmcloughlin/avo/build"
+	"github.com/mmcloughlin/avo/attr"
+	"github.com/mmcloughlin/avo/build"
+	"github.com/mmcloughlin/avo/pass"
+	"github.com/mmcloughlin/avo/printer"
 )
 
 const RSize int = 9
 
+// ctx is the build context kernels are emitted to. Each generation starts
+// with a new context so that generators can run more than once in a process.
+var ctx = build.NewContext()
+
 var logs = false
 
 type bitFlags []int
@@ -32,15 +38,50 @@ func (flag *bitFlags) Set(value string) error {
 	return nil
 }
 
-func GenX86All(output string) error {
-	// a hack for avo output
-	file := filepath.Join(output, "x86_arithmetic.s")
-	if err := flag.Set("out", file); err != nil {
+// text starts building a new function on ctx
+func text(name string, a attr.Attribute, signature string) {
+	ctx.Function(name)
+	ctx.Attributes(a)
+	ctx.SignatureExpr(signature)
+}
+
+// generate runs the peephole pass and collects allocation reports if
+// enabled, then compiles the functions of ctx and writes the assembly
+func generate(filename string) error {
+	f, err := ctx.Result()
+	if err != nil {
 		return err
 	}
+	if peephole {
+		peepholeStats = append(peepholeStats, optimiseFile(f)...)
+	}
+	if allocReport {
+		allocReports = append(allocReports, reportFile(f)...)
+	}
+	w, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	cfg := &build.Config{
+		ErrOut:    os.Stderr,
+		MaxErrors: 10,
+		Passes: []pass.Interface{
+			pass.Compile,
+			&pass.Output{Writer: w, Printer: printer.NewGoAsm(printer.NewGoRunConfig())},
+		},
+	}
+	if status := build.Main(cfg, ctx); status != 0 {
+		return fmt.Errorf("failed to generate %s\n", filename)
+	}
+	return nil
+}
+
+func GenX86All(output string) error {
+	file := filepath.Join(output, "x86_arithmetic.s")
 	if err := os.MkdirAll(output, os.ModePerm); err != nil {
 		return err
 	}
+	ctx = build.NewContext()
 	fixedmod, single, archTag := false, false, true
 	for i := 1; i < 17; i++ {
 		limbSize := i
@@ -53,6 +94,10 @@ func GenX86All(output string) error {
 		generateSub(limbSize, fixedmod, single)
 		generateSubNoCar(limbSize, single)
 		generateNeg(limbSize, fixedmod, single)
+		generateCmov(limbSize, single)
+		generateCswap(limbSize, single)
+		generateCondNeg(limbSize, fixedmod, single)
+		generateLookup(limbSize, single)
 		generateMul2(limbSize, single)
 		generateDiv2(limbSize, single)
 		if limbSize != 1 {
@@ -60,7 +105,9 @@ func GenX86All(output string) error {
 			genMontMulNoADX(limbSize, fixedmod, single, archTag)
 		}
 	}
-	Generate()
+	if err := generate(file); err != nil {
+		return err
+	}
 	appendSingleLimbMultiplicationCode(file)
 	appendIsEvenCode(file)
 	pretty(file)
@@ -68,12 +115,7 @@ func GenX86All(output string) error {
 }
 
 func GenX86(output string, bitSize int, arch string, fixedmod bool, single bool) error {
-	// a hack for avo output
 	file := filepath.Join(output, "arithmetic.s")
-	if err := flag.Set("out", file); err != nil {
-		return err
-	}
-	// Package("github.com/kilic/fp/" + output)
 	limbSize := bitSize / 64
 	if bitSize%64 != 0 {
 		return fmt.Errorf(fmt.Sprintf("bad bit size, %d\n", bitSize))
@@ -81,6 +123,7 @@ func GenX86(output string, bitSize int, arch string, fixedmod bool, single bool)
 	if limbSize < 2 || limbSize > 16 {
 		return fmt.Errorf("limb size %d not implemented\n", limbSize)
 	}
+	ctx = build.NewContext()
 	generateCopy(limbSize, single)
 	generateEq(limbSize, single)
 	generateCmp(limbSize, single)
@@ -90,13 +133,21 @@ func GenX86(output string, bitSize int, arch string, fixedmod bool, single bool)
 	generateSub(limbSize, fixedmod, single)
 	generateSubNoCar(limbSize, single)
 	generateNeg(limbSize, fixedmod, single)
+	generateCmov(limbSize, single)
+	generateCswap(limbSize, single)
+	generateCondNeg(limbSize, fixedmod, single)
+	generateLookup(limbSize, single)
+	generateMul2(limbSize, single)
+	generateDiv2(limbSize, single)
 	switch arch {
 	case "ADX":
 		genMontMulADX(limbSize, fixedmod, single)
 	default:
 		genMontMulNoADX(limbSize, fixedmod, single, false)
 	}
-	Generate()
+	if err := generate(file); err != nil {
+		return err
+	}
 	pretty(file)
 	return nil
 }
@@ -105,15 +156,15 @@ func GenDebugTest(limbs int, fixedmod bool, noadx bool, _logs bool) {
 	logs = _logs
 	file := "debug/mul.s"
 	mkdirDebug()
-	if err := flag.Set("out", file); err != nil {
-		panic(err)
-	}
+	ctx = build.NewContext()
 	if noadx {
 		genMontMulNoADX(limbs, fixedmod, true, false)
 	} else {
 		genMontMulADX(limbs, fixedmod, true)
 	}
-	Generate()
+	if err := generate(file); err != nil {
+		panic(err)
+	}
 	pretty(file)
 	generateTestCode(limbs, fixedmod)
 }
@@ -422,7 +473,7 @@ import (
 var fuz int
 
 func TestMain(m *testing.M) {
-	_fuz := flag.Int("fuzz", 50, "# of iters")
+	_fuz := flag.Int("iter", 50, "# of iters")
 	flag.Parse()
 	fuz = *_fuz
 	m.Run()
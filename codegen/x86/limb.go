@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 
-	. "github.com/mmcloughlin/avo/build"
 	. "github.com/mmcloughlin/avo/operand"
 	. "github.com/mmcloughlin/avo/reg"
 )
@@ -71,15 +70,15 @@ func (r *repr) debug(desc string) *repr {
 }
 
 func (r *repr) commentCurrent(name string) {
-	Commentf("| %s%d @ %s", name, r.i, r.get().String())
+	ctx.Commentf("| %s%d @ %s", name, r.i, r.get().String())
 }
 
 func (r *repr) commentPrevious(name string) {
-	Commentf("| %s%d @ %s", name, (r.i-1+r.size)%r.size, r.at(r.i-1).String())
+	ctx.Commentf("| %s%d @ %s", name, (r.i-1+r.size)%r.size, r.at(r.i-1).String())
 }
 
 func (r *repr) commentNext(name string) {
-	Commentf("| %s%d @ %s", name, (r.i+1)%r.size, r.at(r.i+1).String())
+	ctx.Commentf("| %s%d @ %s", name, (r.i+1)%r.size, r.at(r.i+1).String())
 }
 
 // load will cause changing of source index
@@ -249,7 +248,7 @@ func (r *repr) commentState(desc string) *repr {
 			state += "\n\t// "
 		}
 	}
-	Commentf("| \n\t// | %s\n%s\n\n", desc, state)
+	ctx.Commentf("| \n\t// | %s\n%s\n\n", desc, state)
 	return r
 }
 
@@ -311,7 +310,7 @@ func (l *limb) clone() *limb {
 }
 
 func (l *limb) comment(tag string, index int) *limb {
-	Commentf("| %s%d @ %s", tag, index, l.String())
+	ctx.Commentf("| %s%d @ %s", tag, index, l.String())
 	return l
 }
 
@@ -343,22 +342,22 @@ func (l *limb) op() Op {
 func (l *limb) load(src, dst *limb) {
 	if dst != nil {
 		if dst.atMem() && src.atMem() {
-			MOVQ(src.s, l.swap)
-			MOVQ(l.swap, dst.s)
+			ctx.MOVQ(src.s, l.swap)
+			ctx.MOVQ(l.swap, dst.s)
 		} else {
-			MOVQ(src.s, dst.s)
+			ctx.MOVQ(src.s, dst.s)
 		}
 		l.set(dst)
 		return
 	}
 	if src.atMem() && l.atMem() {
 		if src.String() != l.String() {
-			MOVQ(src.s, l.swap)
-			MOVQ(l.swap, l.s)
+			ctx.MOVQ(src.s, l.swap)
+			ctx.MOVQ(l.swap, l.s)
 		}
 		return
 	}
-	MOVQ(src.s, l.s)
+	ctx.MOVQ(src.s, l.s)
 }
 
 func (l *limb) moveTo(dst *limb, assign bool) *limb {
@@ -367,21 +366,21 @@ func (l *limb) moveTo(dst *limb, assign bool) *limb {
 		return l
 	}
 	if l.atMem() && dst.atMem() {
-		MOVQ(l.s, l.swap)
-		MOVQ(l.swap, dst.s)
+		ctx.MOVQ(l.s, l.swap)
+		ctx.MOVQ(l.swap, dst.s)
 		return l
 	}
-	MOVQ(l.s, dst.s)
+	ctx.MOVQ(l.s, dst.s)
 	return l
 }
 
 func (l *limb) move(dst *limb) *limb {
 	if l.atMem() && dst.atMem() {
-		MOVQ(l.s, l.swap)
-		MOVQ(l.swap, dst.s)
+		ctx.MOVQ(l.s, l.swap)
+		ctx.MOVQ(l.swap, dst.s)
 		return l
 	}
-	MOVQ(l.s, dst.s)
+	ctx.MOVQ(l.s, dst.s)
 	return l
 }
 
@@ -406,12 +405,12 @@ func (l *limb) moveAssignIf(c bool, dst *limb) *limb {
 
 func (l *limb) moveIfNotCF(dst *limb) *limb {
 	if dst.atMem() {
-		MOVQ(dst.s, l.swap)
-		CMOVQCC(l.s, l.swap)
-		MOVQ(l.swap, dst.s)
+		ctx.MOVQ(dst.s, l.swap)
+		ctx.CMOVQCC(l.s, l.swap)
+		ctx.MOVQ(l.swap, dst.s)
 		return l
 	}
-	CMOVQCC(l.s, dst.s)
+	ctx.CMOVQCC(l.s, dst.s)
 	return l
 }
 
@@ -425,24 +424,24 @@ func (l *limb) moveIfNotCFAux(aux *limb, dst *limb) {
 	// Aux: 	R R R	R
 	// Dest:  R	M R	M
 	if aux.atReg() {
-		CMOVQCC(l.s, aux.s)
-		MOVQ(aux.s, dst.s)
+		ctx.CMOVQCC(l.s, aux.s)
+		ctx.MOVQ(aux.s, dst.s)
 		return
 	}
 	// Limb:	R M
 	// Aux: 	M M
 	// Dest:  R R
 	if dst.atReg() {
-		MOVQ(aux.s, dst.s)
-		CMOVQCC(l.s, dst.s)
+		ctx.MOVQ(aux.s, dst.s)
+		ctx.CMOVQCC(l.s, dst.s)
 		return
 	}
 	// Limb:	R
 	// Aux: 	M
 	// Dest:  M
 	if l.atReg() {
-		CMOVQCS(aux.s, l.s)
-		MOVQ(l.s, dst.s)
+		ctx.CMOVQCS(aux.s, l.s)
+		ctx.MOVQ(l.s, dst.s)
 		return
 	}
 	// Limb:	M
@@ -451,23 +450,23 @@ func (l *limb) moveIfNotCFAux(aux *limb, dst *limb) {
 	// MOVQ(aux.s, aux.swap)
 	// CMOVQCC(l.s, aux.swap)
 	// MOVQ(aux.swap, dst.s)
-	MOVQ(aux.s, l.swap)
-	CMOVQCC(l.s, l.swap)
-	MOVQ(l.swap, dst.s)
+	ctx.MOVQ(aux.s, l.swap)
+	ctx.CMOVQCC(l.s, l.swap)
+	ctx.MOVQ(l.swap, dst.s)
 }
 
 // loads subtractin result of (left + rigth)
 // left operand is overwritten if stored at register
 func (l *limb) loadAdd(left *limb, rigth *limb, brw bool) {
-	Add := ADDQ
+	Add := ctx.ADDQ
 	if brw {
-		Add = ADCQ
+		Add = ctx.ADCQ
 	}
 	// Left :	R R M M
 	// Right:	R M R M
 	// Sum  : R R R R
 	if l.atReg() {
-		MOVQ(left.s, l.s)
+		ctx.MOVQ(left.s, l.s)
 		Add(rigth.s, l.s)
 		return
 	}
@@ -476,52 +475,52 @@ func (l *limb) loadAdd(left *limb, rigth *limb, brw bool) {
 	// Sum  : M M M
 	if left.atReg() {
 		Add(rigth.s, left.s)
-		MOVQ(left.s, l.s)
+		ctx.MOVQ(left.s, l.s)
 		return
 	}
 	// Left :	M
 	// Right:	M
 	// Sum  : M
-	MOVQ(left.s, l.swap)
+	ctx.MOVQ(left.s, l.swap)
 	Add(rigth.s, l.swap)
-	MOVQ(l.swap, l.s)
+	ctx.MOVQ(l.swap, l.s)
 }
 
 // loads subtractin result of (left + rigth)
 // left operand is overwritten if stored at register
 func (l *limb) loadAddSafe(left *limb, rigth *limb, brw bool) {
-	Add := ADDQ
+	Add := ctx.ADDQ
 	if brw {
-		Add = ADCQ
+		Add = ctx.ADCQ
 	}
 	// Left :	R R M M
 	// Right:	R M R M
 	// Sum  : R R R R
 	if l.atReg() {
-		MOVQ(left.s, l.s)
+		ctx.MOVQ(left.s, l.s)
 		Add(rigth.s, l.s)
 		return
 	}
 	// Left :	M R R R
 	// Right:	M R M M
 	// Sum  : M M M M
-	MOVQ(left.s, l.swap)
+	ctx.MOVQ(left.s, l.swap)
 	Add(rigth.s, l.swap)
-	MOVQ(l.swap, l.s)
+	ctx.MOVQ(l.swap, l.s)
 }
 
 // loads subtractin result of (left - rigth)
 // left operand is overwritten if stored at register
 func (l *limb) loadSub(left *limb, rigth *limb, brw bool) {
-	Sub := SUBQ
+	Sub := ctx.SUBQ
 	if brw {
-		Sub = SBBQ
+		Sub = ctx.SBBQ
 	}
 	// Left :	R R M M
 	// Right:	R M R M
 	// Sub  : R R R R
 	if l.atReg() {
-		MOVQ(left.s, l.s)
+		ctx.MOVQ(left.s, l.s)
 		Sub(rigth.s, l.s)
 		return
 	}
@@ -530,93 +529,93 @@ func (l *limb) loadSub(left *limb, rigth *limb, brw bool) {
 	// Sub  : M M M
 	if left.atReg() {
 		Sub(rigth.s, left.s)
-		MOVQ(left.s, l.s)
+		ctx.MOVQ(left.s, l.s)
 		return
 	}
 	// Left :	M
 	// Right:	M
 	// Sub  : M
-	MOVQ(left.s, l.swap)
+	ctx.MOVQ(left.s, l.swap)
 	Sub(rigth.s, l.swap)
-	MOVQ(l.swap, l.s)
+	ctx.MOVQ(l.swap, l.s)
 }
 
 // loads subtractin result of (left - rigth)
 func (l *limb) loadSubSafe(left *limb, rigth *limb, brw bool) {
-	Sub := SUBQ
+	Sub := ctx.SUBQ
 	if brw {
-		Sub = SBBQ
+		Sub = ctx.SBBQ
 	}
 	// Left :	R R M M
 	// Right:	R M R M
 	// Sub  : R R R R
 	if l.atReg() {
-		MOVQ(left.s, l.s)
+		ctx.MOVQ(left.s, l.s)
 		Sub(rigth.s, l.s)
 		return
 	}
 	// Left :	M R R R
 	// Right:	M R M M
 	// Sub  : M M M M
-	MOVQ(left.s, l.swap)
+	ctx.MOVQ(left.s, l.swap)
 	Sub(rigth.s, l.swap)
-	MOVQ(l.swap, l.s)
+	ctx.MOVQ(l.swap, l.s)
 }
 
 func (l *limb) loadDouble(l2 *limb, brw bool) {
-	Add := ADDQ
+	Add := ctx.ADDQ
 	if brw {
-		Add = ADCQ
+		Add = ctx.ADCQ
 	}
 	if l.atReg() {
-		MOVQ(l2.s, l.s)
+		ctx.MOVQ(l2.s, l.s)
 		Add(l.s, l.s)
 		return
 	}
-	MOVQ(l2.s, l.swap)
+	ctx.MOVQ(l2.s, l.swap)
 	Add(l.swap, l.swap)
-	MOVQ(l.swap, l.s)
+	ctx.MOVQ(l.swap, l.s)
 }
 
 func (l *limb) mulx(lo, hi *limb) *limb {
-	MULXQ(l.s, lo.s, hi.s)
+	ctx.MULXQ(l.s, lo.s, hi.s)
 	return l
 }
 
 func (l *limb) adcxq(a *limb) *limb {
-	ADCXQ(a.s, l.s)
+	ctx.ADCXQ(a.s, l.s)
 	return l
 }
 
 func (l *limb) adoxq(a *limb) *limb {
-	ADOXQ(a.s, l.s)
+	ctx.ADOXQ(a.s, l.s)
 	return l
 }
 
 func (l *limb) mul(op, a0, a1 *limb, addOrMove bool) {
-	MOVQ(l.s, RAX)
-	MULQ(op.s)
+	ctx.MOVQ(l.s, RAX)
+	ctx.MULQ(op.s)
 	if addOrMove == _MUL_ADD {
 		if a0 != nil {
-			ADDQ(RAX, a0.s)
+			ctx.ADDQ(RAX, a0.s)
 		}
 		if a1 != nil {
-			ADCQ(RDX, a1.s)
+			ctx.ADCQ(RDX, a1.s)
 		}
 		return
 	}
 	if a0 != nil {
-		MOVQ(RAX, a0.s)
+		ctx.MOVQ(RAX, a0.s)
 	}
 	if a1 != nil {
-		MOVQ(RDX, a1.s)
+		ctx.MOVQ(RDX, a1.s)
 	}
 }
 
 func (l *limb) add(op *limb, car bool) *limb {
-	operation := ADDQ
+	operation := ctx.ADDQ
 	if car {
-		operation = ADCQ
+		operation = ctx.ADCQ
 	}
 	if op.atMem() && !l.atReg() {
 		op.moveTo(newLimb(l.swap), _NO_ASSIGN)
@@ -628,7 +627,7 @@ func (l *limb) add(op *limb, car bool) *limb {
 }
 
 func (l *limb) adc(op *limb) *limb {
-	operation := ADCQ
+	operation := ctx.ADCQ
 	if op.atMem() && !l.atReg() {
 		op.moveTo(newLimb(l.swap), _NO_ASSIGN)
 		operation(l.swap, l.s)
@@ -646,7 +645,7 @@ func (l *limb) adcIf(c bool, op *limb) *limb {
 }
 
 func (l *limb) addNoCarry(op *limb) *limb {
-	operation := ADDQ
+	operation := ctx.ADDQ
 	if op.atMem() && !l.atReg() {
 		op.moveTo(newLimb(l.swap), _NO_ASSIGN)
 		operation(l.swap, l.s)
@@ -657,19 +656,19 @@ func (l *limb) addNoCarry(op *limb) *limb {
 }
 
 func (l *limb) addCarry() *limb {
-	ADCQ(Imm(0), l.s)
+	ctx.ADCQ(Imm(0), l.s)
 	return l
 }
 
 func (l *limb) addCarryIf(c bool) *limb {
 	if c {
-		ADCQ(Imm(0), l.s)
+		ctx.ADCQ(Imm(0), l.s)
 	}
 	return l
 }
 
 func (l *limb) clear() *limb {
-	MOVQ(U64(0), l.s)
+	ctx.MOVQ(U64(0), l.s)
 	return l
 }
 
@@ -708,16 +707,16 @@ func (l *limb) asPhysicalUnchecked() GPPhysical {
 
 func (l *limb) clearIf(c bool) *limb {
 	if c {
-		MOVQ(U64(0), l.s)
+		ctx.MOVQ(U64(0), l.s)
 	}
 	return l
 }
 
 func (l *limb) cmp(op *limb) {
-	CMPQ(l.s, op.s)
+	ctx.CMPQ(l.s, op.s)
 }
 
 func (l *limb) xorself() *limb {
-	XORQ(l.s, l.s)
+	ctx.XORQ(l.s, l.s)
 	return l
 }
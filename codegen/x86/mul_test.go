@@ -304,7 +304,7 @@ package x86
 // var fuz int
 
 // func TestMain(m *testing.M) {
-// 	_fuz := flag.Int("fuzz", 50, "# of iters")
+// 	_fuz := flag.Int("iter", 50, "# of iters")
 // 	flag.Parse()
 // 	fuz = *_fuz
 // 	m.Run()
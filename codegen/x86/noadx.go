@@ -3,7 +3,7 @@ package x86
 import (
 	"fmt"
 
-	. "github.com/mmcloughlin/avo/build"
+	"github.com/mmcloughlin/avo/attr"
 	. "github.com/mmcloughlin/avo/operand"
 )
 
@@ -18,9 +18,9 @@ func genMontMulNoADX(size int, fixedmod bool, single, archTag bool) {
 		modulusName = fmt.Sprintf("%s%d", modulusName, size)
 	}
 	if fixedmod {
-		TEXT(funcName, NOSPLIT, fmt.Sprintf("func(c, a, b *[%d]uint64)", size))
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, b *[%d]uint64)", size))
 	} else {
-		TEXT(funcName, NOSPLIT, fmt.Sprintf("func(c, a, b, p *[%d]uint64, inp uint64)", size))
+		text(funcName, attr.NOSPLIT, fmt.Sprintf("func(c, a, b, p *[%d]uint64, inp uint64)", size))
 	}
 	commentHeader("inputs")
 	tape := newTape(_NO_SWAP, ax.s, dx.s)
@@ -121,7 +121,7 @@ func genMontMulNoADX(size int, fixedmod bool, single, archTag bool) {
 			// these two carries will be added to same limb
 			comment("aggregate carries from q2 & q3")
 			comment(fmt.Sprintf("should be added to w%d", W.i))
-			llCarry.adc(lCarry)
+			llCarry.add(lCarry, _NO_CARRY)
 			// Q4
 			montQ4NoADX(montRsize, tape, W, lCarry).commentState("W q4").debug("W q4")
 			lastBit = lCarry
@@ -141,7 +141,7 @@ func genMontMulNoADX(size int, fixedmod bool, single, archTag bool) {
 			i != 0,
 		)
 	}
-	SBBQ(U32(0), lastBit.s)
+	ctx.SBBQ(U32(0), lastBit.s)
 	commentHeader("out")
 	C := tape.newReprAtParam(Red.size, "c", lastBit, 0)
 	for i := 0; i < Red.size; i++ {
@@ -1,5 +1,5 @@
 #!/bin/bash -e
-N_FUZZ=100
+N_ITER=100
 
 
 limb_sizes=(2 3 4 5 6 7 8 9 10 11 12 13 14 15 16)
@@ -7,13 +7,13 @@ limb_sizes=(2 3 4 5 6 7 8 9 10 11 12 13 14 15 16)
 for LIMB_SIZE in "${limb_sizes[@]}"
 do
   go run ./test/ -limb $LIMB_SIZE -noadx -fixed
-  go test ./debug/ -run Hard -fuzz $N_FUZZ -v
+  go test ./debug/ -run Hard -iter $N_ITER -v
 done
 
 for LIMB_SIZE in "${limb_sizes[@]}"
 do
   go run ./test/ -limb $LIMB_SIZE -noadx
-  go test ./debug/ -run Hard -fuzz $N_FUZZ -v
+  go test ./debug/ -run Hard -iter $N_ITER -v
 done
 
 
@@ -91,8 +91,10 @@ func montQ13NoADX(rsize int, tape *tape, W *repr) *repr {
 					w2.comment("w", W.i-1)
 					w2.add(iCarry, _CARRY)
 				} else {
-					modulus.next().mul(u, w, lCarry, _MUL_ADD)
+					modulus.next().mul(u, w, nil, _MUL_ADD)
+					iCarry.addCarry()
 					w.add(sCarry, _NO_CARRY)
+					iCarry.addCarry()
 					// where register rotation happens
 					// if next wi is at memory
 					// bring it to a register that should have
@@ -103,10 +105,13 @@ func montQ13NoADX(rsize int, tape *tape, W *repr) *repr {
 						w2.moveTo(r, _ASSIGN)
 					}
 					// add long carry
-					w2.comment("w", W.i-1).adc(lCarry)
+					w2.comment("w", W.i-1)
+					addLongCarry(w2, iCarry, lCarry)
+				}
+				if firstI {
+					// make long carry
+					lCarry.addCarry()
 				}
-				// make long carry
-				lCarry.clearIf(!firstI).addCarry()
 			}
 			_, _ = firstJ, lastJ // fix: remove declaration if not necesaary
 		}
@@ -178,26 +183,27 @@ func montQ2NoADX(rsize int, tape *tape, W *repr, lCarry *limb) *repr {
 			} else {
 				// w_(i+j+1)
 				w2 := W.next()
+				modulus.next().mul(u, w, nil, _MUL_ADD)
+				iCarry.addCarry()
+				w.add(sCarry, _NO_CARRY)
+				iCarry.addCarry()
 				if firstI {
-					modulus.next().mul(u, w, nil, _MUL_ADD)
-					if span == rsize {
-						comment("bring the carry from q1")
-						iCarry.adc(llCarry)
-					} else {
-						iCarry.addCarry()
-					}
-					w.add(sCarry, _NO_CARRY)
 					if w2.atMem() {
 						comment("move to an idle register")
 						r := tape.lookupLimb("u")
 						w2.moveAssign(r)
 						firstSwap = false
 					}
-					w2.comment("w", W.i-1).adc(iCarry)
-					// :)
+					w2.comment("w", W.i-1)
+					if span == rsize {
+						comment("bring the carry from q1")
+						llCarry.move(lCarry)
+						addLongCarry(w2, iCarry, lCarry)
+					} else {
+						w2.add(iCarry, _NO_CARRY)
+						lCarry.clear().addCarry()
+					}
 				} else {
-					modulus.next().mul(u, w, lCarry, _MUL_ADD)
-					w.add(sCarry, _NO_CARRY)
 					if w2.atMem() {
 						if firstSwap {
 							// use 'u' from q1
@@ -218,16 +224,13 @@ func montQ2NoADX(rsize int, tape *tape, W *repr, lCarry *limb) *repr {
 					}
 					// add long carry
 					k := (W.i - 1 + W.size) % W.size // mod
-					w2.comment("w", k).adc(lCarry)
+					w2.comment("w", k)
+					addLongCarry(w2, iCarry, lCarry)
 				}
-				// make long carry
 				if i == rsize-span-1 {
 					// this is the point where we should inlude long-long-carry from q1
 					comment("bring the carry from q1")
-					llCarry.move(lCarry)
-					lCarry.addCarry()
-				} else {
-					lCarry.clear().addCarry()
+					lCarry.add(llCarry, _NO_CARRY)
 				}
 			}
 			_, _ = firstJ, lastJ // fix: remove if not necesaary
@@ -287,16 +290,15 @@ func montQ4NoADX(rsize int, tape *tape, W *repr, lCarry *limb) *repr {
 				sCarry.clear().adc(iCarry)
 			} else {
 				w2 := W.next().assertAtMem()
+				modulus.next().mul(u, w, nil, _MUL_ADD)
+				iCarry.addCarry()
+				w.add(sCarry, _NO_CARRY)
+				iCarry.addCarry()
 				if firstI {
-					modulus.next().mul(u, w, nil, _MUL_ADD)
-					iCarry.adc(llCarry)
-					w.add(sCarry, _NO_CARRY)
 					w2.moveAssign(idle)
 					w2.comment("w", W.i-1)
-					w2.add(iCarry, _CARRY)
+					llCarry.move(lCarry)
 				} else {
-					modulus.next().mul(u, w, lCarry, _MUL_ADD)
-					w.add(sCarry, _NO_CARRY)
 					if lastI {
 						comment("very last limb goes to short carry register")
 						tape.free(w2)
@@ -308,9 +310,8 @@ func montQ4NoADX(rsize int, tape *tape, W *repr, lCarry *limb) *repr {
 						w2.moveAssign(r)
 					}
 					w2.comment("w", W.i-1)
-					w2.add(lCarry, _CARRY)
 				}
-				lCarry.clear().addCarry()
+				addLongCarry(w2, iCarry, lCarry)
 			}
 			_, _ = firstJ, lastJ // fix: remove if not necesaary
 		}
@@ -351,10 +352,12 @@ func montQ2SpecialCaseNoADX(rsize int, tape *tape, W *repr, sCarry, lCarry *limb
 			continue
 		}
 		modulus.next().mul(u, w1, nil, _MUL_ADD)
-		iCarry.adc(sCarry)
-		sCarry.clear()
+		// short carry may be larger than a bit here,
+		// so it is not folded into the high word with a single adc
+		iCarry.addCarry()
+		iCarry.addNoCarry(sCarry)
+		sCarry.clear().addCarry()
 		w2.add(iCarry, _NO_CARRY)
-		// may not work
 		if i != size-3 {
 			sCarry.addCarry()
 		} else {
@@ -411,15 +414,32 @@ func montQ3SpecialCaseNoADX(rsize int, tape *tape, W *repr, sCarry, lCarry *limb
 			comment("move to idle register")
 			w.moveTo(r, _ASSIGN)
 			w2 := W.next()
-			modulus.next().mul(u, w, lCarry, _MUL_ADD)
+			modulus.next().mul(u, w, nil, _MUL_ADD)
+			iCarry.addCarry()
 			w.add(sCarry, _NO_CARRY)
+			iCarry.addCarry()
+			// long carry from q2 may be more than a bit
+			// collect both overflows of the top limb into the last bit
 			w2.comment("w", W.size-1)
-			lCarry.adc(w2)
+			lCarry.add(iCarry, _NO_CARRY)
+			sCarry.clear().addCarry()
+			lCarry.add(w2, _NO_CARRY)
 			w2.set(lCarry)
 			comment("care the last bit")
-			sCarry.clear().addCarry()
+			sCarry.addCarry()
 		}
 		_, _ = firstJ, lastJ
 	}
 	return W
 }
+
+// addLongCarry adds high word of the last product and the long carry to w.
+// Long carry is then set to the sum of both overflows. Modulus limbs and
+// 'u' can both be all ones, so folding long carry into the high word
+// with a single adc may lose a carry.
+func addLongCarry(w, hi, lCarry *limb) {
+	w.add(lCarry, _NO_CARRY)
+	lCarry.clear().addCarry()
+	w.add(hi, _NO_CARRY)
+	lCarry.addCarry()
+}
@@ -0,0 +1,798 @@
+package x86
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mmcloughlin/avo/ir"
+	"github.com/mmcloughlin/avo/operand"
+	"github.com/mmcloughlin/avo/printer"
+	"github.com/mmcloughlin/avo/reg"
+)
+
+// Peephole pass over the avo IR that runs before kernels are compiled and
+// written. Kernels are built with physical registers so the pass works on
+// final registers. It applies copy propagation, load/store forwarding of
+// stack spills and redundant move removal in a value numbering walk, dead
+// move elimination with liveness, and list scheduling which hoists
+// independent instructions to hide MULX latency.
+
+var peephole = false
+var peepholeStats []kernelDelta
+
+type kernelDelta struct {
+	name          string
+	before, after int
+}
+
+// EnablePeephole turns on the peephole pass for subsequent generation
+func EnablePeephole() {
+	peephole = true
+}
+
+// PeepholeReport returns instruction counts of kernels before and after
+// the peephole pass
+func PeepholeReport() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-24s %8s %8s %8s\n", "kernel", "before", "after", "delta")
+	var before, after int
+	for _, s := range peepholeStats {
+		fmt.Fprintf(&b, "%-24s %8d %8d %8d\n", s.name, s.before, s.after, s.after-s.before)
+		before, after = before+s.before, after+s.after
+	}
+	fmt.Fprintf(&b, "%-24s %8d %8d %8d\n", "total", before, after, after-before)
+	return b.String()
+}
+
+func optimiseFile(f *ir.File) []kernelDelta {
+	var stats []kernelDelta
+	for _, fn := range f.Functions() {
+		before := len(fn.Instructions())
+		optimiseFunction(fn)
+		stats = append(stats, kernelDelta{fn.Name, before, len(fn.Instructions())})
+	}
+	return stats
+}
+
+// writeAsm prints the file as go assembly
+func writeAsm(f *ir.File, filename string) error {
+	b, err := printer.NewGoAsm(printer.NewGoRunConfig()).Print(f)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, b, 0600)
+}
+
+func optimiseFunction(fn *ir.Function) {
+	for {
+		forwarded := forwardValues(fn)
+		removed := removeDeadMoves(fn)
+		if !forwarded && !removed {
+			break
+		}
+	}
+	scheduleFunction(fn)
+}
+
+const (
+	flagsCF = 1 << iota
+	flagsOF
+	flagsRest
+	flagsAll = flagsCF | flagsOF | flagsRest
+)
+
+var flagWrites = map[string]int{
+	"ADDQ": flagsAll, "ADCQ": flagsAll, "SUBQ": flagsAll, "SBBQ": flagsAll, "CMPQ": flagsAll,
+	"XORQ": flagsAll, "ANDQ": flagsAll, "ORQ": flagsAll, "TESTQ": flagsAll, "NEGQ": flagsAll,
+	"MULQ": flagsAll, "RCLQ": flagsCF | flagsOF, "RCRQ": flagsCF | flagsOF,
+	"ADCXQ": flagsCF, "ADOXQ": flagsOF,
+	"MOVQ": 0, "MOVB": 0, "MULXQ": 0, "CMOVQCC": 0, "CMOVQCS": 0, "NOTQ": 0,
+	"RET": 0, "JMP": 0,
+}
+
+var flagReads = map[string]int{
+	"ADCQ": flagsCF, "SBBQ": flagsCF, "ADCXQ": flagsCF, "RCLQ": flagsCF, "RCRQ": flagsCF,
+	"CMOVQCC": flagsCF, "CMOVQCS": flagsCF, "JB": flagsCF, "JCS": flagsCF, "JAE": flagsCF, "JCC": flagsCF,
+	"ADOXQ": flagsOF, "JA": flagsCF | flagsRest, "JHI": flagsCF | flagsRest, "JLS": flagsCF | flagsRest,
+	"JNE": flagsRest, "JNZ": flagsRest, "JEQ": flagsRest, "JE": flagsRest, "JZ": flagsRest,
+}
+
+// ops taking a register or memory source which can be replaced
+var replaceableSources = map[string]bool{
+	"MOVQ": true, "ADDQ": true, "ADCQ": true, "SUBQ": true, "SBBQ": true, "ADCXQ": true, "ADOXQ": true,
+	"MULXQ": true, "MULQ": true, "CMOVQCC": true, "CMOVQCS": true, "ANDQ": true, "ORQ": true, "XORQ": true,
+}
+
+// known tells if effects of the instruction are modeled
+func known(i *ir.Instruction) bool {
+	_, w := flagWrites[i.Opcode]
+	_, r := flagReads[i.Opcode]
+	return w || r
+}
+
+func regKey(r reg.Register) int {
+	if p, ok := r.(reg.Physical); ok {
+		return int(p.Kind())<<16 | int(p.PhysicalID())
+	}
+	return -1
+}
+
+const (
+	memHeap = iota
+	memStack
+	memFrame
+	memStatic
+)
+
+func memKind(m operand.Mem) int {
+	switch m.Base {
+	case reg.StackPointer:
+		return memStack
+	case reg.FramePointer:
+		return memFrame
+	case reg.StaticBase:
+		return memStatic
+	}
+	return memHeap
+}
+
+// effects of an instruction
+type effects struct {
+	regReads, regWrites []int
+	memReads, memWrites []operand.Mem
+	flagReads           int
+	flagWrites          int
+}
+
+func effectsOf(i *ir.Instruction) effects {
+	var e effects
+	addMemRegs := func(m operand.Mem) {
+		for _, r := range operand.Registers(m) {
+			e.regReads = append(e.regReads, regKey(r))
+		}
+	}
+	for _, op := range i.Inputs {
+		switch op := op.(type) {
+		case reg.Register:
+			e.regReads = append(e.regReads, regKey(op))
+		case operand.Mem:
+			e.memReads = append(e.memReads, op)
+			addMemRegs(op)
+		}
+	}
+	for _, op := range i.Outputs {
+		switch op := op.(type) {
+		case reg.Register:
+			e.regWrites = append(e.regWrites, regKey(op))
+		case operand.Mem:
+			e.memWrites = append(e.memWrites, op)
+			addMemRegs(op)
+		}
+	}
+	if cancelling(i) {
+		// zeroing idiom does not read its register
+		e.regReads = nil
+	}
+	e.flagReads = flagReads[i.Opcode]
+	e.flagWrites = flagWrites[i.Opcode]
+	return e
+}
+
+func contains(ops []operand.Op, op operand.Op) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// location is a register or a stack slot that holds a value
+type location struct {
+	stack bool
+	id    int
+}
+
+func locationOf(op operand.Op) (location, bool) {
+	switch op := op.(type) {
+	case reg.Register:
+		if k := regKey(op); k >= 0 {
+			return location{false, k}, true
+		}
+	case operand.Mem:
+		if memKind(op) == memStack && op.Index == nil {
+			return location{true, op.Disp}, true
+		}
+	}
+	return location{}, false
+}
+
+// terminal tells if control does not reach the next instruction
+func terminal(i *ir.Instruction) bool {
+	return i.IsTerminal || i.Opcode == "RET" || i.IsBranch && !i.IsConditional
+}
+
+// cancelling tells if the result does not depend on the register operands
+// as in XORQ AX, AX
+func cancelling(i *ir.Instruction) bool {
+	return i.CancellingInputs && len(i.Operands) == 2 && i.Operands[0] == i.Operands[1]
+}
+
+func constKey(c operand.Constant) string {
+	s := strings.TrimPrefix(c.Asm(), "$")
+	if v, err := strconv.ParseUint(s, 0, 64); err == nil {
+		return strconv.FormatUint(v, 10)
+	}
+	if v, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return strconv.FormatUint(uint64(v), 10)
+	}
+	return s
+}
+
+type memValue struct {
+	mem operand.Mem
+	v   int
+}
+
+// valueTable numbers values held by registers, stack slots and memory
+type valueTable struct {
+	next   int
+	locs   map[location]int
+	since  map[location]int
+	regs   map[location]reg.Register
+	mems   map[string]memValue
+	consts map[string]int
+}
+
+func newValueTable() *valueTable {
+	return &valueTable{
+		locs:   make(map[location]int),
+		since:  make(map[location]int),
+		regs:   make(map[location]reg.Register),
+		mems:   make(map[string]memValue),
+		consts: make(map[string]int),
+	}
+}
+
+func (t *valueTable) fresh() int {
+	t.next++
+	return t.next
+}
+
+// value returns the number of the value of a source operand
+func (t *valueTable) value(op operand.Op, create bool) (int, bool) {
+	if l, ok := locationOf(op); ok {
+		v, ok := t.locs[l]
+		if !ok && create {
+			// value from before the block
+			v, ok = t.fresh(), true
+			t.assign(l, op, v, -1)
+		}
+		return v, ok
+	}
+	switch op := op.(type) {
+	case operand.Mem:
+		mv, ok := t.mems[op.Asm()]
+		if !ok && create {
+			mv, ok = memValue{op, t.fresh()}, true
+			t.mems[op.Asm()] = mv
+		}
+		return mv.v, ok
+	case operand.Constant:
+		v, ok := t.consts[constKey(op)]
+		if !ok && create {
+			v, ok = t.fresh(), true
+			t.consts[constKey(op)] = v
+		}
+		return v, ok
+	}
+	return 0, false
+}
+
+// holder returns the register that holds value v for the longest time
+func (t *valueTable) holder(v int) (location, bool) {
+	var best location
+	found := false
+	for l, w := range t.locs {
+		if l.stack || w != v {
+			continue
+		}
+		if !found || t.since[l] < t.since[best] || t.since[l] == t.since[best] && l.id < best.id {
+			best, found = l, true
+		}
+	}
+	return best, found
+}
+
+func (t *valueTable) assign(l location, op operand.Op, v int, at int) {
+	t.locs[l] = v
+	t.since[l] = at
+	if r, ok := op.(reg.Register); ok {
+		t.regs[l] = r
+	}
+}
+
+// invalidate drops values which are overwritten by the instruction
+func (t *valueTable) invalidate(e effects) {
+	for _, w := range e.memWrites {
+		if l, ok := locationOf(w); ok {
+			delete(t.locs, l)
+			continue
+		}
+		for key, mv := range t.mems {
+			if memKind(w) == memStack || memKind(mv.mem) == memKind(w) && (memKind(w) == memHeap || key == w.Asm()) {
+				delete(t.mems, key)
+			}
+		}
+		if memKind(w) == memStack {
+			for l := range t.locs {
+				if l.stack {
+					delete(t.locs, l)
+				}
+			}
+		}
+	}
+	for _, k := range e.regWrites {
+		delete(t.locs, location{false, k})
+		for key, mv := range t.mems {
+			for _, r := range operand.Registers(mv.mem) {
+				if regKey(r) == k {
+					delete(t.mems, key)
+				}
+			}
+		}
+	}
+}
+
+// forwardValues walks basic blocks with value numbering. Sources held by an
+// older register are replaced with it and moves of a value to a location
+// which already holds it are removed.
+func forwardValues(fn *ir.Function) bool {
+	changed := false
+	t := newValueTable()
+	var nodes []ir.Node
+	for n, node := range fn.Nodes {
+		i, ok := node.(*ir.Instruction)
+		if !ok {
+			if _, ok := node.(ir.Label); ok {
+				t = newValueTable()
+			}
+			nodes = append(nodes, node)
+			continue
+		}
+		if !known(i) {
+			t = newValueTable()
+			nodes = append(nodes, node)
+			continue
+		}
+		if replaceableSources[i.Opcode] && !cancelling(i) {
+			for k, op := range i.Operands {
+				if _, isImm := op.(operand.Constant); isImm || !contains(i.Inputs, op) || contains(i.Outputs, op) {
+					continue
+				}
+				v, ok := t.value(op, false)
+				if !ok {
+					continue
+				}
+				h, ok := t.holder(v)
+				if !ok {
+					continue
+				}
+				if l, isLoc := locationOf(op); isLoc && (l == h || !l.stack && t.since[h] >= t.since[l]) {
+					continue
+				}
+				replaceOperand(i, k, t.regs[h])
+				changed = true
+			}
+		}
+		if i.Opcode == "MOVQ" {
+			if l, ok := locationOf(i.Operands[1]); ok {
+				v, ok := t.value(i.Operands[0], false)
+				if w, had := t.locs[l]; ok && had && w == v {
+					changed = true
+					continue
+				}
+			}
+		}
+		e := effectsOf(i)
+		t.invalidate(e)
+		switch {
+		case i.Opcode == "MOVQ":
+			src, dst := i.Operands[0], i.Operands[1]
+			v, _ := t.value(src, true)
+			if l, ok := locationOf(dst); ok {
+				t.assign(l, dst, v, n)
+			} else if m, ok := dst.(operand.Mem); ok {
+				t.mems[m.Asm()] = memValue{m, v}
+			}
+		case cancelling(i) && i.Opcode == "XORQ":
+			v, _ := t.value(operand.U64(0), true)
+			t.assign(location{false, regKey(i.Operands[1].(reg.Register))}, i.Operands[1], v, n)
+		default:
+			for _, op := range i.Outputs {
+				if l, ok := locationOf(op); ok {
+					t.assign(l, op, t.fresh(), n)
+				}
+			}
+		}
+		// values are kept on fall through but not across jump targets
+		if terminal(i) {
+			t = newValueTable()
+		}
+		nodes = append(nodes, node)
+	}
+	fn.Nodes = nodes
+	return changed
+}
+
+func replaceOperand(i *ir.Instruction, k int, r reg.Register) {
+	old := i.Operands[k]
+	operands := append([]operand.Op(nil), i.Operands...)
+	operands[k] = r
+	i.Operands = operands
+	inputs := append([]operand.Op(nil), i.Inputs...)
+	for j := range inputs {
+		if inputs[j] == old {
+			inputs[j] = r
+			break
+		}
+	}
+	i.Inputs = inputs
+}
+
+// removeDeadMoves removes moves to registers and stack slots which are not
+// read before they are written again or the function returns
+func removeDeadMoves(fn *ir.Function) bool {
+	var code []*ir.Instruction
+	index := make(map[*ir.Instruction]int)
+	labels := make(map[ir.Label]int)
+	for _, node := range fn.Nodes {
+		switch node := node.(type) {
+		case *ir.Instruction:
+			index[node] = len(code)
+			code = append(code, node)
+		case ir.Label:
+			labels[node] = len(code)
+		}
+	}
+	for _, i := range code {
+		if !known(i) {
+			return false
+		}
+	}
+	n := len(code)
+	succ := make([][]int, n)
+	uses := make([]map[location]bool, n)
+	defs := make([]map[location]bool, n)
+	for k, i := range code {
+		if !terminal(i) && k+1 < n {
+			succ[k] = append(succ[k], k+1)
+		}
+		if lbl := i.TargetLabel(); lbl != nil {
+			if target, ok := labels[*lbl]; ok && target < n {
+				succ[k] = append(succ[k], target)
+			}
+		}
+		uses[k], defs[k] = make(map[location]bool), make(map[location]bool)
+		e := effectsOf(i)
+		for _, r := range e.regReads {
+			uses[k][location{false, r}] = true
+		}
+		for _, m := range e.memReads {
+			if l, ok := locationOf(m); ok {
+				uses[k][l] = true
+			} else if memKind(m) == memStack {
+				return false
+			}
+		}
+		for _, r := range e.regWrites {
+			defs[k][location{false, r}] = true
+		}
+		if i.Opcode == "MOVQ" {
+			for _, m := range e.memWrites {
+				if l, ok := locationOf(m); ok {
+					defs[k][l] = true
+				}
+			}
+		}
+	}
+	liveIn := make([]map[location]bool, n)
+	liveOut := make([]map[location]bool, n)
+	for k := range code {
+		liveIn[k], liveOut[k] = make(map[location]bool), make(map[location]bool)
+	}
+	for changed := true; changed; {
+		changed = false
+		for k := n - 1; k >= 0; k-- {
+			for _, s := range succ[k] {
+				for l := range liveIn[s] {
+					if !liveOut[k][l] {
+						liveOut[k][l], changed = true, true
+					}
+				}
+			}
+			for l := range uses[k] {
+				if !liveIn[k][l] {
+					liveIn[k][l], changed = true, true
+				}
+			}
+			for l := range liveOut[k] {
+				if !defs[k][l] && !liveIn[k][l] {
+					liveIn[k][l], changed = true, true
+				}
+			}
+		}
+	}
+	dead := make(map[*ir.Instruction]bool)
+	for k, i := range code {
+		if i.Opcode != "MOVQ" {
+			continue
+		}
+		if l, ok := locationOf(i.Operands[1]); ok && !liveOut[k][l] {
+			dead[i] = true
+		}
+	}
+	if len(dead) == 0 {
+		return false
+	}
+	var nodes []ir.Node
+	for _, node := range fn.Nodes {
+		if i, ok := node.(*ir.Instruction); ok && dead[i] {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	fn.Nodes = nodes
+	return true
+}
+
+// latency in cycles used for scheduling priorities
+func latency(i *ir.Instruction) int {
+	switch i.Opcode {
+	case "MULXQ", "MULQ":
+		return 4
+	}
+	for _, op := range i.Inputs {
+		if operand.IsMem(op) {
+			return 4
+		}
+	}
+	return 1
+}
+
+// unit is an instruction with the comments preceding it
+type unit struct {
+	comments []ir.Node
+	ins      *ir.Instruction
+}
+
+// scheduleFunction reorders instructions of straight line regions. Regions
+// end at labels, branches, returns and instructions whose effects are not
+// modeled.
+func scheduleFunction(fn *ir.Function) {
+	var nodes []ir.Node
+	var region []unit
+	var comments []ir.Node
+	flush := func() {
+		for _, u := range scheduleRegion(region) {
+			nodes = append(nodes, u.comments...)
+			nodes = append(nodes, u.ins)
+		}
+		region = nil
+	}
+	for _, node := range fn.Nodes {
+		switch node := node.(type) {
+		case *ir.Instruction:
+			if node.IsBranch || terminal(node) || !known(node) {
+				flush()
+				nodes = append(nodes, comments...)
+				nodes = append(nodes, node)
+				comments = nil
+				continue
+			}
+			region = append(region, unit{comments, node})
+			comments = nil
+		case ir.Label:
+			flush()
+			nodes = append(nodes, comments...)
+			nodes = append(nodes, node)
+			comments = nil
+		default:
+			comments = append(comments, node)
+		}
+	}
+	flush()
+	fn.Nodes = append(nodes, comments...)
+}
+
+func memResource(m operand.Mem) string {
+	switch memKind(m) {
+	case memHeap:
+		return "heap"
+	case memStack:
+		if m.Index == nil {
+			return fmt.Sprintf("stack%d", m.Disp)
+		}
+		return "stack"
+	}
+	return m.Asm()
+}
+
+// depEdge is a dependency on an earlier instruction with the latency in
+// cycles until the dependent one can issue
+type depEdge struct {
+	to, lat int
+}
+
+// dependencies builds the dependency graph of straight line code over
+// registers, flags and memory resources
+func dependencies(ins []*ir.Instruction) ([][]depEdge, []int) {
+	n := len(ins)
+	succs := make([][]depEdge, n)
+	preds := make([]int, n)
+	lastWrite := make(map[string]int)
+	readers := make(map[string][]int)
+	addEdge := func(from, to, lat int) {
+		if from == to {
+			return
+		}
+		succs[from] = append(succs[from], depEdge{to, lat})
+		preds[to]++
+	}
+	for k, i := range ins {
+		e := effectsOf(i)
+		var reads, writes []string
+		for _, r := range e.regReads {
+			reads = append(reads, fmt.Sprintf("r%d", r))
+		}
+		for _, r := range e.regWrites {
+			writes = append(writes, fmt.Sprintf("r%d", r))
+		}
+		for _, m := range e.memReads {
+			reads = append(reads, memResource(m))
+		}
+		for _, m := range e.memWrites {
+			writes = append(writes, memResource(m))
+		}
+		for f, name := range []string{"CF", "OF", "flags"} {
+			if e.flagReads&(1<<uint(f)) != 0 {
+				reads = append(reads, name)
+			}
+			if e.flagWrites&(1<<uint(f)) != 0 {
+				writes = append(writes, name)
+			}
+		}
+		// indexed stack access conflicts with every slot
+		conflicts := func(res string) []string {
+			if res == "stack" {
+				var all []string
+				for r := range lastWrite {
+					if strings.HasPrefix(r, "stack") {
+						all = append(all, r)
+					}
+				}
+				for r := range readers {
+					if strings.HasPrefix(r, "stack") {
+						all = append(all, r)
+					}
+				}
+				return append(all, res)
+			}
+			if strings.HasPrefix(res, "stack") {
+				return []string{res, "stack"}
+			}
+			return []string{res}
+		}
+		for _, res := range reads {
+			for _, c := range conflicts(res) {
+				if w, ok := lastWrite[c]; ok {
+					addEdge(w, k, latency(ins[w]))
+				}
+			}
+		}
+		for _, res := range writes {
+			for _, c := range conflicts(res) {
+				if w, ok := lastWrite[c]; ok {
+					addEdge(w, k, 1)
+				}
+				for _, r := range readers[c] {
+					addEdge(r, k, 0)
+				}
+			}
+		}
+		for _, res := range reads {
+			readers[res] = append(readers[res], k)
+		}
+		for _, res := range writes {
+			lastWrite[res] = k
+			readers[res] = nil
+		}
+	}
+	return succs, preds
+}
+
+// heights returns the longest latency weighted path from each instruction
+// to the end of the code
+func heights(ins []*ir.Instruction, succs [][]depEdge) []int {
+	height := make([]int, len(ins))
+	for k := len(ins) - 1; k >= 0; k-- {
+		h := latency(ins[k])
+		for _, s := range succs[k] {
+			if s.lat+height[s.to] > h {
+				h = s.lat + height[s.to]
+			}
+		}
+		height[k] = h
+	}
+	return height
+}
+
+func scheduleRegion(region []unit) []unit {
+	n := len(region)
+	if n < 2 {
+		return region
+	}
+	ins := make([]*ir.Instruction, n)
+	for k, u := range region {
+		ins[k] = u.ins
+	}
+	succs, preds := dependencies(ins)
+	height := heights(ins, succs)
+	earliest := make([]int, n)
+	var ready []int
+	for k := 0; k < n; k++ {
+		if preds[k] == 0 {
+			ready = append(ready, k)
+		}
+	}
+	var out []unit
+	cycle := 0
+	for len(ready) > 0 {
+		best := -1
+		for j, k := range ready {
+			if best < 0 {
+				best = j
+				continue
+			}
+			b := ready[best]
+			kReady, bReady := earliest[k] <= cycle, earliest[b] <= cycle
+			switch {
+			case kReady != bReady:
+				if kReady {
+					best = j
+				}
+			case !kReady && earliest[k] != earliest[b]:
+				if earliest[k] < earliest[b] {
+					best = j
+				}
+			case height[k] != height[b]:
+				if height[k] > height[b] {
+					best = j
+				}
+			case k < b:
+				best = j
+			}
+		}
+		k := ready[best]
+		ready = append(ready[:best], ready[best+1:]...)
+		if earliest[k] > cycle {
+			cycle = earliest[k]
+		}
+		out = append(out, region[k])
+		for _, s := range succs[k] {
+			if t := cycle + s.lat; t > earliest[s.to] {
+				earliest[s.to] = t
+			}
+			preds[s.to]--
+			if preds[s.to] == 0 {
+				ready = append(ready, s.to)
+			}
+		}
+		sort.Ints(ready)
+		cycle++
+	}
+	return out
+}
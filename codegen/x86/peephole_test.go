@@ -0,0 +1,119 @@
+package x86
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmcloughlin/avo/ir"
+	. "github.com/mmcloughlin/avo/operand"
+	. "github.com/mmcloughlin/avo/reg"
+	"github.com/mmcloughlin/avo/x86"
+)
+
+func irFunction(t *testing.T, code ...func() (*ir.Instruction, error)) *ir.Function {
+	t.Helper()
+	fn := ir.NewFunction("f")
+	for _, c := range code {
+		i, err := c()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fn.AddInstruction(i)
+	}
+	return fn
+}
+
+func renderFunction(fn *ir.Function) string {
+	var lines []string
+	for _, i := range fn.Instructions() {
+		var ops []string
+		for _, op := range i.Operands {
+			ops = append(ops, op.Asm())
+		}
+		lines = append(lines, strings.TrimSpace(i.Opcode+" "+strings.Join(ops, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestPeephole(t *testing.T) {
+	a, b := NewParamAddr("a", 0), NewParamAddr("b", 8)
+	ret := NewParamAddr("ret", 16)
+	for _, c := range []struct {
+		desc     string
+		fn       *ir.Function
+		expected []string
+	}{
+		{
+			"swap moves",
+			irFunction(t,
+				func() (*ir.Instruction, error) { return x86.MOVQ(a, RAX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(RAX, RBX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(RBX, RAX) },
+				func() (*ir.Instruction, error) { return x86.ADDQ(RBX, RAX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(RAX, ret) },
+				x86.RET,
+			),
+			[]string{"MOVQ a+0(FP), AX", "ADDQ AX, AX", "MOVQ AX, ret+16(FP)", "RET"},
+		},
+		{
+			"stack spill forwarding",
+			irFunction(t,
+				func() (*ir.Instruction, error) { return x86.MOVQ(a, RAX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(b, RCX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(RAX, NewStackAddr(8)) },
+				func() (*ir.Instruction, error) { return x86.ADDQ(NewStackAddr(8), RCX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(RCX, ret) },
+				x86.RET,
+			),
+			[]string{"MOVQ a+0(FP), AX", "MOVQ b+8(FP), CX", "ADDQ AX, CX", "MOVQ CX, ret+16(FP)", "RET"},
+		},
+		{
+			"dead moves",
+			irFunction(t,
+				func() (*ir.Instruction, error) { return x86.MOVQ(a, RAX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(RAX, RBX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(b, RBX) },
+				func() (*ir.Instruction, error) { return x86.ADDQ(RBX, RAX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(RAX, ret) },
+				x86.RET,
+			),
+			[]string{"MOVQ a+0(FP), AX", "MOVQ b+8(FP), BX", "ADDQ BX, AX", "MOVQ AX, ret+16(FP)", "RET"},
+		},
+		{
+			"flags are preserved",
+			irFunction(t,
+				func() (*ir.Instruction, error) { return x86.MOVQ(a, RAX) },
+				func() (*ir.Instruction, error) { return x86.ADDQ(b, RAX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(U32(0), RCX) },
+				func() (*ir.Instruction, error) { return x86.ADCQ(U32(0), RCX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(RCX, ret) },
+				x86.RET,
+			),
+			[]string{"MOVQ a+0(FP), AX", "MOVQ $0x00000000, CX", "ADDQ b+8(FP), AX", "ADCQ $0x00000000, CX", "MOVQ CX, ret+16(FP)", "RET"},
+		},
+		{
+			"multiplication latency",
+			irFunction(t,
+				func() (*ir.Instruction, error) { return x86.MOVQ(a, RDI) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(b, RSI) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(Mem{Base: RDI}, RDX) },
+				func() (*ir.Instruction, error) { return x86.MULXQ(Mem{Base: RSI}, RAX, RBX) },
+				func() (*ir.Instruction, error) { return x86.ADDQ(RBX, RAX) },
+				func() (*ir.Instruction, error) { return x86.MULXQ(Mem{Base: RSI, Disp: 8}, R8, R9) },
+				func() (*ir.Instruction, error) { return x86.ADDQ(R9, R8) },
+				func() (*ir.Instruction, error) { return x86.ADDQ(R8, RAX) },
+				func() (*ir.Instruction, error) { return x86.MOVQ(RAX, ret) },
+				x86.RET,
+			),
+			[]string{
+				"MOVQ a+0(FP), DI", "MOVQ b+8(FP), SI", "MOVQ (DI), DX", "MULXQ (SI), AX, BX", "MULXQ 8(SI), R8, R9",
+				"ADDQ BX, AX", "ADDQ R9, R8", "ADDQ R8, AX", "MOVQ AX, ret+16(FP)", "RET",
+			},
+		},
+	} {
+		optimiseFunction(c.fn)
+		if have, want := renderFunction(c.fn), strings.Join(c.expected, "\n"); have != want {
+			t.Fatalf("%s\nhave:\n%s\nwant:\n%s", c.desc, have, want)
+		}
+	}
+}
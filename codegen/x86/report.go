@@ -0,0 +1,117 @@
+package x86
+
+import (
+	"encoding/json"
+
+	"github.com/mmcloughlin/avo/ir"
+)
+
+// Allocation report of generated kernels. Register pressure and stack slots
+// are taken from the tape of the kernel, spills, reloads, instruction mix
+// and critical path are taken from the final instructions so the report
+// reflects the peephole pass when it is enabled.
+
+var allocReport = false
+var allocReports []KernelReport
+var tapes = make(map[*ir.Function]*tape)
+
+// KernelReport is the allocation report of a generated kernel
+type KernelReport struct {
+	Name string `json:"name"`
+	// general purpose registers available to the allocator
+	GPRs int `json:"gprs"`
+	// highest number of general purpose registers in use at once,
+	// reserved registers included
+	PeakGPR int `json:"peak_gpr"`
+	// 8 byte stack slots used by the allocator
+	StackSlots int `json:"stack_slots"`
+	// instructions storing to and loading from stack slots
+	Spills  int `json:"spills"`
+	Reloads int `json:"reloads"`
+	// instruction count in total and by opcode
+	Instructions int            `json:"instructions"`
+	Mix          map[string]int `json:"mix"`
+	// longest dependency chain in cycles with the latency model of the
+	// scheduler, branches are ignored so it is an estimate for kernels
+	// with loops
+	CriticalPath int `json:"critical_path"`
+}
+
+// EnableAllocReport turns on collection of allocation reports for
+// subsequent generation
+func EnableAllocReport() {
+	allocReport = true
+}
+
+// AllocReport returns allocation reports of generated kernels as JSON
+func AllocReport() ([]byte, error) {
+	reports := allocReports
+	if reports == nil {
+		reports = []KernelReport{}
+	}
+	return json.MarshalIndent(reports, "", "  ")
+}
+
+// trackTape binds the tape to the function being built on ctx
+func trackTape(t *tape) {
+	if !allocReport {
+		return
+	}
+	f, _ := ctx.Result()
+	if f == nil {
+		return
+	}
+	if fns := f.Functions(); len(fns) != 0 {
+		tapes[fns[len(fns)-1]] = t
+	}
+}
+
+// reportFile returns reports of functions of f and releases their tapes
+func reportFile(f *ir.File) []KernelReport {
+	var reports []KernelReport
+	for _, fn := range f.Functions() {
+		reports = append(reports, reportFunction(fn, tapes[fn]))
+		delete(tapes, fn)
+	}
+	return reports
+}
+
+func reportFunction(fn *ir.Function, t *tape) KernelReport {
+	r := KernelReport{Name: fn.Name, Mix: make(map[string]int)}
+	if t != nil {
+		r.GPRs, r.PeakGPR, r.StackSlots = t.gpSet.size, t.gpSet.peak, t.stack.size
+	}
+	ins := fn.Instructions()
+	r.Instructions = len(ins)
+	for _, i := range ins {
+		r.Mix[i.Opcode]++
+		e := effectsOf(i)
+		for _, m := range e.memWrites {
+			if memKind(m) == memStack {
+				r.Spills++
+				break
+			}
+		}
+		for _, m := range e.memReads {
+			if memKind(m) == memStack {
+				r.Reloads++
+				break
+			}
+		}
+	}
+	r.CriticalPath = criticalPath(ins)
+	return r
+}
+
+// criticalPath returns the longest latency weighted path of the code taken
+// as straight line
+func criticalPath(ins []*ir.Instruction) int {
+	succs, _ := dependencies(ins)
+	path := 0
+	for _, h := range heights(ins, succs) {
+		if h > path {
+			path = h
+		}
+	}
+	return path
+}
@@ -0,0 +1,88 @@
+package x86
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mmcloughlin/avo/ir"
+	. "github.com/mmcloughlin/avo/operand"
+	. "github.com/mmcloughlin/avo/reg"
+	"github.com/mmcloughlin/avo/x86"
+)
+
+func TestReport(t *testing.T) {
+	a, b := NewParamAddr("a", 0), NewParamAddr("b", 8)
+	ret := NewParamAddr("ret", 16)
+	fn := irFunction(t,
+		func() (*ir.Instruction, error) { return x86.MOVQ(a, RDX) },
+		func() (*ir.Instruction, error) { return x86.MULXQ(b, RAX, RBX) },
+		func() (*ir.Instruction, error) { return x86.MOVQ(RAX, NewStackAddr(8)) },
+		func() (*ir.Instruction, error) { return x86.MOVQ(NewStackAddr(8), RCX) },
+		func() (*ir.Instruction, error) { return x86.ADDQ(RBX, RCX) },
+		func() (*ir.Instruction, error) { return x86.MOVQ(RCX, ret) },
+		x86.RET,
+	)
+	tape := newTape(RAX, RDX)
+	tape.next()
+	tape.free(newLimb(RAX))
+	tape.stack.next()
+	expected := KernelReport{
+		Name:         "f",
+		GPRs:         14,
+		PeakGPR:      3,
+		StackSlots:   1,
+		Spills:       1,
+		Reloads:      1,
+		Instructions: 7,
+		Mix:          map[string]int{"MOVQ": 4, "MULXQ": 1, "ADDQ": 1, "RET": 1},
+		// load, multiplication, spill, reload, addition and store
+		CriticalPath: 4 + 4 + 1 + 4 + 1 + 1,
+	}
+	r := reportFunction(fn, tape)
+	if !reflect.DeepEqual(r, expected) {
+		t.Fatalf("have %+v, want %+v", r, expected)
+	}
+	b1, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded KernelReport
+	if err := json.Unmarshal(b1, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Fatalf("json round trip, have %+v, want %+v", decoded, expected)
+	}
+}
+
+func TestReportGenerations(t *testing.T) {
+	allocReport = true
+	allocReports = nil
+	defer func() {
+		allocReport = false
+		allocReports = nil
+	}()
+	// each generation builds on its own context so reports of the second
+	// one should be attributed the same way as the first
+	for i := 0; i < 2; i++ {
+		if err := GenX86(t.TempDir(), 256, "ADX", true, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	n := len(allocReports)
+	if n == 0 || n%2 != 0 {
+		t.Fatalf("have %d allocation reports, want two equal generations", n)
+	}
+	if len(tapes) != 0 {
+		t.Fatalf("tapes should be released after generation, have %d", len(tapes))
+	}
+	for i, r := range allocReports[:n/2] {
+		if r.PeakGPR == 0 {
+			t.Fatalf("tape of %s is not tracked", r.Name)
+		}
+		if !reflect.DeepEqual(r, allocReports[n/2+i]) {
+			t.Fatalf("reports of generations differ, %+v, %+v", r, allocReports[n/2+i])
+		}
+	}
+}
@@ -3,7 +3,7 @@ package x86
 import (
 	"fmt"
 
-	. "github.com/mmcloughlin/avo/build"
+	"github.com/mmcloughlin/avo/gotypes"
 	. "github.com/mmcloughlin/avo/operand"
 	. "github.com/mmcloughlin/avo/reg"
 )
@@ -41,7 +41,9 @@ func newTape(reserve ...Op) *tape {
 		gpSet.alloc(newLimb(reserve[i]))
 	}
 	stack := newStack()
-	return &tape{gpSet, stack, nil, make(map[string]*limb), make(map[string]*repr)}
+	t := &tape{gpSet, stack, nil, make(map[string]*limb), make(map[string]*repr)}
+	trackTape(t)
+	return t
 }
 
 func (t tape) newReprNoAlloc(size int) *repr {
@@ -95,6 +97,12 @@ func (t *tape) allocStack(size int) *repr {
 }
 
 func (t *tape) newReprAtParam(size int, param string, dst *limb, offset int) *repr {
+	return t.newReprAtComponent(size, ctx.Param(param), dst, offset)
+}
+
+// newReprAtComponent is newReprAtParam for a component of a parameter such as
+// base of a slice
+func (t *tape) newReprAtComponent(size int, c gotypes.Component, dst *limb, offset int) *repr {
 	t.allocGp(dst)
 	r, ok := dst.asPhysical()
 	if !ok {
@@ -103,7 +111,7 @@ func (t *tape) newReprAtParam(size int, param string, dst *limb, offset int) *re
 	return t.newReprAtMemory(
 		size,
 		Mem{
-			Base: Load(Param(param), r),
+			Base: ctx.Load(c, r),
 		},
 		offset,
 	)
@@ -208,7 +216,7 @@ func (t *tape) moveToStack(r *repr) *repr {
 
 func (t *tape) ret() {
 	t.stack.allocLocal()
-	RET()
+	ctx.RET()
 	commentHeader("end")
 }
 
@@ -258,6 +266,7 @@ type gpSet struct {
 	allocated map[GPPhysical]bool
 	regs      map[int]GPPhysical
 	size      int
+	peak      int
 }
 
 func newGpSet(regs ...Op) *gpSet {
@@ -280,6 +289,7 @@ func (set *gpSet) alloc(regs ...*limb) {
 			set.allocated[reg] = true
 		}
 	}
+	set.track()
 }
 
 func (set *gpSet) free(regs ...*limb) {
@@ -301,6 +311,7 @@ func (set *gpSet) next() *limb {
 		r := set.regs[i]
 		if !set.allocated[r] {
 			set.allocated[r] = true
+			set.track()
 			return newLimb(r)
 		}
 	}
@@ -329,6 +340,13 @@ func (set *gpSet) sizeAllocated() int {
 	return c
 }
 
+// track records the highest number of registers in use
+func (set *gpSet) track() {
+	if c := set.sizeAllocated(); c > set.peak {
+		set.peak = c
+	}
+}
+
 func (set *gpSet) slice() []GPPhysical {
 	regs := make([]GPPhysical, set.size)
 	for i, r := range set.regs {
@@ -368,11 +386,11 @@ func newStack() *stack {
 }
 
 func (s *stack) allocLocal() {
-	AllocLocal(s.size * 8)
+	ctx.AllocLocal(s.size * 8)
 }
 
 func (s *stack) allocLocalFineTuned(finetune int) {
-	AllocLocal((s.size + finetune) * 8)
+	ctx.AllocLocal((s.size + finetune) * 8)
 }
 
 func (s *stack) extend(size int) Mem {
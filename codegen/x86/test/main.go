@@ -11,10 +11,16 @@ func main() {
 	_fixed := flag.Bool("fixed", false, "# of iters")
 	_noadx := flag.Bool("noadx", false, "# of iters")
 	_logs := flag.Bool("logs", false, "# of iters")
+	_emulate := flag.Int("emulate", 0, "# of random inputs to verify in emulator")
 	flag.Parse()
 	var limbs = *_limb
 	var fixed = *_fixed
 	var noadx = *_noadx
 	var logs = *_logs
 	x86.GenDebugTest(limbs, fixed, noadx, logs)
+	if *_emulate != 0 {
+		if err := x86.VerifyKernels("debug/mul.s", limbs, *_emulate); err != nil {
+			panic(err)
+		}
+	}
 }
@@ -2,8 +2,6 @@ package x86
 
 import (
 	"fmt"
-
-	. "github.com/mmcloughlin/avo/build"
 )
 
 func assert(c bool, desc string) {
@@ -13,14 +11,14 @@ func assert(c bool, desc string) {
 }
 
 func comment(str string) {
-	Commentf("| %s", str)
+	ctx.Commentf("| %s", str)
 }
 
 func commentHeader(str string) {
 	s := fmt.Sprintf("%-40s", str)
 
 	s = s[:40]
-	Commentf("| \n\n/* %s*/\n", s)
+	ctx.Commentf("| \n\n/* %s*/\n", s)
 }
 
 func commentSeperator() {
@@ -0,0 +1,467 @@
+package x86
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// kernel bases ordered so that longer names match first
+var kernelBases = []string{
+	"mul_no_adx_bmi2", "mul_two", "div_two", "is_even", "_neg", "_cmov", "_cswap", "_condNeg", "_lookup",
+	"condNeg", "cmov", "cswap", "lookup", "addn", "subn", "double", "add", "sub", "mul", "cpy", "eq", "cmp",
+}
+
+// kernels taking a condition, verified with zero and non zero conditions
+var conditionalKernels = map[string]bool{
+	"cmov": true, "_cmov": true, "cswap": true, "_cswap": true, "condNeg": true, "_condNeg": true,
+}
+
+// kernels that must not branch or address memory depending on their inputs
+var constantTimeKernels = map[string]bool{
+	"cmov": true, "_cmov": true, "cswap": true, "_cswap": true, "condNeg": true, "_condNeg": true,
+	"_neg": true, "lookup": true, "_lookup": true,
+}
+
+// kernelName splits a function name into kernel base and limb size. Names
+// without limb size suffix take the given limb size.
+func kernelName(name string, limbSize int) (string, int, error) {
+	for _, base := range kernelBases {
+		if !strings.HasPrefix(name, base) {
+			continue
+		}
+		rest := strings.TrimPrefix(strings.TrimPrefix(name, base), "_")
+		if rest == "" {
+			return base, limbSize, nil
+		}
+		size, err := strconv.Atoi(rest)
+		if err != nil {
+			continue
+		}
+		return base, size, nil
+	}
+	return "", 0, fmt.Errorf("unknown kernel %s", name)
+}
+
+// VerifyKernels runs every function of the assembly file in the emulator and
+// checks results against big.Int on edge cases and iter random inputs.
+// Functions without limb size suffix are taken as limbSize limbs.
+func VerifyKernels(filename string, limbSize int, iter int) error {
+	prog, err := loadProgram(filename)
+	if err != nil {
+		return err
+	}
+	for _, name := range prog.order {
+		base, size, err := kernelName(name, limbSize)
+		if err != nil {
+			return err
+		}
+		if size < 1 || size > 16 {
+			return fmt.Errorf("%s: bad limb size %d", name, size)
+		}
+		if err := verifyKernel(prog.funcs[name], base, size, iter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type emuField struct {
+	size int
+	p    *big.Int
+	r    *big.Int
+	inp  uint64
+}
+
+// newEmuField returns a prime field with modulus of bitLen bits
+func newEmuField(size int, bitLen int) (*emuField, error) {
+	if bitLen < 3 {
+		bitLen = 3
+	}
+	p, err := rand.Prime(rand.Reader, bitLen)
+	if err != nil {
+		return nil, err
+	}
+	return newEmuFieldFromModulus(size, p), nil
+}
+
+// newEmuTopField returns a prime field with the largest modulus fitting
+// into size limbs. All limbs of such a modulus but the first one are all ones.
+func newEmuTopField(size int) *emuField {
+	one := big.NewInt(1)
+	p := new(big.Int).Lsh(one, uint(size*64))
+	for p.Sub(p, one); !p.ProbablyPrime(20); p.Sub(p, one) {
+	}
+	return newEmuFieldFromModulus(size, p)
+}
+
+func newEmuFieldFromModulus(size int, p *big.Int) *emuField {
+	w := new(big.Int).Lsh(big.NewInt(1), 64)
+	inp := new(big.Int).ModInverse(new(big.Int).Neg(p), w)
+	r := new(big.Int).Lsh(big.NewInt(1), uint(size*64))
+	return &emuField{size, p, r.Mod(r, p), inp.Uint64()}
+}
+
+// randBelow returns a random value in [0, max)
+func randBelow(max *big.Int) *big.Int {
+	v, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func toWords(v *big.Int, size int) []uint64 {
+	words := make([]uint64, size)
+	for i := range words {
+		words[i] = new(big.Int).Rsh(v, uint(i*64)).Uint64()
+	}
+	return words
+}
+
+func fromWords(words []uint64) *big.Int {
+	v := new(big.Int)
+	for i := len(words) - 1; i >= 0; i-- {
+		v.Lsh(v, 64).Or(v, new(big.Int).SetUint64(words[i]))
+	}
+	return v
+}
+
+// fieldSamples returns edge cases and random elements of the field
+func fieldSamples(f *emuField, iter int) []*big.Int {
+	one := big.NewInt(1)
+	pm1 := new(big.Int).Sub(f.p, one)
+	samples := []*big.Int{new(big.Int), big.NewInt(1), pm1, new(big.Int).Sub(f.p, big.NewInt(2)), new(big.Int).Set(f.r)}
+	if half := new(big.Int).Rsh(f.p, 1); half.Sign() > 0 {
+		samples = append(samples, half, new(big.Int).Add(half, one))
+	}
+	for i := 0; i < iter; i++ {
+		samples = append(samples, randBelow(f.p))
+	}
+	return samples
+}
+
+// wordSamples returns edge cases and random values of size limbs
+func wordSamples(size int, iter int) []*big.Int {
+	max := new(big.Int).Lsh(big.NewInt(1), uint(size*64))
+	ones := new(big.Int).Sub(max, big.NewInt(1))
+	top := new(big.Int).Rsh(max, 1)
+	samples := []*big.Int{new(big.Int), big.NewInt(1), ones, top, new(big.Int).Sub(top, big.NewInt(1))}
+	for i := 0; i < iter; i++ {
+		v := randBelow(max)
+		switch v.Bit(0) + 2*v.Bit(1) {
+		case 0:
+			// only a low limb
+			v.SetUint64(v.Uint64())
+		case 1:
+			// all ones low limbs
+			v.Or(v, new(big.Int).Rsh(ones, 64))
+		}
+		samples = append(samples, v)
+	}
+	return samples
+}
+
+type kernelCall struct {
+	m    *machine
+	f    *emuFunc
+	size int
+}
+
+// traceCheck compares traces of all runs of a constant time kernel against
+// the trace of the first run. nil check accepts any trace.
+type traceCheck struct {
+	ref *emuTrace
+}
+
+func newTraceCheck(base string) *traceCheck {
+	if constantTimeKernels[base] {
+		return new(traceCheck)
+	}
+	return nil
+}
+
+func (c *traceCheck) record(k *kernelCall) {
+	if c != nil {
+		k.m.trace = new(emuTrace)
+	}
+}
+
+func (c *traceCheck) check(k *kernelCall) error {
+	if c == nil {
+		return nil
+	}
+	if c.ref == nil {
+		c.ref = k.m.trace
+		return nil
+	}
+	if !c.ref.equal(k.m.trace) {
+		return fmt.Errorf("branches or memory addresses depend on inputs")
+	}
+	return nil
+}
+
+func newKernelCall(f *emuFunc, field *emuField, size int) *kernelCall {
+	m := newMachine()
+	if field != nil {
+		p := toWords(field.p, size)
+		m.setSymbol("·modulus", p)
+		m.setSymbol(fmt.Sprintf("·modulus%d", size), p)
+		m.setSymbol("·inp", []uint64{field.inp})
+	}
+	return &kernelCall{m, f, size}
+}
+
+func (k *kernelCall) in(v *big.Int) uint64 {
+	return k.m.alloc(toWords(v, k.size))
+}
+
+func (k *kernelCall) out() uint64 {
+	return k.m.allocUndefined(k.size)
+}
+
+func (k *kernelCall) value(addr uint64) *big.Int {
+	return fromWords(k.m.load(addr, k.size))
+}
+
+// run calls the kernel with arguments that fit in its frame
+func (k *kernelCall) run(args []uint64, results int) ([]uint64, error) {
+	if n := (k.f.args+7)/8 - results; n < len(args) {
+		args = args[:n]
+	}
+	return k.m.call(k.f, args, results)
+}
+
+func verifyKernel(f *emuFunc, base string, size int, iter int) error {
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(size*64)), big.NewInt(1))
+	fail := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%s: %s", f.name, fmt.Sprintf(format, args...))
+	}
+	conds := []uint64{0}
+	if conditionalKernels[base] {
+		conds = []uint64{0, 1, randBelow(new(big.Int).Lsh(big.NewInt(1), 64)).Uint64() | 1<<63}
+	}
+	switch base {
+	case "lookup", "_lookup":
+		// traces are compared among lookups of the same table size and aliasing
+		for _, n := range []int{1, 2, 3, 8, 16} {
+			traces := map[bool]*traceCheck{false: newTraceCheck(base), true: newTraceCheck(base)}
+			for idx := -1; idx < n+1; idx++ {
+				for _, alias := range []bool{false, true} {
+					if err := verifyLookup(f, size, n, idx, alias, traces[alias]); err != nil {
+						return fail("%s, n: %d, idx: %d, alias: %t", err, n, idx, alias)
+					}
+				}
+			}
+		}
+		return nil
+	case "cpy", "eq", "cmp", "addn", "subn", "mul_two", "div_two", "is_even", "cmov", "_cmov", "cswap", "_cswap":
+		trace := newTraceCheck(base)
+		samples := wordSamples(size, iter)
+		for i, a := range samples {
+			for _, b := range []*big.Int{a, samples[(i+1)%len(samples)], samples[(i*7+3)%len(samples)]} {
+				for _, cond := range conds {
+					if err := verifyWords(f, base, size, a, b, cond, mask, trace); err != nil {
+						return fail("%s, a: %#x, b: %#x, cond: %#x", err, a, b, cond)
+					}
+				}
+			}
+		}
+		return nil
+	case "add", "sub", "double", "_neg", "condNeg", "_condNeg", "mul", "mul_no_adx_bmi2":
+	default:
+		return fail("no verification for kernel %s", base)
+	}
+	// traces differ only if output aliases an input
+	traces := map[bool]*traceCheck{false: newTraceCheck(base), true: newTraceCheck(base)}
+	// largest, full width, random width and short top limb moduli
+	fields := []*emuField{newEmuTopField(size)}
+	bitLens := []int{size * 64, size*64 - 1, (size-1)*64 + 1 + int(randBelow(big.NewInt(64)).Int64())}
+	for _, bitLen := range bitLens {
+		field, err := newEmuField(size, bitLen)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, field)
+	}
+	for _, field := range fields {
+		samples := fieldSamples(field, iter)
+		for i, a := range samples {
+			for _, b := range []*big.Int{a, samples[(i+1)%len(samples)], samples[(i*7+3)%len(samples)]} {
+				for _, alias := range []bool{false, true} {
+					for _, cond := range conds {
+						if err := verifyField(f, base, field, a, b, cond, alias, traces[alias]); err != nil {
+							return fail("%s, p: %#x, a: %#x, b: %#x, cond: %#x, alias: %t", err, field.p, a, b, cond, alias)
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func verifyWords(f *emuFunc, base string, size int, a, b *big.Int, cond uint64, mask *big.Int, trace *traceCheck) error {
+	k := newKernelCall(f, nil, size)
+	trace.record(k)
+	pa, pb := k.in(a), k.in(b)
+	var expected *big.Int
+	var ret []uint64
+	var err error
+	switch base {
+	case "cpy":
+		dst := k.out()
+		if _, err = k.run([]uint64{dst, pa}, 0); err == nil {
+			ret, expected = k.m.load(dst, size), a
+		}
+	case "eq":
+		if ret, err = k.run([]uint64{pa, pb}, 1); err == nil {
+			expected = big.NewInt(0)
+			if a.Cmp(b) == 0 {
+				expected.SetUint64(1)
+			}
+		}
+	case "cmp":
+		if ret, err = k.run([]uint64{pa, pb}, 1); err == nil {
+			expected = new(big.Int).SetUint64(uint64(uint8(int8(a.Cmp(b)))))
+		}
+	case "addn", "subn":
+		if ret, err = k.run([]uint64{pa, pb}, 1); err == nil {
+			v := new(big.Int)
+			if base == "addn" {
+				v.Add(a, b)
+			} else {
+				v.Sub(a, b)
+			}
+			carry := uint64(0)
+			if v.Sign() < 0 || v.Cmp(mask) > 0 {
+				carry = 1
+			}
+			ret = append(k.m.load(pa, size), ret[0])
+			expected = v.And(v, mask).Or(v, new(big.Int).Lsh(new(big.Int).SetUint64(carry), uint(size*64)))
+		}
+	case "mul_two":
+		if ret, err = k.run([]uint64{pa}, 1); err == nil {
+			ret = append(k.m.load(pa, size), ret[0])
+			expected = new(big.Int).Lsh(a, 1)
+		}
+	case "div_two":
+		if _, err = k.run([]uint64{pa}, 0); err == nil {
+			ret, expected = k.m.load(pa, size), new(big.Int).Rsh(a, 1)
+		}
+	case "is_even":
+		if ret, err = k.run([]uint64{pa}, 1); err == nil {
+			expected = big.NewInt(int64(1 - a.Bit(0)))
+		}
+	case "cmov", "_cmov":
+		dst := k.out()
+		if _, err = k.run([]uint64{dst, pa, pb, cond}, 0); err == nil {
+			ret, expected = k.m.load(dst, size), a
+			if cond != 0 {
+				expected = b
+			}
+		}
+	case "cswap", "_cswap":
+		if _, err = k.run([]uint64{pa, pb, cond}, 0); err == nil {
+			// b is placed above a
+			ret = append(k.m.load(pa, size), k.m.load(pb, size)...)
+			expected = new(big.Int).Lsh(b, uint(size*64))
+			expected.Or(expected, a)
+			if cond != 0 {
+				expected.Lsh(a, uint(size*64)).Or(expected, b)
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if fromWords(ret).Cmp(expected) != 0 {
+		return fmt.Errorf("have %#x, want %#x", fromWords(ret), expected)
+	}
+	return trace.check(k)
+}
+
+func verifyField(f *emuFunc, base string, field *emuField, a, b *big.Int, cond uint64, alias bool, trace *traceCheck) error {
+	size := field.size
+	k := newKernelCall(f, field, size)
+	trace.record(k)
+	pa, pb, pp := k.in(a), k.in(b), k.in(field.p)
+	c := k.out()
+	if alias {
+		c = pa
+	}
+	expected := new(big.Int)
+	var args []uint64
+	switch base {
+	case "add":
+		args = []uint64{c, pa, pb, pp}
+		expected.Add(a, b).Mod(expected, field.p)
+	case "sub":
+		args = []uint64{c, pa, pb, pp}
+		expected.Sub(a, b).Mod(expected, field.p)
+	case "double":
+		args = []uint64{c, pa, pp}
+		expected.Lsh(a, 1).Mod(expected, field.p)
+	case "_neg":
+		args = []uint64{c, pa, pp}
+		expected.Neg(a).Mod(expected, field.p)
+	case "condNeg", "_condNeg":
+		args = []uint64{c, pa, pp, cond}
+		if k.f.args == 24 {
+			// fixed modulus kernel does not take the modulus
+			args = []uint64{c, pa, cond}
+		}
+		expected.Set(a)
+		if cond != 0 {
+			expected.Neg(a).Mod(expected, field.p)
+		}
+	case "mul", "mul_no_adx_bmi2":
+		args = []uint64{c, pa, pb, pp, field.inp}
+		ri := new(big.Int).ModInverse(field.r, field.p)
+		expected.Mul(a, b).Mul(expected, ri).Mod(expected, field.p)
+	}
+	if _, err := k.run(args, 0); err != nil {
+		return err
+	}
+	if have := k.value(c); have.Cmp(expected) != 0 {
+		return fmt.Errorf("have %#x, want %#x", have, expected)
+	}
+	return trace.check(k)
+}
+
+// verifyLookup reads entry idx of a table of n random entries. Out of range
+// indexes are expected to give zero. Output aliases the first entry if alias
+// is set.
+func verifyLookup(f *emuFunc, size int, n int, idx int, alias bool, trace *traceCheck) error {
+	k := newKernelCall(f, nil, size)
+	trace.record(k)
+	entries := make([]*big.Int, n)
+	var words []uint64
+	for i := range entries {
+		entries[i] = wordSamples(size, 1)[5]
+		words = append(words, toWords(entries[i], size)...)
+	}
+	table := k.m.alloc(words)
+	dst := k.out()
+	if alias {
+		dst = table
+	}
+	expected := new(big.Int)
+	if idx >= 0 && idx < n {
+		expected.Set(entries[idx])
+	}
+	args := []uint64{dst, table, uint64(n), uint64(idx)}
+	if k.f.args == 40 {
+		// table is a slice, capacity follows length
+		args = []uint64{dst, table, uint64(n), uint64(n), uint64(idx)}
+	}
+	if _, err := k.run(args, 0); err != nil {
+		return err
+	}
+	if have := k.value(dst); have.Cmp(expected) != 0 {
+		return fmt.Errorf("have %#x, want %#x", have, expected)
+	}
+	return trace.check(k)
+}
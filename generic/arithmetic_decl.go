@@ -33,6 +33,18 @@ func subn1(a, b fieldElement) uint64
 //go:noescape
 func _neg1(c, a, p fieldElement)
 
+//go:noescape
+func cmov1(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap1(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg1(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup1(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double1(c, a, p fieldElement)
 
@@ -72,6 +84,18 @@ func subn2(a, b fieldElement) uint64
 //go:noescape
 func _neg2(c, a, p fieldElement)
 
+//go:noescape
+func cmov2(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap2(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg2(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup2(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double2(c, a, p fieldElement)
 
@@ -111,6 +135,18 @@ func subn3(a, b fieldElement) uint64
 //go:noescape
 func _neg3(c, a, p fieldElement)
 
+//go:noescape
+func cmov3(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap3(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg3(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup3(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double3(c, a, p fieldElement)
 
@@ -150,6 +186,18 @@ func subn4(a, b fieldElement) uint64
 //go:noescape
 func _neg4(c, a, p fieldElement)
 
+//go:noescape
+func cmov4(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap4(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg4(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup4(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double4(c, a, p fieldElement)
 
@@ -189,6 +237,18 @@ func subn5(a, b fieldElement) uint64
 //go:noescape
 func _neg5(c, a, p fieldElement)
 
+//go:noescape
+func cmov5(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap5(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg5(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup5(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double5(c, a, p fieldElement)
 
@@ -228,6 +288,18 @@ func subn6(a, b fieldElement) uint64
 //go:noescape
 func _neg6(c, a, p fieldElement)
 
+//go:noescape
+func cmov6(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap6(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg6(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup6(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double6(c, a, p fieldElement)
 
@@ -267,6 +339,18 @@ func subn7(a, b fieldElement) uint64
 //go:noescape
 func _neg7(c, a, p fieldElement)
 
+//go:noescape
+func cmov7(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap7(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg7(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup7(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double7(c, a, p fieldElement)
 
@@ -306,6 +390,18 @@ func subn8(a, b fieldElement) uint64
 //go:noescape
 func _neg8(c, a, p fieldElement)
 
+//go:noescape
+func cmov8(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap8(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg8(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup8(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double8(c, a, p fieldElement)
 
@@ -345,6 +441,18 @@ func subn9(a, b fieldElement) uint64
 //go:noescape
 func _neg9(c, a, p fieldElement)
 
+//go:noescape
+func cmov9(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap9(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg9(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup9(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double9(c, a, p fieldElement)
 
@@ -384,6 +492,18 @@ func subn10(a, b fieldElement) uint64
 //go:noescape
 func _neg10(c, a, p fieldElement)
 
+//go:noescape
+func cmov10(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap10(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg10(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup10(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double10(c, a, p fieldElement)
 
@@ -423,6 +543,18 @@ func subn11(a, b fieldElement) uint64
 //go:noescape
 func _neg11(c, a, p fieldElement)
 
+//go:noescape
+func cmov11(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap11(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg11(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup11(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double11(c, a, p fieldElement)
 
@@ -462,6 +594,18 @@ func subn12(a, b fieldElement) uint64
 //go:noescape
 func _neg12(c, a, p fieldElement)
 
+//go:noescape
+func cmov12(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap12(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg12(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup12(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double12(c, a, p fieldElement)
 
@@ -501,6 +645,18 @@ func subn13(a, b fieldElement) uint64
 //go:noescape
 func _neg13(c, a, p fieldElement)
 
+//go:noescape
+func cmov13(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap13(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg13(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup13(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double13(c, a, p fieldElement)
 
@@ -540,6 +696,18 @@ func subn14(a, b fieldElement) uint64
 //go:noescape
 func _neg14(c, a, p fieldElement)
 
+//go:noescape
+func cmov14(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap14(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg14(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup14(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double14(c, a, p fieldElement)
 
@@ -579,6 +747,18 @@ func subn15(a, b fieldElement) uint64
 //go:noescape
 func _neg15(c, a, p fieldElement)
 
+//go:noescape
+func cmov15(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap15(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg15(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup15(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double15(c, a, p fieldElement)
 
@@ -618,6 +798,18 @@ func subn16(a, b fieldElement) uint64
 //go:noescape
 func _neg16(c, a, p fieldElement)
 
+//go:noescape
+func cmov16(c, a, b fieldElement, cond uint64)
+
+//go:noescape
+func cswap16(a, b fieldElement, cond uint64)
+
+//go:noescape
+func condNeg16(c, a, p fieldElement, cond uint64)
+
+//go:noescape
+func lookup16(dst, table fieldElement, n, idx uint64)
+
 //go:noescape
 func double16(c, a, p fieldElement)
 
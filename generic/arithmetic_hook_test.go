@@ -0,0 +1,25 @@
+package fp
+
+// mulBackends holds ADX and non ADX multiplication kernels of each limb size
+// so that both are run in the same test binary regardless of the no_adx_bmi2
+// build tag
+var mulBackends = map[int]struct {
+	adx, noadx func(c, a, b, p fieldElement, inp uint64)
+}{
+	1:  {mul1, mul_no_adx_bmi2_1},
+	2:  {mul2, mul_no_adx_bmi2_2},
+	3:  {mul3, mul_no_adx_bmi2_3},
+	4:  {mul4, mul_no_adx_bmi2_4},
+	5:  {mul5, mul_no_adx_bmi2_5},
+	6:  {mul6, mul_no_adx_bmi2_6},
+	7:  {mul7, mul_no_adx_bmi2_7},
+	8:  {mul8, mul_no_adx_bmi2_8},
+	9:  {mul9, mul_no_adx_bmi2_9},
+	10: {mul10, mul_no_adx_bmi2_10},
+	11: {mul11, mul_no_adx_bmi2_11},
+	12: {mul12, mul_no_adx_bmi2_12},
+	13: {mul13, mul_no_adx_bmi2_13},
+	14: {mul14, mul_no_adx_bmi2_14},
+	15: {mul15, mul_no_adx_bmi2_15},
+	16: {mul16, mul_no_adx_bmi2_16},
+}
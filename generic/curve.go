@@ -0,0 +1,294 @@
+package fp
+
+import (
+	"math/big"
+)
+
+// curve is a short weierstrass curve y^2 = x^3 + a * x + b over field f.
+type curve struct {
+	f *field
+	a fieldElement
+	b fieldElement
+}
+
+// pointAffine is an affine curve point, inf marks the point at infinity.
+type pointAffine struct {
+	x   fieldElement
+	y   fieldElement
+	inf bool
+}
+
+// pointJacobian is a curve point in jacobian coordinates (X : Y : Z)
+// representing (X / Z^2, Y / Z^3). Point at infinity has Z = 0.
+type pointJacobian struct {
+	x fieldElement
+	y fieldElement
+	z fieldElement
+}
+
+func newCurve(f *field, a, b fieldElement) *curve {
+	c := &curve{f: f, a: f.newFieldElement(), b: f.newFieldElement()}
+	f.copy(c.a, a)
+	f.copy(c.b, b)
+	return c
+}
+
+func (c *curve) newPointAffine() *pointAffine {
+	return &pointAffine{x: c.f.newFieldElement(), y: c.f.newFieldElement(), inf: true}
+}
+
+// newPointJacobian returns point at infinity
+func (c *curve) newPointJacobian() *pointJacobian {
+	p := &pointJacobian{x: c.f.newFieldElement(), y: c.f.newFieldElement(), z: c.f.newFieldElement()}
+	c.f.copy(p.x, c.f.one)
+	c.f.copy(p.y, c.f.one)
+	return p
+}
+
+func (c *curve) copyAffine(r, p *pointAffine) {
+	c.f.copy(r.x, p.x)
+	c.f.copy(r.y, p.y)
+	r.inf = p.inf
+}
+
+func (c *curve) copyJacobian(r, p *pointJacobian) {
+	c.f.copy(r.x, p.x)
+	c.f.copy(r.y, p.y)
+	c.f.copy(r.z, p.z)
+}
+
+func (c *curve) negAffine(r, p *pointAffine) {
+	c.f.copy(r.x, p.x)
+	c.f.neg(r.y, p.y)
+	r.inf = p.inf
+}
+
+// isOnCurve checks y^2 = x^3 + a * x + b
+func (c *curve) isOnCurve(p *pointAffine) bool {
+	if p.inf {
+		return true
+	}
+	f := c.f
+	lhs, rhs := f.newFieldElement(), f.newFieldElement()
+	f.square(lhs, p.y)
+	f.square(rhs, p.x)
+	f.add(rhs, rhs, c.a)
+	f.mul(rhs, rhs, p.x)
+	f.add(rhs, rhs, c.b)
+	return f.equal(lhs, rhs)
+}
+
+func (c *curve) fromAffine(r *pointJacobian, p *pointAffine) {
+	if p.inf {
+		c.f.copy(r.x, c.f.one)
+		c.f.copy(r.y, c.f.one)
+		c.f.copy(r.z, c.f.zero)
+		return
+	}
+	c.f.copy(r.x, p.x)
+	c.f.copy(r.y, p.y)
+	c.f.copy(r.z, c.f.one)
+}
+
+func (c *curve) toAffine(r *pointAffine, p *pointJacobian) {
+	f := c.f
+	if f.isZero(p.z) {
+		r.inf = true
+		return
+	}
+	zInv, zInv2 := f.newFieldElement(), f.newFieldElement()
+	f.inverse(zInv, p.z)
+	f.square(zInv2, zInv)
+	f.mul(r.x, p.x, zInv2)
+	f.mul(zInv2, zInv2, zInv)
+	f.mul(r.y, p.y, zInv2)
+	r.inf = false
+}
+
+// double sets r to 2 * p.
+// Bernstein, Lange 2007, dbl-2007-bl
+func (c *curve) double(r, p *pointJacobian) {
+	f := c.f
+	if f.isZero(p.z) {
+		c.copyJacobian(r, p)
+		return
+	}
+	xx, yy, yyyy, zz, s, m, t := f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement()
+	f.square(xx, p.x)
+	f.square(yy, p.y)
+	f.square(yyyy, yy)
+	f.square(zz, p.z)
+	// s = 2 * ((x + yy)^2 - xx - yyyy)
+	f.add(s, p.x, yy)
+	f.square(s, s)
+	f.sub(s, s, xx)
+	f.sub(s, s, yyyy)
+	f.double(s, s)
+	// m = 3 * xx + a * zz^2
+	f.square(m, zz)
+	f.mul(m, m, c.a)
+	f.add(m, m, xx)
+	f.double(xx, xx)
+	f.add(m, m, xx)
+	// z3 = (y + z)^2 - yy - zz
+	f.add(r.z, p.y, p.z)
+	f.square(r.z, r.z)
+	f.sub(r.z, r.z, yy)
+	f.sub(r.z, r.z, zz)
+	// x3 = m^2 - 2 * s
+	f.square(t, m)
+	f.sub(t, t, s)
+	f.sub(t, t, s)
+	// y3 = m * (s - x3) - 8 * yyyy
+	f.sub(s, s, t)
+	f.mul(r.y, m, s)
+	f.double(yyyy, yyyy)
+	f.double(yyyy, yyyy)
+	f.double(yyyy, yyyy)
+	f.sub(r.y, r.y, yyyy)
+	f.copy(r.x, t)
+}
+
+// add sets r to p + q.
+// Bernstein, Lange 2007, add-2007-bl
+func (c *curve) add(r, p, q *pointJacobian) {
+	f := c.f
+	if f.isZero(p.z) {
+		c.copyJacobian(r, q)
+		return
+	}
+	if f.isZero(q.z) {
+		c.copyJacobian(r, p)
+		return
+	}
+	z1z1, z2z2, u1, u2, s1, s2, h, i, j, v := f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement()
+	f.square(z1z1, p.z)
+	f.square(z2z2, q.z)
+	f.mul(u1, p.x, z2z2)
+	f.mul(u2, q.x, z1z1)
+	f.mul(s1, p.y, q.z)
+	f.mul(s1, s1, z2z2)
+	f.mul(s2, q.y, p.z)
+	f.mul(s2, s2, z1z1)
+	f.sub(h, u2, u1)
+	// s2 = r = 2 * (s2 - s1)
+	f.sub(s2, s2, s1)
+	if f.isZero(h) {
+		if f.isZero(s2) {
+			c.double(r, p)
+			return
+		}
+		c.copyJacobian(r, c.newPointJacobian())
+		return
+	}
+	f.double(s2, s2)
+	f.double(i, h)
+	f.square(i, i)
+	f.mul(j, h, i)
+	f.mul(v, u1, i)
+	// z3 = ((z1 + z2)^2 - z1z1 - z2z2) * h
+	f.add(r.z, p.z, q.z)
+	f.square(r.z, r.z)
+	f.sub(r.z, r.z, z1z1)
+	f.sub(r.z, r.z, z2z2)
+	f.mul(r.z, r.z, h)
+	// x3 = r^2 - j - 2 * v
+	f.square(u2, s2)
+	f.sub(u2, u2, j)
+	f.sub(u2, u2, v)
+	f.sub(u2, u2, v)
+	// y3 = r * (v - x3) - 2 * s1 * j
+	f.sub(v, v, u2)
+	f.mul(v, v, s2)
+	f.mul(s1, s1, j)
+	f.double(s1, s1)
+	f.sub(r.y, v, s1)
+	f.copy(r.x, u2)
+}
+
+// addMixed sets r to p + q where q is affine.
+// Bernstein, Lange 2007, madd-2007-bl
+func (c *curve) addMixed(r, p *pointJacobian, q *pointAffine) {
+	f := c.f
+	if q.inf {
+		c.copyJacobian(r, p)
+		return
+	}
+	if f.isZero(p.z) {
+		c.fromAffine(r, q)
+		return
+	}
+	z1z1, u2, s2, h, hh, i, j, v := f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement()
+	f.square(z1z1, p.z)
+	f.mul(u2, q.x, z1z1)
+	f.mul(s2, q.y, p.z)
+	f.mul(s2, s2, z1z1)
+	f.sub(h, u2, p.x)
+	// s2 = r = 2 * (s2 - y1)
+	f.sub(s2, s2, p.y)
+	if f.isZero(h) {
+		if f.isZero(s2) {
+			c.double(r, p)
+			return
+		}
+		c.copyJacobian(r, c.newPointJacobian())
+		return
+	}
+	f.double(s2, s2)
+	f.square(hh, h)
+	f.double(i, hh)
+	f.double(i, i)
+	f.mul(j, h, i)
+	f.mul(v, p.x, i)
+	// z3 = (z1 + h)^2 - z1z1 - hh
+	f.add(z1z1, z1z1, hh)
+	f.add(r.z, p.z, h)
+	f.square(r.z, r.z)
+	f.sub(r.z, r.z, z1z1)
+	// x3 = r^2 - j - 2 * v
+	f.square(u2, s2)
+	f.sub(u2, u2, j)
+	f.sub(u2, u2, v)
+	f.sub(u2, u2, v)
+	// y3 = r * (v - x3) - 2 * y1 * j
+	f.sub(v, v, u2)
+	f.mul(v, v, s2)
+	f.mul(j, j, p.y)
+	f.double(j, j)
+	f.sub(r.y, v, j)
+	f.copy(r.x, u2)
+}
+
+// mulScalar sets r to e * p with double and add, it is not constant time
+func (c *curve) mulScalar(r *pointJacobian, p *pointAffine, e *big.Int) {
+	q := c.newPointJacobian()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		c.double(q, q)
+		if e.Bit(i) == 1 {
+			c.addMixed(q, q, p)
+		}
+	}
+	c.copyJacobian(r, q)
+}
@@ -0,0 +1,82 @@
+package fp
+
+import (
+	"fmt"
+)
+
+// byteEncoding describes byte representation of canonical field elements.
+type byteEncoding uint8
+
+const (
+	bigEndianPadded byteEncoding = iota
+	bigEndianMinimal
+	littleEndianPadded
+	littleEndianMinimal
+)
+
+// littleEndian reports whether least significant byte comes first.
+func (enc byteEncoding) littleEndian() bool {
+	return enc&2 != 0
+}
+
+// minimal encodings are modulus byte length long rather than limb padded.
+func (enc byteEncoding) minimal() bool {
+	return enc&1 != 0
+}
+
+func (f *field) modulusByteSize() int {
+	return (f.modulusBitSize + 7) / 8
+}
+
+// encodedSize returns length of encoded field elements in bytes.
+func (f *field) encodedSize(enc byteEncoding) int {
+	if enc.minimal() {
+		return f.modulusByteSize()
+	}
+	return f.byteSize()
+}
+
+// encode returns canonical value of fe with given byte encoding.
+func (f *field) encode(fe fieldElement, enc byteEncoding) []byte {
+	out := f.toBytes(fe)
+	if enc.minimal() {
+		out = out[f.byteSize()-f.modulusByteSize():]
+	}
+	if enc.littleEndian() {
+		out = reverseBytes(out)
+	}
+	return out
+}
+
+// decode returns a field element from its canonical value with given byte
+// encoding. Input size must match the encoding, padding bytes must be zero
+// and value must be smaller than modulus.
+func (f *field) decode(in []byte, enc byteEncoding) (fieldElement, error) {
+	size := f.encodedSize(enc)
+	if len(in) != size {
+		return nil, fmt.Errorf("bad input size %d, expected %d", len(in), size)
+	}
+	if enc.littleEndian() {
+		in = reverseBytes(in)
+	}
+	if enc.minimal() {
+		in = padBytes(in, f.byteSize())
+	}
+	for i := 0; i < f.byteSize()-f.modulusByteSize(); i++ {
+		if in[i] != 0 {
+			if enc.littleEndian() {
+				i = f.byteSize() - 1 - i
+			}
+			return nil, fmt.Errorf("non zero padding byte at %d", i)
+		}
+	}
+	if !f.isValid(in) {
+		return nil, fmt.Errorf("input is not smaller than modulus")
+	}
+	fe, _, err := newFieldElementFromBytes(in)
+	if err != nil {
+		return nil, err
+	}
+	f.toMont(fe, fe)
+	return fe, nil
+}
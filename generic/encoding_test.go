@@ -0,0 +1,92 @@
+package fp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestEncoding(t *testing.T) {
+	encodings := []byteEncoding{bigEndianPadded, bigEndianMinimal, littleEndianPadded, littleEndianMinimal}
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			for i := 0; i < fuz; i++ {
+				field := randField(limbSize)
+				modulusByteSize := (field.pbig.BitLen() + 7) / 8
+				for j := 0; j < fieldLifetime; j++ {
+					a := field.randFieldElement(rand.Reader)
+					expected := field.toBytes(a)
+					for _, enc := range encodings {
+						b := field.encode(a, enc)
+						if len(b) != field.encodedSize(enc) {
+							t.Fatalf("bad encoding size")
+						}
+						e := expected
+						if enc.minimal() {
+							e = e[field.byteSize()-modulusByteSize:]
+						}
+						if enc.littleEndian() {
+							e = reverseBytes(e)
+						}
+						if !bytes.Equal(b, e) {
+							t.Fatalf("bad encoding %+v", enc)
+						}
+						c, err := field.decode(b, enc)
+						if err != nil {
+							t.Fatal(err)
+						}
+						if !field.equal(a, c) {
+							t.Fatalf("bad decoding %+v", enc)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEncodingErrors(t *testing.T) {
+	encodings := []byteEncoding{bigEndianPadded, bigEndianMinimal, littleEndianPadded, littleEndianMinimal}
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			field := randField(limbSize)
+			p := new(big.Int).Set(field.pbig)
+			for _, enc := range encodings {
+				size := field.encodedSize(enc)
+				// modulus and larger values
+				for _, v := range []*big.Int{p, new(big.Int).Add(p, big.NewInt(1))} {
+					if v.BitLen() > size*8 {
+						continue
+					}
+					in := padBytes(v.Bytes(), size)
+					if enc.littleEndian() {
+						in = reverseBytes(in)
+					}
+					if _, err := field.decode(in, enc); err == nil {
+						t.Fatalf("values not smaller than modulus should be rejected %+v", enc)
+					}
+				}
+				// bad size
+				if _, err := field.decode(make([]byte, size-1), enc); err == nil {
+					t.Fatalf("short input should be rejected %+v", enc)
+				}
+				if _, err := field.decode(make([]byte, size+1), enc); err == nil {
+					t.Fatalf("long input should be rejected %+v", enc)
+				}
+				// padding
+				if !enc.minimal() && field.byteSize() > field.modulusByteSize() {
+					in := make([]byte, size)
+					in[0] = 1
+					if enc.littleEndian() {
+						in = reverseBytes(in)
+					}
+					if _, err := field.decode(in, enc); err == nil {
+						t.Fatalf("non zero padding should be rejected %+v", enc)
+					}
+				}
+			}
+		})
+	}
+}
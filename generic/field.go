@@ -29,6 +29,7 @@ type field struct {
 	zero           fieldElement
 	r              fieldElement
 	r2             fieldElement
+	r3             fieldElement
 	pbig           *big.Int
 	rbig           *big.Int
 	equal          func(a, b fieldElement) bool
@@ -39,6 +40,10 @@ type field struct {
 	_double        func(c, a, p fieldElement)
 	_sub           func(c, a, b, p fieldElement)
 	_neg           func(c, a, p fieldElement)
+	_cmov          func(c, a, b fieldElement, cond uint64)
+	_cswap         func(a, b fieldElement, cond uint64)
+	_condNeg       func(c, a, p fieldElement, cond uint64)
+	lookup         func(dst, table fieldElement, n, idx uint64)
 	addn           func(a, b fieldElement) uint64
 	subn           func(a, b fieldElement) uint64
 	div_two        func(a fieldElement)
@@ -57,11 +62,14 @@ func newField(p []byte) (*field, error) {
 	R.SetBit(R, f.byteSize()*8, 1).Mod(R, f.pbig)
 	R2 := new(big.Int)
 	R2.Mul(R, R).Mod(R2, f.pbig)
+	R3 := new(big.Int)
+	R3.Mul(R2, R).Mod(R3, f.pbig)
 	inpT := new(big.Int).ModInverse(new(big.Int).Neg(f.pbig), new(big.Int).SetBit(new(big.Int), 64, 1))
 	f.r = newFieldElementFromBigUnchecked(f.limbSize, R)
 	f.rbig = R
 	f.one = newFieldElementFromBigUnchecked(f.limbSize, R)
 	f.r2 = newFieldElementFromBigUnchecked(f.limbSize, R2)
+	f.r3 = newFieldElementFromBigUnchecked(f.limbSize, R3)
 	f._one = newFieldElementFromBigUnchecked(f.limbSize, big.NewInt(1))
 	f.zero = newFieldElementFromBigUnchecked(f.limbSize, new(big.Int))
 	if inpT == nil {
@@ -81,6 +89,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub1
 		f._double = double1
 		f._neg = _neg1
+		f._cmov = cmov1
+		f._cswap = cswap1
+		f._condNeg = condNeg1
+		f.lookup = lookup1
 		f.div_two = div_two_1
 		f.mul_two = mul_two_1
 		if nonADXBMI2 {
@@ -98,6 +110,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub2
 		f._double = double2
 		f._neg = _neg2
+		f._cmov = cmov2
+		f._cswap = cswap2
+		f._condNeg = condNeg2
+		f.lookup = lookup2
 		f.div_two = div_two_2
 		f.mul_two = mul_two_2
 		if nonADXBMI2 {
@@ -115,6 +131,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub3
 		f._double = double3
 		f._neg = _neg3
+		f._cmov = cmov3
+		f._cswap = cswap3
+		f._condNeg = condNeg3
+		f.lookup = lookup3
 		f.div_two = div_two_3
 		f.mul_two = mul_two_3
 		if nonADXBMI2 {
@@ -132,6 +152,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub4
 		f._double = double4
 		f._neg = _neg4
+		f._cmov = cmov4
+		f._cswap = cswap4
+		f._condNeg = condNeg4
+		f.lookup = lookup4
 		f.div_two = div_two_4
 		f.mul_two = mul_two_4
 		if nonADXBMI2 {
@@ -149,6 +173,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub5
 		f._double = double5
 		f._neg = _neg5
+		f._cmov = cmov5
+		f._cswap = cswap5
+		f._condNeg = condNeg5
+		f.lookup = lookup5
 		f.div_two = div_two_5
 		f.mul_two = mul_two_5
 		if nonADXBMI2 {
@@ -166,6 +194,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub6
 		f._double = double6
 		f._neg = _neg6
+		f._cmov = cmov6
+		f._cswap = cswap6
+		f._condNeg = condNeg6
+		f.lookup = lookup6
 		f.div_two = div_two_6
 		f.mul_two = mul_two_6
 		f._mul = mul6
@@ -184,6 +216,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub7
 		f._double = double7
 		f._neg = _neg7
+		f._cmov = cmov7
+		f._cswap = cswap7
+		f._condNeg = condNeg7
+		f.lookup = lookup7
 		f.div_two = div_two_7
 		f.mul_two = mul_two_7
 		if nonADXBMI2 {
@@ -201,6 +237,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub8
 		f._double = double8
 		f._neg = _neg8
+		f._cmov = cmov8
+		f._cswap = cswap8
+		f._condNeg = condNeg8
+		f.lookup = lookup8
 		f.div_two = div_two_8
 		f.mul_two = mul_two_8
 		if nonADXBMI2 {
@@ -218,6 +258,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub9
 		f._double = double9
 		f._neg = _neg9
+		f._cmov = cmov9
+		f._cswap = cswap9
+		f._condNeg = condNeg9
+		f.lookup = lookup9
 		f.div_two = div_two_9
 		f.mul_two = mul_two_9
 		if nonADXBMI2 {
@@ -235,6 +279,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub10
 		f._double = double10
 		f._neg = _neg10
+		f._cmov = cmov10
+		f._cswap = cswap10
+		f._condNeg = condNeg10
+		f.lookup = lookup10
 		f.div_two = div_two_10
 		f.mul_two = mul_two_10
 		if nonADXBMI2 {
@@ -252,6 +300,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub11
 		f._double = double11
 		f._neg = _neg11
+		f._cmov = cmov11
+		f._cswap = cswap11
+		f._condNeg = condNeg11
+		f.lookup = lookup11
 		f.div_two = div_two_11
 		f.mul_two = mul_two_11
 		if nonADXBMI2 {
@@ -269,6 +321,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub12
 		f._double = double12
 		f._neg = _neg12
+		f._cmov = cmov12
+		f._cswap = cswap12
+		f._condNeg = condNeg12
+		f.lookup = lookup12
 		f.div_two = div_two_12
 		f.mul_two = mul_two_12
 		if nonADXBMI2 {
@@ -286,6 +342,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub13
 		f._double = double13
 		f._neg = _neg13
+		f._cmov = cmov13
+		f._cswap = cswap13
+		f._condNeg = condNeg13
+		f.lookup = lookup13
 		f.div_two = div_two_13
 		f.mul_two = mul_two_13
 		if nonADXBMI2 {
@@ -303,6 +363,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub14
 		f._double = double14
 		f._neg = _neg14
+		f._cmov = cmov14
+		f._cswap = cswap14
+		f._condNeg = condNeg14
+		f.lookup = lookup14
 		f.div_two = div_two_14
 		f.mul_two = mul_two_14
 		if nonADXBMI2 {
@@ -320,6 +384,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub15
 		f._double = double15
 		f._neg = _neg15
+		f._cmov = cmov15
+		f._cswap = cswap15
+		f._condNeg = condNeg15
+		f.lookup = lookup15
 		f.div_two = div_two_15
 		f.mul_two = mul_two_15
 		if nonADXBMI2 {
@@ -337,6 +405,10 @@ func newField(p []byte) (*field, error) {
 		f._sub = sub16
 		f._double = double16
 		f._neg = _neg16
+		f._cmov = cmov16
+		f._cswap = cswap16
+		f._condNeg = condNeg16
+		f.lookup = lookup16
 		f.div_two = div_two_16
 		f.mul_two = mul_two_16
 		if nonADXBMI2 {
@@ -371,13 +443,67 @@ func (f *field) sub(c, a, b fieldElement) {
 }
 
 func (f *field) neg(c, a fieldElement) {
-	if f.equal(a, f.zero) {
-		f.copy(c, a)
-		return
-	}
 	f._neg(c, a, f.p)
 }
 
+// cmov sets c to b if cond is true and to a otherwise in constant time.
+func (f *field) cmov(c, a, b fieldElement, cond bool) {
+	f._cmov(c, a, b, b2u(cond))
+}
+
+// cswap swaps a and b if cond is true in constant time.
+func (f *field) cswap(a, b fieldElement, cond bool) {
+	f._cswap(a, b, b2u(cond))
+}
+
+// condNeg sets c to -a if cond is true and to a otherwise in constant time.
+func (f *field) condNeg(c, a fieldElement, cond bool) {
+	f._condNeg(c, a, f.p, b2u(cond))
+}
+
+// table holds field elements in one block so that they can be read with
+// constTimeLookup
+type table struct {
+	limbs    []uint64
+	limbSize int
+}
+
+// newTable returns a table of n zero field elements
+func (f *field) newTable(n int) *table {
+	return &table{make([]uint64, n*f.limbSize), f.limbSize}
+}
+
+// len returns number of entries of the table
+func (t *table) len() int {
+	return len(t.limbs) / t.limbSize
+}
+
+// at returns entry i of the table
+func (t *table) at(i int) fieldElement {
+	return unsafe.Pointer(&t.limbs[i*t.limbSize])
+}
+
+// constTimeLookup sets dst to table[idx] reading every entry of table so that
+// idx does not leak through memory access pattern. It panics if idx is out of
+// range.
+func (f *field) constTimeLookup(dst fieldElement, t *table, idx int) {
+	n := t.len()
+	if idx < 0 || idx >= n {
+		panic("bad table index")
+	}
+	f.lookup(dst, t.at(0), uint64(n), uint64(idx))
+}
+
+// b2u returns 1 if b is true and 0 otherwise. It compiles to a zero extension
+// of b rather than a branch.
+func b2u(b bool) uint64 {
+	var v uint64
+	if b {
+		v = 1
+	}
+	return v
+}
+
 func (f *field) mul(c, a, b fieldElement) {
 	f._mul(c, a, b, f.p, f.inp)
 }
@@ -507,6 +633,40 @@ func (f *field) newFieldElementFromBig(a *big.Int) (fieldElement, error) {
 	return fe, nil
 }
 
+// fromBytesWide reduces a big endian input of arbitrary length into the field.
+// Input is consumed in chunks of field byte size. Most significant chunk is
+// multiplied with r^3 so that it lands in montgomery domain already shifted by
+// one chunk, remaining chunks are lifted with r^2 and accumulated in horner
+// fashion.
+func (f *field) fromBytesWide(in []byte) fieldElement {
+	byteSize := f.byteSize()
+	n := (len(in) + byteSize - 1) / byteSize
+	if n == 0 {
+		return f.newFieldElement()
+	}
+	padded := padBytes(in, n*byteSize)
+	c, _, _ := newFieldElementFromBytes(padded[:byteSize])
+	if n == 1 {
+		f.mul(c, c, f.r2)
+		return c
+	}
+	f.mul(c, c, f.r3)
+	for i := 1; i < n; i++ {
+		t, _, _ := newFieldElementFromBytes(padded[i*byteSize : (i+1)*byteSize])
+		f.mul(t, t, f.r2)
+		f.add(c, c, t)
+		if i != n-1 {
+			f.mul(c, c, f.r2)
+		}
+	}
+	return c
+}
+
+// fromBytesWideLE is little endian counterpart of fromBytesWide.
+func (f *field) fromBytesWideLE(in []byte) fieldElement {
+	return f.fromBytesWide(reverseBytes(in))
+}
+
 func (f *field) toBytes(in fieldElement) []byte {
 	t := f.newFieldElement()
 	f.fromMont(t, in)
@@ -685,6 +845,14 @@ func padBytes(in []byte, size int) []byte {
 	return out
 }
 
+func reverseBytes(in []byte) []byte {
+	out := make([]byte, len(in))
+	for i := 0; i < len(in); i++ {
+		out[i] = in[len(in)-1-i]
+	}
+	return out
+}
+
 func (f *field) inverse(inv, e fieldElement) bool {
 	if f.isZero(e) {
 		f.copy(inv, e)
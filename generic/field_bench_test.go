@@ -0,0 +1,43 @@
+package fp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+)
+
+// BenchmarkField covers every field operation at limb sizes in range of from
+// and to flags, sub benchmarks are named as <bit size>_<operation>
+func BenchmarkField(t *testing.B) {
+	for limbSize := from; limbSize <= to; limbSize++ {
+		field := randField(limbSize)
+		if field.limbSize != limbSize {
+			t.Fatalf("bad field construction")
+		}
+		a := field.randFieldElement(rand.Reader)
+		b := field.randFieldElement(rand.Reader)
+		c := field.newFieldElement()
+		in_a := field.toBytes(a)
+		e := randBig(field.pbig)
+		bench := func(op string, f func()) {
+			t.Run(fmt.Sprintf("%d_%s", limbSize*64, op), func(t *testing.B) {
+				for i := 0; i < t.N; i++ {
+					f()
+				}
+			})
+		}
+		bench("add", func() { field.add(c, a, b) })
+		bench("sub", func() { field.sub(c, a, b) })
+		bench("double", func() { field.double(c, a) })
+		bench("neg", func() { field.neg(c, a) })
+		bench("mul", func() { field.mul(c, a, b) })
+		bench("square", func() { field.square(c, a) })
+		bench("exp", func() { field.exp(c, a, e) })
+		bench("inverse", func() { field.inverse(c, a) })
+		bench("to_mont", func() { field.toMont(c, a) })
+		bench("from_mont", func() { field.fromMont(c, a) })
+		bench("to_bytes", func() { field.toBytes(a) })
+		bench("from_bytes", func() { field.newFieldElementFromBytes(in_a) })
+		bench("cmp", func() { field.cmp(a, b) })
+	}
+}
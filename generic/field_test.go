@@ -27,7 +27,7 @@ func TestArch(t *testing.T) {
 }
 
 func TestMain(m *testing.M) {
-	_fuz := flag.Int("fuzz", 1, "# of iters")
+	_fuz := flag.Int("iter", 1, "# of iters")
 	_fieldLifetime := flag.Int("fl", 5, "life time of a found prime field")
 	nol := flag.Int("nol", 0, "backend bit size")
 	_offset := flag.Int("offset", -1, "random field modulus offset")
@@ -134,48 +134,6 @@ func BenchmarkInverse(t *testing.B) {
 	}
 }
 
-func BenchmarkField(t *testing.B) {
-	var limbSize int
-	if targetNumberOfLimb > 0 {
-		limbSize = targetNumberOfLimb
-	} else {
-		return
-	}
-	field := randField(limbSize)
-	if field.limbSize != limbSize {
-		t.Fatalf("bad field construction")
-	}
-	bitSize := limbSize * 64
-	a := field.randFieldElement(rand.Reader)
-	b := field.randFieldElement(rand.Reader)
-	c := field.newFieldElement()
-	t.Run(fmt.Sprintf("%d_add", bitSize), func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			field.add(c, a, b)
-		}
-	})
-	t.Run(fmt.Sprintf("%d_double", bitSize), func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			field.double(c, a)
-		}
-	})
-	t.Run(fmt.Sprintf("%d_sub", bitSize), func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			field.sub(c, a, b)
-		}
-	})
-	t.Run(fmt.Sprintf("%d_mul", bitSize), func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			field.mul(c, a, b)
-		}
-	})
-	t.Run(fmt.Sprintf("%d_cmp", bitSize), func(t *testing.B) {
-		for i := 0; i < t.N; i++ {
-			field.cmp(a, b)
-		}
-	})
-}
-
 func TestShift(t *testing.T) {
 	two := big.NewInt(2)
 	one := big.NewInt(1)
@@ -308,6 +266,39 @@ func TestSerialization(t *testing.T) {
 	}
 }
 
+func TestWideReduction(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			for i := 0; i < fuz; i++ {
+				field := randField(limbSize)
+				for j := 0; j < fieldLifetime; j++ {
+					for _, size := range []int{0, 1, field.byteSize() - 1, field.byteSize(), field.byteSize() + 1, field.byteSize() * 2, field.byteSize()*3 + 5} {
+						in := make([]byte, size)
+						if _, err := rand.Read(in); err != nil {
+							t.Fatal(err)
+						}
+						if j == 0 {
+							for k := 0; k < size; k++ {
+								in[k] = 0xff
+							}
+						}
+						expected := new(big.Int).SetBytes(in)
+						expected.Mod(expected, field.pbig)
+						a := field.fromBytesWide(in)
+						if field.toBig(a).Cmp(expected) != 0 {
+							t.Fatalf("bad wide reduction (big endian), input size %d", size)
+						}
+						a = field.fromBytesWideLE(reverseBytes(in))
+						if field.toBig(a).Cmp(expected) != 0 {
+							t.Fatalf("bad wide reduction (little endian), input size %d", size)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestAdditionCrossAgainstBigInt(t *testing.T) {
 	for limbSize := from; limbSize < to+1; limbSize++ {
 		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
@@ -424,6 +415,96 @@ func TestAdditionProperties(t *testing.T) {
 	}
 }
 
+func TestConditionalOperations(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			for i := 0; i < fuz; i++ {
+				field := randField(limbSize)
+				for j := 0; j < fieldLifetime; j++ {
+					a := field.randFieldElement(rand.Reader)
+					b := field.randFieldElement(rand.Reader)
+					c := field.newFieldElement()
+					d := field.newFieldElement()
+					negA := field.newFieldElement()
+					field.neg(c, field.zero)
+					if !field.isZero(c) {
+						t.Fatalf("-0 == 0")
+					}
+					field.sub(negA, field.zero, a)
+					for _, cond := range []bool{false, true} {
+						x, y, z := a, b, a
+						if cond {
+							x, y, z = b, a, negA
+						}
+						field.cmov(c, a, b, cond)
+						if !field.equal(c, x) {
+							t.Fatalf("bad conditional move, cond: %t", cond)
+						}
+						field.copy(c, a)
+						field.copy(d, b)
+						field.cswap(c, d, cond)
+						if !field.equal(c, x) || !field.equal(d, y) {
+							t.Fatalf("bad conditional swap, cond: %t", cond)
+						}
+						field.copy(c, a)
+						field.condNeg(c, c, cond)
+						if !field.equal(c, z) {
+							t.Fatalf("bad conditional negation, cond: %t", cond)
+						}
+						field.condNeg(c, field.zero, cond)
+						if !field.isZero(c) {
+							t.Fatalf("bad conditional negation of zero, cond: %t", cond)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestConstTimeLookup(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			for i := 0; i < fuz; i++ {
+				field := randField(limbSize)
+				for _, n := range []int{1, 3, 16} {
+					table := field.newTable(n)
+					if table.len() != n {
+						t.Fatalf("bad table size, have: %d, want: %d", table.len(), n)
+					}
+					for j := 0; j < n; j++ {
+						field.copy(table.at(j), field.randFieldElement(rand.Reader))
+					}
+					c := field.newFieldElement()
+					for idx := 0; idx < n; idx++ {
+						field.constTimeLookup(c, table, idx)
+						if !field.equal(c, table.at(idx)) {
+							t.Fatalf("bad lookup, n: %d, idx: %d", n, idx)
+						}
+					}
+					for _, idx := range []int{-1, n} {
+						func() {
+							defer func() {
+								if recover() == nil {
+									t.Fatalf("out of range lookup does not panic, n: %d, idx: %d", n, idx)
+								}
+							}()
+							field.constTimeLookup(c, table, idx)
+						}()
+					}
+					// output is an entry of the table
+					expected := field.newFieldElement()
+					field.copy(expected, table.at(n-1))
+					field.constTimeLookup(table.at(0), table, n-1)
+					if !field.equal(table.at(0), expected) {
+						t.Fatalf("bad lookup into table entry, n: %d", n)
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestMultiplicationCrossAgainstBigInt(t *testing.T) {
 	for limbSize := from; limbSize < to+1; limbSize++ {
 		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
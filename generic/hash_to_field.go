@@ -0,0 +1,130 @@
+package fp
+
+import (
+	"crypto/sha256"
+	"errors"
+	"hash"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// expander implements expand_message variants of RFC 9380, section 5.3
+type expander interface {
+	expand(msg, dst []byte, lenInBytes int) ([]byte, error)
+}
+
+var oversizeDSTPrefix = []byte("H2C-OVERSIZE-DST-")
+
+type expanderXMD struct {
+	h func() hash.Hash
+}
+
+// newExpanderXMD returns expand_message_xmd with given hash function
+// such as sha256.New or sha512.New
+func newExpanderXMD(h func() hash.Hash) expander {
+	return &expanderXMD{h}
+}
+
+func (e *expanderXMD) expand(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	h := e.h()
+	bInBytes, sInBytes := h.Size(), h.BlockSize()
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	if ell > 255 || lenInBytes > 65535 {
+		return nil, errors.New("requested output length is too large")
+	}
+	if len(dst) > 255 {
+		h.Write(oversizeDSTPrefix)
+		h.Write(dst)
+		dst = h.Sum(nil)
+		h.Reset()
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+	// b0 = H(Z_pad || msg || l_i_b_str || 0 || DST_prime)
+	h.Write(make([]byte, sInBytes))
+	h.Write(msg)
+	h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+	// b1 = H(b0 || 1 || DST_prime)
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+	out := make([]byte, 0, ell*bInBytes)
+	out = append(out, bi...)
+	// bi = H(b0 ^ b(i-1) || i || DST_prime)
+	for i := 2; i <= ell; i++ {
+		for j := 0; j < bInBytes; j++ {
+			bi[j] ^= b0[j]
+		}
+		h.Reset()
+		h.Write(bi)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+		out = append(out, bi...)
+	}
+	return out[:lenInBytes], nil
+}
+
+type expanderXOF struct {
+	h func() sha3.ShakeHash
+	k int
+}
+
+// newExpanderXOF returns expand_message_xof with given extendable output
+// function such as sha3.NewShake128. k is the target security level in bits
+// and is only used to shorten oversized domain separation tags.
+func newExpanderXOF(h func() sha3.ShakeHash, k int) expander {
+	return &expanderXOF{h, k}
+}
+
+func (e *expanderXOF) expand(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	if lenInBytes > 65535 {
+		return nil, errors.New("requested output length is too large")
+	}
+	h := e.h()
+	if len(dst) > 255 {
+		h.Write(oversizeDSTPrefix)
+		h.Write(dst)
+		dst = make([]byte, (2*e.k+7)/8)
+		h.Read(dst)
+		h.Reset()
+	}
+	// H(msg || l_i_b_str || DST_prime, len_in_bytes)
+	h.Write(msg)
+	h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes)})
+	h.Write(dst)
+	h.Write([]byte{byte(len(dst))})
+	out := make([]byte, lenInBytes)
+	h.Read(out)
+	return out, nil
+}
+
+// hashToField hashes a message to count field elements as in RFC 9380,
+// section 5.2. It uses expand_message_xmd with SHA-256 and targets 128 bits
+// of security which is what the standard suites over prime fields do.
+func (f *field) hashToField(msg, dst []byte, count int) ([]fieldElement, error) {
+	return f.hashToFieldWithExpander(newExpanderXMD(sha256.New), 128, msg, dst, count)
+}
+
+// hashToFieldWithExpander is hashToField with a custom expander and security
+// level k in bits. Each element is derived from ceil((ceil(log2(p)) + k) / 8)
+// uniform bytes which are reduced with fromBytesWide so that the bias is
+// negligible.
+func (f *field) hashToFieldWithExpander(e expander, k int, msg, dst []byte, count int) ([]fieldElement, error) {
+	if count < 1 {
+		return nil, errors.New("count must be positive")
+	}
+	L := (f.modulusBitSize + k + 7) / 8
+	uniformBytes, err := e.expand(msg, dst, count*L)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]fieldElement, count)
+	for i := 0; i < count; i++ {
+		out[i] = f.fromBytesWide(uniformBytes[i*L : (i+1)*L])
+	}
+	return out, nil
+}
@@ -0,0 +1,171 @@
+package fp
+
+import (
+	"math/big"
+)
+
+// sgn0 returns the sign of a field element as in RFC 9380, section 4.1
+func (f *field) sgn0(a fieldElement) bool {
+	t := f.newFieldElement()
+	f.fromMont(t, a)
+	return !is_even(t)
+}
+
+// isSquare returns true if a is zero or a quadratic residue
+func (f *field) isSquare(a fieldElement) bool {
+	t := f.newFieldElement()
+	e := new(big.Int).Rsh(f.pbig, 1)
+	f.exp(t, a, e)
+	return f.isOne(t) || f.isZero(t)
+}
+
+// sqrtRatio sets y to sqrt(u/v) and returns true if u/v is square. Otherwise
+// y is set to sqrt(z*u/v) and false is returned. z must be a non square and
+// v must be non zero. Implements sqrt_ratio of RFC 9380, appendix F.2.1.1
+func (f *field) sqrtRatio(y, u, v, z fieldElement) bool {
+	// c1, largest integer such that 2^c1 divides p - 1
+	pMinusOne := new(big.Int).Sub(f.pbig, big.NewInt(1))
+	c1 := pMinusOne.TrailingZeroBits()
+	// c2 = (p - 1) / (2^c1)
+	c2 := new(big.Int).Rsh(pMinusOne, c1)
+	// c3 = (c2 - 1) / 2
+	c3 := new(big.Int).Rsh(c2, 1)
+	// c4 = 2^c1 - 1
+	c4 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), c1), big.NewInt(1))
+	// c5 = 2^(c1 - 1)
+	c5 := new(big.Int).Lsh(big.NewInt(1), c1-1)
+	// c6 = z^c2
+	c6 := f.newFieldElement()
+	f.exp(c6, z, c2)
+	// c7 = z^((c2 + 1) / 2)
+	c7 := f.newFieldElement()
+	f.exp(c7, z, new(big.Int).Rsh(new(big.Int).Add(c2, big.NewInt(1)), 1))
+
+	tv1, tv2, tv3, tv4, tv5 := f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement()
+	f.copy(tv1, c6)
+	f.exp(tv2, v, c4)
+	f.square(tv3, tv2)
+	f.mul(tv3, tv3, v)
+	f.mul(tv5, u, tv3)
+	f.exp(tv5, tv5, c3)
+	f.mul(tv5, tv5, tv2)
+	f.mul(tv2, tv5, v)
+	f.mul(tv3, tv5, u)
+	f.mul(tv4, tv3, tv2)
+	f.exp(tv5, tv4, c5)
+	// straight line procedure would report zero as a non square
+	isQR := f.isOne(tv5) || f.isZero(tv4)
+	f.mul(tv2, tv3, c7)
+	f.mul(tv5, tv4, tv1)
+	f.cmov(tv3, tv2, tv3, isQR)
+	f.cmov(tv4, tv5, tv4, isQR)
+	for i := c1; i >= 2; i-- {
+		f.exp(tv5, tv4, new(big.Int).Lsh(big.NewInt(1), i-2))
+		e1 := f.isOne(tv5)
+		f.mul(tv2, tv3, tv1)
+		f.square(tv1, tv1)
+		f.mul(tv5, tv4, tv1)
+		f.cmov(tv3, tv2, tv3, e1)
+		f.cmov(tv4, tv5, tv4, e1)
+	}
+	f.copy(y, tv3)
+	return isQR
+}
+
+// mapToCurveSSWU maps u to a point of curve y^2 = x^3 + a * x + b and returns
+// its affine coordinates. a and b must be non zero and z must be a non square
+// satisfying requirements of RFC 9380, section 6.6.2. Implements the straight
+// line simplified shallue van de woestijne ulas method of appendix F.2
+func (f *field) mapToCurveSSWU(u, a, b, z fieldElement) (fieldElement, fieldElement) {
+	tv1, tv2, tv3, tv4, tv5, tv6 := f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement()
+	x, y, y1 := f.newFieldElement(), f.newFieldElement(), f.newFieldElement()
+	f.square(tv1, u)
+	f.mul(tv1, z, tv1)
+	f.square(tv2, tv1)
+	f.add(tv2, tv2, tv1)
+	f.add(tv3, tv2, f.one)
+	f.mul(tv3, b, tv3)
+	f.neg(tv4, tv2)
+	f.cmov(tv4, z, tv4, !f.isZero(tv2))
+	f.mul(tv4, a, tv4)
+	f.square(tv2, tv3)
+	f.square(tv6, tv4)
+	f.mul(tv5, a, tv6)
+	f.add(tv2, tv2, tv5)
+	f.mul(tv2, tv2, tv3)
+	f.mul(tv6, tv6, tv4)
+	f.mul(tv5, b, tv6)
+	f.add(tv2, tv2, tv5)
+	f.mul(x, tv1, tv3)
+	isGx1Square := f.sqrtRatio(y1, tv2, tv6, z)
+	f.mul(y, tv1, u)
+	f.mul(y, y, y1)
+	f.cmov(x, x, tv3, isGx1Square)
+	f.cmov(y, y, y1, isGx1Square)
+	if f.sgn0(u) != f.sgn0(y) {
+		f.neg(y, y)
+	}
+	f.inverse(tv4, tv4)
+	f.mul(x, x, tv4)
+	return x, y
+}
+
+// mapToCurveElligator2 maps u to a point of montgomery curve
+// k * t^2 = s^3 + j * s^2 + s and returns its affine coordinates. z must be
+// a non square. Implements elligator 2 method of RFC 9380, section 6.7.1
+func (f *field) mapToCurveElligator2(u, j, k, z fieldElement) (fieldElement, fieldElement) {
+	// a = j / k, b = 1 / k^2
+	a, b := f.newFieldElement(), f.newFieldElement()
+	f.inverse(b, k)
+	f.mul(a, j, b)
+	f.square(b, b)
+	negA := f.newFieldElement()
+	f.neg(negA, a)
+
+	x1, x2, gx1, gx2, t := f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement(),
+		f.newFieldElement()
+	// x1 = -a / (1 + z * u^2)
+	f.square(t, u)
+	f.mul(t, t, z)
+	f.add(t, t, f.one)
+	f.inverse(t, t)
+	f.mul(x1, negA, t)
+	f.cmov(x1, x1, negA, f.isZero(x1))
+	// gx1 = x1^3 + a * x1^2 + b * x1
+	f.add(gx1, x1, a)
+	f.mul(gx1, gx1, x1)
+	f.add(gx1, gx1, b)
+	f.mul(gx1, gx1, x1)
+	// x2 = -x1 - a
+	f.sub(x2, negA, x1)
+	// gx2 = x2^3 + a * x2^2 + b * x2
+	f.add(gx2, x2, a)
+	f.mul(gx2, gx2, x2)
+	f.add(gx2, gx2, b)
+	f.mul(gx2, gx2, x2)
+
+	x, y := f.newFieldElement(), f.newFieldElement()
+	e := f.isSquare(gx1)
+	f.cmov(x, x2, x1, e)
+	f.cmov(t, gx2, gx1, e)
+	f.sqrtRatio(y, t, f.one, z)
+	if f.sgn0(y) != e {
+		f.neg(y, y)
+	}
+	// s = x * k, t = y * k
+	f.mul(x, x, k)
+	f.mul(y, y, k)
+	return x, y
+}
@@ -0,0 +1,202 @@
+package fp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+type mapToCurveVector struct {
+	u, x, y string
+}
+
+// test vectors from RFC 9380, appendix J. Q0 and Q1 of edwards25519 suite are
+// mapped back to montgomery coordinates of curve25519.
+var mapToCurveTestSuites = []struct {
+	name    string
+	modulus string
+	sswu    bool
+	a, b, z string
+	vectors []mapToCurveVector
+}{
+	{
+		"P256_XMD:SHA-256_SSWU_RO_",
+		"0xffffffff00000001000000000000000000000000ffffffffffffffffffffffff",
+		true,
+		"-3", "0x5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b", "-10",
+		[]mapToCurveVector{
+			{"ad5342c66a6dd0ff080df1da0ea1c04b96e0330dd89406465eeba11582515009", "ab640a12220d3ff283510ff3f4b1953d09fad35795140b1c5d64f313967934d5", "dccb558863804a881d4fff3455716c836cef230e5209594ddd33d85c565b19b1"},
+			{"8c0f1d43204bd6f6ea70ae8013070a1518b43873bcd850aafa0a9e220e2eea5a", "51cce63c50d972a6e51c61334f0f4875c9ac1cd2d3238412f84e31da7d980ef5", "b45d1a36d00ad90e5ec7840a60a4de411917fbe7c82c3949a6e699e5a1b66aac"},
+			{"afe47f2ea2b10465cc26ac403194dfb68b7f5ee865cda61e9f3e07a537220af1", "5219ad0ddef3cc49b714145e91b2f7de6ce0a7a7dc7406c7726c7e373c58cb48", "7950144e52d30acbec7b624c203b1996c99617d0b61c2442354301b191d93ecf"},
+			{"379a27833b0bfe6f7bdca08e1e83c760bf9a338ab335542704edcd69ce9e46e0", "019b7cb4efcfeaf39f738fe638e31d375ad6837f58a852d032ff60c69ee3875f", "589a62d2b22357fed5449bc38065b760095ebe6aeac84b01156ee4252715446e"},
+			{"0fad9d125a9477d55cf9357105b0eb3a5c4259809bf87180aa01d651f53d312c", "a17bdf2965eb88074bc01157e644ed409dac97cfcf0c61c998ed0fa45e79e4a2", "4f1bc80c70d411a3cc1d67aeae6e726f0f311639fee560c7f5a664554e3c9c2e"},
+			{"b68597377392cd3419d8fcc7d7660948c8403b19ea78bbca4b133c9d2196c0fb", "7da48bb67225c1a17d452c983798113f47e438e4202219dd0715f8419b274d66", "b765696b2913e36db3016c47edb99e24b1da30e761a8a3215dc0ec4d8f96e6f9"},
+		},
+	},
+	{
+		"curve25519_XMD:SHA-512_ELL2_RO_",
+		"0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed",
+		false,
+		"486662", "1", "2",
+		[]mapToCurveVector{
+			{"03fef4813c8cb5f98c6eef88fae174e6e7d5380de2b007799ac7ee712d203f3a", "2d992b0d270477caef253461ac55d374b0d94e920fffc9829c2eb56c0e86eaa1", "4c98c9144dd04dd6a214b9bd81c6e331d42f4e7c2442d1d6d71f4c210271a10b"},
+			{"780bdddd137290c8f589dc687795aafae35f6b674668d92bf92ae793e6a60c75", "79da2830189fbdf6e2ffee5abad1fd1f392e6128b8c7916e9ff3d361bed10776", "15104017c5efdedb26081ab16fbbbde5b8a2417b57491f92d394f70b86254d3b"},
+			{"5081955c4141e4e7d02ec0e36becffaa1934df4d7a270f70679c78f9bd57c227", "4a23d6be3bebabedc0532c41e6ea72d94049bbfcb2b902e7f6298b5c0a07fa40", "7d863635af1f2f10a13dff747d8b1f694d66d4aa636a63da367cded0a32583dc"},
+			{"005bdc17a9b378b6272573a31b04361f21c371b256252ae5463119aa0b925b76", "5183cb30e4bb924e884075fa7c14e01cecc1b19d99abfd085b68b2466ccd0951", "1fc455718fffd9209d73aa84bd0095f09df7234a9d0004b50b28713fb75e8ea8"},
+			{"285ebaa3be701b79871bcb6e225ecc9b0b32dff2d60424b4c50642636a78d5b3", "25ae496af08995bf1638d4a308857117f0384e3e78a54729d16308c29179e01b", "6923f22bfdc667ffbaede31c8a7fd2dfe5035085735110f41e6efa8e81ff3b39"},
+			{"2e253e6a0ef658fedb8e4bd6a62d1544fd6547922acb3598ec6b369760b81b31", "59beccbca1709115f923f8890c5d83e1a7b10f9cbdd25f61c590cf745102f09c", "01fb9ac02f2352b0cb16fe32bc3ed3ad2be69a2581e64a539da0929c74ecbda6"},
+		},
+	},
+}
+
+func (f *field) newFieldElementFromConstant(t *testing.T, s string) fieldElement {
+	c, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		t.Fatalf("bad constant %s", s)
+	}
+	fe, err := f.newFieldElementFromBig(c.Mod(c, f.pbig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fe
+}
+
+func (f *field) randNonSquare() fieldElement {
+	for {
+		z := f.randFieldElement(rand.Reader)
+		if !f.isSquare(z) {
+			return z
+		}
+	}
+}
+
+func (f *field) randNonZero() fieldElement {
+	for {
+		a := f.randFieldElement(rand.Reader)
+		if !f.isZero(a) {
+			return a
+		}
+	}
+}
+
+func TestSqrtRatio(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			for i := 0; i < fuz; i++ {
+				field := randField(limbSize)
+				z := field.randNonSquare()
+				for j := 0; j < fieldLifetime; j++ {
+					u := field.randFieldElement(rand.Reader)
+					v := field.randNonZero()
+					y, t0, t1 := field.newFieldElement(), field.newFieldElement(), field.newFieldElement()
+					isQR := field.sqrtRatio(y, u, v, z)
+					// u / v is square iff u * v is square
+					field.mul(t0, u, v)
+					if isQR != field.isSquare(t0) {
+						t.Fatalf("bad square detection")
+					}
+					field.square(t0, y)
+					field.mul(t0, t0, v)
+					field.copy(t1, u)
+					if !isQR {
+						field.mul(t1, t1, z)
+					}
+					if !field.equal(t0, t1) {
+						t.Fatalf("y^2 * v == u or y^2 * v == z * u")
+					}
+				}
+				u := field.newFieldElement()
+				y := field.newFieldElement()
+				if !field.sqrtRatio(y, u, field.one, z) || !field.isZero(y) {
+					t.Fatalf("sqrt(0 / 1) == 0")
+				}
+			}
+		})
+	}
+}
+
+func TestMapToCurveSSWU(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			for i := 0; i < fuz; i++ {
+				field := randField(limbSize)
+				a, b, z := field.randNonZero(), field.randNonZero(), field.randNonSquare()
+				for j := 0; j < fieldLifetime; j++ {
+					u := field.randFieldElement(rand.Reader)
+					x, y := field.mapToCurveSSWU(u, a, b, z)
+					// y^2 == x^3 + a * x + b
+					lhs, rhs := field.newFieldElement(), field.newFieldElement()
+					field.square(lhs, y)
+					field.square(rhs, x)
+					field.add(rhs, rhs, a)
+					field.mul(rhs, rhs, x)
+					field.add(rhs, rhs, b)
+					if !field.equal(lhs, rhs) {
+						t.Fatalf("point is not on curve")
+					}
+					if field.sgn0(u) != field.sgn0(y) {
+						t.Fatalf("sgn0(u) == sgn0(y)")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMapToCurveElligator2(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			for i := 0; i < fuz; i++ {
+				field := randField(limbSize)
+				j, k, z := field.randFieldElement(rand.Reader), field.randNonZero(), field.randNonSquare()
+				for l := 0; l < fieldLifetime; l++ {
+					u := field.randFieldElement(rand.Reader)
+					s, v := field.mapToCurveElligator2(u, j, k, z)
+					// k * t^2 == s^3 + j * s^2 + s
+					lhs, rhs := field.newFieldElement(), field.newFieldElement()
+					field.square(lhs, v)
+					field.mul(lhs, lhs, k)
+					field.add(rhs, s, j)
+					field.mul(rhs, rhs, s)
+					field.add(rhs, rhs, field.one)
+					field.mul(rhs, rhs, s)
+					if !field.equal(lhs, rhs) {
+						t.Fatalf("point is not on curve")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMapToCurveVectors(t *testing.T) {
+	for _, suite := range mapToCurveTestSuites {
+		t.Run(suite.name, func(t *testing.T) {
+			p, _ := new(big.Int).SetString(suite.modulus, 0)
+			field, err := newField(p.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+			a := field.newFieldElementFromConstant(t, suite.a)
+			b := field.newFieldElementFromConstant(t, suite.b)
+			z := field.newFieldElementFromConstant(t, suite.z)
+			for _, v := range suite.vectors {
+				u, err := field.newFieldElementFromString(v.u)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var x, y fieldElement
+				if suite.sswu {
+					x, y = field.mapToCurveSSWU(u, a, b, z)
+				} else {
+					x, y = field.mapToCurveElligator2(u, a, b, z)
+				}
+				if field.toString(x) != v.x || field.toString(y) != v.y {
+					t.Fatalf("bad map to curve, u: %s\nhave: %s, %s\nwant: %s, %s",
+						v.u, field.toString(x), field.toString(y), v.x, v.y)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package fp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"runtime"
+	"sync"
+)
+
+// msmBatchSize is the number of bucket additions sharing a single inversion.
+const msmBatchSize = 256
+
+// msmConfig configures bucket method multi scalar multiplication. Zero values
+// select window size from the input size and use all available CPUs.
+type msmConfig struct {
+	window  int
+	workers int
+}
+
+// batchInverse sets out[i] to in[i]^-1 with a single inversion. Inputs must be
+// non zero.
+func (f *field) batchInverse(out, in []fieldElement) {
+	if len(in) == 0 {
+		return
+	}
+	acc := f.newFieldElement()
+	f.copy(acc, f.one)
+	for i := range in {
+		f.copy(out[i], acc)
+		f.mul(acc, acc, in[i])
+	}
+	f.inverse(acc, acc)
+	t := f.newFieldElement()
+	for i := len(in) - 1; i >= 0; i-- {
+		f.mul(t, acc, out[i])
+		f.mul(acc, acc, in[i])
+		f.copy(out[i], t)
+	}
+}
+
+// msmWindowSize returns a window size close to ln(n) that minimizes number of
+// bucket additions and running sums.
+func msmWindowSize(n int) int {
+	c := bits.Len(uint(n)) * 69 / 100
+	if c < 2 {
+		return 2
+	}
+	if c > 16 {
+		return 16
+	}
+	return c
+}
+
+// signedDigits recodes non negative e into windows of c bits where digits are
+// in [-2^(c-1), 2^(c-1)). Sum of d_i * 2^(c * i) is e.
+func signedDigits(e *big.Int, c, windows int) []int32 {
+	digits := make([]int32, windows)
+	words := e.Bits()
+	mask := uint64(1)<<uint(c) - 1
+	half := int64(1) << uint(c-1)
+	var carry int64
+	for w := 0; w < windows; w++ {
+		d := int64(extractBits(words, w*c, c)&mask) + carry
+		carry = 0
+		if d >= half {
+			d -= int64(1) << uint(c)
+			carry = 1
+		}
+		digits[w] = int32(d)
+	}
+	return digits
+}
+
+// extractBits returns n < 64 bits of little endian words starting at offset
+func extractBits(words []big.Word, offset, n int) uint64 {
+	const wordSize = bits.UintSize
+	i, shift := offset/wordSize, uint(offset%wordSize)
+	if i >= len(words) {
+		return 0
+	}
+	out := uint64(words[i]) >> shift
+	if int(shift)+n > wordSize && i+1 < len(words) {
+		out |= uint64(words[i+1]) << (wordSize - shift)
+	}
+	return out
+}
+
+// msm returns sum of scalars[i] * points[i] with bucket method. Scalars are
+// recoded into signed digits, so that buckets are half the size of unsigned
+// windows, and buckets are accumulated in affine coordinates in batches
+// sharing a single inversion. Windows and chunks of points are processed in
+// parallel.
+func (c *curve) msm(points []*pointAffine, scalars []*big.Int, cfg msmConfig) (*pointJacobian, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("bad input size %d, expected %d", len(scalars), len(points))
+	}
+	maxBits := 0
+	for _, e := range scalars {
+		if e.Sign() < 0 {
+			return nil, errors.New("scalars should be non negative")
+		}
+		if e.BitLen() > maxBits {
+			maxBits = e.BitLen()
+		}
+	}
+	window := cfg.window
+	if window == 0 {
+		window = msmWindowSize(len(points))
+	}
+	if window < 2 || window > 31 {
+		return nil, fmt.Errorf("bad window size %d", window)
+	}
+	workers := cfg.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	// one more window for the last carry
+	windows := (maxBits+window-1)/window + 1
+	digits := make([][]int32, len(scalars))
+	for i, e := range scalars {
+		digits[i] = signedDigits(e, window, windows)
+	}
+	// split points into chunks so that there are about as many tasks as
+	// workers
+	chunks := (workers + windows - 1) / windows
+	if chunks > len(points) {
+		chunks = len(points)
+	}
+	if chunks < 1 {
+		chunks = 1
+	}
+	chunkSize := (len(points) + chunks - 1) / chunks
+	sums := make([][]*pointJacobian, windows)
+	for w := range sums {
+		sums[w] = make([]*pointJacobian, chunks)
+	}
+	tasks := make(chan [2]int, windows*chunks)
+	for w := 0; w < windows; w++ {
+		for k := 0; k < chunks; k++ {
+			tasks <- [2]int{w, k}
+		}
+	}
+	close(tasks)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range tasks {
+				w, k := task[0], task[1]
+				from, to := k*chunkSize, (k+1)*chunkSize
+				if to > len(points) {
+					to = len(points)
+				}
+				if from > to {
+					from = to
+				}
+				sums[w][k] = c.bucketSum(points[from:to], digits[from:to], w, window)
+			}
+		}()
+	}
+	wg.Wait()
+	// sum of windows with horner method
+	acc := c.newPointJacobian()
+	for w := windows - 1; w >= 0; w-- {
+		for i := 0; i < window; i++ {
+			c.double(acc, acc)
+		}
+		for k := 0; k < chunks; k++ {
+			c.add(acc, acc, sums[w][k])
+		}
+	}
+	return acc, nil
+}
+
+// bucketEntry is a pending addition of point to bucket
+type bucketEntry struct {
+	bucket int
+	point  int
+	neg    bool
+}
+
+// bucketSum returns sum of d * points[i] for digits d of window w.
+func (c *curve) bucketSum(points []*pointAffine, digits [][]int32, w, window int) *pointJacobian {
+	f := c.f
+	buckets := make([]*pointAffine, 1<<uint(window-1))
+	for i := range buckets {
+		buckets[i] = c.newPointAffine()
+	}
+	pending := make([]bucketEntry, 0, len(points))
+	for i, p := range points {
+		d := digits[i][w]
+		if d == 0 || p.inf {
+			continue
+		}
+		if d > 0 {
+			pending = append(pending, bucketEntry{int(d) - 1, i, false})
+		} else {
+			pending = append(pending, bucketEntry{int(-d) - 1, i, true})
+		}
+	}
+	used := make([]bool, len(buckets))
+	batch := make([]bucketEntry, 0, msmBatchSize)
+	den := make([]fieldElement, msmBatchSize)
+	inv := make([]fieldElement, msmBatchSize)
+	for i := range den {
+		den[i], inv[i] = f.newFieldElement(), f.newFieldElement()
+	}
+	q := &pointAffine{}
+	lambda, t, negY := f.newFieldElement(), f.newFieldElement(), f.newFieldElement()
+	flush := func() {
+		for i, e := range batch {
+			f.sub(den[i], points[e.point].x, buckets[e.bucket].x)
+		}
+		f.batchInverse(inv[:len(batch)], den[:len(batch)])
+		for i, e := range batch {
+			b, p := buckets[e.bucket], points[e.point]
+			// lambda = (y_p - y_b) / (x_p - x_b)
+			if e.neg {
+				f.add(lambda, p.y, b.y)
+				f.neg(lambda, lambda)
+			} else {
+				f.sub(lambda, p.y, b.y)
+			}
+			f.mul(lambda, lambda, inv[i])
+			// x = lambda^2 - x_b - x_p
+			f.square(t, lambda)
+			f.sub(t, t, b.x)
+			f.sub(t, t, p.x)
+			// y = lambda * (x_b - x) - y_b
+			f.sub(b.x, b.x, t)
+			f.mul(b.x, b.x, lambda)
+			f.sub(b.y, b.x, b.y)
+			f.copy(b.x, t)
+			used[e.bucket] = false
+		}
+		batch = batch[:0]
+	}
+	for len(pending) > 0 {
+		deferred := pending[:0]
+		for _, e := range pending {
+			b := buckets[e.bucket]
+			if used[e.bucket] {
+				// bucket is already updated in this batch
+				deferred = append(deferred, e)
+				continue
+			}
+			q.x, q.y = points[e.point].x, points[e.point].y
+			if e.neg {
+				f.neg(negY, q.y)
+				q.y = negY
+			}
+			switch {
+			case b.inf:
+				c.copyAffine(b, q)
+			case f.equal(b.x, q.x):
+				// doubling or cancellation can not share the inversion
+				c.addAffine(b, b, q)
+			default:
+				batch = append(batch, e)
+				used[e.bucket] = true
+				if len(batch) == msmBatchSize {
+					flush()
+				}
+			}
+		}
+		flush()
+		pending = deferred
+	}
+	// sum of i * bucket_i with running sums
+	running, total := c.newPointJacobian(), c.newPointJacobian()
+	for i := len(buckets) - 1; i >= 0; i-- {
+		c.addMixed(running, running, buckets[i])
+		c.add(total, total, running)
+	}
+	return total
+}
+
+// addAffine sets r to p + q with a single inversion
+func (c *curve) addAffine(r, p, q *pointAffine) {
+	t := c.newPointJacobian()
+	c.fromAffine(t, p)
+	c.addMixed(t, t, q)
+	c.toAffine(r, t)
+}
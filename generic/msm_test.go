@@ -0,0 +1,287 @@
+package fp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func randCurve(limbSize int) *curve {
+	f := randField(limbSize)
+	return newCurve(f, f.randFieldElement(rand.Reader), f.randFieldElement(rand.Reader))
+}
+
+// randPoint returns a random point with y = sqrt(x^3 + a * x + b)
+func (c *curve) randPoint() *pointAffine {
+	f := c.f
+	z := f.randNonSquare()
+	p := c.newPointAffine()
+	rhs := f.newFieldElement()
+	for {
+		p.x = f.randFieldElement(rand.Reader)
+		f.square(rhs, p.x)
+		f.add(rhs, rhs, c.a)
+		f.mul(rhs, rhs, p.x)
+		f.add(rhs, rhs, c.b)
+		if f.sqrtRatio(p.y, rhs, f.one, z) {
+			p.inf = false
+			return p
+		}
+	}
+}
+
+// refPointAffine is an affine point for big.Int reference arithmetic, nil is
+// the point at infinity
+type refPointAffine []*big.Int
+
+func (c *curve) toRef(p *pointAffine) refPointAffine {
+	if p.inf {
+		return nil
+	}
+	return refPointAffine{c.f.toBig(p.x), c.f.toBig(p.y)}
+}
+
+func (c *curve) jacobianToRef(p *pointJacobian) refPointAffine {
+	a := c.newPointAffine()
+	c.toAffine(a, p)
+	return c.toRef(a)
+}
+
+func (c *curve) refAdd(p, q refPointAffine) refPointAffine {
+	if p == nil {
+		return q
+	}
+	if q == nil {
+		return p
+	}
+	pbig := c.f.pbig
+	l := new(big.Int)
+	if p[0].Cmp(q[0]) == 0 {
+		if l.Add(p[1], q[1]).Mod(l, pbig).Sign() == 0 {
+			return nil
+		}
+		// (3x^2 + a) / 2y
+		l.Mul(p[0], p[0]).Mul(l, big.NewInt(3)).Add(l, c.f.toBig(c.a))
+		l.Mul(l, new(big.Int).ModInverse(new(big.Int).Lsh(p[1], 1), pbig))
+	} else {
+		// (y2 - y1) / (x2 - x1)
+		l.Sub(q[1], p[1])
+		l.Mul(l, new(big.Int).ModInverse(new(big.Int).Mod(new(big.Int).Sub(q[0], p[0]), pbig), pbig))
+	}
+	l.Mod(l, pbig)
+	x := new(big.Int).Mul(l, l)
+	x.Sub(x, p[0]).Sub(x, q[0]).Mod(x, pbig)
+	y := new(big.Int).Sub(p[0], x)
+	y.Mul(y, l).Sub(y, p[1]).Mod(y, pbig)
+	return refPointAffine{x, y}
+}
+
+func (c *curve) refMul(p refPointAffine, e *big.Int) refPointAffine {
+	var r refPointAffine
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = c.refAdd(r, r)
+		if e.Bit(i) == 1 {
+			r = c.refAdd(r, p)
+		}
+	}
+	return r
+}
+
+// refMSM is the test oracle with big.Int affine arithmetic
+func (c *curve) refMSM(points []*pointAffine, scalars []*big.Int) refPointAffine {
+	var r refPointAffine
+	for i := range points {
+		r = c.refAdd(r, c.refMul(c.toRef(points[i]), scalars[i]))
+	}
+	return r
+}
+
+func refPointEqual(p, q refPointAffine) bool {
+	if p == nil || q == nil {
+		return p == nil && q == nil
+	}
+	return p[0].Cmp(q[0]) == 0 && p[1].Cmp(q[1]) == 0
+}
+
+func TestCurveArithmetic(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			c := randCurve(limbSize)
+			for i := 0; i < fuz; i++ {
+				p, q := c.randPoint(), c.randPoint()
+				if !c.isOnCurve(p) {
+					t.Fatalf("point is not on curve")
+				}
+				a, b := c.toRef(p), c.toRef(q)
+				pj, qj, r := c.newPointJacobian(), c.newPointJacobian(), c.newPointJacobian()
+				c.fromAffine(pj, p)
+				c.double(qj, c.newPointJacobian())
+				c.addMixed(qj, qj, q)
+				c.double(qj, qj)
+				// qj = 2 * q
+				c.add(r, pj, qj)
+				if !refPointEqual(c.jacobianToRef(r), c.refAdd(a, c.refAdd(b, b))) {
+					t.Fatalf("bad jacobian addition")
+				}
+				c.addMixed(r, qj, p)
+				if !refPointEqual(c.jacobianToRef(r), c.refAdd(a, c.refAdd(b, b))) {
+					t.Fatalf("bad mixed addition")
+				}
+				c.add(r, pj, pj)
+				if !refPointEqual(c.jacobianToRef(r), c.refAdd(a, a)) {
+					t.Fatalf("bad addition of equal points")
+				}
+				c.addMixed(r, pj, p)
+				if !refPointEqual(c.jacobianToRef(r), c.refAdd(a, a)) {
+					t.Fatalf("bad mixed addition of equal points")
+				}
+				n := c.newPointAffine()
+				c.negAffine(n, p)
+				c.addMixed(r, pj, n)
+				if !c.f.isZero(r.z) {
+					t.Fatalf("p - p should be at infinity")
+				}
+				e := randBig(c.f.pbig)
+				c.mulScalar(r, p, e)
+				if !refPointEqual(c.jacobianToRef(r), c.refMul(a, e)) {
+					t.Fatalf("bad scalar multiplication")
+				}
+			}
+		})
+	}
+}
+
+func TestBatchInverse(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			f := randField(limbSize)
+			for n := 0; n < 20; n++ {
+				in, out := make([]fieldElement, n), make([]fieldElement, n)
+				for i := range in {
+					in[i], out[i] = f.randNonZero(), f.newFieldElement()
+				}
+				f.batchInverse(out, in)
+				e := f.newFieldElement()
+				for i := range in {
+					f.inverse(e, in[i])
+					if !f.equal(e, out[i]) {
+						t.Fatalf("bad batch inversion")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSignedDigits(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		e := randBig(new(big.Int).Lsh(big.NewInt(1), uint(1+i*7)))
+		for c := 2; c < 20; c++ {
+			windows := (e.BitLen()+c-1)/c + 1
+			digits := signedDigits(e, c, windows)
+			acc := new(big.Int)
+			for w := windows - 1; w >= 0; w-- {
+				if digits[w] < -(1<<uint(c-1)) || digits[w] >= 1<<uint(c-1) {
+					t.Fatalf("digit out of range %d", digits[w])
+				}
+				acc.Lsh(acc, uint(c))
+				acc.Add(acc, big.NewInt(int64(digits[w])))
+			}
+			if acc.Cmp(e) != 0 {
+				t.Fatalf("bad signed digits, c: %d", c)
+			}
+		}
+	}
+}
+
+func TestMSM(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			c := randCurve(limbSize)
+			// scalars are usually of a smaller scalar field
+			max := new(big.Int).Lsh(big.NewInt(1), 256)
+			for _, n := range []int{0, 1, 2, 7, 33} {
+				points, scalars := make([]*pointAffine, n), make([]*big.Int, n)
+				for i := range points {
+					points[i], scalars[i] = c.randPoint(), randBig(max)
+				}
+				// edge cases for buckets
+				if n > 5 {
+					points[1] = points[0]
+					c.negAffine(points[2], points[0])
+					scalars[1].Set(scalars[0])
+					scalars[2].Set(scalars[0])
+					points[3].inf = true
+					scalars[4].SetInt64(0)
+				}
+				expected := c.refMSM(points, scalars)
+				for _, cfg := range []msmConfig{{}, {window: 2, workers: 1}, {window: 5, workers: 3}, {window: 7, workers: 16}} {
+					r, err := c.msm(points, scalars, cfg)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !refPointEqual(c.jacobianToRef(r), expected) {
+						t.Fatalf("bad msm, n: %d, config: %+v", n, cfg)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMSMBatches(t *testing.T) {
+	// more points than batch size to exercise flushes and deferred bucket
+	// additions
+	c := randCurve(4)
+	n := 2*msmBatchSize + 17
+	points, scalars := make([]*pointAffine, n), make([]*big.Int, n)
+	expected := c.newPointJacobian()
+	t0 := c.newPointJacobian()
+	for i := range points {
+		points[i], scalars[i] = c.randPoint(), randBig(c.f.pbig)
+		if i%5 == 0 {
+			points[i] = points[0]
+		}
+		c.mulScalar(t0, points[i], scalars[i])
+		c.add(expected, expected, t0)
+	}
+	for _, cfg := range []msmConfig{{}, {window: 2, workers: 1}, {window: 3, workers: 4}} {
+		r, err := c.msm(points, scalars, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !refPointEqual(c.jacobianToRef(r), c.jacobianToRef(expected)) {
+			t.Fatalf("bad msm, config: %+v", cfg)
+		}
+	}
+}
+
+func TestMSMErrors(t *testing.T) {
+	c := randCurve(4)
+	p := c.randPoint()
+	if _, err := c.msm([]*pointAffine{p}, nil, msmConfig{}); err == nil {
+		t.Fatalf("size mismatch should be rejected")
+	}
+	if _, err := c.msm([]*pointAffine{p}, []*big.Int{big.NewInt(-1)}, msmConfig{}); err == nil {
+		t.Fatalf("negative scalars should be rejected")
+	}
+	if _, err := c.msm([]*pointAffine{p}, []*big.Int{big.NewInt(1)}, msmConfig{window: 1}); err == nil {
+		t.Fatalf("bad window size should be rejected")
+	}
+}
+
+func BenchmarkMSM(b *testing.B) {
+	c := randCurve(4)
+	n := 1 << 12
+	points, scalars := make([]*pointAffine, n), make([]*big.Int, n)
+	for i := range points {
+		points[i], scalars[i] = c.randPoint(), randBig(c.f.pbig)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.msm(points, scalars, msmConfig{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
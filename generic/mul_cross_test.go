@@ -0,0 +1,94 @@
+package fp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"golang.org/x/sys/cpu"
+)
+
+// mulCrossField returns a field with a random prime modulus of bit size
+func mulCrossField(limbSize, bitSize int) *field {
+	if bitSize < 3 {
+		bitSize = 3
+	}
+	pbig, err := rand.Prime(rand.Reader, bitSize)
+	if err != nil {
+		panic(err)
+	}
+	field, err := newField(padBytes(pbig.Bytes(), limbSize*8))
+	if err != nil {
+		panic(err)
+	}
+	return field
+}
+
+// mulCrossSamples returns adversarial and random operands below the modulus
+func mulCrossSamples(field *field) []*big.Int {
+	one := big.NewInt(1)
+	p := field.pbig
+	samples := []*big.Int{
+		new(big.Int),
+		big.NewInt(1),
+		new(big.Int).Sub(p, one),
+		new(big.Int).Sub(p, big.NewInt(2)),
+		new(big.Int).Set(field.rbig),
+		new(big.Int).Rsh(p, 1),
+		// all ones limbs and a single high bit
+		new(big.Int).Sub(new(big.Int).Lsh(one, uint(p.BitLen()-1)), one),
+		new(big.Int).Lsh(one, uint(p.BitLen()-2)),
+	}
+	for i := 0; i < fuz; i++ {
+		samples = append(samples, randBig(p))
+	}
+	return samples
+}
+
+// TestMulBackendsCross runs ADX and non ADX multiplication kernels on the same
+// inputs and reports mismatches per limb size
+func TestMulBackendsCross(t *testing.T) {
+	if !(cpu.X86.HasADX && cpu.X86.HasBMI2) {
+		t.Skip("ADX and BMI2 are not available")
+	}
+	for limbSize := from; limbSize <= to; limbSize++ {
+		backends, ok := mulBackends[limbSize]
+		if !ok {
+			continue
+		}
+		t.Run(fmt.Sprintf("%d", limbSize), func(t *testing.T) {
+			// full width, one bit short and random width moduli
+			bitSizes := []int{limbSize * 64, limbSize*64 - 1, (limbSize-1)*64 + 1 + int(randBig(big.NewInt(64)).Int64())}
+			mismatches := 0
+			for _, bitSize := range bitSizes {
+				field := mulCrossField(limbSize, bitSize)
+				rinv := new(big.Int).ModInverse(field.rbig, field.pbig)
+				samples := mulCrossSamples(field)
+				c0, _ := newFieldElement(limbSize)
+				c1, _ := newFieldElement(limbSize)
+				for _, big_a := range samples {
+					for _, big_b := range samples {
+						a := newFieldElementFromBigUnchecked(limbSize, big_a)
+						b := newFieldElementFromBigUnchecked(limbSize, big_b)
+						backends.adx(c0, a, b, field.p, field.inp)
+						backends.noadx(c1, a, b, field.p, field.inp)
+						expected := new(big.Int).Mul(big_a, big_b)
+						expected.Mul(expected, rinv).Mod(expected, field.pbig)
+						have0, have1 := field.toBigNoTransform(c0), field.toBigNoTransform(c1)
+						if have0.Cmp(expected) == 0 && have1.Cmp(expected) == 0 {
+							continue
+						}
+						if mismatches == 0 {
+							t.Errorf("p: %x\na: %x\nb: %x\nadx: %x\nnon adx: %x\nwant: %x", field.pbig, big_a, big_b, have0, have1, expected)
+						}
+						mismatches++
+					}
+				}
+			}
+			if mismatches != 0 {
+				t.Errorf("%d mismatches at limb size %d", mismatches, limbSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package fp
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// twoAdicity returns largest s such that 2^s divides p - 1.
+func (f *field) twoAdicity() int {
+	pMinusOne := new(big.Int).Sub(f.pbig, big.NewInt(1))
+	return int(pMinusOne.TrailingZeroBits())
+}
+
+// rootOfUnity returns a primitive 2^logN-th root of unity. It is derived from
+// the first non square g as g^((p - 1) / 2^s) which generates the 2-sylow
+// subgroup of order 2^s and squared down to the desired order.
+func (f *field) rootOfUnity(logN int) (fieldElement, error) {
+	s := f.twoAdicity()
+	if logN < 0 || logN > s {
+		return nil, fmt.Errorf("no root of unity of order 2^%d, two adicity is %d", logN, s)
+	}
+	g := f.newFieldElement()
+	f.copy(g, f.one)
+	for {
+		f.add(g, g, f.one)
+		if !f.isSquare(g) {
+			break
+		}
+	}
+	e := new(big.Int).Sub(f.pbig, big.NewInt(1))
+	e.Rsh(e, uint(s))
+	w := f.newFieldElement()
+	f.exp(w, g, e)
+	for i := s; i > logN; i-- {
+		f.square(w, w)
+	}
+	return w, nil
+}
+
+// domain is the multiplicative subgroup of order n = 2^logN with its twiddle
+// tables and a coset shift g such that g * domain is disjoint from domain.
+type domain struct {
+	f        *field
+	logN     int
+	n        int
+	omega    fieldElement
+	omegaInv fieldElement
+	nInv     fieldElement
+	// w^i and w^-i for i < n / 2
+	twiddles    []fieldElement
+	twiddlesInv []fieldElement
+	cosetGen    fieldElement
+	cosetGenInv fieldElement
+}
+
+func (f *field) newDomain(logN int) (*domain, error) {
+	omega, err := f.rootOfUnity(logN)
+	if err != nil {
+		return nil, err
+	}
+	d := &domain{f: f, logN: logN, n: 1 << uint(logN), omega: omega}
+	d.omegaInv = f.newFieldElement()
+	f.inverse(d.omegaInv, omega)
+	d.nInv = f.newFieldElement()
+	n, _ := f.newFieldElementFromBig(big.NewInt(int64(d.n)))
+	f.inverse(d.nInv, n)
+	d.twiddles = d.powers(omega, d.n/2)
+	d.twiddlesInv = d.powers(d.omegaInv, d.n/2)
+	// smallest g > 1 with g^n != 1 is not in the domain
+	d.cosetGen = f.newFieldElement()
+	f.copy(d.cosetGen, f.one)
+	t := f.newFieldElement()
+	for {
+		f.add(d.cosetGen, d.cosetGen, f.one)
+		f.exp(t, d.cosetGen, big.NewInt(int64(d.n)))
+		if !f.isOne(t) {
+			break
+		}
+	}
+	d.cosetGenInv = f.newFieldElement()
+	f.inverse(d.cosetGenInv, d.cosetGen)
+	return d, nil
+}
+
+// powers returns a^i for i < n.
+func (d *domain) powers(a fieldElement, n int) []fieldElement {
+	out := make([]fieldElement, n)
+	if n == 0 {
+		return out
+	}
+	out[0] = d.f.newFieldElement()
+	d.f.copy(out[0], d.f.one)
+	for i := 1; i < n; i++ {
+		out[i] = d.f.newFieldElement()
+		d.f.mul(out[i], out[i-1], a)
+	}
+	return out
+}
+
+func (d *domain) bitReverse(a []fieldElement) {
+	shift := uint(64 - d.logN)
+	for i := 0; i < d.n; i++ {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+}
+
+// butterflies runs iterative cooley tukey stages on bit reversed input.
+func (d *domain) butterflies(a []fieldElement, twiddles []fieldElement) {
+	f := d.f
+	t := f.newFieldElement()
+	for m := 1; m < d.n; m <<= 1 {
+		step := d.n / (2 * m)
+		for k := 0; k < d.n; k += 2 * m {
+			for j := 0; j < m; j++ {
+				f.mul(t, a[k+j+m], twiddles[j*step])
+				f.sub(a[k+j+m], a[k+j], t)
+				f.add(a[k+j], a[k+j], t)
+			}
+		}
+	}
+}
+
+// ntt evaluates the polynomial with coefficients a at w^i in place.
+func (d *domain) ntt(a []fieldElement) {
+	if len(a) != d.n {
+		panic(fmt.Sprintf("bad input size %d, expected %d", len(a), d.n))
+	}
+	d.bitReverse(a)
+	d.butterflies(a, d.twiddles)
+}
+
+// inverseNTT interpolates evaluations at w^i back to coefficients in place.
+func (d *domain) inverseNTT(a []fieldElement) {
+	if len(a) != d.n {
+		panic(fmt.Sprintf("bad input size %d, expected %d", len(a), d.n))
+	}
+	d.bitReverse(a)
+	d.butterflies(a, d.twiddlesInv)
+	for i := 0; i < d.n; i++ {
+		d.f.mul(a[i], a[i], d.nInv)
+	}
+}
+
+// cosetNTT evaluates the polynomial with coefficients a at g * w^i in place.
+func (d *domain) cosetNTT(a []fieldElement) {
+	d.scale(a, d.cosetGen)
+	d.ntt(a)
+}
+
+// inverseCosetNTT interpolates evaluations at g * w^i back to coefficients.
+func (d *domain) inverseCosetNTT(a []fieldElement) {
+	d.inverseNTT(a)
+	d.scale(a, d.cosetGenInv)
+}
+
+// scale multiplies a_i with g^i.
+func (d *domain) scale(a []fieldElement, g fieldElement) {
+	t := d.f.newFieldElement()
+	d.f.copy(t, d.f.one)
+	for i := 0; i < len(a); i++ {
+		d.f.mul(a[i], a[i], t)
+		d.f.mul(t, t, g)
+	}
+}
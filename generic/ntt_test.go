@@ -0,0 +1,171 @@
+package fp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// randFFTField returns a random field with modulus k * 2^s + 1 where k is odd
+func randFFTField(limbSize, s int) *field {
+	t, err := rand.Int(rand.Reader, big.NewInt(32))
+	if err != nil {
+		panic(err)
+	}
+	bitSize := limbSize*64 - int(t.Int64())
+	if bitSize < s+8 {
+		bitSize = s + 8
+	}
+	for {
+		k, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), uint(bitSize-s-1)))
+		if err != nil {
+			panic(err)
+		}
+		k.SetBit(k, bitSize-s-1, 1)
+		k.SetBit(k, 0, 1)
+		p := new(big.Int).Lsh(k, uint(s))
+		p.Add(p, big.NewInt(1))
+		if p.ProbablyPrime(20) {
+			field, err := newField(padBytes(p.Bytes(), limbSize*8))
+			if err != nil {
+				panic(err)
+			}
+			return field
+		}
+	}
+}
+
+// evalNaive evaluates polynomial with coefficients a at x
+func (f *field) evalNaive(a []fieldElement, x fieldElement) fieldElement {
+	acc := f.newFieldElement()
+	for i := len(a) - 1; i >= 0; i-- {
+		f.mul(acc, acc, x)
+		f.add(acc, acc, a[i])
+	}
+	return acc
+}
+
+func TestRootOfUnity(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			field := randFFTField(limbSize, 12)
+			s := field.twoAdicity()
+			if s != 12 {
+				t.Fatalf("bad two adicity")
+			}
+			minusOne := field.newFieldElement()
+			field.neg(minusOne, field.one)
+			u := field.newFieldElement()
+			for logN := 0; logN <= s; logN++ {
+				w, err := field.rootOfUnity(logN)
+				if err != nil {
+					t.Fatal(err)
+				}
+				field.exp(u, w, new(big.Int).Lsh(big.NewInt(1), uint(logN)))
+				if !field.isOne(u) {
+					t.Fatalf("w^(2^%d) == 1", logN)
+				}
+				if logN > 0 {
+					field.exp(u, w, new(big.Int).Lsh(big.NewInt(1), uint(logN-1)))
+					if !field.equal(u, minusOne) {
+						t.Fatalf("w^(2^%d) == -1", logN-1)
+					}
+				}
+			}
+			if _, err := field.rootOfUnity(s + 1); err == nil {
+				t.Fatalf("root of unity of order larger than two adicity should be rejected")
+			}
+		})
+	}
+}
+
+func TestNTT(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			for i := 0; i < fuz; i++ {
+				field := randFFTField(limbSize, 8)
+				for logN := 0; logN <= 6; logN++ {
+					d, err := field.newDomain(logN)
+					if err != nil {
+						t.Fatal(err)
+					}
+					coeffs := make([]fieldElement, d.n)
+					a := make([]fieldElement, d.n)
+					for j := 0; j < d.n; j++ {
+						coeffs[j] = field.randFieldElement(rand.Reader)
+						a[j] = field.newFieldElement()
+						field.copy(a[j], coeffs[j])
+					}
+					// forward
+					d.ntt(a)
+					x := field.newFieldElement()
+					field.copy(x, field.one)
+					for j := 0; j < d.n; j++ {
+						if !field.equal(a[j], field.evalNaive(coeffs, x)) {
+							t.Fatalf("bad ntt, n: %d", d.n)
+						}
+						field.mul(x, x, d.omega)
+					}
+					// inverse
+					d.inverseNTT(a)
+					for j := 0; j < d.n; j++ {
+						if !field.equal(a[j], coeffs[j]) {
+							t.Fatalf("bad inverse ntt, n: %d", d.n)
+						}
+					}
+					// coset
+					d.cosetNTT(a)
+					field.copy(x, d.cosetGen)
+					for j := 0; j < d.n; j++ {
+						if !field.equal(a[j], field.evalNaive(coeffs, x)) {
+							t.Fatalf("bad coset ntt, n: %d", d.n)
+						}
+						field.mul(x, x, d.omega)
+					}
+					d.inverseCosetNTT(a)
+					for j := 0; j < d.n; j++ {
+						if !field.equal(a[j], coeffs[j]) {
+							t.Fatalf("bad inverse coset ntt, n: %d", d.n)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNTTKnownFields(t *testing.T) {
+	for _, p := range []string{
+		// goldilocks
+		"ffffffff00000001",
+		// bn254 scalar field
+		"30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001",
+		// bls12-381 scalar field
+		"73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001",
+	} {
+		pBytes, _ := new(big.Int).SetString(p, 16)
+		field, err := newField(padBytes(pBytes.Bytes(), len(p)/2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := field.newDomain(10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := make([]fieldElement, d.n)
+		b := make([]fieldElement, d.n)
+		for j := 0; j < d.n; j++ {
+			a[j] = field.randFieldElement(rand.Reader)
+			b[j] = field.newFieldElement()
+			field.copy(b[j], a[j])
+		}
+		d.ntt(b)
+		d.inverseNTT(b)
+		for j := 0; j < d.n; j++ {
+			if !field.equal(a[j], b[j]) {
+				t.Fatalf("bad ntt round trip")
+			}
+		}
+	}
+}
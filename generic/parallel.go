@@ -0,0 +1,173 @@
+package fp
+
+import (
+	"fmt"
+	"math/bits"
+	"runtime"
+	"sync"
+)
+
+// parallelGrain is the minimum number of elements handed to a goroutine.
+const parallelGrain = 16
+
+// workerPool splits work on slices into contiguous ranges across a bounded
+// number of goroutines. Each range is processed by exactly one goroutine, so
+// results do not depend on the concurrency level.
+type workerPool struct {
+	workers int
+}
+
+// newWorkerPool returns a pool with given concurrency, non positive values
+// select number of available CPUs.
+func newWorkerPool(workers int) *workerPool {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &workerPool{workers: workers}
+}
+
+// execute calls fn on ranges covering [0, n) and waits for all of them.
+func (p *workerPool) execute(n int, fn func(start, end int)) {
+	if n <= 0 {
+		return
+	}
+	k := (n + parallelGrain - 1) / parallelGrain
+	if k > p.workers {
+		k = p.workers
+	}
+	if k <= 1 {
+		fn(0, n)
+		return
+	}
+	size := (n + k - 1) / k
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// toMontParallel sets out[i] to Montgomery form of in[i].
+func (f *field) toMontParallel(p *workerPool, out, in []fieldElement) {
+	p.execute(len(in), func(start, end int) {
+		for i := start; i < end; i++ {
+			f.toMont(out[i], in[i])
+		}
+	})
+}
+
+// fromMontParallel sets out[i] to canonical form of in[i].
+func (f *field) fromMontParallel(p *workerPool, out, in []fieldElement) {
+	p.execute(len(in), func(start, end int) {
+		for i := start; i < end; i++ {
+			f.fromMont(out[i], in[i])
+		}
+	})
+}
+
+// toBytesParallel returns concatenated encodings of elements with given byte
+// encoding.
+func (f *field) toBytesParallel(p *workerPool, in []fieldElement, enc byteEncoding) []byte {
+	size := f.encodedSize(enc)
+	out := make([]byte, len(in)*size)
+	p.execute(len(in), func(start, end int) {
+		for i := start; i < end; i++ {
+			copy(out[i*size:], f.encode(in[i], enc))
+		}
+	})
+	return out
+}
+
+// fromBytesParallel decodes concatenated encodings of elements. If some of
+// the inputs are invalid the error of the first one is returned.
+func (f *field) fromBytesParallel(p *workerPool, in []byte, enc byteEncoding) ([]fieldElement, error) {
+	size := f.encodedSize(enc)
+	if len(in)%size != 0 {
+		return nil, fmt.Errorf("bad input size %d, expected multiple of %d", len(in), size)
+	}
+	n := len(in) / size
+	out := make([]fieldElement, n)
+	errs := make([]error, n)
+	p.execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i], errs[i] = f.decode(in[i*size:(i+1)*size], enc)
+		}
+	})
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %v", i, err)
+		}
+	}
+	return out, nil
+}
+
+// batchInverseParallel sets out[i] to in[i]^-1 with one inversion per range.
+// Inputs must be non zero.
+func (f *field) batchInverseParallel(p *workerPool, out, in []fieldElement) {
+	p.execute(len(in), func(start, end int) {
+		f.batchInverse(out[start:end], in[start:end])
+	})
+}
+
+// nttParallel is ntt with butterflies of each stage split across the pool.
+func (d *domain) nttParallel(p *workerPool, a []fieldElement) {
+	if len(a) != d.n {
+		panic(fmt.Sprintf("bad input size %d, expected %d", len(a), d.n))
+	}
+	d.bitReverseParallel(p, a)
+	d.butterfliesParallel(p, a, d.twiddles)
+}
+
+// inverseNTTParallel is inverseNTT with butterflies of each stage split across
+// the pool.
+func (d *domain) inverseNTTParallel(p *workerPool, a []fieldElement) {
+	if len(a) != d.n {
+		panic(fmt.Sprintf("bad input size %d, expected %d", len(a), d.n))
+	}
+	d.bitReverseParallel(p, a)
+	d.butterfliesParallel(p, a, d.twiddlesInv)
+	p.execute(d.n, func(start, end int) {
+		for i := start; i < end; i++ {
+			d.f.mul(a[i], a[i], d.nInv)
+		}
+	})
+}
+
+// bitReverseParallel swaps a[i] and a[rev(i)] in the range owning i < rev(i)
+func (d *domain) bitReverseParallel(p *workerPool, a []fieldElement) {
+	shift := uint(64 - d.logN)
+	p.execute(d.n, func(start, end int) {
+		for i := start; i < end; i++ {
+			j := int(bits.Reverse64(uint64(i)) >> shift)
+			if i < j {
+				a[i], a[j] = a[j], a[i]
+			}
+		}
+	})
+}
+
+// butterfliesParallel runs stages one after another where n / 2 independent
+// butterflies of a stage are split across the pool.
+func (d *domain) butterfliesParallel(p *workerPool, a []fieldElement, twiddles []fieldElement) {
+	f := d.f
+	for m := 1; m < d.n; m <<= 1 {
+		step := d.n / (2 * m)
+		p.execute(d.n/2, func(start, end int) {
+			t := f.newFieldElement()
+			for b := start; b < end; b++ {
+				k, j := (b/m)*2*m, b%m
+				f.mul(t, a[k+j+m], twiddles[j*step])
+				f.sub(a[k+j+m], a[k+j], t)
+				f.add(a[k+j], a[k+j], t)
+			}
+		})
+	}
+}
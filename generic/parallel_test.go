@@ -0,0 +1,187 @@
+package fp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+var parallelTestWorkers = []int{0, 1, 2, 3, 8, 64}
+
+func (f *field) randElements(n int) []fieldElement {
+	out := make([]fieldElement, n)
+	for i := range out {
+		out[i] = f.randFieldElement(rand.Reader)
+	}
+	return out
+}
+
+func (f *field) newElements(n int) []fieldElement {
+	out := make([]fieldElement, n)
+	for i := range out {
+		out[i] = f.newFieldElement()
+	}
+	return out
+}
+
+func TestWorkerPool(t *testing.T) {
+	for _, workers := range parallelTestWorkers {
+		for _, n := range []int{0, 1, 15, 16, 17, 1000} {
+			p := newWorkerPool(workers)
+			var calls int64
+			visited := make([]int32, n)
+			p.execute(n, func(start, end int) {
+				atomic.AddInt64(&calls, 1)
+				for i := start; i < end; i++ {
+					atomic.AddInt32(&visited[i], 1)
+				}
+			})
+			for i := range visited {
+				if visited[i] != 1 {
+					t.Fatalf("index %d is visited %d times", i, visited[i])
+				}
+			}
+			if calls > int64(p.workers) {
+				t.Fatalf("more ranges %d than workers %d", calls, p.workers)
+			}
+		}
+	}
+}
+
+func TestParallelMont(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			f := randField(limbSize)
+			in := f.randElements(100)
+			expected := f.newElements(len(in))
+			for i := range in {
+				f.toMont(expected[i], in[i])
+			}
+			for _, workers := range parallelTestWorkers {
+				p := newWorkerPool(workers)
+				out := f.newElements(len(in))
+				f.toMontParallel(p, out, in)
+				back := f.newElements(len(in))
+				f.fromMontParallel(p, back, out)
+				for i := range in {
+					if !f.equal(out[i], expected[i]) {
+						t.Fatalf("bad parallel montgomery conversion")
+					}
+					if !f.equal(back[i], in[i]) {
+						t.Fatalf("bad parallel montgomery conversion")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParallelBytes(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			f := randField(limbSize)
+			in := f.randElements(100)
+			for _, enc := range []byteEncoding{bigEndianPadded, littleEndianMinimal} {
+				var expected []byte
+				for i := range in {
+					expected = append(expected, f.encode(in[i], enc)...)
+				}
+				for _, workers := range parallelTestWorkers {
+					p := newWorkerPool(workers)
+					b := f.toBytesParallel(p, in, enc)
+					if !bytes.Equal(b, expected) {
+						t.Fatalf("bad parallel encoding")
+					}
+					out, err := f.fromBytesParallel(p, b, enc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					for i := range in {
+						if !f.equal(out[i], in[i]) {
+							t.Fatalf("bad parallel decoding")
+						}
+					}
+					// first invalid element is reported
+					size := f.encodedSize(enc)
+					c := append([]byte{}, b...)
+					for i := size * 40; i < size*41; i++ {
+						c[i] = 0xff
+					}
+					for i := size * 70; i < size*71; i++ {
+						c[i] = 0xff
+					}
+					if _, err := f.fromBytesParallel(p, c, enc); err == nil || err.Error()[:11] != "element 40:" {
+						t.Fatalf("first invalid element should be reported, have %v", err)
+					}
+					if _, err := f.fromBytesParallel(p, b[1:], enc); err == nil {
+						t.Fatalf("bad input size should be rejected")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParallelBatchInverse(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			f := randField(limbSize)
+			in := make([]fieldElement, 100)
+			for i := range in {
+				in[i] = f.randNonZero()
+			}
+			expected := f.newElements(len(in))
+			f.batchInverse(expected, in)
+			for _, workers := range parallelTestWorkers {
+				out := f.newElements(len(in))
+				f.batchInverseParallel(newWorkerPool(workers), out, in)
+				for i := range in {
+					if !f.equal(out[i], expected[i]) {
+						t.Fatalf("bad parallel batch inversion")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParallelNTT(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			f := randFFTField(limbSize, 8)
+			for _, logN := range []int{0, 1, 5, 8} {
+				d, err := f.newDomain(logN)
+				if err != nil {
+					t.Fatal(err)
+				}
+				coeffs := f.randElements(d.n)
+				expected := f.newElements(d.n)
+				for i := range coeffs {
+					f.copy(expected[i], coeffs[i])
+				}
+				d.ntt(expected)
+				for _, workers := range parallelTestWorkers {
+					p := newWorkerPool(workers)
+					a := f.newElements(d.n)
+					for i := range coeffs {
+						f.copy(a[i], coeffs[i])
+					}
+					d.nttParallel(p, a)
+					for i := range a {
+						if !f.equal(a[i], expected[i]) {
+							t.Fatalf("bad parallel ntt, n: %d", d.n)
+						}
+					}
+					d.inverseNTTParallel(p, a)
+					for i := range a {
+						if !f.equal(a[i], coeffs[i]) {
+							t.Fatalf("bad parallel inverse ntt, n: %d", d.n)
+						}
+					}
+				}
+			}
+		})
+	}
+}
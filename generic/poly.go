@@ -0,0 +1,300 @@
+package fp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// poly is a dense univariate polynomial with coefficients in ascending degree
+// order. Polynomials returned by field methods are trimmed so that leading
+// coefficient is non zero and zero polynomial is the empty slice.
+type poly []fieldElement
+
+// polyMulSchoolbookThreshold is the smaller operand length below which
+// schoolbook multiplication is used instead of NTT.
+const polyMulSchoolbookThreshold = 32
+
+// polyEvalMultiThreshold is the number of points below which remainder tree
+// falls back to Horner evaluation.
+const polyEvalMultiThreshold = 8
+
+func (a poly) degree() int {
+	return len(a) - 1
+}
+
+// newPoly returns a trimmed copy of given coefficients.
+func (f *field) newPoly(coeffs ...fieldElement) poly {
+	out := make(poly, len(coeffs))
+	for i := range coeffs {
+		out[i] = f.newFieldElement()
+		f.copy(out[i], coeffs[i])
+	}
+	return f.polyTrim(out)
+}
+
+func (f *field) polyZero(n int) poly {
+	out := make(poly, n)
+	for i := range out {
+		out[i] = f.newFieldElement()
+	}
+	return out
+}
+
+// polyTrim drops zero leading coefficients in place.
+func (f *field) polyTrim(a poly) poly {
+	n := len(a)
+	for n > 0 && f.isZero(a[n-1]) {
+		n--
+	}
+	return a[:n]
+}
+
+func (f *field) polyEqual(a, b poly) bool {
+	a, b = f.polyTrim(a), f.polyTrim(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !f.equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *field) polyAdd(a, b poly) poly {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	out := f.newPoly(a...)
+	out = append(out, f.polyZero(len(a)-len(out))...)
+	for i := range b {
+		f.add(out[i], out[i], b[i])
+	}
+	return f.polyTrim(out)
+}
+
+func (f *field) polySub(a, b poly) poly {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	out := f.polyZero(n)
+	for i := range a {
+		f.copy(out[i], a[i])
+	}
+	for i := range b {
+		f.sub(out[i], out[i], b[i])
+	}
+	return f.polyTrim(out)
+}
+
+func (f *field) polyScale(a poly, c fieldElement) poly {
+	out := f.polyZero(len(a))
+	for i := range a {
+		f.mul(out[i], a[i], c)
+	}
+	return f.polyTrim(out)
+}
+
+// polyMul multiplies with schoolbook method for short operands and with NTT
+// otherwise. Schoolbook method is also used if two adicity of the field is
+// not enough for the product size.
+func (f *field) polyMul(a, b poly) poly {
+	a, b = f.polyTrim(a), f.polyTrim(b)
+	if len(a) < polyMulSchoolbookThreshold || len(b) < polyMulSchoolbookThreshold {
+		return f.polyMulSchoolbook(a, b)
+	}
+	out, err := f.polyMulNTT(a, b)
+	if err != nil {
+		return f.polyMulSchoolbook(a, b)
+	}
+	return out
+}
+
+func (f *field) polyMulSchoolbook(a, b poly) poly {
+	if len(a) == 0 || len(b) == 0 {
+		return poly{}
+	}
+	out := f.polyZero(len(a) + len(b) - 1)
+	t := f.newFieldElement()
+	for i := range a {
+		for j := range b {
+			f.mul(t, a[i], b[j])
+			f.add(out[i+j], out[i+j], t)
+		}
+	}
+	return f.polyTrim(out)
+}
+
+// polyMulNTT multiplies evaluating both operands over a domain of size at
+// least the product length.
+func (f *field) polyMulNTT(a, b poly) (poly, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return poly{}, nil
+	}
+	n := len(a) + len(b) - 1
+	logN := bits.Len(uint(n - 1))
+	d, err := f.newDomain(logN)
+	if err != nil {
+		return nil, err
+	}
+	ea, eb := f.polyZero(d.n), f.polyZero(d.n)
+	for i := range a {
+		f.copy(ea[i], a[i])
+	}
+	for i := range b {
+		f.copy(eb[i], b[i])
+	}
+	d.ntt(ea)
+	d.ntt(eb)
+	for i := 0; i < d.n; i++ {
+		f.mul(ea[i], ea[i], eb[i])
+	}
+	d.inverseNTT(ea)
+	return f.polyTrim(ea[:n]), nil
+}
+
+// polyDivRem returns q and r such that a = q * b + r and deg(r) < deg(b).
+func (f *field) polyDivRem(a, b poly) (poly, poly, error) {
+	a, b = f.polyTrim(a), f.polyTrim(b)
+	if len(b) == 0 {
+		return nil, nil, errors.New("division by zero polynomial")
+	}
+	if len(a) < len(b) {
+		return poly{}, f.newPoly(a...), nil
+	}
+	leadInv := f.newFieldElement()
+	f.inverse(leadInv, b[len(b)-1])
+	r := f.newPoly(a...)
+	q := f.polyZero(len(a) - len(b) + 1)
+	t := f.newFieldElement()
+	for i := len(q) - 1; i >= 0; i-- {
+		f.mul(q[i], r[i+len(b)-1], leadInv)
+		for j := range b {
+			f.mul(t, q[i], b[j])
+			f.sub(r[i+j], r[i+j], t)
+		}
+	}
+	return f.polyTrim(q), f.polyTrim(r[:len(b)-1]), nil
+}
+
+// polyEval evaluates a at x with Horner's method.
+func (f *field) polyEval(a poly, x fieldElement) fieldElement {
+	acc := f.newFieldElement()
+	for i := len(a) - 1; i >= 0; i-- {
+		f.mul(acc, acc, x)
+		f.add(acc, acc, a[i])
+	}
+	return acc
+}
+
+// polyEvalMulti evaluates a at each point of xs reducing a with a remainder
+// tree of vanishing polynomials of halves of the point set.
+func (f *field) polyEvalMulti(a poly, xs []fieldElement) []fieldElement {
+	out := make([]fieldElement, len(xs))
+	f.polyEvalTree(f.polyTrim(a), xs, out)
+	return out
+}
+
+func (f *field) polyEvalTree(a poly, xs []fieldElement, out []fieldElement) {
+	if len(xs) <= polyEvalMultiThreshold {
+		for i := range xs {
+			out[i] = f.polyEval(a, xs[i])
+		}
+		return
+	}
+	h := len(xs) / 2
+	for _, s := range [][2]int{{0, h}, {h, len(xs)}} {
+		// vanishing polynomial is monic so division can not fail
+		_, r, _ := f.polyDivRem(a, f.vanishing(xs[s[0]:s[1]]))
+		f.polyEvalTree(r, xs[s[0]:s[1]], out[s[0]:s[1]])
+	}
+}
+
+// polyDerivative returns formal derivative of a.
+func (f *field) polyDerivative(a poly) poly {
+	a = f.polyTrim(a)
+	if len(a) < 2 {
+		return poly{}
+	}
+	out := f.polyZero(len(a) - 1)
+	for i := 1; i < len(a); i++ {
+		c, _ := f.newFieldElementFromBig(big.NewInt(int64(i)))
+		f.mul(out[i-1], a[i], c)
+	}
+	return f.polyTrim(out)
+}
+
+// polyInterpolate returns the unique polynomial of degree less than len(xs)
+// passing through (xs[i], ys[i]) in Lagrange form
+// sum ys[i] * Z(x) / ((x - xs[i]) * Z'(xs[i])) where Z is vanishing on xs.
+func (f *field) polyInterpolate(xs, ys []fieldElement) (poly, error) {
+	if len(xs) != len(ys) {
+		return nil, fmt.Errorf("bad input size %d, expected %d", len(ys), len(xs))
+	}
+	z := f.vanishing(xs)
+	dz := f.polyDerivative(z)
+	out := f.polyZero(len(xs))
+	t := f.newFieldElement()
+	q := f.polyZero(len(xs))
+	for i := range xs {
+		w := f.polyEval(dz, xs[i])
+		if f.isZero(w) {
+			return nil, errors.New("interpolation points are not distinct")
+		}
+		f.inverse(w, w)
+		f.mul(w, w, ys[i])
+		// q = Z / (x - xs[i]) by synthetic division
+		f.copy(t, z[len(z)-1])
+		for j := len(z) - 2; j >= 0; j-- {
+			f.copy(q[j], t)
+			f.mul(t, t, xs[i])
+			f.add(t, t, z[j])
+		}
+		for j := range q {
+			f.mul(t, q[j], w)
+			f.add(out[j], out[j], t)
+		}
+	}
+	return f.polyTrim(out), nil
+}
+
+// vanishing returns monic polynomial with roots xs.
+func (f *field) vanishing(xs []fieldElement) poly {
+	if len(xs) > polyEvalMultiThreshold {
+		h := len(xs) / 2
+		return f.polyMul(f.vanishing(xs[:h]), f.vanishing(xs[h:]))
+	}
+	out := f.polyZero(len(xs) + 1)
+	f.copy(out[0], f.one)
+	t := f.newFieldElement()
+	for i := range xs {
+		// multiply with (x - xs[i])
+		for j := i + 1; j > 0; j-- {
+			f.mul(t, out[j], xs[i])
+			f.sub(out[j], out[j-1], t)
+		}
+		f.mul(out[0], out[0], xs[i])
+		f.neg(out[0], out[0])
+	}
+	return out
+}
+
+// vanishing returns x^n - 1 which vanishes on the domain.
+func (d *domain) vanishing() poly {
+	out := d.f.polyZero(d.n + 1)
+	d.f.neg(out[0], d.f.one)
+	d.f.copy(out[d.n], d.f.one)
+	return out
+}
+
+// evalVanishing evaluates x^n - 1 at x.
+func (d *domain) evalVanishing(x fieldElement) fieldElement {
+	out := d.f.newFieldElement()
+	d.f.exp(out, x, big.NewInt(int64(d.n)))
+	d.f.sub(out, out, d.f.one)
+	return out
+}
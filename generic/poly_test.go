@@ -0,0 +1,182 @@
+package fp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+)
+
+func (f *field) randPoly(n int) poly {
+	out := make(poly, n)
+	for i := range out {
+		out[i] = f.randFieldElement(rand.Reader)
+	}
+	return out
+}
+
+func (f *field) randPoints(n int) []fieldElement {
+	out := make([]fieldElement, n)
+	for i := range out {
+		out[i] = f.randFieldElement(rand.Reader)
+	}
+	return out
+}
+
+func TestPolyAddSub(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			field := randField(limbSize)
+			for i := 0; i < fuz; i++ {
+				a, b := field.randPoly(i+3), field.randPoly(2*i+1)
+				x := field.randFieldElement(rand.Reader)
+				c := field.polyAdd(a, b)
+				e := field.newFieldElement()
+				field.add(e, field.polyEval(a, x), field.polyEval(b, x))
+				if !field.equal(e, field.polyEval(c, x)) {
+					t.Fatalf("bad poly addition")
+				}
+				if !field.polyEqual(field.polySub(c, b), a) {
+					t.Fatalf("bad poly subtraction")
+				}
+				if len(field.polySub(a, a)) != 0 {
+					t.Fatalf("a - a == 0")
+				}
+			}
+		})
+	}
+}
+
+func TestPolyMul(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			field := randFFTField(limbSize, 8)
+			for _, n := range [][2]int{{0, 5}, {1, 1}, {3, 7}, {17, 40}, {64, 64}, {100, 31}} {
+				a, b := field.randPoly(n[0]), field.randPoly(n[1])
+				c0 := field.polyMulSchoolbook(a, b)
+				c1, err := field.polyMulNTT(a, b)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !field.polyEqual(c0, c1) {
+					t.Fatalf("schoolbook and ntt multiplication mismatch")
+				}
+				if !field.polyEqual(c0, field.polyMul(a, b)) {
+					t.Fatalf("bad multiplication")
+				}
+				x := field.randFieldElement(rand.Reader)
+				e := field.newFieldElement()
+				field.mul(e, field.polyEval(a, x), field.polyEval(b, x))
+				if !field.equal(e, field.polyEval(c0, x)) {
+					t.Fatalf("bad multiplication")
+				}
+			}
+			// product size exceeds two adicity
+			if _, err := field.polyMulNTT(field.randPoly(200), field.randPoly(200)); err == nil {
+				t.Fatalf("product larger than two adicity should be rejected")
+			}
+			a, b := field.randPoly(200), field.randPoly(200)
+			if !field.polyEqual(field.polyMul(a, b), field.polyMulSchoolbook(a, b)) {
+				t.Fatalf("bad fallback multiplication")
+			}
+		})
+	}
+}
+
+func TestPolyDivRem(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			field := randField(limbSize)
+			for _, n := range [][2]int{{0, 1}, {3, 5}, {5, 5}, {20, 1}, {40, 13}} {
+				a, b := field.randPoly(n[0]), field.randPoly(n[1])
+				q, r, err := field.polyDivRem(a, b)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if r.degree() >= b.degree() && len(r) != 0 {
+					t.Fatalf("bad remainder degree")
+				}
+				if !field.polyEqual(field.polyAdd(field.polyMul(q, b), r), a) {
+					t.Fatalf("a != q * b + r")
+				}
+			}
+			if _, _, err := field.polyDivRem(field.randPoly(3), poly{field.zero}); err == nil {
+				t.Fatalf("division by zero should be rejected")
+			}
+		})
+	}
+}
+
+func TestPolyEvalMulti(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			field := randField(limbSize)
+			for _, n := range [][2]int{{0, 3}, {5, 3}, {20, 33}, {40, 17}} {
+				a := field.randPoly(n[0])
+				xs := field.randPoints(n[1])
+				ys := field.polyEvalMulti(a, xs)
+				for i := range xs {
+					if !field.equal(ys[i], field.polyEval(a, xs[i])) {
+						t.Fatalf("bad multi point evaluation")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPolyInterpolate(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			field := randField(limbSize)
+			for _, n := range []int{1, 2, 9, 30} {
+				a := field.randPoly(n)
+				xs := field.randPoints(n)
+				ys := field.polyEvalMulti(a, xs)
+				b, err := field.polyInterpolate(xs, ys)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !field.polyEqual(a, b) {
+					t.Fatalf("bad interpolation")
+				}
+				z := field.vanishing(xs)
+				if z.degree() != n || !field.isOne(z[n]) {
+					t.Fatalf("vanishing polynomial should be monic with degree %d", n)
+				}
+				for i := range xs {
+					if !field.isZero(field.polyEval(z, xs[i])) {
+						t.Fatalf("bad vanishing polynomial")
+					}
+				}
+			}
+			xs := field.randPoints(3)
+			xs[2] = xs[0]
+			if _, err := field.polyInterpolate(xs, field.randPoints(3)); err == nil {
+				t.Fatalf("repeated points should be rejected")
+			}
+		})
+	}
+}
+
+func TestDomainVanishing(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			field := randFFTField(limbSize, 8)
+			d, err := field.newDomain(4)
+			if err != nil {
+				t.Fatal(err)
+			}
+			z := d.vanishing()
+			if !field.polyEqual(z, field.vanishing(d.powers(d.omega, d.n))) {
+				t.Fatalf("bad domain vanishing polynomial")
+			}
+			x := field.randFieldElement(rand.Reader)
+			if !field.equal(d.evalVanishing(x), field.polyEval(z, x)) {
+				t.Fatalf("bad vanishing evaluation")
+			}
+			if field.isZero(d.evalVanishing(d.cosetGen)) {
+				t.Fatalf("vanishing polynomial should not vanish on coset")
+			}
+		})
+	}
+}
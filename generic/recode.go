@@ -0,0 +1,160 @@
+package fp
+
+import (
+	"fmt"
+)
+
+// Recodings of scalars given in canonical little endian limbs into signed
+// digits where e = sum of d_i * 2^i for bitwise recodings and sum of
+// d_i * 2^(w * i) for fixed windows. Constant time variants return a fixed
+// number of digits depending only on the limb size and do not branch on or
+// index by scalar bits.
+
+// canonicalLimbs returns limbs of a in canonical form
+func (f *field) canonicalLimbs(a fieldElement) uintN {
+	e := newUintN(f.limbSize)
+	f.fromMont(e.ptr(), a)
+	return e
+}
+
+// naf returns non adjacent form of e where no two consecutive digits are non
+// zero. It has at most bit length of e plus one digits.
+func naf(e uintN) []int8 {
+	return wnaf(e, 2)
+}
+
+// nafCT returns non adjacent form of e in len(e) * 64 + 1 digits in constant
+// time. Digits are bits of 3e / 2 minus bits of e / 2.
+func nafCT(e uintN) []int8 {
+	n := len(e) * 64
+	h := newUintN(len(e)).set(e)
+	carry := h.lsh1()
+	carry += h.add(e)
+	digits := make([]int8, n+1)
+	for i := 0; i <= n; i++ {
+		// 3e is n + 2 bits wide where carry holds the top two bits
+		var hi uint64
+		switch {
+		case i+1 < n:
+			hi = h.bit(i + 1)
+		case i+1 == n:
+			hi = carry & 1
+		default:
+			hi = carry >> 1
+		}
+		digits[i] = int8(hi) - int8(e.bit(i+1))
+	}
+	return digits
+}
+
+// wnaf returns width w non adjacent form of e where non zero digits are odd
+// and less than 2^(w - 1) in magnitude and any w consecutive digits have at
+// most one non zero digit. It is not constant time.
+func wnaf(e uintN, w int) []int8 {
+	if w < 2 || w > 8 {
+		panic(fmt.Sprintf("bad window size %d", w))
+	}
+	// k may exceed the width by one bit after adding a negative digit
+	k := newUintN(len(e)).set(e)
+	var top uint64
+	digits := make([]int8, 0, e.bitLen()+1)
+	mask := uint64(1)<<uint(w) - 1
+	half := int64(1) << uint(w-1)
+	for top != 0 || !k.isZero() {
+		var d int64
+		if k[0]&1 == 1 {
+			d = int64(k[0] & mask)
+			if d >= half {
+				d -= int64(1) << uint(w)
+				top += k.addUint64(uint64(-d))
+			} else {
+				k.subUint64(uint64(d))
+			}
+		}
+		digits = append(digits, int8(d))
+		k.rsh1()
+		k[len(k)-1] |= top << 63
+		top = 0
+	}
+	return digits
+}
+
+// signedWindows returns digits of w bit windows in [-2^(w - 1), 2^(w - 1))
+// with sum of d_i * 2^(w * i) equals to e. There are ceil(len(e) * 64 / w) + 1
+// windows and recoding runs in constant time.
+func signedWindows(e uintN, w int) []int8 {
+	if w < 2 || w > 8 {
+		panic(fmt.Sprintf("bad window size %d", w))
+	}
+	n := len(e) * 64
+	windows := (n+w-1)/w + 1
+	digits := make([]int8, windows)
+	half := uint64(1) << uint(w-1)
+	var carry uint64
+	for i := 0; i < windows; i++ {
+		var d uint64
+		for j := w - 1; j >= 0; j-- {
+			d = d<<1 | e.bit(i*w+j)
+		}
+		d += carry
+		// d is in [0, 2^w] and carry is one iff d >= 2^(w - 1)
+		carry = (d + half) >> uint(w)
+		digits[i] = int8(int64(d) - int64(carry<<uint(w)))
+	}
+	return digits
+}
+
+// jsf returns joint sparse form of e0 and e1 where of any three consecutive
+// digit pairs at least one is zero. It has at most max bit length plus one
+// digits. It is not constant time.
+func jsf(e0, e1 uintN) [2][]int8 {
+	n := e0.bitLen()
+	if m := e1.bitLen(); m > n {
+		n = m
+	}
+	return jsfDigits(e0, e1, n+1, false)
+}
+
+// jsfCT returns joint sparse form of e0 and e1 in len(e0) * 64 + 1 digits in
+// constant time.
+func jsfCT(e0, e1 uintN) [2][]int8 {
+	return jsfDigits(e0, e1, len(e0)*64+1, true)
+}
+
+// jsfDigits runs Solinas' joint sparse form algorithm with branch free digit
+// rules where k_i / 2^j + d_i is tracked only mod 8.
+// Guide to Elliptic Curve Cryptography, algorithm 3.50
+func jsfDigits(e0, e1 uintN, n int, fixed bool) [2][]int8 {
+	var out [2][]int8
+	out[0], out[1] = make([]int8, 0, n), make([]int8, 0, n)
+	e := [2]uintN{e0, e1}
+	var d [2]uint64
+	for j := 0; j < n; j++ {
+		var l [2]uint64
+		for i := 0; i < 2; i++ {
+			k := e[i].bit(j) | e[i].bit(j+1)<<1 | e[i].bit(j+2)<<2
+			l[i] = (k + d[i]) & 7
+		}
+		if !fixed && l[0] == 0 && l[1] == 0 && e0.bitLen() <= j && e1.bitLen() <= j {
+			break
+		}
+		var u [2]int64
+		var neg [2]uint64
+		for i := 0; i < 2; i++ {
+			odd := l[i] & 1
+			// l = 3 or 5 mod 8
+			three := ((l[i] >> 1) ^ (l[i] >> 2)) & l[i] & 1
+			// other is 2 mod 4
+			two := (l[1-i] >> 1) & ^l[1-i] & 1
+			// l mods 4 is 1 or -1, negated if l = +-3 mod 8 and other is 2 mod 4
+			neg[i] = odd & ((l[i] >> 1) ^ (three & two))
+			u[i] = int64(odd) - 2*int64(neg[i])
+		}
+		for i := 0; i < 2; i++ {
+			// d = 1 - d if 2 * d = 1 + u
+			d[i] ^= (l[i] & 1) & (d[i] ^ neg[i])
+			out[i] = append(out[i], int8(u[i]))
+		}
+	}
+	return out
+}
@@ -0,0 +1,204 @@
+package fp
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// fromDigits returns sum of d_i * 2^(w * i)
+func fromDigits(digits []int8, w int) *big.Int {
+	acc := new(big.Int)
+	for i := len(digits) - 1; i >= 0; i-- {
+		acc.Lsh(acc, uint(w))
+		acc.Add(acc, big.NewInt(int64(digits[i])))
+	}
+	return acc
+}
+
+// trimDigits drops leading zero digits
+func trimDigits(digits []int8) []int8 {
+	n := len(digits)
+	for n > 0 && digits[n-1] == 0 {
+		n--
+	}
+	return digits[:n]
+}
+
+func equalDigits(a, b []int8) bool {
+	a, b = trimDigits(a), trimDigits(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func recodeTestScalars(t *testing.T, limbSize int) []uintN {
+	scalars := []uintN{newUintN(limbSize), newUintN(limbSize).setUint64(1), newUintN(limbSize).setUint64(3)}
+	ones := newUintN(limbSize)
+	for i := range ones {
+		ones[i] = ^uint64(0)
+	}
+	alternate := newUintN(limbSize)
+	for i := range alternate {
+		alternate[i] = 0x5555555555555555
+	}
+	scalars = append(scalars, ones, alternate, newUintN(limbSize).setBit(limbSize*64-1, 1))
+	for i := 0; i < fuz*10; i++ {
+		scalars = append(scalars, randUintN(t, limbSize))
+	}
+	return scalars
+}
+
+func TestNAF(t *testing.T) {
+	for limbSize := 1; limbSize < 17; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			for _, e := range recodeTestScalars(t, limbSize) {
+				expected := e.toBig()
+				digits := naf(e)
+				if fromDigits(digits, 1).Cmp(expected) != 0 {
+					t.Fatalf("bad naf")
+				}
+				if len(digits) > e.bitLen()+1 {
+					t.Fatalf("naf is too long")
+				}
+				for i := range digits {
+					if digits[i] < -1 || digits[i] > 1 || i > 0 && digits[i] != 0 && digits[i-1] != 0 {
+						t.Fatalf("bad naf digits")
+					}
+				}
+				ct := nafCT(e)
+				if len(ct) != limbSize*64+1 || !equalDigits(ct, digits) {
+					t.Fatalf("constant time naf does not match")
+				}
+				for w := 2; w < 9; w++ {
+					digits := wnaf(e, w)
+					if fromDigits(digits, 1).Cmp(expected) != 0 {
+						t.Fatalf("bad wnaf, w: %d", w)
+					}
+					last := -w
+					for i, d := range digits {
+						if d == 0 {
+							continue
+						}
+						if d&1 == 0 || int(d) >= 1<<uint(w-1) || int(d) <= -(1<<uint(w-1)) || i-last < w {
+							t.Fatalf("bad wnaf digits, w: %d", w)
+						}
+						last = i
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSignedWindows(t *testing.T) {
+	for limbSize := 1; limbSize < 17; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			for _, e := range recodeTestScalars(t, limbSize) {
+				for w := 2; w < 9; w++ {
+					digits := signedWindows(e, w)
+					if len(digits) != (limbSize*64+w-1)/w+1 {
+						t.Fatalf("bad number of windows")
+					}
+					if fromDigits(digits, w).Cmp(e.toBig()) != 0 {
+						t.Fatalf("bad signed windows, w: %d", w)
+					}
+					for _, d := range digits {
+						if int(d) < -(1<<uint(w-1)) || int(d) >= 1<<uint(w-1) {
+							t.Fatalf("digit out of range %d", d)
+						}
+					}
+					// same digits as msm recoding
+					expected := signedDigits(e.toBig(), w, len(digits))
+					for i := range digits {
+						if int32(digits[i]) != expected[i] {
+							t.Fatalf("signed windows do not match msm digits")
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestJSF(t *testing.T) {
+	for limbSize := 1; limbSize < 17; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			scalars := recodeTestScalars(t, limbSize)
+			for i := range scalars {
+				for _, j := range []int{i, (i + 1) % len(scalars), (i * 7) % len(scalars)} {
+					e0, e1 := scalars[i], scalars[j]
+					digits := jsf(e0, e1)
+					n := e0.bitLen()
+					if e1.bitLen() > n {
+						n = e1.bitLen()
+					}
+					if len(digits[0]) > n+1 || len(digits[0]) != len(digits[1]) {
+						t.Fatalf("bad jsf length")
+					}
+					if fromDigits(digits[0], 1).Cmp(e0.toBig()) != 0 || fromDigits(digits[1], 1).Cmp(e1.toBig()) != 0 {
+						t.Fatalf("bad jsf")
+					}
+					zero := func(k int) bool { return digits[0][k] == 0 && digits[1][k] == 0 }
+					for k := range digits[0] {
+						if digits[0][k] < -1 || digits[0][k] > 1 || digits[1][k] < -1 || digits[1][k] > 1 {
+							t.Fatalf("bad jsf digits")
+						}
+						if k+2 < len(digits[0]) && !zero(k) && !zero(k+1) && !zero(k+2) {
+							t.Fatalf("jsf is not sparse")
+						}
+					}
+					ct := jsfCT(e0, e1)
+					if len(ct[0]) != limbSize*64+1 || !equalDigits(ct[0], digits[0]) || !equalDigits(ct[1], digits[1]) {
+						t.Fatalf("constant time jsf does not match")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCanonicalLimbs(t *testing.T) {
+	for limbSize := from; limbSize < to+1; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			f := randField(limbSize)
+			for i := 0; i < fuz; i++ {
+				a := f.randNonZero()
+				e := f.canonicalLimbs(a)
+				if e.toBig().Cmp(f.toBig(a)) != 0 {
+					t.Fatalf("bad canonical limbs")
+				}
+				// a^e with signed windows
+				w := 4
+				digits := signedWindows(e, w)
+				inv := f.newFieldElement()
+				f.inverse(inv, a)
+				r := f.newFieldElement()
+				f.copy(r, f.one)
+				for k := len(digits) - 1; k >= 0; k-- {
+					for j := 0; j < w; j++ {
+						f.square(r, r)
+					}
+					d := digits[k]
+					for ; d > 0; d-- {
+						f.mul(r, r, a)
+					}
+					for ; d < 0; d++ {
+						f.mul(r, r, inv)
+					}
+				}
+				expected := f.newFieldElement()
+				f.exp(expected, a, e.toBig())
+				if !f.equal(r, expected) {
+					t.Fatalf("bad exponentiation with signed windows")
+				}
+			}
+		})
+	}
+}
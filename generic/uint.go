@@ -0,0 +1,241 @@
+package fp
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"unsafe"
+)
+
+// uintN is a fixed width unsigned integer given in little endian limbs. It is
+// built on the non modular assembly primitives, arithmetic wraps modulo
+// 2^(64 * len) and carries, borrows and shifted out bits are returned so that
+// operations can be chained. Operands should have the same number of limbs.
+type uintN []uint64
+
+type uintNArith struct {
+	addn    func(a, b fieldElement) uint64
+	subn    func(a, b fieldElement) uint64
+	mul_two func(a fieldElement) uint64
+	div_two func(a fieldElement)
+	cmp     func(a, b fieldElement) int8
+}
+
+var uintNAriths = [...]uintNArith{
+	1:  {addn1, subn1, mul_two_1, div_two_1, cmp1},
+	2:  {addn2, subn2, mul_two_2, div_two_2, cmp2},
+	3:  {addn3, subn3, mul_two_3, div_two_3, cmp3},
+	4:  {addn4, subn4, mul_two_4, div_two_4, cmp4},
+	5:  {addn5, subn5, mul_two_5, div_two_5, cmp5},
+	6:  {addn6, subn6, mul_two_6, div_two_6, cmp6},
+	7:  {addn7, subn7, mul_two_7, div_two_7, cmp7},
+	8:  {addn8, subn8, mul_two_8, div_two_8, cmp8},
+	9:  {addn9, subn9, mul_two_9, div_two_9, cmp9},
+	10: {addn10, subn10, mul_two_10, div_two_10, cmp10},
+	11: {addn11, subn11, mul_two_11, div_two_11, cmp11},
+	12: {addn12, subn12, mul_two_12, div_two_12, cmp12},
+	13: {addn13, subn13, mul_two_13, div_two_13, cmp13},
+	14: {addn14, subn14, mul_two_14, div_two_14, cmp14},
+	15: {addn15, subn15, mul_two_15, div_two_15, cmp15},
+	16: {addn16, subn16, mul_two_16, div_two_16, cmp16},
+}
+
+// newUintN returns zero with given limb size
+func newUintN(limbSize int) uintN {
+	if limbSize < 1 || limbSize > 16 {
+		panic(fmt.Sprintf("limb size %d not supported", limbSize))
+	}
+	return make(uintN, limbSize)
+}
+
+// newUintNFromBig returns b if it fits into given limb size
+func newUintNFromBig(limbSize int, b *big.Int) (uintN, error) {
+	if b.Sign() < 0 || b.BitLen() > limbSize*64 {
+		return nil, fmt.Errorf("value does not fit into %d limbs", limbSize)
+	}
+	a := newUintN(limbSize)
+	bts := b.Bytes()
+	for i := range bts {
+		a[i/8] |= uint64(bts[len(bts)-1-i]) << uint(8*(i%8))
+	}
+	return a, nil
+}
+
+func (a uintN) toBig() *big.Int {
+	bts := make([]byte, len(a)*8)
+	for i := range bts {
+		bts[len(bts)-1-i] = byte(a[i/8] >> uint(8*(i%8)))
+	}
+	return new(big.Int).SetBytes(bts)
+}
+
+func (a uintN) ptr() fieldElement {
+	return unsafe.Pointer(&a[0])
+}
+
+func (a uintN) arith() *uintNArith {
+	return &uintNAriths[len(a)]
+}
+
+func (a uintN) set(b uintN) uintN {
+	copy(a, b)
+	return a
+}
+
+func (a uintN) setUint64(x uint64) uintN {
+	for i := range a {
+		a[i] = 0
+	}
+	a[0] = x
+	return a
+}
+
+func (a uintN) isZero() bool {
+	var acc uint64
+	for i := range a {
+		acc |= a[i]
+	}
+	return acc == 0
+}
+
+// cmp returns 1 if a > b, -1 if a < b and 0 otherwise
+func (a uintN) cmp(b uintN) int8 {
+	return a.arith().cmp(a.ptr(), b.ptr())
+}
+
+func (a uintN) equal(b uintN) bool {
+	return a.cmp(b) == 0
+}
+
+// add sets a to a + b and returns the carry
+func (a uintN) add(b uintN) uint64 {
+	return a.arith().addn(a.ptr(), b.ptr())
+}
+
+// sub sets a to a - b and returns the borrow
+func (a uintN) sub(b uintN) uint64 {
+	return a.arith().subn(a.ptr(), b.ptr())
+}
+
+// addUint64 sets a to a + x and returns the carry. It can take carry of
+// another addition.
+func (a uintN) addUint64(x uint64) uint64 {
+	for i := range a {
+		a[i], x = bits.Add64(a[i], x, 0)
+	}
+	return x
+}
+
+// subUint64 sets a to a - x and returns the borrow
+func (a uintN) subUint64(x uint64) uint64 {
+	for i := range a {
+		a[i], x = bits.Sub64(a[i], x, 0)
+	}
+	return x
+}
+
+// lsh1 sets a to 2 * a and returns the shifted out bit
+func (a uintN) lsh1() uint64 {
+	return a.arith().mul_two(a.ptr())
+}
+
+// rsh1 sets a to a / 2 and returns the shifted out bit
+func (a uintN) rsh1() uint64 {
+	b := a[0] & 1
+	a.arith().div_two(a.ptr())
+	return b
+}
+
+// lsh sets a to a * 2^n mod 2^(64 * len)
+func (a uintN) lsh(n uint) uintN {
+	w, s := int(n/64), n%64
+	for i := len(a) - 1; i >= 0; i-- {
+		var x uint64
+		if j := i - w; j >= 0 {
+			x = a[j] << s
+			if j > 0 && s != 0 {
+				x |= a[j-1] >> (64 - s)
+			}
+		}
+		a[i] = x
+	}
+	return a
+}
+
+// rsh sets a to a / 2^n
+func (a uintN) rsh(n uint) uintN {
+	w, s := int(n/64), n%64
+	for i := range a {
+		var x uint64
+		if j := i + w; j < len(a) {
+			x = a[j] >> s
+			if j+1 < len(a) && s != 0 {
+				x |= a[j+1] << (64 - s)
+			}
+		}
+		a[i] = x
+	}
+	return a
+}
+
+// bit returns i-th bit of a, bits out of width are zero
+func (a uintN) bit(i int) uint64 {
+	if i < 0 || i >= len(a)*64 {
+		return 0
+	}
+	return (a[i/64] >> uint(i%64)) & 1
+}
+
+// setBit sets i-th bit of a to the lowest bit of b
+func (a uintN) setBit(i int, b uint64) uintN {
+	m := uint64(1) << uint(i%64)
+	a[i/64] = a[i/64]&^m | (b&1)<<uint(i%64)
+	return a
+}
+
+func (a uintN) bitLen() int {
+	for i := len(a) - 1; i >= 0; i-- {
+		if a[i] != 0 {
+			return i*64 + bits.Len64(a[i])
+		}
+	}
+	return 0
+}
+
+// mulWide sets hi and lo to high and low halves of a * b. Outputs should not
+// overlap with inputs.
+func mulWide(hi, lo, a, b uintN) {
+	n := len(a)
+	for i := range lo {
+		lo[i], hi[i] = 0, 0
+	}
+	at := func(i int) *uint64 {
+		if i < n {
+			return &lo[i]
+		}
+		return &hi[i-n]
+	}
+	for i := 0; i < n; i++ {
+		var carry uint64
+		for j := 0; j < n; j++ {
+			h, l := bits.Mul64(a[i], b[j])
+			r := at(i + j)
+			var c uint64
+			l, c = bits.Add64(l, *r, 0)
+			h += c
+			l, c = bits.Add64(l, carry, 0)
+			h += c
+			*r, carry = l, h
+		}
+		*at(i + n) = carry
+	}
+}
+
+// divWord sets a to a / d and returns the remainder. d should be non zero.
+func (a uintN) divWord(d uint64) uint64 {
+	var r uint64
+	for i := len(a) - 1; i >= 0; i-- {
+		a[i], r = bits.Div64(r, a[i], d)
+	}
+	return r
+}
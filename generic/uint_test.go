@@ -0,0 +1,153 @@
+package fp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// randUintN returns random values with edge cases of limb patterns
+func randUintN(t *testing.T, limbSize int) uintN {
+	max := new(big.Int).Lsh(big.NewInt(1), uint(limbSize*64))
+	b, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := newUintNFromBig(limbSize, b)
+	switch b.Bit(0) + 2*b.Bit(1) {
+	case 0:
+		for i := range a {
+			a[i] = ^uint64(0)
+		}
+	case 1:
+		a[len(a)-1] = 0
+	}
+	return a
+}
+
+func TestUintN(t *testing.T) {
+	for limbSize := 1; limbSize < 17; limbSize++ {
+		t.Run(fmt.Sprintf("%d", limbSize*64), func(t *testing.T) {
+			width := uint(limbSize * 64)
+			mod := new(big.Int).Lsh(big.NewInt(1), width)
+			wrap := func(x *big.Int) *big.Int {
+				return x.Mod(x, mod)
+			}
+			negative := func(x *big.Int) uint64 {
+				if x.Sign() < 0 {
+					return 1
+				}
+				return 0
+			}
+			for i := 0; i < fuz*20; i++ {
+				a, b := randUintN(t, limbSize), randUintN(t, limbSize)
+				A, B := a.toBig(), b.toBig()
+				if back, err := newUintNFromBig(limbSize, A); err != nil || !back.equal(a) {
+					t.Fatalf("bad conversion")
+				}
+				c := newUintN(limbSize).set(a)
+				carry := c.add(b)
+				sum := new(big.Int).Add(A, B)
+				if c.toBig().Cmp(wrap(new(big.Int).Set(sum))) != 0 || carry != uint64(sum.Bit(int(width))) {
+					t.Fatalf("bad addition")
+				}
+				c.set(a)
+				borrow := c.sub(b)
+				diff := new(big.Int).Sub(A, B)
+				if c.toBig().Cmp(wrap(new(big.Int).Set(diff))) != 0 || borrow != negative(diff) {
+					t.Fatalf("bad subtraction")
+				}
+				x := A.Uint64()
+				c.set(a)
+				carry = c.addUint64(x)
+				sum.Add(A, new(big.Int).SetUint64(x))
+				if c.toBig().Cmp(wrap(new(big.Int).Set(sum))) != 0 || carry != uint64(sum.Bit(int(width))) {
+					t.Fatalf("bad word addition")
+				}
+				c.set(a)
+				borrow = c.subUint64(^x)
+				diff.Sub(A, new(big.Int).SetUint64(^x))
+				if c.toBig().Cmp(wrap(new(big.Int).Set(diff))) != 0 || borrow != negative(diff) {
+					t.Fatalf("bad word subtraction")
+				}
+				if int(a.cmp(b)) != A.Cmp(B) || a.cmp(a) != 0 || !a.equal(newUintN(limbSize).set(a)) {
+					t.Fatalf("bad comparison")
+				}
+				c.set(a)
+				if c.lsh1() != uint64(A.Bit(int(width)-1)) || c.toBig().Cmp(wrap(new(big.Int).Lsh(A, 1))) != 0 {
+					t.Fatalf("bad shift left by one")
+				}
+				c.set(a)
+				if c.rsh1() != uint64(A.Bit(0)) || c.toBig().Cmp(new(big.Int).Rsh(A, 1)) != 0 {
+					t.Fatalf("bad shift right by one")
+				}
+				for _, n := range []uint{0, 1, 63, 64, 65, 127, width - 1, width, width + 1, uint(x % uint64(width))} {
+					if c.set(a).lsh(n).toBig().Cmp(wrap(new(big.Int).Lsh(A, n))) != 0 {
+						t.Fatalf("bad shift left by %d", n)
+					}
+					if c.set(a).rsh(n).toBig().Cmp(new(big.Int).Rsh(A, n)) != 0 {
+						t.Fatalf("bad shift right by %d", n)
+					}
+				}
+				for j := -1; j < int(width)+1; j++ {
+					var expected uint64
+					if j >= 0 {
+						expected = uint64(A.Bit(j))
+					}
+					if a.bit(j) != expected {
+						t.Fatalf("bad bit %d", j)
+					}
+				}
+				j := int(x % uint64(width))
+				if c.set(a).setBit(j, 1).toBig().Cmp(new(big.Int).SetBit(A, j, 1)) != 0 ||
+					c.set(a).setBit(j, 0).toBig().Cmp(new(big.Int).SetBit(A, j, 0)) != 0 {
+					t.Fatalf("bad bit setting")
+				}
+				if a.bitLen() != A.BitLen() || a.isZero() != (A.Sign() == 0) {
+					t.Fatalf("bad bit length")
+				}
+				hi, lo := newUintN(limbSize), newUintN(limbSize)
+				mulWide(hi, lo, a, b)
+				prod := new(big.Int).Mul(A, B)
+				if lo.toBig().Cmp(wrap(new(big.Int).Set(prod))) != 0 || hi.toBig().Cmp(prod.Rsh(prod, width)) != 0 {
+					t.Fatalf("bad wide multiplication")
+				}
+				for _, d := range []uint64{1, 3, x | 1, ^uint64(0)} {
+					q, r := new(big.Int).QuoRem(A, new(big.Int).SetUint64(d), new(big.Int))
+					c.set(a)
+					if c.divWord(d) != r.Uint64() || c.toBig().Cmp(q) != 0 {
+						t.Fatalf("bad division by word %d", d)
+					}
+				}
+			}
+			if _, err := newUintNFromBig(limbSize, mod); err == nil {
+				t.Fatalf("too large value should be rejected")
+			}
+			if _, err := newUintNFromBig(limbSize, big.NewInt(-1)); err == nil {
+				t.Fatalf("negative value should be rejected")
+			}
+			if c := newUintN(limbSize).setUint64(7); c.toBig().Cmp(big.NewInt(7)) != 0 {
+				t.Fatalf("bad word conversion")
+			}
+		})
+	}
+}
+
+func BenchmarkUintN(b *testing.B) {
+	x, y := newUintN(4).setUint64(1), newUintN(4)
+	for i := range y {
+		y[i] = ^uint64(i)
+	}
+	b.Run("add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.add(y)
+		}
+	})
+	b.Run("mulWide", func(b *testing.B) {
+		hi, lo := newUintN(4), newUintN(4)
+		for i := 0; i < b.N; i++ {
+			mulWide(hi, lo, x, y)
+		}
+	})
+}
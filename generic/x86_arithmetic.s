@@ -79,6 +79,7 @@ TEXT ·addn1(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -177,6 +178,11 @@ TEXT ·_neg1(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -184,6 +190,7 @@ TEXT ·_neg1(SB), NOSPLIT, $0-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
 	RET
 
@@ -193,6 +200,99 @@ TEXT ·_neg1(SB), NOSPLIT, $0-24
 
 	RET
 
+// func cmov1(c *[1]uint64, a *[1]uint64, b *[1]uint64, cond uint64)
+TEXT ·cmov1(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	RET
+
+// func cswap1(a *[1]uint64, b *[1]uint64, cond uint64)
+TEXT ·cswap1(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	RET
+
+// func condNeg1(c *[1]uint64, a *[1]uint64, p *[1]uint64, cond uint64)
+TEXT ·condNeg1(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	RET
+
+// func lookup1(dst *[1]uint64, table *[1]uint64, n uint64, idx uint64)
+TEXT ·lookup1(SB), NOSPLIT, $0-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	ADDQ $0x00000008, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_1(a *[1]uint64) uint64
 TEXT ·mul_two_1(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -306,6 +406,7 @@ TEXT ·addn2(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -422,6 +523,12 @@ TEXT ·_neg2(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -431,7 +538,9 @@ TEXT ·_neg2(SB), NOSPLIT, $0-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
 	RET
 
@@ -441,6 +550,124 @@ TEXT ·_neg2(SB), NOSPLIT, $0-24
 
 	RET
 
+// func cmov2(c *[2]uint64, a *[2]uint64, b *[2]uint64, cond uint64)
+TEXT ·cmov2(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	RET
+
+// func cswap2(a *[2]uint64, b *[2]uint64, cond uint64)
+TEXT ·cswap2(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	RET
+
+// func condNeg2(c *[2]uint64, a *[2]uint64, p *[2]uint64, cond uint64)
+TEXT ·condNeg2(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	RET
+
+// func lookup2(dst *[2]uint64, table *[2]uint64, n uint64, idx uint64)
+TEXT ·lookup2(SB), NOSPLIT, $0-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	ADDQ $0x00000010, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_2(a *[2]uint64) uint64
 TEXT ·mul_two_2(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -783,13 +1010,16 @@ TEXT ·mul_no_adx_bmi2_2(SB), NOSPLIT, $0-40
 	MOVQ 8(SI), AX
 	MULQ DI
 	ADDQ AX, R10
-	ADCQ DX, R8
+	ADCQ $0x00, DX
 	ADDQ CX, R10
+	ADCQ $0x00, DX
 
 	// | w-1 @ BX
-	ADCQ R8, BX
+	ADDQ R8, BX
 	MOVQ $0x00, R8
 	ADCQ $0x00, R8
+	ADDQ DX, BX
+	ADCQ $0x00, R8
 
 	// | 
 	// | W montgomerry reduction ends
@@ -930,6 +1160,7 @@ TEXT ·addn3(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -1064,6 +1295,13 @@ TEXT ·_neg3(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -1075,8 +1313,11 @@ TEXT ·_neg3(SB), NOSPLIT, $0-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
 	RET
 
@@ -1086,6 +1327,149 @@ TEXT ·_neg3(SB), NOSPLIT, $0-24
 
 	RET
 
+// func cmov3(c *[3]uint64, a *[3]uint64, b *[3]uint64, cond uint64)
+TEXT ·cmov3(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	RET
+
+// func cswap3(a *[3]uint64, b *[3]uint64, cond uint64)
+TEXT ·cswap3(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	RET
+
+// func condNeg3(c *[3]uint64, a *[3]uint64, p *[3]uint64, cond uint64)
+TEXT ·condNeg3(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	RET
+
+// func lookup3(dst *[3]uint64, table *[3]uint64, n uint64, idx uint64)
+TEXT ·lookup3(SB), NOSPLIT, $0-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	ADDQ $0x00000018, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_3(a *[3]uint64) uint64
 TEXT ·mul_two_3(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -1594,13 +1978,16 @@ TEXT ·mul_no_adx_bmi2_3(SB), NOSPLIT, $0-40
 	MOVQ 16(SI), AX
 	MULQ DI
 	ADDQ AX, R11
-	ADCQ DX, R8
+	ADCQ $0x00, DX
 	ADDQ CX, R11
+	ADCQ $0x00, DX
 
 	// | w4 @ R12
-	ADCQ R8, R12
+	ADDQ R8, R12
 	MOVQ $0x00, R8
 	ADCQ $0x00, R8
+	ADDQ DX, R12
+	ADCQ $0x00, R8
 
 	// | 
 
@@ -1647,13 +2034,16 @@ TEXT ·mul_no_adx_bmi2_3(SB), NOSPLIT, $0-40
 	MOVQ 16(SI), AX
 	MULQ DI
 	ADDQ AX, R12
-	ADCQ DX, R8
+	ADCQ $0x00, DX
 	ADDQ CX, R12
+	ADCQ $0x00, DX
 
 	// | w-1 @ BX
-	ADCQ R8, BX
+	ADDQ R8, BX
 	MOVQ $0x00, R8
 	ADCQ $0x00, R8
+	ADDQ DX, BX
+	ADCQ $0x00, R8
 
 	// | 
 	// | W montgomerry reduction ends
@@ -1813,6 +2203,7 @@ TEXT ·addn4(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -1965,6 +2356,14 @@ TEXT ·_neg4(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -1978,9 +2377,13 @@ TEXT ·_neg4(SB), NOSPLIT, $0-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
 	RET
 
@@ -1990,6 +2393,174 @@ TEXT ·_neg4(SB), NOSPLIT, $0-24
 
 	RET
 
+// func cmov4(c *[4]uint64, a *[4]uint64, b *[4]uint64, cond uint64)
+TEXT ·cmov4(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	RET
+
+// func cswap4(a *[4]uint64, b *[4]uint64, cond uint64)
+TEXT ·cswap4(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	RET
+
+// func condNeg4(c *[4]uint64, a *[4]uint64, p *[4]uint64, cond uint64)
+TEXT ·condNeg4(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	RET
+
+// func lookup4(dst *[4]uint64, table *[4]uint64, n uint64, idx uint64)
+TEXT ·lookup4(SB), NOSPLIT, $0-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	ADDQ $0x00000020, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_4(a *[4]uint64) uint64
 TEXT ·mul_two_4(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -2707,13 +3278,16 @@ TEXT ·mul_no_adx_bmi2_4(SB), NOSPLIT, $0-40
 	MOVQ 24(SI), AX
 	MULQ DI
 	ADDQ AX, R12
-	ADCQ DX, R8
+	ADCQ $0x00, DX
 	ADDQ CX, R12
+	ADCQ $0x00, DX
 
 	// | w5 @ R13
-	ADCQ R8, R13
+	ADDQ R8, R13
 	MOVQ $0x00, R8
 	ADCQ $0x00, R8
+	ADDQ DX, R13
+	ADCQ $0x00, R8
 
 	// | 
 
@@ -2771,13 +3345,16 @@ TEXT ·mul_no_adx_bmi2_4(SB), NOSPLIT, $0-40
 	MOVQ 24(SI), AX
 	MULQ DI
 	ADDQ AX, R13
-	ADCQ DX, R8
+	ADCQ $0x00, DX
 	ADDQ CX, R13
+	ADCQ $0x00, DX
 
 	// | w6 @ R14
-	ADCQ R8, R14
+	ADDQ R8, R14
 	MOVQ $0x00, R8
 	ADCQ $0x00, R8
+	ADDQ DX, R14
+	ADCQ $0x00, R8
 
 	// | 
 
@@ -2835,13 +3412,16 @@ TEXT ·mul_no_adx_bmi2_4(SB), NOSPLIT, $0-40
 	MOVQ 24(SI), AX
 	MULQ DI
 	ADDQ AX, R14
-	ADCQ DX, R8
+	ADCQ $0x00, DX
 	ADDQ CX, R14
+	ADCQ $0x00, DX
 
 	// | w-1 @ BX
-	ADCQ R8, BX
+	ADDQ R8, BX
 	MOVQ $0x00, R8
 	ADCQ $0x00, R8
+	ADDQ DX, BX
+	ADCQ $0x00, R8
 
 	// | 
 	// | W montgomerry reduction ends
@@ -3020,6 +3600,7 @@ TEXT ·addn5(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -3190,6 +3771,15 @@ TEXT ·_neg5(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -3205,10 +3795,15 @@ TEXT ·_neg5(SB), NOSPLIT, $0-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
 	RET
 
@@ -3218,6 +3813,199 @@ TEXT ·_neg5(SB), NOSPLIT, $0-24
 
 	RET
 
+// func cmov5(c *[5]uint64, a *[5]uint64, b *[5]uint64, cond uint64)
+TEXT ·cmov5(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	RET
+
+// func cswap5(a *[5]uint64, b *[5]uint64, cond uint64)
+TEXT ·cswap5(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	RET
+
+// func condNeg5(c *[5]uint64, a *[5]uint64, p *[5]uint64, cond uint64)
+TEXT ·condNeg5(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	RET
+
+// func lookup5(dst *[5]uint64, table *[5]uint64, n uint64, idx uint64)
+TEXT ·lookup5(SB), NOSPLIT, $0-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	ADDQ $0x00000028, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_5(a *[5]uint64) uint64
 TEXT ·mul_two_5(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -4189,13 +4977,16 @@ TEXT ·mul_no_adx_bmi2_5(SB), NOSPLIT, $16-40
 	MOVQ 32(SI), AX
 	MULQ DI
 	ADDQ AX, R12
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ BX, R12
+	ADCQ $0x00, DX
 
 	// | w6 @ R13
-	ADCQ CX, R13
+	ADDQ CX, R13
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R13
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -4264,13 +5055,16 @@ TEXT ·mul_no_adx_bmi2_5(SB), NOSPLIT, $16-40
 	MOVQ 32(SI), AX
 	MULQ DI
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ BX, R13
+	ADCQ $0x00, DX
 
 	// | w7 @ R14
-	ADCQ CX, R14
+	ADDQ CX, R14
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R14
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -4339,13 +5133,16 @@ TEXT ·mul_no_adx_bmi2_5(SB), NOSPLIT, $16-40
 	MOVQ 32(SI), AX
 	MULQ DI
 	ADDQ AX, R14
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ BX, R14
+	ADCQ $0x00, DX
 
 	// | w8 @ R15
-	ADCQ CX, R15
+	ADDQ CX, R15
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R15
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -4414,16 +5211,19 @@ TEXT ·mul_no_adx_bmi2_5(SB), NOSPLIT, $16-40
 	MOVQ 32(SI), AX
 	MULQ DI
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ BX, R15
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ (SP), R8
 
 	// | w-1 @ R8
-	ADCQ CX, R8
+	ADDQ CX, R8
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R8
+	ADCQ $0x00, CX
 
 	// | 
 	// | W montgomerry reduction ends
@@ -4625,6 +5425,7 @@ TEXT ·addn6(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -4815,6 +5616,16 @@ TEXT ·_neg6(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -4832,11 +5643,17 @@ TEXT ·_neg6(SB), NOSPLIT, $0-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
+	ANDQ AX, R11
 	MOVQ R11, 40(DI)
 	RET
 
@@ -4846,6 +5663,224 @@ TEXT ·_neg6(SB), NOSPLIT, $0-24
 
 	RET
 
+// func cmov6(c *[6]uint64, a *[6]uint64, b *[6]uint64, cond uint64)
+TEXT ·cmov6(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	MOVQ    40(DI), R8
+	CMOVQCS 40(SI), R8
+	MOVQ    R8, 40(BX)
+	RET
+
+// func cswap6(a *[6]uint64, b *[6]uint64, cond uint64)
+TEXT ·cswap6(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	MOVQ    40(DI), R8
+	MOVQ    40(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 40(DI)
+	MOVQ    R9, 40(SI)
+	RET
+
+// func condNeg6(c *[6]uint64, a *[6]uint64, p *[6]uint64, cond uint64)
+TEXT ·condNeg6(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	SBBQ DX, DX
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	MOVQ 40(SI), R8
+	ADDQ DX, DX
+	SBBQ 40(DI), R8
+	MOVQ 40(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 40(BX)
+	RET
+
+// func lookup6(dst *[6]uint64, table *[6]uint64, n uint64, idx uint64)
+TEXT ·lookup6(SB), NOSPLIT, $0-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	XORQ  R14, R14
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	MOVQ 40(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R14
+	ADDQ $0x00000030, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	MOVQ R14, 40(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_6(a *[6]uint64) uint64
 TEXT ·mul_two_6(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -6132,13 +7167,16 @@ TEXT ·mul_no_adx_bmi2_6(SB), NOSPLIT, $32-40
 	MOVQ 40(R9), AX
 	MULQ R8
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ BX, R13
+	ADCQ $0x00, DX
 
 	// | w7 @ R14
-	ADCQ CX, R14
+	ADDQ CX, R14
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R14
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -6218,13 +7256,16 @@ TEXT ·mul_no_adx_bmi2_6(SB), NOSPLIT, $32-40
 	MOVQ 40(R9), AX
 	MULQ R8
 	ADDQ AX, R14
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ BX, R14
+	ADCQ $0x00, DX
 
 	// | w8 @ R15
-	ADCQ CX, R15
+	ADDQ CX, R15
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R15
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -6304,16 +7345,19 @@ TEXT ·mul_no_adx_bmi2_6(SB), NOSPLIT, $32-40
 	MOVQ 40(R9), AX
 	MULQ R8
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ BX, R15
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 16(SP), DI
 
 	// | w9 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -6393,16 +7437,19 @@ TEXT ·mul_no_adx_bmi2_6(SB), NOSPLIT, $32-40
 	MOVQ 40(R9), AX
 	MULQ R8
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ BX, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 8(SP), SI
 
 	// | w10 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -6482,16 +7529,19 @@ TEXT ·mul_no_adx_bmi2_6(SB), NOSPLIT, $32-40
 	MOVQ 40(R9), AX
 	MULQ R8
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ BX, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ (SP), R10
 
 	// | w-1 @ R10
-	ADCQ CX, R10
+	ADDQ CX, R10
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R10
+	ADCQ $0x00, CX
 
 	// | 
 	// | W montgomerry reduction ends
@@ -6716,6 +7766,7 @@ TEXT ·addn7(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -6926,6 +7977,17 @@ TEXT ·_neg7(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -6945,12 +8007,19 @@ TEXT ·_neg7(SB), NOSPLIT, $0-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
+	ANDQ AX, R11
 	MOVQ R11, 40(DI)
+	ANDQ AX, R12
 	MOVQ R12, 48(DI)
 	RET
 
@@ -6960,6 +8029,249 @@ TEXT ·_neg7(SB), NOSPLIT, $0-24
 
 	RET
 
+// func cmov7(c *[7]uint64, a *[7]uint64, b *[7]uint64, cond uint64)
+TEXT ·cmov7(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	MOVQ    40(DI), R8
+	CMOVQCS 40(SI), R8
+	MOVQ    R8, 40(BX)
+	MOVQ    48(DI), R8
+	CMOVQCS 48(SI), R8
+	MOVQ    R8, 48(BX)
+	RET
+
+// func cswap7(a *[7]uint64, b *[7]uint64, cond uint64)
+TEXT ·cswap7(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	MOVQ    40(DI), R8
+	MOVQ    40(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 40(DI)
+	MOVQ    R9, 40(SI)
+	MOVQ    48(DI), R8
+	MOVQ    48(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 48(DI)
+	MOVQ    R9, 48(SI)
+	RET
+
+// func condNeg7(c *[7]uint64, a *[7]uint64, p *[7]uint64, cond uint64)
+TEXT ·condNeg7(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	SBBQ DX, DX
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	MOVQ 40(SI), R8
+	ADDQ DX, DX
+	SBBQ 40(DI), R8
+	SBBQ DX, DX
+	MOVQ 40(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 40(BX)
+	MOVQ 48(SI), R8
+	ADDQ DX, DX
+	SBBQ 48(DI), R8
+	MOVQ 48(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 48(BX)
+	RET
+
+// func lookup7(dst *[7]uint64, table *[7]uint64, n uint64, idx uint64)
+TEXT ·lookup7(SB), NOSPLIT, $0-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	XORQ  R14, R14
+	XORQ  R15, R15
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	MOVQ 40(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R14
+	MOVQ 48(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R15
+	ADDQ $0x00000038, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	MOVQ R14, 40(DI)
+	MOVQ R15, 48(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_7(a *[7]uint64) uint64
 TEXT ·mul_two_7(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -8603,13 +9915,16 @@ TEXT ·mul_no_adx_bmi2_7(SB), NOSPLIT, $48-40
 	MOVQ 48(R10), AX
 	MULQ R9
 	ADDQ AX, R14
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R14
+	ADCQ $0x00, DX
 
 	// | w8 @ R15
-	ADCQ CX, R15
+	ADDQ CX, R15
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R15
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -8700,16 +10015,19 @@ TEXT ·mul_no_adx_bmi2_7(SB), NOSPLIT, $48-40
 	MOVQ 48(R10), AX
 	MULQ R9
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R15
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 32(SP), DI
 
 	// | w9 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -8800,16 +10118,19 @@ TEXT ·mul_no_adx_bmi2_7(SB), NOSPLIT, $48-40
 	MOVQ 48(R10), AX
 	MULQ R9
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 24(SP), BX
 
 	// | w10 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -8900,16 +10221,19 @@ TEXT ·mul_no_adx_bmi2_7(SB), NOSPLIT, $48-40
 	MOVQ 48(R10), AX
 	MULQ R9
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 16(SP), SI
 
 	// | w11 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -9000,16 +10324,19 @@ TEXT ·mul_no_adx_bmi2_7(SB), NOSPLIT, $48-40
 	MOVQ 48(R10), AX
 	MULQ R9
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 8(SP), R11
 
 	// | w12 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -9100,16 +10427,19 @@ TEXT ·mul_no_adx_bmi2_7(SB), NOSPLIT, $48-40
 	MOVQ 48(R10), AX
 	MULQ R9
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ (SP), R12
 
 	// | w-1 @ R12
-	ADCQ CX, R12
+	ADDQ CX, R12
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R12
+	ADCQ $0x00, CX
 
 	// | 
 	// | W montgomerry reduction ends
@@ -9357,6 +10687,7 @@ TEXT ·addn8(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -9587,6 +10918,18 @@ TEXT ·_neg8(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -9608,13 +10951,21 @@ TEXT ·_neg8(SB), NOSPLIT, $0-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
+	ANDQ AX, R11
 	MOVQ R11, 40(DI)
+	ANDQ AX, R12
 	MOVQ R12, 48(DI)
+	ANDQ AX, R13
 	MOVQ R13, 56(DI)
 	RET
 
@@ -9624,6 +10975,275 @@ TEXT ·_neg8(SB), NOSPLIT, $0-24
 
 	RET
 
+// func cmov8(c *[8]uint64, a *[8]uint64, b *[8]uint64, cond uint64)
+TEXT ·cmov8(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	MOVQ    40(DI), R8
+	CMOVQCS 40(SI), R8
+	MOVQ    R8, 40(BX)
+	MOVQ    48(DI), R8
+	CMOVQCS 48(SI), R8
+	MOVQ    R8, 48(BX)
+	MOVQ    56(DI), R8
+	CMOVQCS 56(SI), R8
+	MOVQ    R8, 56(BX)
+	RET
+
+// func cswap8(a *[8]uint64, b *[8]uint64, cond uint64)
+TEXT ·cswap8(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	MOVQ    40(DI), R8
+	MOVQ    40(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 40(DI)
+	MOVQ    R9, 40(SI)
+	MOVQ    48(DI), R8
+	MOVQ    48(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 48(DI)
+	MOVQ    R9, 48(SI)
+	MOVQ    56(DI), R8
+	MOVQ    56(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 56(DI)
+	MOVQ    R9, 56(SI)
+	RET
+
+// func condNeg8(c *[8]uint64, a *[8]uint64, p *[8]uint64, cond uint64)
+TEXT ·condNeg8(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	SBBQ DX, DX
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	MOVQ 40(SI), R8
+	ADDQ DX, DX
+	SBBQ 40(DI), R8
+	SBBQ DX, DX
+	MOVQ 40(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 40(BX)
+	MOVQ 48(SI), R8
+	ADDQ DX, DX
+	SBBQ 48(DI), R8
+	SBBQ DX, DX
+	MOVQ 48(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 48(BX)
+	MOVQ 56(SI), R8
+	ADDQ DX, DX
+	SBBQ 56(DI), R8
+	MOVQ 56(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 56(BX)
+	RET
+
+// func lookup8(dst *[8]uint64, table *[8]uint64, n uint64, idx uint64)
+TEXT ·lookup8(SB), NOSPLIT, $8-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	XORQ  R14, R14
+	XORQ  R15, R15
+	MOVQ  $0x00, (SP)
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	MOVQ 40(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R14
+	MOVQ 48(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R15
+	MOVQ 56(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, (SP)
+	ADDQ $0x00000040, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	MOVQ R14, 40(DI)
+	MOVQ R15, 48(DI)
+	MOVQ (SP), R9
+	MOVQ R9, 56(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_8(a *[8]uint64) uint64
 TEXT ·mul_two_8(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -11672,16 +13292,19 @@ TEXT ·mul_no_adx_bmi2_8(SB), NOSPLIT, $128-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R15
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 48(SP), DI
 
 	// | w9 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -11786,16 +13409,19 @@ TEXT ·mul_no_adx_bmi2_8(SB), NOSPLIT, $128-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 40(SP), SI
 
 	// | w10 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -11900,16 +13526,19 @@ TEXT ·mul_no_adx_bmi2_8(SB), NOSPLIT, $128-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 32(SP), BX
 
 	// | w11 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -12014,16 +13643,19 @@ TEXT ·mul_no_adx_bmi2_8(SB), NOSPLIT, $128-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 24(SP), R13
 
 	// | w12 @ R13
-	ADCQ CX, R13
+	ADDQ CX, R13
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R13
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -12128,16 +13760,19 @@ TEXT ·mul_no_adx_bmi2_8(SB), NOSPLIT, $128-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R13
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 16(SP), R12
 
 	// | w13 @ R12
-	ADCQ CX, R12
+	ADDQ CX, R12
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R12
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -12242,16 +13877,19 @@ TEXT ·mul_no_adx_bmi2_8(SB), NOSPLIT, $128-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R12
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R12
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 8(SP), R11
 
 	// | w14 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -12356,16 +13994,19 @@ TEXT ·mul_no_adx_bmi2_8(SB), NOSPLIT, $128-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ (SP), R14
 
 	// | w-1 @ R14
-	ADCQ CX, R14
+	ADDQ CX, R14
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R14
+	ADCQ $0x00, CX
 
 	// | 
 	// | W montgomerry reduction ends
@@ -12636,6 +14277,7 @@ TEXT ·addn9(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -12677,154 +14319,268 @@ TEXT ·addn9(SB), NOSPLIT, $0-24
 
 	RET
 
-// func double9(c *[9]uint64, a *[9]uint64, p *[9]uint64)
-TEXT ·double9(SB), NOSPLIT, $64-24
+// func double9(c *[9]uint64, a *[9]uint64, p *[9]uint64)
+TEXT ·double9(SB), NOSPLIT, $64-24
+	// |
+	MOVQ a+8(FP), DI
+	XORQ AX, AX
+	MOVQ (DI), CX
+	ADDQ CX, CX
+	MOVQ 8(DI), DX
+	ADCQ DX, DX
+	MOVQ 16(DI), R8
+	ADCQ R8, R8
+	MOVQ 24(DI), R9
+	ADCQ R9, R9
+	MOVQ 32(DI), R10
+	ADCQ R10, R10
+	MOVQ 40(DI), R11
+	ADCQ R11, R11
+	MOVQ 48(DI), R12
+	ADCQ R12, R12
+	MOVQ 56(DI), R13
+	ADCQ R13, R13
+	MOVQ 64(DI), R14
+	ADCQ R14, R14
+	ADCQ $0x00, AX
+
+	// |
+	MOVQ p+16(FP), SI
+	MOVQ CX, R15
+	SUBQ (SI), R15
+	MOVQ DX, BX
+	SBBQ 8(SI), BX
+	MOVQ BX, (SP)
+	MOVQ R8, BX
+	SBBQ 16(SI), BX
+	MOVQ BX, 8(SP)
+	MOVQ R9, BX
+	SBBQ 24(SI), BX
+	MOVQ BX, 16(SP)
+	MOVQ R10, BX
+	SBBQ 32(SI), BX
+	MOVQ BX, 24(SP)
+	MOVQ R11, BX
+	SBBQ 40(SI), BX
+	MOVQ BX, 32(SP)
+	MOVQ R12, BX
+	SBBQ 48(SI), BX
+	MOVQ BX, 40(SP)
+	MOVQ R13, BX
+	SBBQ 56(SI), BX
+	MOVQ BX, 48(SP)
+	MOVQ R14, BX
+	SBBQ 64(SI), BX
+	MOVQ BX, 56(SP)
+	SBBQ $0x00, AX
+
+	// |
+	MOVQ    c+0(FP), DI
+	CMOVQCC R15, CX
+	MOVQ    CX, (DI)
+	CMOVQCC (SP), DX
+	MOVQ    DX, 8(DI)
+	CMOVQCC 8(SP), R8
+	MOVQ    R8, 16(DI)
+	CMOVQCC 16(SP), R9
+	MOVQ    R9, 24(DI)
+	CMOVQCC 24(SP), R10
+	MOVQ    R10, 32(DI)
+	CMOVQCC 32(SP), R11
+	MOVQ    R11, 40(DI)
+	CMOVQCC 40(SP), R12
+	MOVQ    R12, 48(DI)
+	CMOVQCC 48(SP), R13
+	MOVQ    R13, 56(DI)
+	CMOVQCC 56(SP), R14
+	MOVQ    R14, 64(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
+// func sub9(c *[9]uint64, a *[9]uint64, b *[9]uint64, p *[9]uint64)
+TEXT ·sub9(SB), NOSPLIT, $64-32
+	// |
+	MOVQ a+8(FP), DI
+	MOVQ b+16(FP), SI
+	XORQ AX, AX
+	MOVQ (DI), CX
+	SUBQ (SI), CX
+	MOVQ 8(DI), DX
+	SBBQ 8(SI), DX
+	MOVQ 16(DI), R8
+	SBBQ 16(SI), R8
+	MOVQ 24(DI), R9
+	SBBQ 24(SI), R9
+	MOVQ 32(DI), R10
+	SBBQ 32(SI), R10
+	MOVQ 40(DI), R11
+	SBBQ 40(SI), R11
+	MOVQ 48(DI), R12
+	SBBQ 48(SI), R12
+	MOVQ 56(DI), R13
+	SBBQ 56(SI), R13
+	MOVQ 64(DI), R14
+	SBBQ 64(SI), R14
+
+	// |
+	MOVQ    p+24(FP), SI
+	MOVQ    (SI), R15
+	CMOVQCC AX, R15
+	CMOVQCS 8(SI), AX
+	MOVQ    AX, (SP)
+	CMOVQCS 16(SI), AX
+	MOVQ    AX, 8(SP)
+	CMOVQCS 24(SI), AX
+	MOVQ    AX, 16(SP)
+	CMOVQCS 32(SI), AX
+	MOVQ    AX, 24(SP)
+	CMOVQCS 40(SI), AX
+	MOVQ    AX, 32(SP)
+	CMOVQCS 48(SI), AX
+	MOVQ    AX, 40(SP)
+	CMOVQCS 56(SI), AX
+	MOVQ    AX, 48(SP)
+	CMOVQCS 64(SI), AX
+	MOVQ    AX, 56(SP)
+
+	// |
+	MOVQ c+0(FP), DI
+	ADDQ R15, CX
+	MOVQ CX, (DI)
+	ADCQ (SP), DX
+	MOVQ DX, 8(DI)
+	ADCQ 8(SP), R8
+	MOVQ R8, 16(DI)
+	ADCQ 16(SP), R9
+	MOVQ R9, 24(DI)
+	ADCQ 24(SP), R10
+	MOVQ R10, 32(DI)
+	ADCQ 32(SP), R11
+	MOVQ R11, 40(DI)
+	ADCQ 40(SP), R12
+	MOVQ R12, 48(DI)
+	ADCQ 48(SP), R13
+	MOVQ R13, 56(DI)
+	ADCQ 56(SP), R14
+	MOVQ R14, 64(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
+// func subn9(a *[9]uint64, b *[9]uint64) uint64
+TEXT ·subn9(SB), NOSPLIT, $0-24
+	// |
+	MOVQ a+0(FP), DI
+	MOVQ b+8(FP), SI
+	XORQ AX, AX
+
+	// |
+	MOVQ (DI), CX
+	SUBQ (SI), CX
+	MOVQ 8(DI), DX
+	SBBQ 8(SI), DX
+	MOVQ 16(DI), R8
+	SBBQ 16(SI), R8
+	MOVQ 24(DI), R9
+	SBBQ 24(SI), R9
+	MOVQ 32(DI), R10
+	SBBQ 32(SI), R10
+	MOVQ 40(DI), R11
+	SBBQ 40(SI), R11
+	MOVQ 48(DI), R12
+	SBBQ 48(SI), R12
+	MOVQ 56(DI), R13
+	SBBQ 56(SI), R13
+	MOVQ 64(DI), R14
+	SBBQ 64(SI), R14
+	ADCQ $0x00, AX
+
+	// |
+	MOVQ CX, (DI)
+	MOVQ DX, 8(DI)
+	MOVQ R8, 16(DI)
+	MOVQ R9, 24(DI)
+	MOVQ R10, 32(DI)
+	MOVQ R11, 40(DI)
+	MOVQ R12, 48(DI)
+	MOVQ R13, 56(DI)
+	MOVQ R14, 64(DI)
+	MOVQ AX, ret+16(FP)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
+// func _neg9(c *[9]uint64, a *[9]uint64, p *[9]uint64)
+TEXT ·_neg9(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+8(FP), DI
-	XORQ AX, AX
-	MOVQ (DI), CX
-	ADDQ CX, CX
-	MOVQ 8(DI), DX
-	ADCQ DX, DX
-	MOVQ 16(DI), R8
-	ADCQ R8, R8
-	MOVQ 24(DI), R9
-	ADCQ R9, R9
-	MOVQ 32(DI), R10
-	ADCQ R10, R10
-	MOVQ 40(DI), R11
-	ADCQ R11, R11
-	MOVQ 48(DI), R12
-	ADCQ R12, R12
-	MOVQ 56(DI), R13
-	ADCQ R13, R13
-	MOVQ 64(DI), R14
-	ADCQ R14, R14
-	ADCQ $0x00, AX
-
-	// |
-	MOVQ p+16(FP), SI
-	MOVQ CX, R15
-	SUBQ (SI), R15
-	MOVQ DX, BX
-	SBBQ 8(SI), BX
-	MOVQ BX, (SP)
-	MOVQ R8, BX
-	SBBQ 16(SI), BX
-	MOVQ BX, 8(SP)
-	MOVQ R9, BX
-	SBBQ 24(SI), BX
-	MOVQ BX, 16(SP)
-	MOVQ R10, BX
-	SBBQ 32(SI), BX
-	MOVQ BX, 24(SP)
-	MOVQ R11, BX
-	SBBQ 40(SI), BX
-	MOVQ BX, 32(SP)
-	MOVQ R12, BX
-	SBBQ 48(SI), BX
-	MOVQ BX, 40(SP)
-	MOVQ R13, BX
-	SBBQ 56(SI), BX
-	MOVQ BX, 48(SP)
-	MOVQ R14, BX
-	SBBQ 64(SI), BX
-	MOVQ BX, 56(SP)
-	SBBQ $0x00, AX
-
-	// |
-	MOVQ    c+0(FP), DI
-	CMOVQCC R15, CX
-	MOVQ    CX, (DI)
-	CMOVQCC (SP), DX
-	MOVQ    DX, 8(DI)
-	CMOVQCC 8(SP), R8
-	MOVQ    R8, 16(DI)
-	CMOVQCC 16(SP), R9
-	MOVQ    R9, 24(DI)
-	CMOVQCC 24(SP), R10
-	MOVQ    R10, 32(DI)
-	CMOVQCC 32(SP), R11
-	MOVQ    R11, 40(DI)
-	CMOVQCC 40(SP), R12
-	MOVQ    R12, 48(DI)
-	CMOVQCC 48(SP), R13
-	MOVQ    R13, 56(DI)
-	CMOVQCC 56(SP), R14
-	MOVQ    R14, 64(DI)
-	RET
-
-	// | 
-
-/* end                                     */
-
-	RET
 
-// func sub9(c *[9]uint64, a *[9]uint64, b *[9]uint64, p *[9]uint64)
-TEXT ·sub9(SB), NOSPLIT, $64-32
 	// |
-	MOVQ a+8(FP), DI
-	MOVQ b+16(FP), SI
-	XORQ AX, AX
-	MOVQ (DI), CX
-	SUBQ (SI), CX
-	MOVQ 8(DI), DX
-	SBBQ 8(SI), DX
-	MOVQ 16(DI), R8
-	SBBQ 16(SI), R8
-	MOVQ 24(DI), R9
-	SBBQ 24(SI), R9
-	MOVQ 32(DI), R10
-	SBBQ 32(SI), R10
-	MOVQ 40(DI), R11
-	SBBQ 40(SI), R11
-	MOVQ 48(DI), R12
-	SBBQ 48(SI), R12
-	MOVQ 56(DI), R13
-	SBBQ 56(SI), R13
-	MOVQ 64(DI), R14
-	SBBQ 64(SI), R14
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
 
 	// |
-	MOVQ    p+24(FP), SI
-	MOVQ    (SI), R15
-	CMOVQCC AX, R15
-	CMOVQCS 8(SI), AX
-	MOVQ    AX, (SP)
-	CMOVQCS 16(SI), AX
-	MOVQ    AX, 8(SP)
-	CMOVQCS 24(SI), AX
-	MOVQ    AX, 16(SP)
-	CMOVQCS 32(SI), AX
-	MOVQ    AX, 24(SP)
-	CMOVQCS 40(SI), AX
-	MOVQ    AX, 32(SP)
-	CMOVQCS 48(SI), AX
-	MOVQ    AX, 40(SP)
-	CMOVQCS 56(SI), AX
-	MOVQ    AX, 48(SP)
-	CMOVQCS 64(SI), AX
-	MOVQ    AX, 56(SP)
+	MOVQ p+16(FP), SI
+	MOVQ (SI), CX
+	SUBQ (DI), CX
+	MOVQ 8(SI), DX
+	SBBQ 8(DI), DX
+	MOVQ 16(SI), R8
+	SBBQ 16(DI), R8
+	MOVQ 24(SI), R9
+	SBBQ 24(DI), R9
+	MOVQ 32(SI), R10
+	SBBQ 32(DI), R10
+	MOVQ 40(SI), R11
+	SBBQ 40(DI), R11
+	MOVQ 48(SI), R12
+	SBBQ 48(DI), R12
+	MOVQ 56(SI), R13
+	SBBQ 56(DI), R13
+	MOVQ 64(SI), R14
+	SBBQ 64(DI), R14
 
 	// |
 	MOVQ c+0(FP), DI
-	ADDQ R15, CX
+	ANDQ AX, CX
 	MOVQ CX, (DI)
-	ADCQ (SP), DX
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
-	ADCQ 8(SP), R8
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
-	ADCQ 16(SP), R9
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
-	ADCQ 24(SP), R10
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
-	ADCQ 32(SP), R11
+	ANDQ AX, R11
 	MOVQ R11, 40(DI)
-	ADCQ 40(SP), R12
+	ANDQ AX, R12
 	MOVQ R12, 48(DI)
-	ADCQ 48(SP), R13
+	ANDQ AX, R13
 	MOVQ R13, 56(DI)
-	ADCQ 56(SP), R14
+	ANDQ AX, R14
 	MOVQ R14, 64(DI)
 	RET
 
@@ -12834,90 +14590,293 @@ TEXT ·sub9(SB), NOSPLIT, $64-32
 
 	RET
 
-// func subn9(a *[9]uint64, b *[9]uint64) uint64
-TEXT ·subn9(SB), NOSPLIT, $0-24
-	// |
-	MOVQ a+0(FP), DI
-	MOVQ b+8(FP), SI
-	XORQ AX, AX
-
-	// |
-	MOVQ (DI), CX
-	SUBQ (SI), CX
-	MOVQ 8(DI), DX
-	SBBQ 8(SI), DX
-	MOVQ 16(DI), R8
-	SBBQ 16(SI), R8
-	MOVQ 24(DI), R9
-	SBBQ 24(SI), R9
-	MOVQ 32(DI), R10
-	SBBQ 32(SI), R10
-	MOVQ 40(DI), R11
-	SBBQ 40(SI), R11
-	MOVQ 48(DI), R12
-	SBBQ 48(SI), R12
-	MOVQ 56(DI), R13
-	SBBQ 56(SI), R13
-	MOVQ 64(DI), R14
-	SBBQ 64(SI), R14
-	ADCQ $0x00, AX
-
-	// |
-	MOVQ CX, (DI)
-	MOVQ DX, 8(DI)
-	MOVQ R8, 16(DI)
-	MOVQ R9, 24(DI)
-	MOVQ R10, 32(DI)
-	MOVQ R11, 40(DI)
-	MOVQ R12, 48(DI)
-	MOVQ R13, 56(DI)
-	MOVQ R14, 64(DI)
-	MOVQ AX, ret+16(FP)
+// func cmov9(c *[9]uint64, a *[9]uint64, b *[9]uint64, cond uint64)
+TEXT ·cmov9(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	MOVQ    40(DI), R8
+	CMOVQCS 40(SI), R8
+	MOVQ    R8, 40(BX)
+	MOVQ    48(DI), R8
+	CMOVQCS 48(SI), R8
+	MOVQ    R8, 48(BX)
+	MOVQ    56(DI), R8
+	CMOVQCS 56(SI), R8
+	MOVQ    R8, 56(BX)
+	MOVQ    64(DI), R8
+	CMOVQCS 64(SI), R8
+	MOVQ    R8, 64(BX)
 	RET
 
-	// | 
-
-/* end                                     */
-
+// func cswap9(a *[9]uint64, b *[9]uint64, cond uint64)
+TEXT ·cswap9(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	MOVQ    40(DI), R8
+	MOVQ    40(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 40(DI)
+	MOVQ    R9, 40(SI)
+	MOVQ    48(DI), R8
+	MOVQ    48(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 48(DI)
+	MOVQ    R9, 48(SI)
+	MOVQ    56(DI), R8
+	MOVQ    56(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 56(DI)
+	MOVQ    R9, 56(SI)
+	MOVQ    64(DI), R8
+	MOVQ    64(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 64(DI)
+	MOVQ    R9, 64(SI)
 	RET
 
-// func _neg9(c *[9]uint64, a *[9]uint64, p *[9]uint64)
-TEXT ·_neg9(SB), NOSPLIT, $0-24
-	// |
+// func condNeg9(c *[9]uint64, a *[9]uint64, p *[9]uint64, cond uint64)
+TEXT ·condNeg9(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
 	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
 
 	// |
-	MOVQ p+16(FP), SI
-	MOVQ (SI), CX
-	SUBQ (DI), CX
-	MOVQ 8(SI), DX
-	SBBQ 8(DI), DX
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
 	MOVQ 16(SI), R8
+	ADDQ DX, DX
 	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	SBBQ DX, DX
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	MOVQ 40(SI), R8
+	ADDQ DX, DX
+	SBBQ 40(DI), R8
+	SBBQ DX, DX
+	MOVQ 40(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 40(BX)
+	MOVQ 48(SI), R8
+	ADDQ DX, DX
+	SBBQ 48(DI), R8
+	SBBQ DX, DX
+	MOVQ 48(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 48(BX)
+	MOVQ 56(SI), R8
+	ADDQ DX, DX
+	SBBQ 56(DI), R8
+	SBBQ DX, DX
+	MOVQ 56(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 56(BX)
+	MOVQ 64(SI), R8
+	ADDQ DX, DX
+	SBBQ 64(DI), R8
+	MOVQ 64(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 64(BX)
+	RET
+
+// func lookup9(dst *[9]uint64, table *[9]uint64, n uint64, idx uint64)
+TEXT ·lookup9(SB), NOSPLIT, $16-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	XORQ  R14, R14
+	XORQ  R15, R15
+	MOVQ  $0x00, (SP)
+	MOVQ  $0x00, 8(SP)
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
 	MOVQ 24(SI), R9
-	SBBQ 24(DI), R9
-	MOVQ 32(SI), R10
-	SBBQ 32(DI), R10
-	MOVQ 40(SI), R11
-	SBBQ 40(DI), R11
-	MOVQ 48(SI), R12
-	SBBQ 48(DI), R12
-	MOVQ 56(SI), R13
-	SBBQ 56(DI), R13
-	MOVQ 64(SI), R14
-	SBBQ 64(DI), R14
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	MOVQ 40(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R14
+	MOVQ 48(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R15
+	MOVQ 56(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, (SP)
+	MOVQ 64(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 8(SP)
+	ADDQ $0x00000048, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
 
 	// |
-	MOVQ c+0(FP), DI
-	MOVQ CX, (DI)
-	MOVQ DX, 8(DI)
-	MOVQ R8, 16(DI)
-	MOVQ R9, 24(DI)
-	MOVQ R10, 32(DI)
-	MOVQ R11, 40(DI)
-	MOVQ R12, 48(DI)
-	MOVQ R13, 56(DI)
-	MOVQ R14, 64(DI)
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	MOVQ R14, 40(DI)
+	MOVQ R15, 48(DI)
+	MOVQ (SP), R9
+	MOVQ R9, 56(DI)
+	MOVQ 8(SP), R9
+	MOVQ R9, 64(DI)
 	RET
 
 	// | 
@@ -15472,16 +17431,19 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 64(SP), DI
 
 	// | w9 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -15586,16 +17548,19 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 56(SP), SI
 
 	// | w10 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -15700,16 +17665,19 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 48(SP), BX
 
 	// | w11 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -15814,16 +17782,19 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 40(SP), R15
 
 	// | w12 @ R15
-	ADCQ CX, R15
+	ADDQ CX, R15
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R15
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -15928,16 +17899,19 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R15
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 32(SP), R14
 
 	// | w13 @ R14
-	ADCQ CX, R14
+	ADDQ CX, R14
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R14
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -16042,16 +18016,19 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R14
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R14
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 24(SP), R13
 
 	// | w14 @ R13
-	ADCQ CX, R13
+	ADDQ CX, R13
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R13
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -16156,16 +18133,19 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R13
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 16(SP), R12
 
 	// | w15 @ R12
-	ADCQ CX, R12
+	ADDQ CX, R12
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R12
+	ADCQ $0x00, CX
 
 	// | 
 	// | W q1
@@ -16198,8 +18178,10 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 64(R10), AX
 	MULQ 80(SP)
 	ADDQ AX, DI
-	ADCQ R8, DX
+	ADCQ $0x00, DX
+	ADDQ R8, DX
 	MOVQ $0x00, R8
+	ADCQ $0x00, R8
 	ADDQ DX, SI
 	ADCQ $0x00, R8
 
@@ -16211,8 +18193,10 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 64(R10), AX
 	MULQ 64(SP)
 	ADDQ AX, SI
-	ADCQ R8, DX
+	ADCQ $0x00, DX
+	ADDQ R8, DX
 	MOVQ $0x00, R8
+	ADCQ $0x00, R8
 	ADDQ DX, BX
 	ADCQ $0x00, R8
 
@@ -16224,8 +18208,10 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 64(R10), AX
 	MULQ 56(SP)
 	ADDQ AX, BX
-	ADCQ R8, DX
+	ADCQ $0x00, DX
+	ADDQ R8, DX
 	MOVQ $0x00, R8
+	ADCQ $0x00, R8
 	ADDQ DX, R15
 	ADCQ $0x00, R8
 
@@ -16237,8 +18223,10 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 64(R10), AX
 	MULQ 48(SP)
 	ADDQ AX, R15
-	ADCQ R8, DX
+	ADCQ $0x00, DX
+	ADDQ R8, DX
 	MOVQ $0x00, R8
+	ADCQ $0x00, R8
 	ADDQ DX, R14
 	ADCQ $0x00, R8
 
@@ -16250,8 +18238,10 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 64(R10), AX
 	MULQ 40(SP)
 	ADDQ AX, R14
-	ADCQ R8, DX
+	ADCQ $0x00, DX
+	ADDQ R8, DX
 	MOVQ $0x00, R8
+	ADCQ $0x00, R8
 	ADDQ DX, R13
 	ADCQ $0x00, R8
 
@@ -16263,8 +18253,10 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 64(R10), AX
 	MULQ 32(SP)
 	ADDQ AX, R13
-	ADCQ R8, DX
+	ADCQ $0x00, DX
+	ADDQ R8, DX
 	MOVQ $0x00, R8
+	ADCQ $0x00, R8
 	ADDQ DX, R12
 
 	// | carry from q1
@@ -16278,8 +18270,10 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 64(R10), AX
 	MULQ 24(SP)
 	ADDQ AX, R12
-	ADCQ R8, DX
+	ADCQ $0x00, DX
+	ADDQ R8, DX
 	MOVQ $0x00, R8
+	ADCQ $0x00, R8
 	ADDQ DX, 8(SP)
 	ADCQ $0x00, R8
 
@@ -16396,14 +18390,17 @@ TEXT ·mul_no_adx_bmi2_9(SB), NOSPLIT, $144-40
 	MOVQ 64(R10), AX
 	MULQ R9
 	ADDQ AX, R11
-	ADCQ DX, R8
+	ADCQ $0x00, DX
 	ADDQ CX, R11
+	ADCQ $0x00, DX
 
 	// | w17 @ (SP)
-	ADCQ (SP), R8
+	ADDQ DX, R8
+	MOVQ $0x00, CX
+	ADCQ $0x00, CX
+	ADDQ (SP), R8
 
 	// | care the last bit
-	MOVQ $0x00, CX
 	ADCQ $0x00, CX
 
 	// | 
@@ -16697,6 +18694,7 @@ TEXT ·addn10(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -16967,6 +18965,20 @@ TEXT ·_neg10(SB), NOSPLIT, $0-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -16992,15 +19004,25 @@ TEXT ·_neg10(SB), NOSPLIT, $0-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
+	ANDQ AX, R11
 	MOVQ R11, 40(DI)
+	ANDQ AX, R12
 	MOVQ R12, 48(DI)
+	ANDQ AX, R13
 	MOVQ R13, 56(DI)
+	ANDQ AX, R14
 	MOVQ R14, 64(DI)
+	ANDQ AX, R15
 	MOVQ R15, 72(DI)
 	RET
 
@@ -17010,6 +19032,327 @@ TEXT ·_neg10(SB), NOSPLIT, $0-24
 
 	RET
 
+// func cmov10(c *[10]uint64, a *[10]uint64, b *[10]uint64, cond uint64)
+TEXT ·cmov10(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	MOVQ    40(DI), R8
+	CMOVQCS 40(SI), R8
+	MOVQ    R8, 40(BX)
+	MOVQ    48(DI), R8
+	CMOVQCS 48(SI), R8
+	MOVQ    R8, 48(BX)
+	MOVQ    56(DI), R8
+	CMOVQCS 56(SI), R8
+	MOVQ    R8, 56(BX)
+	MOVQ    64(DI), R8
+	CMOVQCS 64(SI), R8
+	MOVQ    R8, 64(BX)
+	MOVQ    72(DI), R8
+	CMOVQCS 72(SI), R8
+	MOVQ    R8, 72(BX)
+	RET
+
+// func cswap10(a *[10]uint64, b *[10]uint64, cond uint64)
+TEXT ·cswap10(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	MOVQ    40(DI), R8
+	MOVQ    40(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 40(DI)
+	MOVQ    R9, 40(SI)
+	MOVQ    48(DI), R8
+	MOVQ    48(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 48(DI)
+	MOVQ    R9, 48(SI)
+	MOVQ    56(DI), R8
+	MOVQ    56(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 56(DI)
+	MOVQ    R9, 56(SI)
+	MOVQ    64(DI), R8
+	MOVQ    64(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 64(DI)
+	MOVQ    R9, 64(SI)
+	MOVQ    72(DI), R8
+	MOVQ    72(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 72(DI)
+	MOVQ    R9, 72(SI)
+	RET
+
+// func condNeg10(c *[10]uint64, a *[10]uint64, p *[10]uint64, cond uint64)
+TEXT ·condNeg10(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	SBBQ DX, DX
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	MOVQ 40(SI), R8
+	ADDQ DX, DX
+	SBBQ 40(DI), R8
+	SBBQ DX, DX
+	MOVQ 40(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 40(BX)
+	MOVQ 48(SI), R8
+	ADDQ DX, DX
+	SBBQ 48(DI), R8
+	SBBQ DX, DX
+	MOVQ 48(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 48(BX)
+	MOVQ 56(SI), R8
+	ADDQ DX, DX
+	SBBQ 56(DI), R8
+	SBBQ DX, DX
+	MOVQ 56(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 56(BX)
+	MOVQ 64(SI), R8
+	ADDQ DX, DX
+	SBBQ 64(DI), R8
+	SBBQ DX, DX
+	MOVQ 64(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 64(BX)
+	MOVQ 72(SI), R8
+	ADDQ DX, DX
+	SBBQ 72(DI), R8
+	MOVQ 72(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 72(BX)
+	RET
+
+// func lookup10(dst *[10]uint64, table *[10]uint64, n uint64, idx uint64)
+TEXT ·lookup10(SB), NOSPLIT, $24-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	XORQ  R14, R14
+	XORQ  R15, R15
+	MOVQ  $0x00, (SP)
+	MOVQ  $0x00, 8(SP)
+	MOVQ  $0x00, 16(SP)
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	MOVQ 40(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R14
+	MOVQ 48(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R15
+	MOVQ 56(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, (SP)
+	MOVQ 64(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 8(SP)
+	MOVQ 72(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 16(SP)
+	ADDQ $0x00000050, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	MOVQ R14, 40(DI)
+	MOVQ R15, 48(DI)
+	MOVQ (SP), R9
+	MOVQ R9, 56(DI)
+	MOVQ 8(SP), R9
+	MOVQ R9, 64(DI)
+	MOVQ 16(SP), R9
+	MOVQ R9, 72(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_10(a *[10]uint64) uint64
 TEXT ·mul_two_10(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -20262,16 +22605,19 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R13
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 152(SP), DI
 
 	// | w9 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -20376,16 +22722,19 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 160(SP), SI
 
 	// | w10 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -20490,16 +22839,19 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 64(SP), BX
 
 	// | w11 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -20604,16 +22956,19 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 56(SP), R9
 
 	// | w12 @ R9
-	ADCQ CX, R9
+	ADDQ CX, R9
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R9
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -20718,16 +23073,19 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, R9
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R9
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 48(SP), R8
 
 	// | w13 @ R8
-	ADCQ CX, R8
+	ADDQ CX, R8
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R8
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -20832,16 +23190,19 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, R8
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R8
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 40(SP), R15
 
 	// | w14 @ R15
-	ADCQ CX, R15
+	ADDQ CX, R15
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R15
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -20946,16 +23307,19 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R15
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 32(SP), R14
 
 	// | w15 @ R14
-	ADCQ CX, R14
+	ADDQ CX, R14
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R14
+	ADCQ $0x00, CX
 
 	// | 
 	// | W q1
@@ -21003,9 +23367,10 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	ADDQ AX, DI
 	ADCQ $0x00, DX
 	ADDQ R10, DI
+	ADCQ $0x00, DX
 
 	// | w10 @ SI
-	ADCQ DX, SI
+	ADDQ DX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
 
@@ -21039,13 +23404,16 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 72(R12), AX
 	MULQ 80(SP)
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, SI
+	ADCQ $0x00, DX
 
 	// | w11 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -21077,13 +23445,16 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 72(R12), AX
 	MULQ 88(SP)
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, BX
+	ADCQ $0x00, DX
 
 	// | w12 @ R9
-	ADCQ CX, R9
+	ADDQ CX, R9
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R9
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -21115,13 +23486,16 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 72(R12), AX
 	MULQ 96(SP)
 	ADDQ AX, R9
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R9
+	ADCQ $0x00, DX
 
 	// | w13 @ R8
-	ADCQ CX, R8
+	ADDQ CX, R8
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R8
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -21153,13 +23527,16 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 72(R12), AX
 	MULQ 64(SP)
 	ADDQ AX, R8
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R8
+	ADCQ $0x00, DX
 
 	// | w14 @ R15
-	ADCQ CX, R15
+	ADDQ CX, R15
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R15
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -21191,15 +23568,19 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 72(R12), AX
 	MULQ 56(SP)
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R15
+	ADCQ $0x00, DX
 
 	// | w15 @ R14
-	ADCQ CX, R14
+	ADDQ CX, R14
+	MOVQ $0x00, CX
+	ADCQ $0x00, CX
+	ADDQ DX, R14
+	ADCQ $0x00, CX
 
 	// | bring the carry from q1
-	MOVQ 32(SP), CX
-	ADCQ $0x00, CX
+	ADDQ 32(SP), CX
 
 	// | 
 
@@ -21231,16 +23612,19 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 72(R12), AX
 	MULQ 48(SP)
 	ADDQ AX, R14
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R14
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 24(SP), R11
 
 	// | w16 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -21272,14 +23656,17 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 72(R12), AX
 	MULQ 40(SP)
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R11
+	ADCQ $0x00, DX
 
 	// | tolarete this limb to stay in stack
 	// | w17 @ 16(SP)
-	ADCQ CX, 16(SP)
+	ADDQ CX, 16(SP)
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, 16(SP)
+	ADCQ $0x00, CX
 
 	// | 
 	// | q2
@@ -21514,16 +23901,19 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 56(R12), AX
 	MULQ CX
 	ADDQ AX, R11
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 16(SP), DI
 
 	// | w17 @ DI
-	ADCQ R13, DI
+	ADDQ R13, DI
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, DI
+	ADCQ $0x00, R13
 
 	// | 
 	// | W q3
@@ -21533,7 +23923,7 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 
 	// | aggregate carries from q2 & q3
 	// | should be added to w18
-	ADCQ R13, 32(SP)
+	ADDQ R13, 32(SP)
 
 	// | 
 
@@ -21569,14 +23959,18 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 72(R12), AX
 	MULQ 40(SP)
 	ADDQ AX, DI
-	ADCQ 32(SP), DX
+	ADCQ $0x00, DX
 	ADDQ R10, DI
+	ADCQ $0x00, DX
 	MOVQ 8(SP), CX
 
 	// | w18 @ CX
-	ADCQ DX, CX
+	MOVQ 32(SP), R13
+	ADDQ R13, CX
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, CX
+	ADCQ $0x00, R13
 
 	// | 
 
@@ -21608,16 +24002,19 @@ TEXT ·mul_no_adx_bmi2_10(SB), NOSPLIT, $168-40
 	MOVQ 72(R12), AX
 	MULQ 48(SP)
 	ADDQ AX, CX
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, CX
+	ADCQ $0x00, DX
 
 	// | very last limb goes to short carry register
 	MOVQ (SP), R10
 
 	// | w-1 @ R10
-	ADCQ R13, R10
+	ADDQ R13, R10
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, R10
+	ADCQ $0x00, R13
 
 	// | 
 	// | W q4
@@ -21933,6 +24330,7 @@ TEXT ·addn11(SB), NOSPLIT, $8-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -22230,6 +24628,21 @@ TEXT ·_neg11(SB), NOSPLIT, $8-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -22258,16 +24671,27 @@ TEXT ·_neg11(SB), NOSPLIT, $8-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
+	ANDQ AX, R11
 	MOVQ R11, 40(DI)
+	ANDQ AX, R12
 	MOVQ R12, 48(DI)
+	ANDQ AX, R13
 	MOVQ R13, 56(DI)
+	ANDQ AX, R14
 	MOVQ R14, 64(DI)
+	ANDQ AX, R15
 	MOVQ R15, 72(DI)
+	ANDQ AX, (SP)
 	MOVQ (SP), BX
 	MOVQ BX, 80(DI)
 	RET
@@ -22278,6 +24702,353 @@ TEXT ·_neg11(SB), NOSPLIT, $8-24
 
 	RET
 
+// func cmov11(c *[11]uint64, a *[11]uint64, b *[11]uint64, cond uint64)
+TEXT ·cmov11(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	MOVQ    40(DI), R8
+	CMOVQCS 40(SI), R8
+	MOVQ    R8, 40(BX)
+	MOVQ    48(DI), R8
+	CMOVQCS 48(SI), R8
+	MOVQ    R8, 48(BX)
+	MOVQ    56(DI), R8
+	CMOVQCS 56(SI), R8
+	MOVQ    R8, 56(BX)
+	MOVQ    64(DI), R8
+	CMOVQCS 64(SI), R8
+	MOVQ    R8, 64(BX)
+	MOVQ    72(DI), R8
+	CMOVQCS 72(SI), R8
+	MOVQ    R8, 72(BX)
+	MOVQ    80(DI), R8
+	CMOVQCS 80(SI), R8
+	MOVQ    R8, 80(BX)
+	RET
+
+// func cswap11(a *[11]uint64, b *[11]uint64, cond uint64)
+TEXT ·cswap11(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	MOVQ    40(DI), R8
+	MOVQ    40(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 40(DI)
+	MOVQ    R9, 40(SI)
+	MOVQ    48(DI), R8
+	MOVQ    48(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 48(DI)
+	MOVQ    R9, 48(SI)
+	MOVQ    56(DI), R8
+	MOVQ    56(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 56(DI)
+	MOVQ    R9, 56(SI)
+	MOVQ    64(DI), R8
+	MOVQ    64(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 64(DI)
+	MOVQ    R9, 64(SI)
+	MOVQ    72(DI), R8
+	MOVQ    72(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 72(DI)
+	MOVQ    R9, 72(SI)
+	MOVQ    80(DI), R8
+	MOVQ    80(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 80(DI)
+	MOVQ    R9, 80(SI)
+	RET
+
+// func condNeg11(c *[11]uint64, a *[11]uint64, p *[11]uint64, cond uint64)
+TEXT ·condNeg11(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	SBBQ DX, DX
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	MOVQ 40(SI), R8
+	ADDQ DX, DX
+	SBBQ 40(DI), R8
+	SBBQ DX, DX
+	MOVQ 40(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 40(BX)
+	MOVQ 48(SI), R8
+	ADDQ DX, DX
+	SBBQ 48(DI), R8
+	SBBQ DX, DX
+	MOVQ 48(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 48(BX)
+	MOVQ 56(SI), R8
+	ADDQ DX, DX
+	SBBQ 56(DI), R8
+	SBBQ DX, DX
+	MOVQ 56(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 56(BX)
+	MOVQ 64(SI), R8
+	ADDQ DX, DX
+	SBBQ 64(DI), R8
+	SBBQ DX, DX
+	MOVQ 64(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 64(BX)
+	MOVQ 72(SI), R8
+	ADDQ DX, DX
+	SBBQ 72(DI), R8
+	SBBQ DX, DX
+	MOVQ 72(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 72(BX)
+	MOVQ 80(SI), R8
+	ADDQ DX, DX
+	SBBQ 80(DI), R8
+	MOVQ 80(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 80(BX)
+	RET
+
+// func lookup11(dst *[11]uint64, table *[11]uint64, n uint64, idx uint64)
+TEXT ·lookup11(SB), NOSPLIT, $32-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	XORQ  R14, R14
+	XORQ  R15, R15
+	MOVQ  $0x00, (SP)
+	MOVQ  $0x00, 8(SP)
+	MOVQ  $0x00, 16(SP)
+	MOVQ  $0x00, 24(SP)
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	MOVQ 40(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R14
+	MOVQ 48(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R15
+	MOVQ 56(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, (SP)
+	MOVQ 64(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 8(SP)
+	MOVQ 72(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 16(SP)
+	MOVQ 80(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 24(SP)
+	ADDQ $0x00000058, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	MOVQ R14, 40(DI)
+	MOVQ R15, 48(DI)
+	MOVQ (SP), R9
+	MOVQ R9, 56(DI)
+	MOVQ 8(SP), R9
+	MOVQ R9, 64(DI)
+	MOVQ 16(SP), R9
+	MOVQ R9, 72(DI)
+	MOVQ 24(SP), R9
+	MOVQ R9, 80(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_11(a *[11]uint64) uint64
 TEXT ·mul_two_11(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -26372,16 +29143,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R15
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 160(SP), DI
 
 	// | w9 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -26486,16 +29260,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 168(SP), SI
 
 	// | w10 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -26600,16 +29377,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 176(SP), BX
 
 	// | w11 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -26714,16 +29494,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 184(SP), R11
 
 	// | w12 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -26828,16 +29611,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 64(SP), R10
 
 	// | w13 @ R10
-	ADCQ CX, R10
+	ADDQ CX, R10
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R10
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -26942,16 +29728,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, R10
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R10
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 56(SP), R9
 
 	// | w14 @ R9
-	ADCQ CX, R9
+	ADDQ CX, R9
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R9
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -27056,16 +29845,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, R9
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R9
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 48(SP), R8
 
 	// | w15 @ R8
-	ADCQ CX, R8
+	ADDQ CX, R8
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R8
+	ADCQ $0x00, CX
 
 	// | 
 	// | W q1
@@ -27124,9 +29916,10 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	ADDQ AX, SI
 	ADCQ $0x00, DX
 	ADDQ R12, SI
+	ADCQ $0x00, DX
 
 	// | w11 @ BX
-	ADCQ DX, BX
+	ADDQ DX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
 
@@ -27172,13 +29965,16 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 80(R14), AX
 	MULQ 80(SP)
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, BX
+	ADCQ $0x00, DX
 
 	// | w12 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -27221,13 +30017,16 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 80(R14), AX
 	MULQ 88(SP)
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R11
+	ADCQ $0x00, DX
 
 	// | w13 @ R10
-	ADCQ CX, R10
+	ADDQ CX, R10
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R10
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -27270,13 +30069,16 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 80(R14), AX
 	MULQ 96(SP)
 	ADDQ AX, R10
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R10
+	ADCQ $0x00, DX
 
 	// | w14 @ R9
-	ADCQ CX, R9
+	ADDQ CX, R9
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R9
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -27319,15 +30121,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 80(R14), AX
 	MULQ 104(SP)
 	ADDQ AX, R9
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R9
+	ADCQ $0x00, DX
 
 	// | w15 @ R8
-	ADCQ CX, R8
+	ADDQ CX, R8
+	MOVQ $0x00, CX
+	ADCQ $0x00, CX
+	ADDQ DX, R8
+	ADCQ $0x00, CX
 
 	// | bring the carry from q1
-	MOVQ 48(SP), CX
-	ADCQ $0x00, CX
+	ADDQ 48(SP), CX
 
 	// | 
 
@@ -27370,16 +30176,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 80(R14), AX
 	MULQ 112(SP)
 	ADDQ AX, R8
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R8
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 40(SP), R13
 
 	// | w16 @ R13
-	ADCQ CX, R13
+	ADDQ CX, R13
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R13
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -27422,16 +30231,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 80(R14), AX
 	MULQ 64(SP)
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R13
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 32(SP), DI
 
 	// | w17 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -27474,14 +30286,17 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 80(R14), AX
 	MULQ 56(SP)
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, DI
+	ADCQ $0x00, DX
 
 	// | tolarete this limb to stay in stack
 	// | w18 @ 24(SP)
-	ADCQ CX, 24(SP)
+	ADDQ CX, 24(SP)
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, 24(SP)
+	ADCQ $0x00, CX
 
 	// | 
 	// | q2
@@ -27723,16 +30538,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 56(R14), AX
 	MULQ DI
 	ADDQ AX, R13
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, R13
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 72(SP), CX
 
 	// | w17 @ CX
-	ADCQ R15, CX
+	ADDQ R15, CX
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, CX
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -27837,16 +30655,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 56(R14), AX
 	MULQ DI
 	ADDQ AX, CX
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, CX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 24(SP), SI
 
 	// | w18 @ SI
-	ADCQ R15, SI
+	ADDQ R15, SI
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, SI
+	ADCQ $0x00, R15
 
 	// | 
 	// | W q3
@@ -27856,7 +30677,7 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 
 	// | aggregate carries from q2 & q3
 	// | should be added to w19
-	ADCQ R15, 48(SP)
+	ADDQ R15, 48(SP)
 
 	// | 
 
@@ -27903,14 +30724,18 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 80(R14), AX
 	MULQ 56(SP)
 	ADDQ AX, SI
-	ADCQ 48(SP), DX
+	ADCQ $0x00, DX
 	ADDQ R12, SI
+	ADCQ $0x00, DX
 	MOVQ 16(SP), DI
 
 	// | w19 @ DI
-	ADCQ DX, DI
+	MOVQ 48(SP), R15
+	ADDQ R15, DI
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, DI
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -27954,14 +30779,17 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 80(R14), AX
 	MULQ 64(SP)
 	ADDQ AX, DI
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, DI
+	ADCQ $0x00, DX
 	MOVQ 8(SP), CX
 
 	// | w20 @ CX
-	ADCQ R15, CX
+	ADDQ R15, CX
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, CX
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -28004,16 +30832,19 @@ TEXT ·mul_no_adx_bmi2_11(SB), NOSPLIT, $192-40
 	MOVQ 80(R14), AX
 	MULQ 72(SP)
 	ADDQ AX, CX
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, CX
+	ADCQ $0x00, DX
 
 	// | very last limb goes to short carry register
 	MOVQ (SP), R12
 
 	// | w-1 @ R12
-	ADCQ R15, R12
+	ADDQ R15, R12
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, R12
+	ADCQ $0x00, R15
 
 	// | 
 	// | W q4
@@ -28353,6 +31184,7 @@ TEXT ·addn12(SB), NOSPLIT, $16-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -28677,6 +31509,22 @@ TEXT ·_neg12(SB), NOSPLIT, $16-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	ORQ  88(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -28708,18 +31556,30 @@ TEXT ·_neg12(SB), NOSPLIT, $16-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
+	ANDQ AX, R11
 	MOVQ R11, 40(DI)
+	ANDQ AX, R12
 	MOVQ R12, 48(DI)
+	ANDQ AX, R13
 	MOVQ R13, 56(DI)
+	ANDQ AX, R14
 	MOVQ R14, 64(DI)
+	ANDQ AX, R15
 	MOVQ R15, 72(DI)
+	ANDQ AX, (SP)
 	MOVQ (SP), BX
 	MOVQ BX, 80(DI)
+	ANDQ AX, 8(SP)
 	MOVQ 8(SP), BX
 	MOVQ BX, 88(DI)
 	RET
@@ -28730,6 +31590,379 @@ TEXT ·_neg12(SB), NOSPLIT, $16-24
 
 	RET
 
+// func cmov12(c *[12]uint64, a *[12]uint64, b *[12]uint64, cond uint64)
+TEXT ·cmov12(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	MOVQ    40(DI), R8
+	CMOVQCS 40(SI), R8
+	MOVQ    R8, 40(BX)
+	MOVQ    48(DI), R8
+	CMOVQCS 48(SI), R8
+	MOVQ    R8, 48(BX)
+	MOVQ    56(DI), R8
+	CMOVQCS 56(SI), R8
+	MOVQ    R8, 56(BX)
+	MOVQ    64(DI), R8
+	CMOVQCS 64(SI), R8
+	MOVQ    R8, 64(BX)
+	MOVQ    72(DI), R8
+	CMOVQCS 72(SI), R8
+	MOVQ    R8, 72(BX)
+	MOVQ    80(DI), R8
+	CMOVQCS 80(SI), R8
+	MOVQ    R8, 80(BX)
+	MOVQ    88(DI), R8
+	CMOVQCS 88(SI), R8
+	MOVQ    R8, 88(BX)
+	RET
+
+// func cswap12(a *[12]uint64, b *[12]uint64, cond uint64)
+TEXT ·cswap12(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	MOVQ    40(DI), R8
+	MOVQ    40(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 40(DI)
+	MOVQ    R9, 40(SI)
+	MOVQ    48(DI), R8
+	MOVQ    48(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 48(DI)
+	MOVQ    R9, 48(SI)
+	MOVQ    56(DI), R8
+	MOVQ    56(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 56(DI)
+	MOVQ    R9, 56(SI)
+	MOVQ    64(DI), R8
+	MOVQ    64(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 64(DI)
+	MOVQ    R9, 64(SI)
+	MOVQ    72(DI), R8
+	MOVQ    72(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 72(DI)
+	MOVQ    R9, 72(SI)
+	MOVQ    80(DI), R8
+	MOVQ    80(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 80(DI)
+	MOVQ    R9, 80(SI)
+	MOVQ    88(DI), R8
+	MOVQ    88(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 88(DI)
+	MOVQ    R9, 88(SI)
+	RET
+
+// func condNeg12(c *[12]uint64, a *[12]uint64, p *[12]uint64, cond uint64)
+TEXT ·condNeg12(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	ORQ  88(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	SBBQ DX, DX
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	MOVQ 40(SI), R8
+	ADDQ DX, DX
+	SBBQ 40(DI), R8
+	SBBQ DX, DX
+	MOVQ 40(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 40(BX)
+	MOVQ 48(SI), R8
+	ADDQ DX, DX
+	SBBQ 48(DI), R8
+	SBBQ DX, DX
+	MOVQ 48(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 48(BX)
+	MOVQ 56(SI), R8
+	ADDQ DX, DX
+	SBBQ 56(DI), R8
+	SBBQ DX, DX
+	MOVQ 56(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 56(BX)
+	MOVQ 64(SI), R8
+	ADDQ DX, DX
+	SBBQ 64(DI), R8
+	SBBQ DX, DX
+	MOVQ 64(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 64(BX)
+	MOVQ 72(SI), R8
+	ADDQ DX, DX
+	SBBQ 72(DI), R8
+	SBBQ DX, DX
+	MOVQ 72(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 72(BX)
+	MOVQ 80(SI), R8
+	ADDQ DX, DX
+	SBBQ 80(DI), R8
+	SBBQ DX, DX
+	MOVQ 80(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 80(BX)
+	MOVQ 88(SI), R8
+	ADDQ DX, DX
+	SBBQ 88(DI), R8
+	MOVQ 88(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 88(BX)
+	RET
+
+// func lookup12(dst *[12]uint64, table *[12]uint64, n uint64, idx uint64)
+TEXT ·lookup12(SB), NOSPLIT, $40-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	XORQ  R14, R14
+	XORQ  R15, R15
+	MOVQ  $0x00, (SP)
+	MOVQ  $0x00, 8(SP)
+	MOVQ  $0x00, 16(SP)
+	MOVQ  $0x00, 24(SP)
+	MOVQ  $0x00, 32(SP)
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	MOVQ 40(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R14
+	MOVQ 48(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R15
+	MOVQ 56(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, (SP)
+	MOVQ 64(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 8(SP)
+	MOVQ 72(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 16(SP)
+	MOVQ 80(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 24(SP)
+	MOVQ 88(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 32(SP)
+	ADDQ $0x00000060, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	MOVQ R14, 40(DI)
+	MOVQ R15, 48(DI)
+	MOVQ (SP), R9
+	MOVQ R9, 56(DI)
+	MOVQ 8(SP), R9
+	MOVQ R9, 64(DI)
+	MOVQ 16(SP), R9
+	MOVQ R9, 72(DI)
+	MOVQ 24(SP), R9
+	MOVQ R9, 80(DI)
+	MOVQ 32(SP), R9
+	MOVQ R9, 88(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_12(a *[12]uint64) uint64
 TEXT ·mul_two_12(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -33425,16 +36658,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, R9
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R9
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 168(SP), DI
 
 	// | w9 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -33539,16 +36775,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 176(SP), SI
 
 	// | w10 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -33653,16 +36892,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 184(SP), BX
 
 	// | w11 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -33767,16 +37009,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 192(SP), R13
 
 	// | w12 @ R13
-	ADCQ CX, R13
+	ADDQ CX, R13
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R13
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -33881,16 +37126,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R13
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 200(SP), R12
 
 	// | w13 @ R12
-	ADCQ CX, R12
+	ADDQ CX, R12
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R12
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -33995,16 +37243,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, R12
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R12
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 208(SP), R11
 
 	// | w14 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -34109,16 +37360,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 64(SP), R10
 
 	// | w15 @ R10
-	ADCQ CX, R10
+	ADDQ CX, R10
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R10
+	ADCQ $0x00, CX
 
 	// | 
 	// | W q1
@@ -34188,9 +37442,10 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	ADDQ AX, BX
 	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 
 	// | w12 @ R13
-	ADCQ DX, R13
+	ADDQ DX, R13
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
 
@@ -34247,13 +37502,16 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 88(R15), AX
 	MULQ 80(SP)
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R13
+	ADCQ $0x00, DX
 
 	// | w13 @ R12
-	ADCQ CX, R12
+	ADDQ CX, R12
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R12
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -34308,13 +37566,16 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 88(R15), AX
 	MULQ 88(SP)
 	ADDQ AX, R12
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R12
+	ADCQ $0x00, DX
 
 	// | w14 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -34368,15 +37629,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 88(R15), AX
 	MULQ 96(SP)
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R11
+	ADCQ $0x00, DX
 
 	// | w15 @ R10
-	ADCQ CX, R10
+	ADDQ CX, R10
+	MOVQ $0x00, CX
+	ADCQ $0x00, CX
+	ADDQ DX, R10
+	ADCQ $0x00, CX
 
 	// | bring the carry from q1
-	MOVQ 64(SP), CX
-	ADCQ $0x00, CX
+	ADDQ 64(SP), CX
 
 	// | 
 
@@ -34430,16 +37695,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 88(R15), AX
 	MULQ 104(SP)
 	ADDQ AX, R10
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R10
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 56(SP), R14
 
 	// | w16 @ R14
-	ADCQ CX, R14
+	ADDQ CX, R14
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R14
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -34493,16 +37761,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 88(R15), AX
 	MULQ 112(SP)
 	ADDQ AX, R14
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R14
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 48(SP), DI
 
 	// | w17 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -34556,16 +37827,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 88(R15), AX
 	MULQ 120(SP)
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 40(SP), SI
 
 	// | w18 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -34619,14 +37893,17 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 88(R15), AX
 	MULQ 128(SP)
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | tolarete this limb to stay in stack
 	// | w19 @ 32(SP)
-	ADCQ CX, 32(SP)
+	ADDQ CX, 32(SP)
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, 32(SP)
+	ADCQ $0x00, CX
 
 	// | 
 	// | q2
@@ -34870,16 +38147,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 56(R15), AX
 	MULQ DI
 	ADDQ AX, R14
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, R14
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 80(SP), CX
 
 	// | w17 @ CX
-	ADCQ R9, CX
+	ADDQ R9, CX
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, CX
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -34984,16 +38264,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 56(R15), AX
 	MULQ DI
 	ADDQ AX, CX
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, CX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 72(SP), SI
 
 	// | w18 @ SI
-	ADCQ R9, SI
+	ADDQ R9, SI
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, SI
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -35098,16 +38381,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 56(R15), AX
 	MULQ DI
 	ADDQ AX, SI
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 32(SP), BX
 
 	// | w19 @ BX
-	ADCQ R9, BX
+	ADDQ R9, BX
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, BX
+	ADCQ $0x00, R9
 
 	// | 
 	// | W q3
@@ -35117,7 +38403,7 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 
 	// | aggregate carries from q2 & q3
 	// | should be added to w20
-	ADCQ R9, 64(SP)
+	ADDQ R9, 64(SP)
 
 	// | 
 
@@ -35175,14 +38461,18 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 88(R15), AX
 	MULQ 88(SP)
 	ADDQ AX, BX
-	ADCQ 64(SP), DX
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 	MOVQ 24(SP), DI
 
 	// | w20 @ DI
-	ADCQ DX, DI
+	MOVQ 64(SP), R9
+	ADDQ R9, DI
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, DI
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -35237,14 +38527,17 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 88(R15), AX
 	MULQ 96(SP)
 	ADDQ AX, DI
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 	MOVQ 16(SP), CX
 
 	// | w21 @ CX
-	ADCQ R9, CX
+	ADDQ R9, CX
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, CX
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -35299,14 +38592,17 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 88(R15), AX
 	MULQ 80(SP)
 	ADDQ AX, CX
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, CX
+	ADCQ $0x00, DX
 	MOVQ 8(SP), SI
 
 	// | w22 @ SI
-	ADCQ R9, SI
+	ADDQ R9, SI
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, SI
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -35360,16 +38656,19 @@ TEXT ·mul_no_adx_bmi2_12(SB), NOSPLIT, $216-40
 	MOVQ 88(R15), AX
 	MULQ 72(SP)
 	ADDQ AX, SI
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | very last limb goes to short carry register
 	MOVQ (SP), R8
 
 	// | w-1 @ R8
-	ADCQ R9, R8
+	ADDQ R9, R8
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, R8
+	ADCQ $0x00, R9
 
 	// | 
 	// | W q4
@@ -35733,6 +39032,7 @@ TEXT ·addn13(SB), NOSPLIT, $24-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -36084,6 +39384,23 @@ TEXT ·_neg13(SB), NOSPLIT, $24-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	ORQ  88(DI), AX
+	ORQ  96(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -36118,20 +39435,33 @@ TEXT ·_neg13(SB), NOSPLIT, $24-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
+	ANDQ AX, R11
 	MOVQ R11, 40(DI)
+	ANDQ AX, R12
 	MOVQ R12, 48(DI)
+	ANDQ AX, R13
 	MOVQ R13, 56(DI)
+	ANDQ AX, R14
 	MOVQ R14, 64(DI)
+	ANDQ AX, R15
 	MOVQ R15, 72(DI)
+	ANDQ AX, (SP)
 	MOVQ (SP), BX
 	MOVQ BX, 80(DI)
+	ANDQ AX, 8(SP)
 	MOVQ 8(SP), BX
 	MOVQ BX, 88(DI)
+	ANDQ AX, 16(SP)
 	MOVQ 16(SP), BX
 	MOVQ BX, 96(DI)
 	RET
@@ -36142,6 +39472,405 @@ TEXT ·_neg13(SB), NOSPLIT, $24-24
 
 	RET
 
+// func cmov13(c *[13]uint64, a *[13]uint64, b *[13]uint64, cond uint64)
+TEXT ·cmov13(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	MOVQ    40(DI), R8
+	CMOVQCS 40(SI), R8
+	MOVQ    R8, 40(BX)
+	MOVQ    48(DI), R8
+	CMOVQCS 48(SI), R8
+	MOVQ    R8, 48(BX)
+	MOVQ    56(DI), R8
+	CMOVQCS 56(SI), R8
+	MOVQ    R8, 56(BX)
+	MOVQ    64(DI), R8
+	CMOVQCS 64(SI), R8
+	MOVQ    R8, 64(BX)
+	MOVQ    72(DI), R8
+	CMOVQCS 72(SI), R8
+	MOVQ    R8, 72(BX)
+	MOVQ    80(DI), R8
+	CMOVQCS 80(SI), R8
+	MOVQ    R8, 80(BX)
+	MOVQ    88(DI), R8
+	CMOVQCS 88(SI), R8
+	MOVQ    R8, 88(BX)
+	MOVQ    96(DI), R8
+	CMOVQCS 96(SI), R8
+	MOVQ    R8, 96(BX)
+	RET
+
+// func cswap13(a *[13]uint64, b *[13]uint64, cond uint64)
+TEXT ·cswap13(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	MOVQ    40(DI), R8
+	MOVQ    40(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 40(DI)
+	MOVQ    R9, 40(SI)
+	MOVQ    48(DI), R8
+	MOVQ    48(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 48(DI)
+	MOVQ    R9, 48(SI)
+	MOVQ    56(DI), R8
+	MOVQ    56(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 56(DI)
+	MOVQ    R9, 56(SI)
+	MOVQ    64(DI), R8
+	MOVQ    64(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 64(DI)
+	MOVQ    R9, 64(SI)
+	MOVQ    72(DI), R8
+	MOVQ    72(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 72(DI)
+	MOVQ    R9, 72(SI)
+	MOVQ    80(DI), R8
+	MOVQ    80(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 80(DI)
+	MOVQ    R9, 80(SI)
+	MOVQ    88(DI), R8
+	MOVQ    88(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 88(DI)
+	MOVQ    R9, 88(SI)
+	MOVQ    96(DI), R8
+	MOVQ    96(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 96(DI)
+	MOVQ    R9, 96(SI)
+	RET
+
+// func condNeg13(c *[13]uint64, a *[13]uint64, p *[13]uint64, cond uint64)
+TEXT ·condNeg13(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	ORQ  88(DI), AX
+	ORQ  96(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	SBBQ DX, DX
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	MOVQ 40(SI), R8
+	ADDQ DX, DX
+	SBBQ 40(DI), R8
+	SBBQ DX, DX
+	MOVQ 40(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 40(BX)
+	MOVQ 48(SI), R8
+	ADDQ DX, DX
+	SBBQ 48(DI), R8
+	SBBQ DX, DX
+	MOVQ 48(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 48(BX)
+	MOVQ 56(SI), R8
+	ADDQ DX, DX
+	SBBQ 56(DI), R8
+	SBBQ DX, DX
+	MOVQ 56(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 56(BX)
+	MOVQ 64(SI), R8
+	ADDQ DX, DX
+	SBBQ 64(DI), R8
+	SBBQ DX, DX
+	MOVQ 64(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 64(BX)
+	MOVQ 72(SI), R8
+	ADDQ DX, DX
+	SBBQ 72(DI), R8
+	SBBQ DX, DX
+	MOVQ 72(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 72(BX)
+	MOVQ 80(SI), R8
+	ADDQ DX, DX
+	SBBQ 80(DI), R8
+	SBBQ DX, DX
+	MOVQ 80(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 80(BX)
+	MOVQ 88(SI), R8
+	ADDQ DX, DX
+	SBBQ 88(DI), R8
+	SBBQ DX, DX
+	MOVQ 88(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 88(BX)
+	MOVQ 96(SI), R8
+	ADDQ DX, DX
+	SBBQ 96(DI), R8
+	MOVQ 96(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 96(BX)
+	RET
+
+// func lookup13(dst *[13]uint64, table *[13]uint64, n uint64, idx uint64)
+TEXT ·lookup13(SB), NOSPLIT, $48-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	XORQ  R14, R14
+	XORQ  R15, R15
+	MOVQ  $0x00, (SP)
+	MOVQ  $0x00, 8(SP)
+	MOVQ  $0x00, 16(SP)
+	MOVQ  $0x00, 24(SP)
+	MOVQ  $0x00, 32(SP)
+	MOVQ  $0x00, 40(SP)
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	MOVQ 40(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R14
+	MOVQ 48(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R15
+	MOVQ 56(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, (SP)
+	MOVQ 64(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 8(SP)
+	MOVQ 72(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 16(SP)
+	MOVQ 80(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 24(SP)
+	MOVQ 88(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 32(SP)
+	MOVQ 96(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 40(SP)
+	ADDQ $0x00000068, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	MOVQ R14, 40(DI)
+	MOVQ R15, 48(DI)
+	MOVQ (SP), R9
+	MOVQ R9, 56(DI)
+	MOVQ 8(SP), R9
+	MOVQ R9, 64(DI)
+	MOVQ 16(SP), R9
+	MOVQ R9, 72(DI)
+	MOVQ 24(SP), R9
+	MOVQ R9, 80(DI)
+	MOVQ 32(SP), R9
+	MOVQ R9, 88(DI)
+	MOVQ 40(SP), R9
+	MOVQ R9, 96(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_13(a *[13]uint64) uint64
 TEXT ·mul_two_13(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -41480,16 +45209,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 176(SP), DI
 
 	// | w9 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -41594,16 +45326,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 184(SP), SI
 
 	// | w10 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -41708,16 +45443,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 192(SP), BX
 
 	// | w11 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -41822,16 +45560,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 200(SP), R15
 
 	// | w12 @ R15
-	ADCQ CX, R15
+	ADDQ CX, R15
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R15
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -41936,16 +45677,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R15
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 208(SP), R14
 
 	// | w13 @ R14
-	ADCQ CX, R14
+	ADDQ CX, R14
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R14
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -42050,16 +45794,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R14
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R14
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 216(SP), R13
 
 	// | w14 @ R13
-	ADCQ CX, R13
+	ADDQ CX, R13
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R13
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -42164,16 +45911,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 56(R10), AX
 	MULQ R9
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R13
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 224(SP), R12
 
 	// | w15 @ R12
-	ADCQ CX, R12
+	ADDQ CX, R12
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R12
+	ADCQ $0x00, CX
 
 	// | 
 	// | W q1
@@ -42254,9 +46004,10 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	ADDQ AX, R15
 	ADCQ $0x00, DX
 	ADDQ R8, R15
+	ADCQ $0x00, DX
 
 	// | w13 @ R14
-	ADCQ DX, R14
+	ADDQ DX, R14
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
 
@@ -42324,13 +46075,16 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 80(SP)
 	ADDQ AX, R14
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R14
+	ADCQ $0x00, DX
 
 	// | w14 @ R13
-	ADCQ CX, R13
+	ADDQ CX, R13
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R13
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -42396,15 +46150,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 88(SP)
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R13
+	ADCQ $0x00, DX
 
 	// | w15 @ R12
-	ADCQ CX, R12
+	ADDQ CX, R12
+	MOVQ $0x00, CX
+	ADCQ $0x00, CX
+	ADDQ DX, R12
+	ADCQ $0x00, CX
 
 	// | bring the carry from q1
-	MOVQ 136(SP), CX
-	ADCQ $0x00, CX
+	ADDQ 136(SP), CX
 
 	// | 
 
@@ -42470,16 +46228,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 96(SP)
 	ADDQ AX, R12
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R12
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 232(SP), R9
 
 	// | w16 @ R9
-	ADCQ CX, R9
+	ADDQ CX, R9
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R9
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -42544,16 +46305,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 104(SP)
 	ADDQ AX, R9
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R9
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 64(SP), BX
 
 	// | w17 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -42618,16 +46382,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 112(SP)
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 56(SP), DI
 
 	// | w18 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -42692,16 +46459,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 120(SP)
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 48(SP), SI
 
 	// | w19 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -42766,14 +46536,17 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 128(SP)
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | tolarete this limb to stay in stack
 	// | w20 @ 40(SP)
-	ADCQ CX, 40(SP)
+	ADDQ CX, 40(SP)
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, 40(SP)
+	ADCQ $0x00, CX
 
 	// | 
 	// | q2
@@ -43019,16 +46792,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 56(R10), AX
 	MULQ BX
 	ADDQ AX, R9
-	ADCQ DX, R11
+	ADCQ $0x00, DX
 	ADDQ R8, R9
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 88(SP), CX
 
 	// | w17 @ CX
-	ADCQ R11, CX
+	ADDQ R11, CX
 	MOVQ $0x00, R11
 	ADCQ $0x00, R11
+	ADDQ DX, CX
+	ADCQ $0x00, R11
 
 	// | 
 
@@ -43133,16 +46909,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 56(R10), AX
 	MULQ BX
 	ADDQ AX, CX
-	ADCQ DX, R11
+	ADCQ $0x00, DX
 	ADDQ R8, CX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 80(SP), SI
 
 	// | w18 @ SI
-	ADCQ R11, SI
+	ADDQ R11, SI
 	MOVQ $0x00, R11
 	ADCQ $0x00, R11
+	ADDQ DX, SI
+	ADCQ $0x00, R11
 
 	// | 
 
@@ -43247,16 +47026,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 56(R10), AX
 	MULQ BX
 	ADDQ AX, SI
-	ADCQ DX, R11
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 72(SP), DI
 
 	// | w19 @ DI
-	ADCQ R11, DI
+	ADDQ R11, DI
 	MOVQ $0x00, R11
 	ADCQ $0x00, R11
+	ADDQ DX, DI
+	ADCQ $0x00, R11
 
 	// | 
 
@@ -43361,16 +47143,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 56(R10), AX
 	MULQ BX
 	ADDQ AX, DI
-	ADCQ DX, R11
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 40(SP), R15
 
 	// | w20 @ R15
-	ADCQ R11, R15
+	ADDQ R11, R15
 	MOVQ $0x00, R11
 	ADCQ $0x00, R11
+	ADDQ DX, R15
+	ADCQ $0x00, R11
 
 	// | 
 	// | W q3
@@ -43380,7 +47165,7 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 
 	// | aggregate carries from q2 & q3
 	// | should be added to w21
-	ADCQ R11, 136(SP)
+	ADDQ R11, 136(SP)
 
 	// | 
 
@@ -43449,14 +47234,18 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 96(SP)
 	ADDQ AX, R15
-	ADCQ 136(SP), DX
+	ADCQ $0x00, DX
 	ADDQ R8, R15
+	ADCQ $0x00, DX
 	MOVQ 32(SP), BX
 
 	// | w21 @ BX
-	ADCQ DX, BX
+	MOVQ 136(SP), R11
+	ADDQ R11, BX
 	MOVQ $0x00, R11
 	ADCQ $0x00, R11
+	ADDQ DX, BX
+	ADCQ $0x00, R11
 
 	// | 
 
@@ -43522,14 +47311,17 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 104(SP)
 	ADDQ AX, BX
-	ADCQ DX, R11
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 	MOVQ 24(SP), CX
 
 	// | w22 @ CX
-	ADCQ R11, CX
+	ADDQ R11, CX
 	MOVQ $0x00, R11
 	ADCQ $0x00, R11
+	ADDQ DX, CX
+	ADCQ $0x00, R11
 
 	// | 
 
@@ -43595,14 +47387,17 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 88(SP)
 	ADDQ AX, CX
-	ADCQ DX, R11
+	ADCQ $0x00, DX
 	ADDQ R8, CX
+	ADCQ $0x00, DX
 	MOVQ 16(SP), SI
 
 	// | w23 @ SI
-	ADCQ R11, SI
+	ADDQ R11, SI
 	MOVQ $0x00, R11
 	ADCQ $0x00, R11
+	ADDQ DX, SI
+	ADCQ $0x00, R11
 
 	// | 
 
@@ -43668,14 +47463,17 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 80(SP)
 	ADDQ AX, SI
-	ADCQ DX, R11
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 	MOVQ 8(SP), DI
 
 	// | w24 @ DI
-	ADCQ R11, DI
+	ADDQ R11, DI
 	MOVQ $0x00, R11
 	ADCQ $0x00, R11
+	ADDQ DX, DI
+	ADCQ $0x00, R11
 
 	// | 
 
@@ -43740,16 +47538,19 @@ TEXT ·mul_no_adx_bmi2_13(SB), NOSPLIT, $240-40
 	MOVQ 96(R10), AX
 	MULQ 72(SP)
 	ADDQ AX, DI
-	ADCQ DX, R11
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 
 	// | very last limb goes to short carry register
 	MOVQ (SP), R8
 
 	// | w-1 @ R8
-	ADCQ R11, R8
+	ADDQ R11, R8
 	MOVQ $0x00, R11
 	ADCQ $0x00, R11
+	ADDQ DX, R8
+	ADCQ $0x00, R11
 
 	// | 
 	// | W q4
@@ -44137,6 +47938,7 @@ TEXT ·addn14(SB), NOSPLIT, $32-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -44515,6 +48317,24 @@ TEXT ·_neg14(SB), NOSPLIT, $32-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	ORQ  88(DI), AX
+	ORQ  96(DI), AX
+	ORQ  104(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -44552,22 +48372,36 @@ TEXT ·_neg14(SB), NOSPLIT, $32-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
+	ANDQ AX, R11
 	MOVQ R11, 40(DI)
+	ANDQ AX, R12
 	MOVQ R12, 48(DI)
+	ANDQ AX, R13
 	MOVQ R13, 56(DI)
+	ANDQ AX, R14
 	MOVQ R14, 64(DI)
+	ANDQ AX, R15
 	MOVQ R15, 72(DI)
+	ANDQ AX, (SP)
 	MOVQ (SP), BX
 	MOVQ BX, 80(DI)
+	ANDQ AX, 8(SP)
 	MOVQ 8(SP), BX
 	MOVQ BX, 88(DI)
+	ANDQ AX, 16(SP)
 	MOVQ 16(SP), BX
 	MOVQ BX, 96(DI)
+	ANDQ AX, 24(SP)
 	MOVQ 24(SP), BX
 	MOVQ BX, 104(DI)
 	RET
@@ -44578,6 +48412,431 @@ TEXT ·_neg14(SB), NOSPLIT, $32-24
 
 	RET
 
+// func cmov14(c *[14]uint64, a *[14]uint64, b *[14]uint64, cond uint64)
+TEXT ·cmov14(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	MOVQ    40(DI), R8
+	CMOVQCS 40(SI), R8
+	MOVQ    R8, 40(BX)
+	MOVQ    48(DI), R8
+	CMOVQCS 48(SI), R8
+	MOVQ    R8, 48(BX)
+	MOVQ    56(DI), R8
+	CMOVQCS 56(SI), R8
+	MOVQ    R8, 56(BX)
+	MOVQ    64(DI), R8
+	CMOVQCS 64(SI), R8
+	MOVQ    R8, 64(BX)
+	MOVQ    72(DI), R8
+	CMOVQCS 72(SI), R8
+	MOVQ    R8, 72(BX)
+	MOVQ    80(DI), R8
+	CMOVQCS 80(SI), R8
+	MOVQ    R8, 80(BX)
+	MOVQ    88(DI), R8
+	CMOVQCS 88(SI), R8
+	MOVQ    R8, 88(BX)
+	MOVQ    96(DI), R8
+	CMOVQCS 96(SI), R8
+	MOVQ    R8, 96(BX)
+	MOVQ    104(DI), R8
+	CMOVQCS 104(SI), R8
+	MOVQ    R8, 104(BX)
+	RET
+
+// func cswap14(a *[14]uint64, b *[14]uint64, cond uint64)
+TEXT ·cswap14(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	MOVQ    40(DI), R8
+	MOVQ    40(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 40(DI)
+	MOVQ    R9, 40(SI)
+	MOVQ    48(DI), R8
+	MOVQ    48(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 48(DI)
+	MOVQ    R9, 48(SI)
+	MOVQ    56(DI), R8
+	MOVQ    56(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 56(DI)
+	MOVQ    R9, 56(SI)
+	MOVQ    64(DI), R8
+	MOVQ    64(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 64(DI)
+	MOVQ    R9, 64(SI)
+	MOVQ    72(DI), R8
+	MOVQ    72(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 72(DI)
+	MOVQ    R9, 72(SI)
+	MOVQ    80(DI), R8
+	MOVQ    80(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 80(DI)
+	MOVQ    R9, 80(SI)
+	MOVQ    88(DI), R8
+	MOVQ    88(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 88(DI)
+	MOVQ    R9, 88(SI)
+	MOVQ    96(DI), R8
+	MOVQ    96(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 96(DI)
+	MOVQ    R9, 96(SI)
+	MOVQ    104(DI), R8
+	MOVQ    104(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 104(DI)
+	MOVQ    R9, 104(SI)
+	RET
+
+// func condNeg14(c *[14]uint64, a *[14]uint64, p *[14]uint64, cond uint64)
+TEXT ·condNeg14(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	ORQ  88(DI), AX
+	ORQ  96(DI), AX
+	ORQ  104(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	SBBQ DX, DX
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	MOVQ 40(SI), R8
+	ADDQ DX, DX
+	SBBQ 40(DI), R8
+	SBBQ DX, DX
+	MOVQ 40(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 40(BX)
+	MOVQ 48(SI), R8
+	ADDQ DX, DX
+	SBBQ 48(DI), R8
+	SBBQ DX, DX
+	MOVQ 48(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 48(BX)
+	MOVQ 56(SI), R8
+	ADDQ DX, DX
+	SBBQ 56(DI), R8
+	SBBQ DX, DX
+	MOVQ 56(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 56(BX)
+	MOVQ 64(SI), R8
+	ADDQ DX, DX
+	SBBQ 64(DI), R8
+	SBBQ DX, DX
+	MOVQ 64(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 64(BX)
+	MOVQ 72(SI), R8
+	ADDQ DX, DX
+	SBBQ 72(DI), R8
+	SBBQ DX, DX
+	MOVQ 72(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 72(BX)
+	MOVQ 80(SI), R8
+	ADDQ DX, DX
+	SBBQ 80(DI), R8
+	SBBQ DX, DX
+	MOVQ 80(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 80(BX)
+	MOVQ 88(SI), R8
+	ADDQ DX, DX
+	SBBQ 88(DI), R8
+	SBBQ DX, DX
+	MOVQ 88(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 88(BX)
+	MOVQ 96(SI), R8
+	ADDQ DX, DX
+	SBBQ 96(DI), R8
+	SBBQ DX, DX
+	MOVQ 96(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 96(BX)
+	MOVQ 104(SI), R8
+	ADDQ DX, DX
+	SBBQ 104(DI), R8
+	MOVQ 104(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 104(BX)
+	RET
+
+// func lookup14(dst *[14]uint64, table *[14]uint64, n uint64, idx uint64)
+TEXT ·lookup14(SB), NOSPLIT, $56-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	XORQ  R14, R14
+	XORQ  R15, R15
+	MOVQ  $0x00, (SP)
+	MOVQ  $0x00, 8(SP)
+	MOVQ  $0x00, 16(SP)
+	MOVQ  $0x00, 24(SP)
+	MOVQ  $0x00, 32(SP)
+	MOVQ  $0x00, 40(SP)
+	MOVQ  $0x00, 48(SP)
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	MOVQ 40(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R14
+	MOVQ 48(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R15
+	MOVQ 56(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, (SP)
+	MOVQ 64(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 8(SP)
+	MOVQ 72(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 16(SP)
+	MOVQ 80(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 24(SP)
+	MOVQ 88(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 32(SP)
+	MOVQ 96(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 40(SP)
+	MOVQ 104(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 48(SP)
+	ADDQ $0x00000070, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	MOVQ R14, 40(DI)
+	MOVQ R15, 48(DI)
+	MOVQ (SP), R9
+	MOVQ R9, 56(DI)
+	MOVQ 8(SP), R9
+	MOVQ R9, 64(DI)
+	MOVQ 16(SP), R9
+	MOVQ R9, 72(DI)
+	MOVQ 24(SP), R9
+	MOVQ R9, 80(DI)
+	MOVQ 32(SP), R9
+	MOVQ R9, 88(DI)
+	MOVQ 40(SP), R9
+	MOVQ R9, 96(DI)
+	MOVQ 48(SP), R9
+	MOVQ R9, 104(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_14(a *[14]uint64) uint64
 TEXT ·mul_two_14(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -50601,16 +54860,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R13
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 184(SP), DI
 
 	// | w9 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -50715,16 +54977,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 192(SP), SI
 
 	// | w10 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -50829,16 +55094,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 200(SP), BX
 
 	// | w11 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -50943,16 +55211,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 208(SP), R9
 
 	// | w12 @ R9
-	ADCQ CX, R9
+	ADDQ CX, R9
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R9
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -51057,16 +55328,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, R9
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R9
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 216(SP), R8
 
 	// | w13 @ R8
-	ADCQ CX, R8
+	ADDQ CX, R8
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R8
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -51171,16 +55445,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, R8
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R8
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 224(SP), R15
 
 	// | w14 @ R15
-	ADCQ CX, R15
+	ADDQ CX, R15
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R15
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -51285,16 +55562,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ R11
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R15
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 232(SP), R14
 
 	// | w15 @ R14
-	ADCQ CX, R14
+	ADDQ CX, R14
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R14
+	ADCQ $0x00, CX
 
 	// | 
 	// | W q1
@@ -51386,9 +55666,10 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	ADDQ AX, R8
 	ADCQ $0x00, DX
 	ADDQ R10, R8
+	ADCQ $0x00, DX
 
 	// | w14 @ R15
-	ADCQ DX, R15
+	ADDQ DX, R15
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
 
@@ -51467,15 +55748,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 80(SP)
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R15
+	ADCQ $0x00, DX
 
 	// | w15 @ R14
-	ADCQ CX, R14
+	ADDQ CX, R14
+	MOVQ $0x00, CX
+	ADCQ $0x00, CX
+	ADDQ DX, R14
+	ADCQ $0x00, CX
 
 	// | bring the carry from q1
-	MOVQ 136(SP), CX
-	ADCQ $0x00, CX
+	ADDQ 136(SP), CX
 
 	// | 
 
@@ -51552,16 +55837,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 88(SP)
 	ADDQ AX, R14
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R14
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 240(SP), R11
 
 	// | w16 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -51638,16 +55926,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 96(SP)
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R11
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 248(SP), BX
 
 	// | w17 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -51724,16 +56015,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 104(SP)
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, BX
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 256(SP), DI
 
 	// | w18 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -51809,16 +56103,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 112(SP)
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, DI
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 64(SP), SI
 
 	// | w19 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -51894,16 +56191,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 120(SP)
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, SI
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 56(SP), R9
 
 	// | w20 @ R9
-	ADCQ CX, R9
+	ADDQ CX, R9
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R9
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -51979,14 +56279,17 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 128(SP)
 	ADDQ AX, R9
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R10, R9
+	ADCQ $0x00, DX
 
 	// | tolarete this limb to stay in stack
 	// | w21 @ 48(SP)
-	ADCQ CX, 48(SP)
+	ADDQ CX, 48(SP)
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, 48(SP)
+	ADCQ $0x00, CX
 
 	// | 
 	// | q2
@@ -52234,16 +56537,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ BX
 	ADDQ AX, R11
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 96(SP), CX
 
 	// | w17 @ CX
-	ADCQ R13, CX
+	ADDQ R13, CX
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, CX
+	ADCQ $0x00, R13
 
 	// | 
 
@@ -52348,16 +56654,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ BX
 	ADDQ AX, CX
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, CX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 88(SP), R9
 
 	// | w18 @ R9
-	ADCQ R13, R9
+	ADDQ R13, R9
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, R9
+	ADCQ $0x00, R13
 
 	// | 
 
@@ -52462,16 +56771,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ BX
 	ADDQ AX, R9
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, R9
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 80(SP), SI
 
 	// | w19 @ SI
-	ADCQ R13, SI
+	ADDQ R13, SI
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, SI
+	ADCQ $0x00, R13
 
 	// | 
 
@@ -52576,16 +56888,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ BX
 	ADDQ AX, SI
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 72(SP), DI
 
 	// | w20 @ DI
-	ADCQ R13, DI
+	ADDQ R13, DI
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, DI
+	ADCQ $0x00, R13
 
 	// | 
 
@@ -52690,16 +57005,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 56(R12), AX
 	MULQ BX
 	ADDQ AX, DI
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 48(SP), R8
 
 	// | w21 @ R8
-	ADCQ R13, R8
+	ADDQ R13, R8
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, R8
+	ADCQ $0x00, R13
 
 	// | 
 	// | W q3
@@ -52709,7 +57027,7 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 
 	// | aggregate carries from q2 & q3
 	// | should be added to w22
-	ADCQ R13, 136(SP)
+	ADDQ R13, 136(SP)
 
 	// | 
 
@@ -52789,14 +57107,18 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 104(SP)
 	ADDQ AX, R8
-	ADCQ 136(SP), DX
+	ADCQ $0x00, DX
 	ADDQ R10, R8
+	ADCQ $0x00, DX
 	MOVQ 40(SP), BX
 
 	// | w22 @ BX
-	ADCQ DX, BX
+	MOVQ 136(SP), R13
+	ADDQ R13, BX
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, BX
+	ADCQ $0x00, R13
 
 	// | 
 
@@ -52873,14 +57195,17 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 112(SP)
 	ADDQ AX, BX
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, BX
+	ADCQ $0x00, DX
 	MOVQ 32(SP), CX
 
 	// | w23 @ CX
-	ADCQ R13, CX
+	ADDQ R13, CX
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, CX
+	ADCQ $0x00, R13
 
 	// | 
 
@@ -52957,14 +57282,17 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 96(SP)
 	ADDQ AX, CX
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, CX
+	ADCQ $0x00, DX
 	MOVQ 24(SP), R9
 
 	// | w24 @ R9
-	ADCQ R13, R9
+	ADDQ R13, R9
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, R9
+	ADCQ $0x00, R13
 
 	// | 
 
@@ -53041,14 +57369,17 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 88(SP)
 	ADDQ AX, R9
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, R9
+	ADCQ $0x00, DX
 	MOVQ 16(SP), SI
 
 	// | w25 @ SI
-	ADCQ R13, SI
+	ADDQ R13, SI
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, SI
+	ADCQ $0x00, R13
 
 	// | 
 
@@ -53125,14 +57456,17 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 80(SP)
 	ADDQ AX, SI
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, SI
+	ADCQ $0x00, DX
 	MOVQ 8(SP), DI
 
 	// | w26 @ DI
-	ADCQ R13, DI
+	ADDQ R13, DI
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, DI
+	ADCQ $0x00, R13
 
 	// | 
 
@@ -53208,16 +57542,19 @@ TEXT ·mul_no_adx_bmi2_14(SB), NOSPLIT, $264-40
 	MOVQ 104(R12), AX
 	MULQ 72(SP)
 	ADDQ AX, DI
-	ADCQ DX, R13
+	ADCQ $0x00, DX
 	ADDQ R10, DI
+	ADCQ $0x00, DX
 
 	// | very last limb goes to short carry register
 	MOVQ (SP), R10
 
 	// | w-1 @ R10
-	ADCQ R13, R10
+	ADDQ R13, R10
 	MOVQ $0x00, R13
 	ADCQ $0x00, R13
+	ADDQ DX, R10
+	ADCQ $0x00, R13
 
 	// | 
 	// | W q4
@@ -53629,6 +57966,7 @@ TEXT ·addn15(SB), NOSPLIT, $40-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -54034,6 +58372,25 @@ TEXT ·_neg15(SB), NOSPLIT, $40-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	ORQ  88(DI), AX
+	ORQ  96(DI), AX
+	ORQ  104(DI), AX
+	ORQ  112(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -54074,24 +58431,39 @@ TEXT ·_neg15(SB), NOSPLIT, $40-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
+	ANDQ AX, R11
 	MOVQ R11, 40(DI)
+	ANDQ AX, R12
 	MOVQ R12, 48(DI)
+	ANDQ AX, R13
 	MOVQ R13, 56(DI)
+	ANDQ AX, R14
 	MOVQ R14, 64(DI)
+	ANDQ AX, R15
 	MOVQ R15, 72(DI)
+	ANDQ AX, (SP)
 	MOVQ (SP), BX
 	MOVQ BX, 80(DI)
+	ANDQ AX, 8(SP)
 	MOVQ 8(SP), BX
 	MOVQ BX, 88(DI)
+	ANDQ AX, 16(SP)
 	MOVQ 16(SP), BX
 	MOVQ BX, 96(DI)
+	ANDQ AX, 24(SP)
 	MOVQ 24(SP), BX
 	MOVQ BX, 104(DI)
+	ANDQ AX, 32(SP)
 	MOVQ 32(SP), BX
 	MOVQ BX, 112(DI)
 	RET
@@ -54102,6 +58474,457 @@ TEXT ·_neg15(SB), NOSPLIT, $40-24
 
 	RET
 
+// func cmov15(c *[15]uint64, a *[15]uint64, b *[15]uint64, cond uint64)
+TEXT ·cmov15(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	MOVQ    40(DI), R8
+	CMOVQCS 40(SI), R8
+	MOVQ    R8, 40(BX)
+	MOVQ    48(DI), R8
+	CMOVQCS 48(SI), R8
+	MOVQ    R8, 48(BX)
+	MOVQ    56(DI), R8
+	CMOVQCS 56(SI), R8
+	MOVQ    R8, 56(BX)
+	MOVQ    64(DI), R8
+	CMOVQCS 64(SI), R8
+	MOVQ    R8, 64(BX)
+	MOVQ    72(DI), R8
+	CMOVQCS 72(SI), R8
+	MOVQ    R8, 72(BX)
+	MOVQ    80(DI), R8
+	CMOVQCS 80(SI), R8
+	MOVQ    R8, 80(BX)
+	MOVQ    88(DI), R8
+	CMOVQCS 88(SI), R8
+	MOVQ    R8, 88(BX)
+	MOVQ    96(DI), R8
+	CMOVQCS 96(SI), R8
+	MOVQ    R8, 96(BX)
+	MOVQ    104(DI), R8
+	CMOVQCS 104(SI), R8
+	MOVQ    R8, 104(BX)
+	MOVQ    112(DI), R8
+	CMOVQCS 112(SI), R8
+	MOVQ    R8, 112(BX)
+	RET
+
+// func cswap15(a *[15]uint64, b *[15]uint64, cond uint64)
+TEXT ·cswap15(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	MOVQ    40(DI), R8
+	MOVQ    40(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 40(DI)
+	MOVQ    R9, 40(SI)
+	MOVQ    48(DI), R8
+	MOVQ    48(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 48(DI)
+	MOVQ    R9, 48(SI)
+	MOVQ    56(DI), R8
+	MOVQ    56(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 56(DI)
+	MOVQ    R9, 56(SI)
+	MOVQ    64(DI), R8
+	MOVQ    64(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 64(DI)
+	MOVQ    R9, 64(SI)
+	MOVQ    72(DI), R8
+	MOVQ    72(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 72(DI)
+	MOVQ    R9, 72(SI)
+	MOVQ    80(DI), R8
+	MOVQ    80(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 80(DI)
+	MOVQ    R9, 80(SI)
+	MOVQ    88(DI), R8
+	MOVQ    88(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 88(DI)
+	MOVQ    R9, 88(SI)
+	MOVQ    96(DI), R8
+	MOVQ    96(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 96(DI)
+	MOVQ    R9, 96(SI)
+	MOVQ    104(DI), R8
+	MOVQ    104(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 104(DI)
+	MOVQ    R9, 104(SI)
+	MOVQ    112(DI), R8
+	MOVQ    112(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 112(DI)
+	MOVQ    R9, 112(SI)
+	RET
+
+// func condNeg15(c *[15]uint64, a *[15]uint64, p *[15]uint64, cond uint64)
+TEXT ·condNeg15(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	ORQ  88(DI), AX
+	ORQ  96(DI), AX
+	ORQ  104(DI), AX
+	ORQ  112(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	SBBQ DX, DX
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	MOVQ 40(SI), R8
+	ADDQ DX, DX
+	SBBQ 40(DI), R8
+	SBBQ DX, DX
+	MOVQ 40(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 40(BX)
+	MOVQ 48(SI), R8
+	ADDQ DX, DX
+	SBBQ 48(DI), R8
+	SBBQ DX, DX
+	MOVQ 48(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 48(BX)
+	MOVQ 56(SI), R8
+	ADDQ DX, DX
+	SBBQ 56(DI), R8
+	SBBQ DX, DX
+	MOVQ 56(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 56(BX)
+	MOVQ 64(SI), R8
+	ADDQ DX, DX
+	SBBQ 64(DI), R8
+	SBBQ DX, DX
+	MOVQ 64(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 64(BX)
+	MOVQ 72(SI), R8
+	ADDQ DX, DX
+	SBBQ 72(DI), R8
+	SBBQ DX, DX
+	MOVQ 72(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 72(BX)
+	MOVQ 80(SI), R8
+	ADDQ DX, DX
+	SBBQ 80(DI), R8
+	SBBQ DX, DX
+	MOVQ 80(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 80(BX)
+	MOVQ 88(SI), R8
+	ADDQ DX, DX
+	SBBQ 88(DI), R8
+	SBBQ DX, DX
+	MOVQ 88(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 88(BX)
+	MOVQ 96(SI), R8
+	ADDQ DX, DX
+	SBBQ 96(DI), R8
+	SBBQ DX, DX
+	MOVQ 96(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 96(BX)
+	MOVQ 104(SI), R8
+	ADDQ DX, DX
+	SBBQ 104(DI), R8
+	SBBQ DX, DX
+	MOVQ 104(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 104(BX)
+	MOVQ 112(SI), R8
+	ADDQ DX, DX
+	SBBQ 112(DI), R8
+	MOVQ 112(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 112(BX)
+	RET
+
+// func lookup15(dst *[15]uint64, table *[15]uint64, n uint64, idx uint64)
+TEXT ·lookup15(SB), NOSPLIT, $64-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	XORQ  R14, R14
+	XORQ  R15, R15
+	MOVQ  $0x00, (SP)
+	MOVQ  $0x00, 8(SP)
+	MOVQ  $0x00, 16(SP)
+	MOVQ  $0x00, 24(SP)
+	MOVQ  $0x00, 32(SP)
+	MOVQ  $0x00, 40(SP)
+	MOVQ  $0x00, 48(SP)
+	MOVQ  $0x00, 56(SP)
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	MOVQ 40(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R14
+	MOVQ 48(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R15
+	MOVQ 56(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, (SP)
+	MOVQ 64(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 8(SP)
+	MOVQ 72(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 16(SP)
+	MOVQ 80(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 24(SP)
+	MOVQ 88(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 32(SP)
+	MOVQ 96(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 40(SP)
+	MOVQ 104(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 48(SP)
+	MOVQ 112(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 56(SP)
+	ADDQ $0x00000078, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	MOVQ R14, 40(DI)
+	MOVQ R15, 48(DI)
+	MOVQ (SP), R9
+	MOVQ R9, 56(DI)
+	MOVQ 8(SP), R9
+	MOVQ R9, 64(DI)
+	MOVQ 16(SP), R9
+	MOVQ R9, 72(DI)
+	MOVQ 24(SP), R9
+	MOVQ R9, 80(DI)
+	MOVQ 32(SP), R9
+	MOVQ R9, 88(DI)
+	MOVQ 40(SP), R9
+	MOVQ R9, 96(DI)
+	MOVQ 48(SP), R9
+	MOVQ R9, 104(DI)
+	MOVQ 56(SP), R9
+	MOVQ R9, 112(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_15(a *[15]uint64) uint64
 TEXT ·mul_two_15(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -60852,16 +65675,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, R15
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R15
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 192(SP), DI
 
 	// | w9 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -60966,16 +65792,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 200(SP), SI
 
 	// | w10 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -61080,16 +65909,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 208(SP), BX
 
 	// | w11 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -61194,16 +66026,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 216(SP), R11
 
 	// | w12 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -61308,16 +66143,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 224(SP), R10
 
 	// | w13 @ R10
-	ADCQ CX, R10
+	ADDQ CX, R10
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R10
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -61422,16 +66260,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, R10
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R10
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 232(SP), R9
 
 	// | w14 @ R9
-	ADCQ CX, R9
+	ADDQ CX, R9
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R9
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -61536,16 +66377,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ R13
 	ADDQ AX, R9
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R9
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 240(SP), R8
 
 	// | w15 @ R8
-	ADCQ CX, R8
+	ADDQ CX, R8
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R8
+	ADCQ $0x00, CX
 
 	// | 
 	// | W q1
@@ -61648,13 +66492,15 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	ADDQ AX, R9
 	ADCQ $0x00, DX
 	ADDQ R12, R9
+	ADCQ $0x00, DX
 
 	// | w15 @ R8
-	ADCQ DX, R8
+	ADDQ DX, R8
+	MOVQ $0x00, CX
+	ADCQ $0x00, CX
 
 	// | bring the carry from q1
-	MOVQ 136(SP), CX
-	ADCQ $0x00, CX
+	ADDQ 136(SP), CX
 
 	// | 
 
@@ -61742,16 +66588,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 80(SP)
 	ADDQ AX, R8
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R8
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 248(SP), R13
 
 	// | w16 @ R13
-	ADCQ CX, R13
+	ADDQ CX, R13
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R13
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -61839,16 +66688,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 88(SP)
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R13
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 256(SP), DI
 
 	// | w17 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -61936,16 +66788,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 96(SP)
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, DI
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 264(SP), BX
 
 	// | w18 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -62033,16 +66888,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 104(SP)
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, BX
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 272(SP), SI
 
 	// | w19 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -62130,16 +66988,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 112(SP)
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, SI
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 280(SP), R10
 
 	// | w20 @ R10
-	ADCQ CX, R10
+	ADDQ CX, R10
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R10
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -62226,16 +67087,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 120(SP)
 	ADDQ AX, R10
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R10
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 64(SP), R11
 
 	// | w21 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -62322,14 +67186,17 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 128(SP)
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R12, R11
+	ADCQ $0x00, DX
 
 	// | tolarete this limb to stay in stack
 	// | w22 @ 56(SP)
-	ADCQ CX, 56(SP)
+	ADDQ CX, 56(SP)
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, 56(SP)
+	ADCQ $0x00, CX
 
 	// | 
 	// | q2
@@ -62579,16 +67446,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ DI
 	ADDQ AX, R13
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, R13
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 104(SP), CX
 
 	// | w17 @ CX
-	ADCQ R15, CX
+	ADDQ R15, CX
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, CX
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -62693,16 +67563,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ DI
 	ADDQ AX, CX
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, CX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 96(SP), R11
 
 	// | w18 @ R11
-	ADCQ R15, R11
+	ADDQ R15, R11
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, R11
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -62807,16 +67680,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ DI
 	ADDQ AX, R11
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 88(SP), R10
 
 	// | w19 @ R10
-	ADCQ R15, R10
+	ADDQ R15, R10
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, R10
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -62921,16 +67797,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ DI
 	ADDQ AX, R10
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, R10
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 80(SP), SI
 
 	// | w20 @ SI
-	ADCQ R15, SI
+	ADDQ R15, SI
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, SI
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -63035,16 +67914,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ DI
 	ADDQ AX, SI
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 72(SP), BX
 
 	// | w21 @ BX
-	ADCQ R15, BX
+	ADDQ R15, BX
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, BX
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -63149,16 +68031,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 56(R14), AX
 	MULQ DI
 	ADDQ AX, BX
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 56(SP), R9
 
 	// | w22 @ R9
-	ADCQ R15, R9
+	ADDQ R15, R9
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, R9
+	ADCQ $0x00, R15
 
 	// | 
 	// | W q3
@@ -63168,7 +68053,7 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 
 	// | aggregate carries from q2 & q3
 	// | should be added to w23
-	ADCQ R15, 136(SP)
+	ADDQ R15, 136(SP)
 
 	// | 
 
@@ -63259,14 +68144,18 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 112(SP)
 	ADDQ AX, R9
-	ADCQ 136(SP), DX
+	ADCQ $0x00, DX
 	ADDQ R12, R9
+	ADCQ $0x00, DX
 	MOVQ 48(SP), DI
 
 	// | w23 @ DI
-	ADCQ DX, DI
+	MOVQ 136(SP), R15
+	ADDQ R15, DI
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, DI
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -63354,14 +68243,17 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 120(SP)
 	ADDQ AX, DI
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, DI
+	ADCQ $0x00, DX
 	MOVQ 40(SP), CX
 
 	// | w24 @ CX
-	ADCQ R15, CX
+	ADDQ R15, CX
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, CX
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -63449,14 +68341,17 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 104(SP)
 	ADDQ AX, CX
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, CX
+	ADCQ $0x00, DX
 	MOVQ 32(SP), R11
 
 	// | w25 @ R11
-	ADCQ R15, R11
+	ADDQ R15, R11
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, R11
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -63544,14 +68439,17 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 96(SP)
 	ADDQ AX, R11
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, R11
+	ADCQ $0x00, DX
 	MOVQ 24(SP), R10
 
 	// | w26 @ R10
-	ADCQ R15, R10
+	ADDQ R15, R10
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, R10
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -63639,14 +68537,17 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 88(SP)
 	ADDQ AX, R10
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, R10
+	ADCQ $0x00, DX
 	MOVQ 16(SP), SI
 
 	// | w27 @ SI
-	ADCQ R15, SI
+	ADDQ R15, SI
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, SI
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -63734,14 +68635,17 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 80(SP)
 	ADDQ AX, SI
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, SI
+	ADCQ $0x00, DX
 	MOVQ 8(SP), BX
 
 	// | w28 @ BX
-	ADCQ R15, BX
+	ADDQ R15, BX
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, BX
+	ADCQ $0x00, R15
 
 	// | 
 
@@ -63828,16 +68732,19 @@ TEXT ·mul_no_adx_bmi2_15(SB), NOSPLIT, $288-40
 	MOVQ 112(R14), AX
 	MULQ 72(SP)
 	ADDQ AX, BX
-	ADCQ DX, R15
+	ADCQ $0x00, DX
 	ADDQ R12, BX
+	ADCQ $0x00, DX
 
 	// | very last limb goes to short carry register
 	MOVQ (SP), R12
 
 	// | w-1 @ R12
-	ADCQ R15, R12
+	ADDQ R15, R12
 	MOVQ $0x00, R15
 	ADCQ $0x00, R15
+	ADDQ DX, R12
+	ADCQ $0x00, R15
 
 	// | 
 	// | W q4
@@ -64273,6 +69180,7 @@ TEXT ·addn16(SB), NOSPLIT, $48-24
 	// |
 	MOVQ a+0(FP), DI
 	MOVQ b+8(FP), SI
+	XORQ AX, AX
 
 	// |
 	MOVQ (DI), CX
@@ -64705,6 +69613,26 @@ TEXT ·_neg16(SB), NOSPLIT, $48-24
 	// |
 	MOVQ a+8(FP), DI
 
+	// |
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	ORQ  88(DI), AX
+	ORQ  96(DI), AX
+	ORQ  104(DI), AX
+	ORQ  112(DI), AX
+	ORQ  120(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+
 	// |
 	MOVQ p+16(FP), SI
 	MOVQ (SI), CX
@@ -64748,26 +69676,42 @@ TEXT ·_neg16(SB), NOSPLIT, $48-24
 
 	// |
 	MOVQ c+0(FP), DI
+	ANDQ AX, CX
 	MOVQ CX, (DI)
+	ANDQ AX, DX
 	MOVQ DX, 8(DI)
+	ANDQ AX, R8
 	MOVQ R8, 16(DI)
+	ANDQ AX, R9
 	MOVQ R9, 24(DI)
+	ANDQ AX, R10
 	MOVQ R10, 32(DI)
+	ANDQ AX, R11
 	MOVQ R11, 40(DI)
+	ANDQ AX, R12
 	MOVQ R12, 48(DI)
+	ANDQ AX, R13
 	MOVQ R13, 56(DI)
+	ANDQ AX, R14
 	MOVQ R14, 64(DI)
+	ANDQ AX, R15
 	MOVQ R15, 72(DI)
+	ANDQ AX, (SP)
 	MOVQ (SP), BX
 	MOVQ BX, 80(DI)
+	ANDQ AX, 8(SP)
 	MOVQ 8(SP), BX
 	MOVQ BX, 88(DI)
+	ANDQ AX, 16(SP)
 	MOVQ 16(SP), BX
 	MOVQ BX, 96(DI)
+	ANDQ AX, 24(SP)
 	MOVQ 24(SP), BX
 	MOVQ BX, 104(DI)
+	ANDQ AX, 32(SP)
 	MOVQ 32(SP), BX
 	MOVQ BX, 112(DI)
+	ANDQ AX, 40(SP)
 	MOVQ 40(SP), BX
 	MOVQ BX, 120(DI)
 	RET
@@ -64778,6 +69722,483 @@ TEXT ·_neg16(SB), NOSPLIT, $48-24
 
 	RET
 
+// func cmov16(c *[16]uint64, a *[16]uint64, b *[16]uint64, cond uint64)
+TEXT ·cmov16(SB), NOSPLIT, $0-32
+	// | c = b if cond != 0 else a
+	MOVQ    a+8(FP), DI
+	MOVQ    b+16(FP), SI
+	MOVQ    c+0(FP), BX
+	MOVQ    cond+24(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	CMOVQCS (SI), R8
+	MOVQ    R8, (BX)
+	MOVQ    8(DI), R8
+	CMOVQCS 8(SI), R8
+	MOVQ    R8, 8(BX)
+	MOVQ    16(DI), R8
+	CMOVQCS 16(SI), R8
+	MOVQ    R8, 16(BX)
+	MOVQ    24(DI), R8
+	CMOVQCS 24(SI), R8
+	MOVQ    R8, 24(BX)
+	MOVQ    32(DI), R8
+	CMOVQCS 32(SI), R8
+	MOVQ    R8, 32(BX)
+	MOVQ    40(DI), R8
+	CMOVQCS 40(SI), R8
+	MOVQ    R8, 40(BX)
+	MOVQ    48(DI), R8
+	CMOVQCS 48(SI), R8
+	MOVQ    R8, 48(BX)
+	MOVQ    56(DI), R8
+	CMOVQCS 56(SI), R8
+	MOVQ    R8, 56(BX)
+	MOVQ    64(DI), R8
+	CMOVQCS 64(SI), R8
+	MOVQ    R8, 64(BX)
+	MOVQ    72(DI), R8
+	CMOVQCS 72(SI), R8
+	MOVQ    R8, 72(BX)
+	MOVQ    80(DI), R8
+	CMOVQCS 80(SI), R8
+	MOVQ    R8, 80(BX)
+	MOVQ    88(DI), R8
+	CMOVQCS 88(SI), R8
+	MOVQ    R8, 88(BX)
+	MOVQ    96(DI), R8
+	CMOVQCS 96(SI), R8
+	MOVQ    R8, 96(BX)
+	MOVQ    104(DI), R8
+	CMOVQCS 104(SI), R8
+	MOVQ    R8, 104(BX)
+	MOVQ    112(DI), R8
+	CMOVQCS 112(SI), R8
+	MOVQ    R8, 112(BX)
+	MOVQ    120(DI), R8
+	CMOVQCS 120(SI), R8
+	MOVQ    R8, 120(BX)
+	RET
+
+// func cswap16(a *[16]uint64, b *[16]uint64, cond uint64)
+TEXT ·cswap16(SB), NOSPLIT, $0-24
+	// | a, b = b, a if cond != 0
+	MOVQ    a+0(FP), DI
+	MOVQ    b+8(FP), SI
+	MOVQ    cond+16(FP), AX
+	NEGQ    AX
+	SBBQ    AX, AX
+	MOVQ    (DI), R8
+	MOVQ    (SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, (DI)
+	MOVQ    R9, (SI)
+	MOVQ    8(DI), R8
+	MOVQ    8(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 8(DI)
+	MOVQ    R9, 8(SI)
+	MOVQ    16(DI), R8
+	MOVQ    16(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 16(DI)
+	MOVQ    R9, 16(SI)
+	MOVQ    24(DI), R8
+	MOVQ    24(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 24(DI)
+	MOVQ    R9, 24(SI)
+	MOVQ    32(DI), R8
+	MOVQ    32(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 32(DI)
+	MOVQ    R9, 32(SI)
+	MOVQ    40(DI), R8
+	MOVQ    40(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 40(DI)
+	MOVQ    R9, 40(SI)
+	MOVQ    48(DI), R8
+	MOVQ    48(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 48(DI)
+	MOVQ    R9, 48(SI)
+	MOVQ    56(DI), R8
+	MOVQ    56(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 56(DI)
+	MOVQ    R9, 56(SI)
+	MOVQ    64(DI), R8
+	MOVQ    64(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 64(DI)
+	MOVQ    R9, 64(SI)
+	MOVQ    72(DI), R8
+	MOVQ    72(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 72(DI)
+	MOVQ    R9, 72(SI)
+	MOVQ    80(DI), R8
+	MOVQ    80(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 80(DI)
+	MOVQ    R9, 80(SI)
+	MOVQ    88(DI), R8
+	MOVQ    88(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 88(DI)
+	MOVQ    R9, 88(SI)
+	MOVQ    96(DI), R8
+	MOVQ    96(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 96(DI)
+	MOVQ    R9, 96(SI)
+	MOVQ    104(DI), R8
+	MOVQ    104(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 104(DI)
+	MOVQ    R9, 104(SI)
+	MOVQ    112(DI), R8
+	MOVQ    112(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 112(DI)
+	MOVQ    R9, 112(SI)
+	MOVQ    120(DI), R8
+	MOVQ    120(SI), R9
+	MOVQ    R8, R10
+	CMOVQCS R9, R8
+	CMOVQCS R10, R9
+	MOVQ    R8, 120(DI)
+	MOVQ    R9, 120(SI)
+	RET
+
+// func condNeg16(c *[16]uint64, a *[16]uint64, p *[16]uint64, cond uint64)
+TEXT ·condNeg16(SB), NOSPLIT, $0-32
+	// | c = -a if cond != 0 else a
+	MOVQ a+8(FP), DI
+	MOVQ p+16(FP), SI
+	MOVQ c+0(FP), BX
+	MOVQ (DI), AX
+	ORQ  8(DI), AX
+	ORQ  16(DI), AX
+	ORQ  24(DI), AX
+	ORQ  32(DI), AX
+	ORQ  40(DI), AX
+	ORQ  48(DI), AX
+	ORQ  56(DI), AX
+	ORQ  64(DI), AX
+	ORQ  72(DI), AX
+	ORQ  80(DI), AX
+	ORQ  88(DI), AX
+	ORQ  96(DI), AX
+	ORQ  104(DI), AX
+	ORQ  112(DI), AX
+	ORQ  120(DI), AX
+	NEGQ AX
+	SBBQ AX, AX
+	MOVQ cond+24(FP), DX
+	NEGQ DX
+	SBBQ DX, DX
+	ANDQ DX, AX
+
+	// |
+	MOVQ (SI), R8
+	SUBQ (DI), R8
+	SBBQ DX, DX
+	MOVQ (DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, (BX)
+	MOVQ 8(SI), R8
+	ADDQ DX, DX
+	SBBQ 8(DI), R8
+	SBBQ DX, DX
+	MOVQ 8(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 8(BX)
+	MOVQ 16(SI), R8
+	ADDQ DX, DX
+	SBBQ 16(DI), R8
+	SBBQ DX, DX
+	MOVQ 16(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 16(BX)
+	MOVQ 24(SI), R8
+	ADDQ DX, DX
+	SBBQ 24(DI), R8
+	SBBQ DX, DX
+	MOVQ 24(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 24(BX)
+	MOVQ 32(SI), R8
+	ADDQ DX, DX
+	SBBQ 32(DI), R8
+	SBBQ DX, DX
+	MOVQ 32(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 32(BX)
+	MOVQ 40(SI), R8
+	ADDQ DX, DX
+	SBBQ 40(DI), R8
+	SBBQ DX, DX
+	MOVQ 40(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 40(BX)
+	MOVQ 48(SI), R8
+	ADDQ DX, DX
+	SBBQ 48(DI), R8
+	SBBQ DX, DX
+	MOVQ 48(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 48(BX)
+	MOVQ 56(SI), R8
+	ADDQ DX, DX
+	SBBQ 56(DI), R8
+	SBBQ DX, DX
+	MOVQ 56(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 56(BX)
+	MOVQ 64(SI), R8
+	ADDQ DX, DX
+	SBBQ 64(DI), R8
+	SBBQ DX, DX
+	MOVQ 64(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 64(BX)
+	MOVQ 72(SI), R8
+	ADDQ DX, DX
+	SBBQ 72(DI), R8
+	SBBQ DX, DX
+	MOVQ 72(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 72(BX)
+	MOVQ 80(SI), R8
+	ADDQ DX, DX
+	SBBQ 80(DI), R8
+	SBBQ DX, DX
+	MOVQ 80(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 80(BX)
+	MOVQ 88(SI), R8
+	ADDQ DX, DX
+	SBBQ 88(DI), R8
+	SBBQ DX, DX
+	MOVQ 88(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 88(BX)
+	MOVQ 96(SI), R8
+	ADDQ DX, DX
+	SBBQ 96(DI), R8
+	SBBQ DX, DX
+	MOVQ 96(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 96(BX)
+	MOVQ 104(SI), R8
+	ADDQ DX, DX
+	SBBQ 104(DI), R8
+	SBBQ DX, DX
+	MOVQ 104(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 104(BX)
+	MOVQ 112(SI), R8
+	ADDQ DX, DX
+	SBBQ 112(DI), R8
+	SBBQ DX, DX
+	MOVQ 112(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 112(BX)
+	MOVQ 120(SI), R8
+	ADDQ DX, DX
+	SBBQ 120(DI), R8
+	MOVQ 120(DI), R9
+	XORQ R9, R8
+	ANDQ AX, R8
+	XORQ R8, R9
+	MOVQ R9, 120(BX)
+	RET
+
+// func lookup16(dst *[16]uint64, table *[16]uint64, n uint64, idx uint64)
+TEXT ·lookup16(SB), NOSPLIT, $72-32
+	// | dst = table[idx] where every entry of table is read
+	MOVQ  table+8(FP), SI
+	MOVQ  dst+0(FP), DI
+	XORQ  BX, BX
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	XORQ  R14, R14
+	XORQ  R15, R15
+	MOVQ  $0x00, (SP)
+	MOVQ  $0x00, 8(SP)
+	MOVQ  $0x00, 16(SP)
+	MOVQ  $0x00, 24(SP)
+	MOVQ  $0x00, 32(SP)
+	MOVQ  $0x00, 40(SP)
+	MOVQ  $0x00, 48(SP)
+	MOVQ  $0x00, 56(SP)
+	MOVQ  $0x00, 64(SP)
+	MOVQ  n+16(FP), CX
+	MOVQ  idx+24(FP), DX
+	XORQ  R8, R8
+	TESTQ CX, CX
+	JEQ   done
+
+	// |
+loop:
+	MOVQ R8, AX
+	XORQ DX, AX
+	NEGQ AX
+	SBBQ AX, AX
+	NOTQ AX
+	MOVQ (SI), R9
+	ANDQ AX, R9
+	ORQ  R9, BX
+	MOVQ 8(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R10
+	MOVQ 16(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R11
+	MOVQ 24(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R12
+	MOVQ 32(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R13
+	MOVQ 40(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R14
+	MOVQ 48(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, R15
+	MOVQ 56(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, (SP)
+	MOVQ 64(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 8(SP)
+	MOVQ 72(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 16(SP)
+	MOVQ 80(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 24(SP)
+	MOVQ 88(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 32(SP)
+	MOVQ 96(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 40(SP)
+	MOVQ 104(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 48(SP)
+	MOVQ 112(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 56(SP)
+	MOVQ 120(SI), R9
+	ANDQ AX, R9
+	ORQ  R9, 64(SP)
+	ADDQ $0x00000080, SI
+	ADDQ $0x01, R8
+	CMPQ R8, CX
+	JNE  loop
+
+	// |
+done:
+	MOVQ BX, (DI)
+	MOVQ R10, 8(DI)
+	MOVQ R11, 16(DI)
+	MOVQ R12, 24(DI)
+	MOVQ R13, 32(DI)
+	MOVQ R14, 40(DI)
+	MOVQ R15, 48(DI)
+	MOVQ (SP), R9
+	MOVQ R9, 56(DI)
+	MOVQ 8(SP), R9
+	MOVQ R9, 64(DI)
+	MOVQ 16(SP), R9
+	MOVQ R9, 72(DI)
+	MOVQ 24(SP), R9
+	MOVQ R9, 80(DI)
+	MOVQ 32(SP), R9
+	MOVQ R9, 88(DI)
+	MOVQ 40(SP), R9
+	MOVQ R9, 96(DI)
+	MOVQ 48(SP), R9
+	MOVQ R9, 104(DI)
+	MOVQ 56(SP), R9
+	MOVQ R9, 112(DI)
+	MOVQ 64(SP), R9
+	MOVQ R9, 120(DI)
+	RET
+
+	// | 
+
+/* end                                     */
+
+	RET
+
 // func mul_two_16(a *[16]uint64) uint64
 TEXT ·mul_two_16(SB), NOSPLIT, $0-16
 	MOVQ a+0(FP), DI
@@ -72297,16 +77718,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, R9
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R9
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 200(SP), DI
 
 	// | w9 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -72411,16 +77835,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 208(SP), SI
 
 	// | w10 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -72525,16 +77952,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 216(SP), BX
 
 	// | w11 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -72639,16 +78069,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 224(SP), R13
 
 	// | w12 @ R13
-	ADCQ CX, R13
+	ADDQ CX, R13
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R13
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -72753,16 +78186,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R13
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 232(SP), R12
 
 	// | w13 @ R12
-	ADCQ CX, R12
+	ADDQ CX, R12
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R12
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -72867,16 +78303,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, R12
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R12
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 240(SP), R11
 
 	// | w14 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -72981,16 +78420,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ R14
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 248(SP), R10
 
 	// | w15 @ R10
-	ADCQ CX, R10
+	ADDQ CX, R10
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R10
+	ADCQ $0x00, CX
 
 	// | 
 	// | W q1
@@ -73102,18 +78544,21 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 72(SP)
 	ADDQ AX, R10
-
-	// | bring the carry from q1
-	ADCQ 136(SP), DX
+	ADCQ $0x00, DX
 	ADDQ R8, R10
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 256(SP), R14
 
 	// | w16 @ R14
-	ADCQ DX, R14
+	// | bring the carry from q1
+	MOVQ 136(SP), CX
+	ADDQ CX, R14
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R14
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -73212,16 +78657,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 80(SP)
 	ADDQ AX, R14
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R14
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 264(SP), DI
 
 	// | w17 @ DI
-	ADCQ CX, DI
+	ADDQ CX, DI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, DI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -73320,16 +78768,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 88(SP)
 	ADDQ AX, DI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 272(SP), SI
 
 	// | w18 @ SI
-	ADCQ CX, SI
+	ADDQ CX, SI
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, SI
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -73428,16 +78879,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 96(SP)
 	ADDQ AX, SI
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 280(SP), BX
 
 	// | w19 @ BX
-	ADCQ CX, BX
+	ADDQ CX, BX
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, BX
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -73536,16 +78990,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 104(SP)
 	ADDQ AX, BX
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 288(SP), R13
 
 	// | w20 @ R13
-	ADCQ CX, R13
+	ADDQ CX, R13
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R13
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -73644,16 +79101,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 112(SP)
 	ADDQ AX, R13
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R13
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 296(SP), R12
 
 	// | w21 @ R12
-	ADCQ CX, R12
+	ADDQ CX, R12
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R12
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -73752,16 +79212,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 120(SP)
 	ADDQ AX, R12
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R12
+	ADCQ $0x00, DX
 
 	// | move to an idle register
 	MOVQ 304(SP), R11
 
 	// | w22 @ R11
-	ADCQ CX, R11
+	ADDQ CX, R11
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, R11
+	ADCQ $0x00, CX
 
 	// | 
 
@@ -73859,14 +79322,17 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 128(SP)
 	ADDQ AX, R11
-	ADCQ DX, CX
+	ADCQ $0x00, DX
 	ADDQ R8, R11
+	ADCQ $0x00, DX
 
 	// | tolarete this limb to stay in stack
 	// | w23 @ 64(SP)
-	ADCQ CX, 64(SP)
+	ADDQ CX, 64(SP)
 	MOVQ $0x00, CX
 	ADCQ $0x00, CX
+	ADDQ DX, 64(SP)
+	ADCQ $0x00, CX
 
 	// | 
 	// | q2
@@ -74118,16 +79584,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ DI
 	ADDQ AX, R14
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, R14
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 112(SP), CX
 
 	// | w17 @ CX
-	ADCQ R9, CX
+	ADDQ R9, CX
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, CX
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -74232,16 +79701,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ DI
 	ADDQ AX, CX
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, CX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 104(SP), R11
 
 	// | w18 @ R11
-	ADCQ R9, R11
+	ADDQ R9, R11
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, R11
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -74346,16 +79818,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ DI
 	ADDQ AX, R11
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, R11
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 96(SP), R12
 
 	// | w19 @ R12
-	ADCQ R9, R12
+	ADDQ R9, R12
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, R12
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -74460,16 +79935,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ DI
 	ADDQ AX, R12
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, R12
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 88(SP), R13
 
 	// | w20 @ R13
-	ADCQ R9, R13
+	ADDQ R9, R13
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, R13
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -74574,16 +80052,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ DI
 	ADDQ AX, R13
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, R13
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 80(SP), BX
 
 	// | w21 @ BX
-	ADCQ R9, BX
+	ADDQ R9, BX
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, BX
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -74688,16 +80169,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ DI
 	ADDQ AX, BX
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 72(SP), SI
 
 	// | w22 @ SI
-	ADCQ R9, SI
+	ADDQ R9, SI
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, SI
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -74802,16 +80286,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 56(R15), AX
 	MULQ DI
 	ADDQ AX, SI
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | move to idle register
 	MOVQ 64(SP), R10
 
 	// | w23 @ R10
-	ADCQ R9, R10
+	ADDQ R9, R10
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, R10
+	ADCQ $0x00, R9
 
 	// | 
 	// | W q3
@@ -74821,7 +80308,7 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 
 	// | aggregate carries from q2 & q3
 	// | should be added to w24
-	ADCQ R9, 136(SP)
+	ADDQ R9, 136(SP)
 
 	// | 
 
@@ -74923,14 +80410,18 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 120(SP)
 	ADDQ AX, R10
-	ADCQ 136(SP), DX
+	ADCQ $0x00, DX
 	ADDQ R8, R10
+	ADCQ $0x00, DX
 	MOVQ 56(SP), DI
 
 	// | w24 @ DI
-	ADCQ DX, DI
+	MOVQ 136(SP), R9
+	ADDQ R9, DI
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, DI
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -75029,14 +80520,17 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 128(SP)
 	ADDQ AX, DI
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, DI
+	ADCQ $0x00, DX
 	MOVQ 48(SP), CX
 
 	// | w25 @ CX
-	ADCQ R9, CX
+	ADDQ R9, CX
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, CX
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -75135,14 +80629,17 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 112(SP)
 	ADDQ AX, CX
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, CX
+	ADCQ $0x00, DX
 	MOVQ 40(SP), R11
 
 	// | w26 @ R11
-	ADCQ R9, R11
+	ADDQ R9, R11
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, R11
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -75241,14 +80738,17 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 104(SP)
 	ADDQ AX, R11
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, R11
+	ADCQ $0x00, DX
 	MOVQ 32(SP), R12
 
 	// | w27 @ R12
-	ADCQ R9, R12
+	ADDQ R9, R12
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, R12
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -75347,14 +80847,17 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 96(SP)
 	ADDQ AX, R12
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, R12
+	ADCQ $0x00, DX
 	MOVQ 24(SP), R13
 
 	// | w28 @ R13
-	ADCQ R9, R13
+	ADDQ R9, R13
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, R13
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -75453,14 +80956,17 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 88(SP)
 	ADDQ AX, R13
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, R13
+	ADCQ $0x00, DX
 	MOVQ 16(SP), BX
 
 	// | w29 @ BX
-	ADCQ R9, BX
+	ADDQ R9, BX
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, BX
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -75559,14 +81065,17 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 80(SP)
 	ADDQ AX, BX
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, BX
+	ADCQ $0x00, DX
 	MOVQ 8(SP), SI
 
 	// | w30 @ SI
-	ADCQ R9, SI
+	ADDQ R9, SI
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, SI
+	ADCQ $0x00, R9
 
 	// | 
 
@@ -75664,16 +81173,19 @@ TEXT ·mul_no_adx_bmi2_16(SB), NOSPLIT, $312-40
 	MOVQ 120(R15), AX
 	MULQ 72(SP)
 	ADDQ AX, SI
-	ADCQ DX, R9
+	ADCQ $0x00, DX
 	ADDQ R8, SI
+	ADCQ $0x00, DX
 
 	// | very last limb goes to short carry register
 	MOVQ (SP), R8
 
 	// | w-1 @ R8
-	ADCQ R9, R8
+	ADDQ R9, R8
 	MOVQ $0x00, R9
 	ADCQ $0x00, R9
+	ADDQ DX, R8
+	ADCQ $0x00, R9
 
 	// | 
 	// | W q4
@@ -3,11 +3,11 @@ module github.com/kilic/fp
 go 1.12
 
 require (
-	github.com/mmcloughlin/avo v0.0.0-20190729005849-d43efabdbe34 // indirect
+	github.com/mmcloughlin/avo v0.0.0-20190729005849-d43efabdbe34
 	golang.org/x/arch v0.0.0-20190312162104-788fe5ffcd8c // indirect
-	golang.org/x/crypto v0.0.0-20190701094942-4def268fd1a4 // indirect
+	golang.org/x/crypto v0.0.0-20190701094942-4def268fd1a4
 	golang.org/x/net v0.0.0-20190724013045-ca1201d0de80 // indirect
-	golang.org/x/sys v0.0.0-20190726091711-fc99dfbffb4e // indirect
+	golang.org/x/sys v0.0.0-20190726091711-fc99dfbffb4e
 	golang.org/x/text v0.3.2 // indirect
 	golang.org/x/tools v0.0.0-20190729092621-ff9f1409240a // indirect
 )